@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"cloud.google.com/go/profiler"
+	"github.com/leandro-lugaresi/hub"
 	"github.com/spf13/viper"
 	"go.uber.org/zap"
 	"google.golang.org/api/option"
@@ -18,6 +19,7 @@ import (
 	"github.com/traPtitech/traQ/router/auth"
 	"github.com/traPtitech/traQ/service/channel"
 	"github.com/traPtitech/traQ/service/counter"
+	"github.com/traPtitech/traQ/service/email"
 	"github.com/traPtitech/traQ/service/fcm"
 	"github.com/traPtitech/traQ/service/imaging"
 	"github.com/traPtitech/traQ/service/message"
@@ -60,6 +62,9 @@ type Config struct {
 		MaxPixels int `mapstructure:"maxPixels" yaml:"maxPixels"`
 		// Concurrency 処理並列数 (default: 1)
 		Concurrency int `mapstructure:"concurrency" yaml:"concurrency"`
+		// VariantFormats PNGに加えて保存するサムネイル画像のフォーマット (webp, avif) (default: [webp])
+		// ImageMagickが設定されている場合のみ有効です
+		VariantFormats []string `mapstructure:"variantFormats" yaml:"variantFormats"`
 	} `mapstructure:"imaging" yaml:"imaging"`
 
 	// MariaDB データベース接続設定
@@ -90,6 +95,15 @@ type Config struct {
 		URL string `mapstructure:"url" yaml:"url"`
 	} `mapstructure:"es" yaml:"es"`
 
+	// Search 検索設定
+	Search struct {
+		// Engine 検索エンジン (default: "")
+		// 	es: Elasticsearch (es.urlの設定が必要)
+		// 	db: MariaDBのFULLTEXTインデックス
+		// 	"": es.urlが設定されている場合はElasticsearch、そうでない場合は検索無効
+		Engine string `mapstructure:"engine" yaml:"engine"`
+	} `mapstructure:"search" yaml:"search"`
+
 	// Storage ファイルストレージ設定
 	Storage struct {
 		// Type ストレージタイプ (default: local)
@@ -176,6 +190,20 @@ type Config struct {
 		} `mapstructure:"serviceAccount" yaml:"serviceAccount"`
 	} `mapstructure:"firebase" yaml:"firebase"`
 
+	// SMTP メール通知用SMTPサーバー設定
+	SMTP struct {
+		// Host ホスト名 (空の場合はメール通知を無効化)
+		Host string `mapstructure:"host" yaml:"host"`
+		// Port ポート番号 (default: 587)
+		Port int `mapstructure:"port" yaml:"port"`
+		// Username 認証ユーザー名
+		Username string `mapstructure:"username" yaml:"username"`
+		// Password 認証パスワード
+		Password string `mapstructure:"password" yaml:"password"`
+		// From 送信元アドレス
+		From string `mapstructure:"from" yaml:"from"`
+	} `mapstructure:"smtp" yaml:"smtp"`
+
 	// OAuth2 OAuth2認可サーバー設定
 	OAuth2 struct {
 		// IsRefreshEnabled リフレッシュトークンを有効にするかどうか (default: false)
@@ -259,6 +287,7 @@ func init() {
 	viper.SetDefault("imagemagick", "")
 	viper.SetDefault("imaging.maxPixels", 2560*1600)
 	viper.SetDefault("imaging.concurrency", 1)
+	viper.SetDefault("imaging.variantFormats", []string{"webp"})
 	viper.SetDefault("mariadb.host", "127.0.0.1")
 	viper.SetDefault("mariadb.port", 3306)
 	viper.SetDefault("mariadb.username", "root")
@@ -268,6 +297,7 @@ func init() {
 	viper.SetDefault("mariadb.connection.maxIdle", 2)
 	viper.SetDefault("mariadb.connection.lifetime", 0)
 	viper.SetDefault("es.url", "")
+	viper.SetDefault("search.engine", "")
 	viper.SetDefault("storage.type", "local")
 	viper.SetDefault("storage.local.dir", "./storage")
 	viper.SetDefault("storage.swift.username", "")
@@ -289,6 +319,11 @@ func init() {
 	viper.SetDefault("gcp.serviceAccount.file", "")
 	viper.SetDefault("gcp.stackdriver.profiler.enabled", false)
 	viper.SetDefault("firebase.serviceAccount.file", "")
+	viper.SetDefault("smtp.host", "")
+	viper.SetDefault("smtp.port", 587)
+	viper.SetDefault("smtp.username", "")
+	viper.SetDefault("smtp.password", "")
+	viper.SetDefault("smtp.from", "")
 	viper.SetDefault("oauth2.isRefreshEnabled", false)
 	viper.SetDefault("oauth2.accessTokenExp", 60*60*24*365)
 	viper.SetDefault("externalAuthentication.enabled", false)
@@ -320,6 +355,17 @@ func init() {
 	viper.SetDefault("jwt.keys.private", "")
 }
 
+// validate 設定値を検証します
+func (c Config) validate() error {
+	for _, s := range c.Imaging.VariantFormats {
+		f, err := imaging.FormatFromString(s)
+		if err != nil || (f != imaging.FormatWebP && f != imaging.FormatAVIF) {
+			return fmt.Errorf("imaging.variantFormats: unsupported format: %s", s)
+		}
+	}
+	return nil
+}
+
 func (c Config) getFileStorage() (storage.FileStorage, error) {
 	switch c.Storage.Type {
 	case "swift":
@@ -430,11 +476,49 @@ func newFCMClientIfAvailable(repo repository.Repository, logger *zap.Logger, unr
 	return fcm.NewNullClient(), nil
 }
 
-func initSearchServiceIfAvailable(mm message.Manager, cm channel.Manager, repo repository.Repository, logger *zap.Logger, config search.ESEngineConfig) (search.Engine, error) {
-	if len(config.URL) > 0 {
-		return search.NewESEngine(mm, cm, repo, logger, config)
+func newEmailClientIfAvailable(config email.SMTPConfig, logger *zap.Logger) (email.Client, error) {
+	if len(config.Host) == 0 {
+		return email.NewNullClient(), nil
+	}
+	if !config.Valid() {
+		logger.Warn("smtp config is incomplete (host, port and from are required). email notification is disabled",
+			zap.String("host", config.Host),
+			zap.Int("port", config.Port),
+			zap.String("from", config.From))
+		return email.NewNullClient(), nil
+	}
+	return email.NewSMTPClient(config, logger)
+}
+
+func initSearchServiceIfAvailable(db *gorm.DB, mm message.Manager, cm channel.Manager, repo repository.Repository, hub *hub.Hub, logger *zap.Logger, c *Config) (search.Engine, error) {
+	config := provideESEngineConfig(c)
+	switch c.Search.Engine {
+	case "es":
+		return search.NewESEngine(mm, cm, repo, hub, logger, config)
+	case "db":
+		return search.NewDBEngine(db, mm, cm, repo, hub, logger)
+	case "":
+		if len(config.URL) > 0 {
+			return search.NewESEngine(mm, cm, repo, hub, logger, config)
+		}
+		return search.NewNullEngine(), nil
+	default:
+		return nil, fmt.Errorf("unknown search engine: %s", c.Search.Engine)
+	}
+}
+
+func initEntitySearchServiceIfAvailable(db *gorm.DB, hub *hub.Hub, cm channel.Manager, repo repository.Repository, logger *zap.Logger, c *Config) (search.EntityEngine, error) {
+	switch c.Search.Engine {
+	case "es", "db":
+		return search.NewEntityEngine(db, hub, cm, repo, logger)
+	case "":
+		if len(c.ES.URL) > 0 {
+			return search.NewEntityEngine(db, hub, cm, repo, logger)
+		}
+		return search.NewNullEntityEngine(), nil
+	default:
+		return nil, fmt.Errorf("unknown search engine: %s", c.Search.Engine)
 	}
-	return search.NewNullEngine(), nil
 }
 
 func provideServerOriginString(c *Config) variable.ServerOriginString {
@@ -445,6 +529,16 @@ func provideFirebaseCredentialsFilePathString(c *Config) variable.FirebaseCreden
 	return variable.FirebaseCredentialsFilePathString(c.Firebase.ServiceAccount.File)
 }
 
+func provideSMTPConfig(c *Config) email.SMTPConfig {
+	return email.SMTPConfig{
+		Host:     c.SMTP.Host,
+		Port:     c.SMTP.Port,
+		Username: c.SMTP.Username,
+		Password: c.SMTP.Password,
+		From:     c.SMTP.From,
+	}
+}
+
 func provideESEngineConfig(c *Config) search.ESEngineConfig {
 	return search.ESEngineConfig{
 		URL: c.ES.URL,
@@ -452,11 +546,18 @@ func provideESEngineConfig(c *Config) search.ESEngineConfig {
 }
 
 func provideImageProcessorConfig(c *Config) imaging.Config {
+	// 起動時に Config.validate で検証済み
+	variantFormats := make([]imaging.Format, 0, len(c.Imaging.VariantFormats))
+	for _, s := range c.Imaging.VariantFormats {
+		f, _ := imaging.FormatFromString(s)
+		variantFormats = append(variantFormats, f)
+	}
 	return imaging.Config{
 		MaxPixels:        c.Imaging.MaxPixels,
 		Concurrency:      c.Imaging.Concurrency,
 		ThumbnailMaxSize: image.Pt(360, 480),
 		ImageMagickPath:  c.ImageMagick,
+		VariantFormats:   variantFormats,
 	}
 }
 
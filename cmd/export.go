@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"os"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/leandro-lugaresi/hub"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/traPtitech/traQ/repository/gorm"
+	"github.com/traPtitech/traQ/service/channel"
+	"github.com/traPtitech/traQ/service/export"
+	"github.com/traPtitech/traQ/service/file"
+	"github.com/traPtitech/traQ/service/imaging"
+	"github.com/traPtitech/traQ/utils/gormZap"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+// exportCommand チャンネルデータエクスポートコマンド
+func exportCommand() *cobra.Command {
+	var (
+		channelID    string
+		since        string
+		until        string
+		output       string
+		excludeFiles bool
+	)
+
+	cmd := cobra.Command{
+		Use:   "export",
+		Short: "export public channel subtree to zip archive",
+		Run: func(cmd *cobra.Command, args []string) {
+			// Logger
+			logger := getCLILogger()
+			defer logger.Sync()
+
+			q := export.Query{ExcludeFiles: excludeFiles}
+			cid, err := uuid.FromString(channelID)
+			if err != nil {
+				logger.Fatal("invalid channel id", zap.Error(err))
+			}
+			q.ChannelID = cid
+			if len(since) > 0 {
+				t, err := time.Parse(time.RFC3339, since)
+				if err != nil {
+					logger.Fatal("invalid since", zap.Error(err))
+				}
+				q.Since = optional.From(t)
+			}
+			if len(until) > 0 {
+				t, err := time.Parse(time.RFC3339, until)
+				if err != nil {
+					logger.Fatal("invalid until", zap.Error(err))
+				}
+				q.Until = optional.From(t)
+			}
+
+			// Database
+			db, err := c.getDatabase()
+			if err != nil {
+				logger.Fatal("failed to connect database", zap.Error(err))
+			}
+			db.Logger = gormZap.New(logger.Named("gorm"))
+			sqlDB, err := db.DB()
+			if err != nil {
+				logger.Fatal("failed to get *sql.DB", zap.Error(err))
+			}
+			defer sqlDB.Close()
+
+			// FileStorage
+			fs, err := c.getFileStorage()
+			if err != nil {
+				logger.Fatal("failed to setup file storage", zap.Error(err))
+			}
+
+			// Repository
+			repo, _, err := gorm.NewGormRepository(db, hub.New(), logger, false)
+			if err != nil {
+				logger.Fatal("failed to initialize repository", zap.Error(err))
+			}
+
+			// ChannelManager
+			cm, err := channel.InitChannelManager(repo, logger)
+			if err != nil {
+				logger.Fatal("failed to initialize channel manager", zap.Error(err))
+			}
+
+			// FileManager
+			fm, err := file.InitFileManager(repo, fs, imaging.NewProcessor(provideImageProcessorConfig(c)), logger)
+			if err != nil {
+				logger.Fatal("failed to initialize file manager", zap.Error(err))
+			}
+
+			f, err := os.Create(output)
+			if err != nil {
+				logger.Fatal("failed to create output file", zap.Error(err))
+			}
+			defer f.Close()
+
+			if err := export.NewExporter(repo, cm, fm).Export(f, q); err != nil {
+				logger.Fatal("failed to export", zap.Error(err))
+			}
+			logger.Sugar().Infof("exported to %s", output)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&channelID, "channel", "", "root channel id to export")
+	flags.StringVar(&since, "since", "", "export messages posted after this time (RFC3339)")
+	flags.StringVar(&until, "until", "", "export messages posted before this time (RFC3339)")
+	flags.StringVarP(&output, "output", "o", "export.zip", "output zip file path")
+	flags.BoolVar(&excludeFiles, "exclude-files", false, "do not include attached files")
+	_ = cmd.MarkFlagRequired("channel")
+
+	return &cmd
+}
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"image"
 	"image/png"
 	"io"
 	"time"
@@ -134,7 +135,7 @@ func filePruneCommand() *cobra.Command {
 func genMissingThumbnails() *cobra.Command {
 	canGenerateImageThumb := func(mimeType string) bool {
 		switch mimeType {
-		case "image/jpeg", "image/png", "image/gif", "image/webp":
+		case "image/jpeg", "image/png", "image/gif", "image/webp", "image/avif":
 			return true
 		default:
 			return false
@@ -151,7 +152,7 @@ func genMissingThumbnails() *cobra.Command {
 
 	return &cobra.Command{
 		Use:   "gen-missing-thumbs",
-		Short: "Generate missing thumbnails",
+		Short: "Generate missing thumbnails (including WebP / AVIF variants)",
 		Run: func(cmd *cobra.Command, args []string) {
 			// Logger
 			logger := getCLILogger()
@@ -219,6 +220,19 @@ func genMissingThumbnails() *cobra.Command {
 
 				return nil
 			}
+			generateImageThumbVariant := func(f *model.FileMeta, thumb image.Image, format imaging.Format) error {
+				thumbnail, err := file.SaveThumbnailVariant(fs, ip, f.ID, thumb, format)
+				if err != nil {
+					return err
+				}
+				if err := db.Create(thumbnail).Error; err != nil {
+					if err := fs.DeleteByKey(f.ID.String()+"-"+thumbnail.Type.Suffix(), model.FileTypeThumbnail); err != nil {
+						logger.Error("failed to rollback thumbnail on storage", zap.Error(err), zap.Stringer("fid", f.ID))
+					}
+					return fmt.Errorf("failed to save file thumbnail to db: %w", err)
+				}
+				return nil
+			}
 			generateWaveform := func(file *model.FileMeta) error {
 				fid := file.ID
 
@@ -289,7 +303,7 @@ func genMissingThumbnails() *cobra.Command {
 					"WHERE f.type = '' AND f.deleted_at IS NULL AND f.created_at > ? "+
 					"AND f.mime IN ("+
 					// サムネイル生成が可能なmimeが変わったらここを変える
-					"'image/jpeg', 'image/png', 'image/gif', 'image/webp', "+
+					"'image/jpeg', 'image/png', 'image/gif', 'image/webp', 'image/avif', "+
 					"'audio/mpeg', 'audio/mp3', 'audio/wav', 'audio/x-wav'"+
 					") "+
 					"GROUP BY f.id, f.created_at "+
@@ -335,6 +349,88 @@ func genMissingThumbnails() *cobra.Command {
 			}
 
 			logger.Info(fmt.Sprintf("finished generating missing thumbnails: images success / total (%d / %d), waveform success / total (%d / %d)", imageThumbSuccess, imageThumbTotal, waveformSuccess, waveformTotal))
+
+			// サムネイル画像の追加フォーマット (WebP, AVIF) の生成
+			formats := ip.VariantFormats()
+			if len(formats) == 0 {
+				logger.Info("skipped generating thumbnail variants: no variant formats are available")
+				return
+			}
+			variantTypes := make([]string, len(formats))
+			for i, format := range formats {
+				t, _ := file.ThumbnailVariantType(format)
+				variantTypes[i] = t.String()
+			}
+
+			lastCreatedAt = time.Time{}
+			total = 0
+			var (
+				variantTotal   = 0
+				variantSuccess = 0
+			)
+			for {
+				var files []*model.FileMeta
+				err = db.Raw("SELECT f.* FROM files f "+
+					"JOIN files_thumbnails ft ON f.id = ft.file_id AND ft.type = 'image' "+
+					"LEFT JOIN files_thumbnails fv ON f.id = fv.file_id AND fv.type IN ? "+
+					"WHERE f.deleted_at IS NULL AND f.created_at > ? "+
+					"GROUP BY f.id, f.created_at "+
+					"HAVING COUNT(fv.file_id) < ? "+
+					"ORDER BY f.created_at "+
+					"LIMIT ?", variantTypes, lastCreatedAt, len(variantTypes), batch).
+					Scan(&files).Error
+				if err != nil {
+					logger.Fatal("failed to list files", zap.Error(err))
+				}
+
+				logger.Info(fmt.Sprintf("listing files from %d to %d", total, total+len(files)-1))
+
+				for _, f := range files {
+					lastCreatedAt = f.CreatedAt
+
+					var thumbs []*model.FileThumbnail
+					if err := db.Where("file_id = ?", f.ID).Find(&thumbs).Error; err != nil {
+						logger.Fatal("failed to list file thumbnails", zap.Error(err))
+					}
+					exists := make(map[model.ThumbnailType]bool, len(thumbs))
+					for _, t := range thumbs {
+						exists[t.Type] = true
+					}
+
+					thumb, thumbErr := func() (image.Image, error) {
+						src, err := fs.OpenFileByKey(f.ID.String()+"-"+model.ThumbnailTypeImage.Suffix(), model.FileTypeThumbnail)
+						if err != nil {
+							return nil, fmt.Errorf("failed to open thumbnail: %w", err)
+						}
+						defer src.Close()
+						return png.Decode(src)
+					}()
+					for _, format := range formats {
+						if t, _ := file.ThumbnailVariantType(format); exists[t] {
+							continue
+						}
+						variantTotal++
+						err := thumbErr
+						if err == nil {
+							err = generateImageThumbVariant(f, thumb, format)
+						}
+						if err != nil {
+							logger.Error("failed to generate thumbnail variant", zap.Error(err), zap.Stringer("format", format), zap.Stringer("fid", f.ID))
+						} else {
+							variantSuccess++
+						}
+					}
+				}
+
+				if len(files) < batch {
+					break
+				}
+				total += batch
+
+				logger.Info(fmt.Sprintf("generating missing thumbnail variants: success / total (%d / %d)", variantSuccess, variantTotal))
+			}
+
+			logger.Info(fmt.Sprintf("finished generating missing thumbnail variants: success / total (%d / %d)", variantSuccess, variantTotal))
 		},
 	}
 }
@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"archive/zip"
+	"os"
+
+	"github.com/gofrs/uuid"
+	"github.com/leandro-lugaresi/hub"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/traPtitech/traQ/repository/gorm"
+	"github.com/traPtitech/traQ/router/auth"
+	"github.com/traPtitech/traQ/service/channel"
+	"github.com/traPtitech/traQ/service/file"
+	"github.com/traPtitech/traQ/service/imaging"
+	"github.com/traPtitech/traQ/service/importer/slack"
+	"github.com/traPtitech/traQ/utils/gormZap"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+// importCommand 外部サービスからのデータ取り込みコマンド
+func importCommand() *cobra.Command {
+	cmd := cobra.Command{
+		Use:   "import",
+		Short: "import data from other services",
+	}
+
+	cmd.AddCommand(
+		importSlackCommand(),
+	)
+
+	return &cmd
+}
+
+// importSlackCommand Slackエクスポートアーカイブ取り込みコマンド
+func importSlackCommand() *cobra.Command {
+	var (
+		parentChannel string
+		fallbackUser  string
+		token         string
+		dryRun        bool
+	)
+
+	cmd := cobra.Command{
+		Use:   "slack <export zip>",
+		Short: "import channels and messages from Slack export archive",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			// Logger
+			logger := getCLILogger()
+			defer logger.Sync()
+
+			zr, err := zip.OpenReader(args[0])
+			if err != nil {
+				logger.Fatal("failed to open archive", zap.Error(err))
+			}
+			defer zr.Close()
+
+			// Database
+			db, err := c.getDatabase()
+			if err != nil {
+				logger.Fatal("failed to connect database", zap.Error(err))
+			}
+			db.Logger = gormZap.New(logger.Named("gorm"))
+			sqlDB, err := db.DB()
+			if err != nil {
+				logger.Fatal("failed to get *sql.DB", zap.Error(err))
+			}
+			defer sqlDB.Close()
+
+			// FileStorage
+			fs, err := c.getFileStorage()
+			if err != nil {
+				logger.Fatal("failed to setup file storage", zap.Error(err))
+			}
+
+			// Repository
+			repo, _, err := gorm.NewGormRepository(db, hub.New(), logger, false)
+			if err != nil {
+				logger.Fatal("failed to initialize repository", zap.Error(err))
+			}
+
+			// ChannelManager
+			cm, err := channel.InitChannelManager(repo, logger)
+			if err != nil {
+				logger.Fatal("failed to initialize channel manager", zap.Error(err))
+			}
+
+			// FileManager
+			fm, err := file.InitFileManager(repo, fs, imaging.NewProcessor(provideImageProcessorConfig(c)), logger)
+			if err != nil {
+				logger.Fatal("failed to initialize file manager", zap.Error(err))
+			}
+
+			conf := slack.Config{
+				ProviderName: auth.SlackProviderName,
+				Token:        token,
+				Origin:       c.Origin,
+				DryRun:       dryRun,
+			}
+			if len(parentChannel) > 0 {
+				conf.ParentChannelID = cm.PublicChannelTree().GetChannelIDFromPath(parentChannel)
+				if conf.ParentChannelID == uuid.Nil {
+					logger.Fatal("parent channel was not found", zap.String("path", parentChannel))
+				}
+			}
+			if len(fallbackUser) > 0 {
+				u, err := repo.GetUserByName(fallbackUser, false)
+				if err != nil {
+					logger.Fatal("failed to get fallback user", zap.Error(err), zap.String("name", fallbackUser))
+				}
+				conf.FallbackUserID = optional.From(u.GetID())
+			}
+
+			report, err := slack.NewImporter(repo, cm, fm, logger).Import(&zr.Reader, conf)
+			if err != nil {
+				logger.Fatal("failed to import", zap.Error(err))
+			}
+			cm.Wait()
+			if err := report.Write(os.Stdout); err != nil {
+				logger.Fatal("failed to write report", zap.Error(err))
+			}
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&parentChannel, "parent", "", "path of the channel under which Slack channels are created (default: root)")
+	flags.StringVar(&fallbackUser, "fallback-user", "", "traQ user name to post messages of unmapped Slack users and bots as (default: skip them)")
+	flags.StringVar(&token, "token", "", "Slack token used to download attached files (default: skip files)")
+	flags.BoolVar(&dryRun, "dry-run", false, "report what would be imported without making any changes")
+
+	return &cmd
+}
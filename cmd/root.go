@@ -63,6 +63,9 @@ func init() {
 		if err := viper.Unmarshal(&c); err != nil {
 			log.Fatal(err)
 		}
+		if err := c.validate(); err != nil {
+			log.Fatalf("invalid config: %v", err)
+		}
 		message.SetOrigin(c.Origin)
 	})
 
@@ -73,6 +76,8 @@ func init() {
 		confCommand(),
 		fileCommand(),
 		stampCommand(),
+		exportCommand(),
+		importCommand(),
 		versionCommand(),
 		healthcheckCommand(),
 	)
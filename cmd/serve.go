@@ -218,15 +218,35 @@ func (s *Server) Shutdown(ctx context.Context) error {
 		s.L.Info("Bot shutdown")
 		return err
 	})
+	eg.Go(func() error {
+		err := s.SS.OutgoingWebhook.Shutdown(ctx)
+		s.L.Info("Outgoing webhook shutdown")
+		return err
+	})
 	eg.Go(func() error {
 		err := s.SS.OGP.Shutdown()
 		s.L.Info("OGP shutdown")
 		return err
 	})
 	eg.Go(func() error {
+		err := s.SS.Schedule.Shutdown()
+		s.L.Info("Schedule shutdown")
+		return err
+	})
+	eg.Go(func() error {
+		err := s.SS.EntitySearch.Close()
+		s.L.Info("Entity search shutdown")
+		return err
+	})
+	eg.Go(func() error {
+		// 停止時に保留中の通知を送信するため、FCM・Emailより先に停止
+		err := s.SS.Notification.Shutdown(ctx)
+		s.L.Info("Notification shutdown")
 		s.SS.FCM.Close()
 		s.L.Info("FCM shutdown")
-		return nil
+		s.SS.Email.Close()
+		s.L.Info("Email shutdown")
+		return err
 	})
 	eg.Go(func() error {
 		s.SS.ChannelManager.Wait()
@@ -22,7 +22,9 @@ import (
 	"github.com/traPtitech/traQ/service/message"
 	"github.com/traPtitech/traQ/service/notification"
 	"github.com/traPtitech/traQ/service/ogp"
+	"github.com/traPtitech/traQ/service/outgoingwebhook"
 	rbac2 "github.com/traPtitech/traQ/service/rbac"
+	"github.com/traPtitech/traQ/service/schedule"
 	"github.com/traPtitech/traQ/service/viewer"
 	"github.com/traPtitech/traQ/service/webrtcv3"
 	"github.com/traPtitech/traQ/service/ws"
@@ -43,19 +45,23 @@ func newServer(hub *hub.Hub, db *gorm.DB, repo repository.Repository, fs storage
 		imaging.NewProcessor,
 		notification.NewService,
 		ogp.NewServiceImpl,
+		outgoingwebhook.NewService,
 		rbac2.New,
+		schedule.NewService,
 		viewer.NewManager,
 		webrtcv3.NewManager,
 		ws.NewStreamer,
 		botWS.NewStreamer,
 		router.Setup,
 		newFCMClientIfAvailable,
+		newEmailClientIfAvailable,
 		initSearchServiceIfAvailable,
+		initEntitySearchServiceIfAvailable,
 		provideServerOriginString,
 		provideFirebaseCredentialsFilePathString,
+		provideSMTPConfig,
 		provideImageProcessorConfig,
 		provideRouterConfig,
-		provideESEngineConfig,
 		wire.Struct(new(service.Services), "*"),
 		wire.Struct(new(Server), "*"),
 		wire.Bind(new(repository.ChannelRepository), new(repository.Repository)),
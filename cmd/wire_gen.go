@@ -21,7 +21,9 @@ import (
 	"github.com/traPtitech/traQ/service/message"
 	"github.com/traPtitech/traQ/service/notification"
 	"github.com/traPtitech/traQ/service/ogp"
+	"github.com/traPtitech/traQ/service/outgoingwebhook"
 	"github.com/traPtitech/traQ/service/rbac"
+	"github.com/traPtitech/traQ/service/schedule"
 	"github.com/traPtitech/traQ/service/viewer"
 	"github.com/traPtitech/traQ/service/webrtcv3"
 	ws2 "github.com/traPtitech/traQ/service/ws"
@@ -43,7 +45,6 @@ func newServer(hub2 *hub.Hub, db *gorm.DB, repo repository.Repository, fs storag
 	}
 	webrtcv3Manager := webrtcv3.NewManager(hub2)
 	streamer := ws.NewStreamer(hub2, webrtcv3Manager, logger)
-	botService := bot.NewService(repo, manager, hub2, streamer, logger)
 	onlineCounter := counter.NewOnlineCounter(hub2)
 	unreadMessageCounter, err := counter.NewUnreadMessageCounter(db, hub2)
 	if err != nil {
@@ -75,8 +76,15 @@ func newServer(hub2 *hub.Hub, db *gorm.DB, repo repository.Repository, fs storag
 	}
 	viewerManager := viewer.NewManager(hub2)
 	wsStreamer := ws2.NewStreamer(hub2, viewerManager, webrtcv3Manager, logger)
+	botService := bot.NewService(repo, manager, hub2, streamer, wsStreamer, logger)
+	smtpConfig := provideSMTPConfig(c2)
+	emailClient, err := newEmailClientIfAvailable(smtpConfig, logger)
+	if err != nil {
+		return nil, err
+	}
 	serverOriginString := provideServerOriginString(c2)
-	notificationService := notification.NewService(repo, manager, messageManager, fileManager, hub2, logger, client, wsStreamer, viewerManager, serverOriginString)
+	notificationService := notification.NewService(repo, manager, messageManager, fileManager, hub2, logger, client, emailClient, wsStreamer, viewerManager, serverOriginString)
+	outgoingwebhookService := outgoingwebhook.NewService(repo, manager, hub2, logger)
 	ogpService, err := ogp.NewServiceImpl(repo, logger)
 	if err != nil {
 		return nil, err
@@ -85,8 +93,15 @@ func newServer(hub2 *hub.Hub, db *gorm.DB, repo repository.Repository, fs storag
 	if err != nil {
 		return nil, err
 	}
-	esEngineConfig := provideESEngineConfig(c2)
-	engine, err := initSearchServiceIfAvailable(messageManager, manager, repo, logger, esEngineConfig)
+	scheduleService, err := schedule.NewService(repo, manager, messageManager, logger)
+	if err != nil {
+		return nil, err
+	}
+	engine, err := initSearchServiceIfAvailable(db, messageManager, manager, repo, hub2, logger, c2)
+	if err != nil {
+		return nil, err
+	}
+	entityEngine, err := initEntitySearchServiceIfAvailable(db, hub2, manager, repo, logger, c2)
 	if err != nil {
 		return nil, err
 	}
@@ -99,13 +114,17 @@ func newServer(hub2 *hub.Hub, db *gorm.DB, repo repository.Repository, fs storag
 		ChannelCounter:       channelCounter,
 		StampThrottler:       stampThrottler,
 		FCM:                  client,
+		Email:                emailClient,
 		FileManager:          fileManager,
 		Imaging:              processor,
 		MessageManager:       messageManager,
 		Notification:         notificationService,
 		OGP:                  ogpService,
+		OutgoingWebhook:      outgoingwebhookService,
 		RBAC:                 rbacRBAC,
+		Schedule:             scheduleService,
 		Search:               engine,
+		EntitySearch:         entityEngine,
 		ViewerManager:        viewerManager,
 		WebRTCv3:             webrtcv3Manager,
 		WS:                   wsStreamer,
@@ -15,6 +15,12 @@ const (
 	// 		user_id: uuid.UUID
 	// 		file_id: uuid.UUID
 	UserIconUpdated = "user.icon_updated"
+	// UserAccountStatusUpdated ユーザーのアカウント状態が変更された
+	// 	Fields:
+	// 		user_id: uuid.UUID
+	// 		status: model.UserAccountStatus
+	// 		old_status: model.UserAccountStatus
+	UserAccountStatusUpdated = "user.account_status.updated"
 	// UserOnline ユーザーがオンラインになった
 	// 	Fields:
 	// 		user_id: uuid.UUID
@@ -29,6 +35,16 @@ const (
 	// 		user_id: uuid.UUID
 	// 		view_states: map[string]viewer.StateWithChannel
 	UserViewStateChanged = "user.viewstate.changed"
+	// UserKeywordsUpdated ユーザーの通知キーワードが更新された
+	// 	Fields:
+	// 		user_id: uuid.UUID
+	UserKeywordsUpdated = "user.keywords.updated"
+	// UserEmailVerificationRequested ユーザーのメール通知の宛先アドレスの確認が要求された
+	// 	Fields:
+	// 		user_id: uuid.UUID
+	// 		address: string
+	// 		token: string
+	UserEmailVerificationRequested = "user.email_verification.requested"
 
 	// UserTagAdded ユーザーにタグが追加された
 	// 	Fields:
@@ -97,6 +113,25 @@ const (
 	// 		message: *model.Message
 	// 		old_message: *model.Message
 	MessageUpdated = "message.updated"
+	// MessageComponentsUpdated メッセージのコンポーネントが更新された
+	// 	Fields:
+	// 		message_id: uuid.UUID
+	// 		message: *model.Message
+	MessageComponentsUpdated = "message.components_updated"
+	// MessageInteractionCreated メッセージのコンポーネントが操作された
+	// 	Fields:
+	// 		message_id: uuid.UUID
+	// 		message: *model.Message
+	// 		interaction: *model.MessageInteraction
+	MessageInteractionCreated = "message.interaction_created"
+	// MessageInteractionReplied メッセージコンポーネントの操作に対して操作したユーザーのみに見える返信が送信された
+	// 	Fields:
+	// 		message_id: uuid.UUID
+	// 		channel_id: uuid.UUID
+	// 		interaction: *model.MessageInteraction
+	// 		bot_user_id: uuid.UUID
+	// 		content: string
+	MessageInteractionReplied = "message.interaction_replied"
 	// MessageDeleted メッセージが削除された
 	// 	Fields:
 	// 		message_id: uuid.UUID
@@ -139,6 +174,32 @@ const (
 	// 		message: *model.Message
 	// 		cited_ids: []uuid.UUID	引用されたメッセージのIDの配列
 	MessageCited = "message.cited"
+	// ThreadFollowed スレッドがフォローされた
+	// 	Fields:
+	// 		user_id: uuid.UUID
+	// 		message_id: uuid.UUID	スレッドの親メッセージのID
+	ThreadFollowed = "thread.followed"
+	// ThreadUnfollowed スレッドのフォローが解除された
+	// 	Fields:
+	// 		user_id: uuid.UUID
+	// 		message_id: uuid.UUID	スレッドの親メッセージのID
+	ThreadUnfollowed = "thread.unfollowed"
+	// MessageReportCreated メッセージが通報された
+	// 	Fields:
+	// 		report_id: uuid.UUID
+	// 		report: *model.MessageReport
+	MessageReportCreated = "message_report.created"
+	// MessageReportResolved メッセージ通報の対応状態が更新された
+	// 	Fields:
+	// 		report_id: uuid.UUID
+	// 		report: *model.MessageReport
+	MessageReportResolved = "message_report.resolved"
+	// SavedSearchMatched 保存された検索に新しくメッセージがヒットした
+	// 	Fields:
+	// 		user_id: uuid.UUID
+	// 		saved_search: *model.SavedSearch
+	// 		message_ids: []uuid.UUID	投稿日時の新しい順
+	SavedSearchMatched = "saved_search.matched"
 
 	// ChannelCreated チャンネルが作成された
 	// 	Fields:
@@ -267,6 +328,24 @@ const (
 	// 		bot_id: uuid.UUID
 	// 		channel_id: uuid.UUID
 	BotLeft = "bot.left"
+	// BotSlashCommandInvoked Botのスラッシュコマンドが実行された
+	// 	Fields:
+	// 		bot_id: uuid.UUID
+	// 		command: *model.BotSlashCommand
+	// 		arguments: string
+	// 		options: map[string]interface{}
+	// 		user_id: uuid.UUID
+	// 		channel_id: uuid.UUID
+	BotSlashCommandInvoked = "bot.slash_command"
+	// BotEphemeralMessageRequested Botが指定したユーザーのみに見えるメッセージの送信を要求した
+	// 	Fields:
+	// 		bot_id: uuid.UUID
+	// 		message_id: uuid.UUID
+	// 		channel_id: uuid.UUID
+	// 		user_id: uuid.UUID
+	// 		content: string
+	// 		ttl: time.Duration
+	BotEphemeralMessageRequested = "bot.ephemeral_message"
 
 	// UserWebRTCv3StateChanged ユーザーのWebRTCの状態が変化した
 	// 	Fields:
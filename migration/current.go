@@ -42,6 +42,21 @@ func Migrations() []*gormigrate.Migration {
 		v29(), // BotにModeを追加、WebSocket Modeを追加
 		v30(), // bot_event_logsにresultを追加
 		v31(), // お気に入りスタンプパーミッション削除（削除忘れ）
+		v32(), // メッセージスレッドの追加
+		v33(), // 予約投稿の追加
+		v34(), // メッセージの編集回数を追加
+		v35(), // メッセージ通報の対応状態を追加
+		v36(), // ユーザー設定におやすみモードを追加
+		v37(), // ユーザーの通知キーワードの追加
+		v38(), // ユーザー設定にメール通知を追加
+		v39(), // 送信Webhookの追加
+		v40(), // BOTスラッシュコマンドの追加
+		v41(), // メッセージコンポーネントの追加
+		v42(), // Botの権限設定を追加
+		v43(), // Botイベント配送キューの追加
+		v44(), // DB検索エンジン用メッセージインデックスの追加
+		v45(), // ファイル・チャンネル・ユーザー検索用インデックスの追加
+		v46(), // 保存された検索の追加
 	}
 }
 
@@ -55,19 +70,25 @@ func AllTables() []interface{} {
 		&model.RolePermission{},
 		&model.DMChannelMapping{},
 		&model.ChannelLatestMessage{},
+		&model.BotSlashCommand{},
 		&model.BotEventLog{},
+		&model.BotEventDelivery{},
 		&model.BotJoinChannel{},
 		&model.Bot{},
 		&model.OAuth2Client{},
 		&model.OAuth2Authorize{},
 		&model.OAuth2Token{},
 		&model.MessageReport{},
+		&model.OutgoingWebhookDelivery{},
+		&model.OutgoingWebhook{},
 		&model.WebhookBot{},
 		&model.Stamp{},
 		&model.UsersTag{},
 		&model.Unread{},
 		&model.Star{},
 		&model.Device{},
+		&model.MessageInteraction{},
+		&model.MessageComponents{},
 		&model.Pin{},
 		&model.FileACLEntry{},
 		&model.FileThumbnail{},
@@ -76,6 +97,8 @@ func AllTables() []interface{} {
 		&model.UserSubscribeChannel{},
 		&model.Tag{},
 		&model.ArchivedMessage{},
+		&model.ThreadFollow{},
+		&model.ScheduledMessage{},
 		&model.ClipFolderMessage{},
 		&model.Message{},
 		&model.StampPalette{},
@@ -87,9 +110,13 @@ func AllTables() []interface{} {
 		&model.Channel{},
 		&model.ClipFolder{},
 		&model.UserSettings{},
+		&model.NotificationKeyword{},
+		&model.SavedSearch{},
 		&model.User{},
 		&model.MessageStamp{},
 		&model.SessionRecord{},
 		&model.OgpCache{},
+		&model.MessageSearchIndex{},
+		&model.SearchEntityIndex{},
 	}
 }
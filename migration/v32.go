@@ -0,0 +1,59 @@
+package migration
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+)
+
+// v32 メッセージスレッドの追加
+func v32() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "32",
+		Migrate: func(db *gorm.DB) error {
+			if err := db.Exec("ALTER TABLE messages ADD COLUMN parent_id char(36) NULL AFTER text").Error; err != nil {
+				return err
+			}
+
+			// 複合インデックス
+			indexes := [][3]string{
+				// table name, index name, field names
+				{"messages", "idx_messages_parent_id_deleted_at_created_at", "(parent_id, deleted_at, created_at)"},
+			}
+			for _, c := range indexes {
+				if err := db.Exec(fmt.Sprintf("ALTER TABLE %s ADD KEY %s %s", c[0], c[1], c[2])).Error; err != nil {
+					return err
+				}
+			}
+
+			if err := db.AutoMigrate(&v32ThreadFollow{}); err != nil {
+				return err
+			}
+
+			foreignKeys := [][6]string{
+				// table name, constraint name, field name, references, on delete, on update
+				{"users_follow_threads", "users_follow_threads_user_id_users_id_foreign", "user_id", "users(id)", "CASCADE", "CASCADE"},
+				{"users_follow_threads", "users_follow_threads_message_id_messages_id_foreign", "message_id", "messages(id)", "CASCADE", "CASCADE"},
+			}
+			for _, c := range foreignKeys {
+				if err := db.Exec(fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s ON DELETE %s ON UPDATE %s", c[0], c[1], c[2], c[3], c[4], c[5])).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
+
+type v32ThreadFollow struct {
+	UserID    uuid.UUID `gorm:"type:char(36);not null;primaryKey"`
+	MessageID uuid.UUID `gorm:"type:char(36);not null;primaryKey;index"`
+	CreatedAt time.Time `gorm:"precision:6"`
+}
+
+func (*v32ThreadFollow) TableName() string {
+	return "users_follow_threads"
+}
@@ -0,0 +1,21 @@
+package migration
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// v34 メッセージの編集回数を追加
+func v34() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "34",
+		Migrate: func(db *gorm.DB) error {
+			if err := db.Exec("ALTER TABLE messages ADD COLUMN revision_count int NOT NULL DEFAULT 0 AFTER parent_id").Error; err != nil {
+				return err
+			}
+
+			// 既存の編集履歴から編集回数を埋める
+			return db.Exec("UPDATE messages m INNER JOIN (SELECT message_id, COUNT(*) AS c FROM archived_messages GROUP BY message_id) a ON m.id = a.message_id SET m.revision_count = a.c").Error
+		},
+	}
+}
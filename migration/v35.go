@@ -0,0 +1,39 @@
+package migration
+
+import (
+	"fmt"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// v35 メッセージ通報の対応状態を追加
+func v35() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "35",
+		Migrate: func(db *gorm.DB) error {
+			columns := []string{
+				"ADD COLUMN state varchar(16) NOT NULL DEFAULT 'open' AFTER reason",
+				"ADD COLUMN resolver_id char(36) NULL AFTER state",
+				"ADD COLUMN note TEXT COLLATE utf8mb4_bin NOT NULL AFTER resolver_id",
+				"ADD COLUMN resolved_at datetime(6) NULL AFTER created_at",
+			}
+			for _, c := range columns {
+				if err := db.Exec(fmt.Sprintf("ALTER TABLE message_reports %s", c)).Error; err != nil {
+					return err
+				}
+			}
+
+			indexes := [][3]string{
+				// table name, index name, field names
+				{"message_reports", "idx_message_reports_state", "(state)"},
+			}
+			for _, c := range indexes {
+				if err := db.Exec(fmt.Sprintf("ALTER TABLE %s ADD KEY %s %s", c[0], c[1], c[2])).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,29 @@
+package migration
+
+import (
+	"fmt"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// v36 ユーザー設定におやすみモードを追加
+func v36() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "36",
+		Migrate: func(db *gorm.DB) error {
+			columns := []string{
+				"ADD COLUMN dnd_schedules TEXT COLLATE utf8mb4_bin NOT NULL AFTER notify_citation",
+				"ADD COLUMN dnd_timezone varchar(64) NOT NULL DEFAULT '' AFTER dnd_schedules",
+				"ADD COLUMN dnd_digest boolean NOT NULL DEFAULT false AFTER dnd_timezone",
+				"ADD COLUMN snooze_until datetime(6) NULL AFTER dnd_digest",
+			}
+			for _, c := range columns {
+				if err := db.Exec(fmt.Sprintf("ALTER TABLE user_settings %s", c)).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
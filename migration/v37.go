@@ -0,0 +1,43 @@
+package migration
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+)
+
+// v37 ユーザーの通知キーワードの追加
+func v37() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "37",
+		Migrate: func(db *gorm.DB) error {
+			if err := db.AutoMigrate(&v37NotificationKeyword{}); err != nil {
+				return err
+			}
+
+			foreignKeys := [][6]string{
+				// table name, constraint name, field name, references, on delete, on update
+				{"user_notification_keywords", "user_notification_keywords_user_id_users_id_foreign", "user_id", "users(id)", "CASCADE", "CASCADE"},
+			}
+			for _, c := range foreignKeys {
+				if err := db.Exec(fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s ON DELETE %s ON UPDATE %s", c[0], c[1], c[2], c[3], c[4], c[5])).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
+
+type v37NotificationKeyword struct {
+	UserID    uuid.UUID `gorm:"type:char(36);not null;primaryKey"`
+	Keyword   string    `gorm:"type:varchar(50);not null;primaryKey"`
+	CreatedAt time.Time `gorm:"precision:6"`
+}
+
+func (*v37NotificationKeyword) TableName() string {
+	return "user_notification_keywords"
+}
@@ -0,0 +1,35 @@
+package migration
+
+import (
+	"fmt"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// v38 ユーザー設定にメール通知を追加
+func v38() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "38",
+		Migrate: func(db *gorm.DB) error {
+			columns := []string{
+				"ADD COLUMN email_address varchar(254) NOT NULL DEFAULT '' AFTER snooze_until",
+				"ADD COLUMN email_immediate boolean NOT NULL DEFAULT false AFTER email_address",
+				"ADD COLUMN email_digest varchar(10) NOT NULL DEFAULT '' AFTER email_immediate",
+				"ADD COLUMN email_digest_sent_at datetime(6) NULL AFTER email_digest",
+				"ADD COLUMN email_unsubscribe_token varchar(64) NOT NULL DEFAULT '' AFTER email_digest_sent_at",
+				"ADD INDEX idx_user_settings_email_unsubscribe_token (email_unsubscribe_token)",
+				"ADD COLUMN pending_email_address varchar(254) NOT NULL DEFAULT '' AFTER email_unsubscribe_token",
+				"ADD COLUMN email_verification_token varchar(64) NOT NULL DEFAULT '' AFTER pending_email_address",
+				"ADD COLUMN email_verification_sent_at datetime(6) NULL AFTER email_verification_token",
+				"ADD INDEX idx_user_settings_email_verification_token (email_verification_token)",
+			}
+			for _, c := range columns {
+				if err := db.Exec(fmt.Sprintf("ALTER TABLE user_settings %s", c)).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,67 @@
+package migration
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+)
+
+// v39 送信Webhookの追加
+func v39() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "39",
+		Migrate: func(db *gorm.DB) error {
+			if err := db.AutoMigrate(&v39OutgoingWebhook{}, &v39OutgoingWebhookDelivery{}); err != nil {
+				return err
+			}
+
+			foreignKeys := [][6]string{
+				// table name, constraint name, field name, references, on delete, on update
+				{"outgoing_webhooks", "outgoing_webhooks_channel_id_channels_id_foreign", "channel_id", "channels(id)", "CASCADE", "CASCADE"},
+				{"outgoing_webhooks", "outgoing_webhooks_creator_id_users_id_foreign", "creator_id", "users(id)", "CASCADE", "CASCADE"},
+				{"outgoing_webhook_deliveries", "outgoing_webhook_deliveries_webhook_id_outgoing_webhooks_id_foreign", "webhook_id", "outgoing_webhooks(id)", "CASCADE", "CASCADE"},
+			}
+			for _, c := range foreignKeys {
+				if err := db.Exec(fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s ON DELETE %s ON UPDATE %s", c[0], c[1], c[2], c[3], c[4], c[5])).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
+
+type v39OutgoingWebhook struct {
+	ID        uuid.UUID `gorm:"type:char(36);not null;primaryKey"`
+	ChannelID uuid.UUID `gorm:"type:char(36);not null;index"`
+	CreatorID uuid.UUID `gorm:"type:char(36);not null"`
+	URL       string    `gorm:"type:text;not null"`
+	Secret    string    `gorm:"type:varchar(50);not null"`
+	Events    string    `gorm:"type:text;not null"`
+	CreatedAt time.Time `gorm:"precision:6"`
+	UpdatedAt time.Time `gorm:"precision:6"`
+}
+
+func (*v39OutgoingWebhook) TableName() string {
+	return "outgoing_webhooks"
+}
+
+type v39OutgoingWebhookDelivery struct {
+	ID        uuid.UUID `gorm:"type:char(36);not null;primaryKey"`
+	WebhookID uuid.UUID `gorm:"type:char(36);not null;index:webhook_id_date_time_idx"`
+	Event     string    `gorm:"type:varchar(30);not null"`
+	Body      string    `gorm:"type:text"`
+	Result    string    `gorm:"type:char(2);not null"`
+	Error     string    `gorm:"type:text"`
+	Code      int       `gorm:"not null;default:0"`
+	Attempts  int       `gorm:"not null;default:0"`
+	Latency   int64     `gorm:"not null;default:0"`
+	DateTime  time.Time `gorm:"precision:6;index:webhook_id_date_time_idx"`
+}
+
+func (*v39OutgoingWebhookDelivery) TableName() string {
+	return "outgoing_webhook_deliveries"
+}
@@ -0,0 +1,75 @@
+package migration
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+)
+
+// v40 BOTスラッシュコマンドの追加
+func v40() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "40",
+		Migrate: func(db *gorm.DB) error {
+			if err := db.AutoMigrate(&v40BotSlashCommand{}); err != nil {
+				return err
+			}
+
+			addedRolePermissions := map[string][]string{
+				"bot": {
+					"edit_bot_slash_command",
+				},
+				"user": {
+					"edit_bot_slash_command",
+				},
+				"manage_bot": {
+					"edit_bot_slash_command",
+				},
+			}
+			for role, perms := range addedRolePermissions {
+				for _, perm := range perms {
+					if err := db.Create(&v40RolePermission{Role: role, Permission: perm}).Error; err != nil {
+						return err
+					}
+				}
+			}
+
+			foreignKeys := [][6]string{
+				// table name, constraint name, field name, references, on delete, on update
+				{"bot_slash_commands", "bot_slash_commands_bot_id_bots_id_foreign", "bot_id", "bots(id)", "CASCADE", "CASCADE"},
+			}
+			for _, c := range foreignKeys {
+				if err := db.Exec(fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s ON DELETE %s ON UPDATE %s", c[0], c[1], c[2], c[3], c[4], c[5])).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
+
+type v40BotSlashCommand struct {
+	ID          uuid.UUID `gorm:"type:char(36);not null;primaryKey"`
+	BotID       uuid.UUID `gorm:"type:char(36);not null;uniqueIndex:bot_id_name"`
+	Name        string    `gorm:"type:varchar(32);not null;uniqueIndex:bot_id_name"`
+	Description string    `gorm:"type:text;not null"`
+	Options     string    `gorm:"type:text;not null"`
+	CreatedAt   time.Time `gorm:"precision:6"`
+	UpdatedAt   time.Time `gorm:"precision:6"`
+}
+
+func (*v40BotSlashCommand) TableName() string {
+	return "bot_slash_commands"
+}
+
+type v40RolePermission struct {
+	Role       string `gorm:"type:varchar(30);not null;primaryKey"`
+	Permission string `gorm:"type:varchar(30);not null;primaryKey"`
+}
+
+func (*v40RolePermission) TableName() string {
+	return "user_role_permissions"
+}
@@ -0,0 +1,84 @@
+package migration
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+)
+
+// v41 メッセージコンポーネントの追加
+func v41() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "41",
+		Migrate: func(db *gorm.DB) error {
+			if err := db.AutoMigrate(&v41MessageComponents{}, &v41MessageInteraction{}); err != nil {
+				return err
+			}
+
+			addedRolePermissions := map[string][]string{
+				"write": {
+					"interact_message",
+				},
+				"user": {
+					"interact_message",
+				},
+			}
+			for role, perms := range addedRolePermissions {
+				for _, perm := range perms {
+					if err := db.Create(&v41RolePermission{Role: role, Permission: perm}).Error; err != nil {
+						return err
+					}
+				}
+			}
+
+			foreignKeys := [][6]string{
+				// table name, constraint name, field name, references, on delete, on update
+				{"message_components", "message_components_message_id_messages_id_foreign", "message_id", "messages(id)", "CASCADE", "CASCADE"},
+				{"message_interactions", "message_interactions_message_id_messages_id_foreign", "message_id", "messages(id)", "CASCADE", "CASCADE"},
+				{"message_interactions", "message_interactions_user_id_users_id_foreign", "user_id", "users(id)", "CASCADE", "CASCADE"},
+			}
+			for _, c := range foreignKeys {
+				if err := db.Exec(fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s ON DELETE %s ON UPDATE %s", c[0], c[1], c[2], c[3], c[4], c[5])).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
+
+type v41MessageComponents struct {
+	MessageID  uuid.UUID `gorm:"type:char(36);not null;primaryKey"`
+	Components string    `gorm:"type:text;not null"`
+	CreatedAt  time.Time `gorm:"precision:6"`
+	UpdatedAt  time.Time `gorm:"precision:6"`
+}
+
+func (*v41MessageComponents) TableName() string {
+	return "message_components"
+}
+
+type v41MessageInteraction struct {
+	ID        uuid.UUID `gorm:"type:char(36);not null;primaryKey"`
+	MessageID uuid.UUID `gorm:"type:char(36);not null;index"`
+	UserID    uuid.UUID `gorm:"type:char(36);not null"`
+	CustomID  string    `gorm:"type:varchar(100);not null"`
+	Values    string    `gorm:"type:text;not null"`
+	CreatedAt time.Time `gorm:"precision:6"`
+}
+
+func (*v41MessageInteraction) TableName() string {
+	return "message_interactions"
+}
+
+type v41RolePermission struct {
+	Role       string `gorm:"type:varchar(30);not null;primaryKey"`
+	Permission string `gorm:"type:varchar(30);not null;primaryKey"`
+}
+
+func (*v41RolePermission) TableName() string {
+	return "user_role_permissions"
+}
@@ -0,0 +1,17 @@
+package migration
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// v42 Botの権限設定を追加
+func v42() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "42",
+		Migrate: func(db *gorm.DB) error {
+			// NULLは制限なしを表す
+			return db.Exec("ALTER TABLE bots ADD COLUMN permissions text NULL AFTER subscribe_events").Error
+		},
+	}
+}
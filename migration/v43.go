@@ -0,0 +1,36 @@
+package migration
+
+import (
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+)
+
+// v43 Botイベント配送キューの追加
+func v43() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "43",
+		Migrate: func(db *gorm.DB) error {
+			return db.AutoMigrate(&v43BotEventDelivery{})
+		},
+	}
+}
+
+type v43BotEventDelivery struct {
+	ID            uuid.UUID `gorm:"type:char(36);not null;primaryKey"`
+	BotID         uuid.UUID `gorm:"type:char(36);not null;index:bot_id_dead_idx"`
+	Event         string    `gorm:"type:varchar(30);not null"`
+	Body          string    `gorm:"type:text"`
+	Attempts      int       `gorm:"not null;default:0"`
+	LastError     string    `gorm:"type:text"`
+	Dead          bool      `gorm:"type:boolean;not null;default:false;index:bot_id_dead_idx"`
+	NextAttemptAt time.Time `gorm:"precision:6;index"`
+	CreatedAt     time.Time `gorm:"precision:6"`
+	UpdatedAt     time.Time `gorm:"precision:6"`
+}
+
+func (*v43BotEventDelivery) TableName() string {
+	return "bot_event_deliveries"
+}
@@ -0,0 +1,39 @@
+package migration
+
+import (
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+)
+
+// v44 DB検索エンジン用メッセージインデックスの追加
+func v44() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "44",
+		Migrate: func(db *gorm.DB) error {
+			return db.AutoMigrate(&v44MessageSearchIndex{})
+		},
+	}
+}
+
+type v44MessageSearchIndex struct {
+	MessageID      uuid.UUID `gorm:"type:char(36);not null;primaryKey"`
+	UserID         uuid.UUID `gorm:"type:char(36);not null"`
+	ChannelID      uuid.UUID `gorm:"type:char(36);not null;index"`
+	IsPublic       bool      `gorm:"type:boolean;not null"`
+	Bot            bool      `gorm:"type:boolean;not null"`
+	Tokens         string    `gorm:"type:mediumtext;not null;index:idx_message_search_index_tokens,class:FULLTEXT"`
+	HasURL         bool      `gorm:"type:boolean;not null"`
+	HasAttachments bool      `gorm:"type:boolean;not null"`
+	HasImage       bool      `gorm:"type:boolean;not null"`
+	HasVideo       bool      `gorm:"type:boolean;not null"`
+	HasAudio       bool      `gorm:"type:boolean;not null"`
+	CreatedAt      time.Time `gorm:"precision:6;index"`
+	UpdatedAt      time.Time `gorm:"precision:6;index"`
+}
+
+func (*v44MessageSearchIndex) TableName() string {
+	return "message_search_indices"
+}
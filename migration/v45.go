@@ -0,0 +1,35 @@
+package migration
+
+import (
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+)
+
+// v45 ファイル・チャンネル・ユーザー検索用インデックスの追加
+func v45() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "45",
+		Migrate: func(db *gorm.DB) error {
+			return db.AutoMigrate(&v45SearchEntityIndex{})
+		},
+	}
+}
+
+type v45SearchEntityIndex struct {
+	Type      string    `gorm:"type:varchar(16);not null;primaryKey"`
+	EntityID  uuid.UUID `gorm:"type:char(36);not null;primaryKey"`
+	Tokens    string    `gorm:"type:mediumtext;not null;index:idx_search_entity_indices_tokens,class:FULLTEXT"`
+	Mime      string    `gorm:"type:varchar(255);not null"`
+	CreatorID uuid.UUID `gorm:"type:char(36);not null"`
+	ChannelID uuid.UUID `gorm:"type:char(36);not null"`
+	IsPublic  bool      `gorm:"type:boolean;not null"`
+	CreatedAt time.Time `gorm:"precision:6;index"`
+	UpdatedAt time.Time `gorm:"precision:6;index"`
+}
+
+func (*v45SearchEntityIndex) TableName() string {
+	return "search_entity_indices"
+}
@@ -3,12 +3,15 @@ package model
 import (
 	"database/sql/driver"
 	"errors"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/gofrs/uuid"
 	jsonIter "github.com/json-iterator/go"
 	"gorm.io/gorm"
+
+	"github.com/traPtitech/traQ/utils/optional"
 )
 
 // BotMode Bot動作モード
@@ -39,21 +42,22 @@ const (
 
 // Bot Bot構造体
 type Bot struct {
-	ID                uuid.UUID      `gorm:"type:char(36);not null;primaryKey"`
-	BotUserID         uuid.UUID      `gorm:"type:char(36);not null;unique"`
-	Description       string         `gorm:"type:text;not null"`
-	VerificationToken string         `gorm:"type:varchar(30);not null"`
-	AccessTokenID     uuid.UUID      `gorm:"type:char(36);not null"`
-	PostURL           string         `gorm:"type:text;not null"`
-	SubscribeEvents   BotEventTypes  `gorm:"type:text;not null"`
-	Privileged        bool           `gorm:"type:boolean;not null;default:false"`
-	Mode              BotMode        `gorm:"type:varchar(30);not null"`
-	State             BotState       `gorm:"type:tinyint;not null;default:0"`
-	BotCode           string         `gorm:"type:varchar(30);not null;unique"`
-	CreatorID         uuid.UUID      `gorm:"type:char(36);not null"`
-	CreatedAt         time.Time      `gorm:"precision:6"`
-	UpdatedAt         time.Time      `gorm:"precision:6"`
-	DeletedAt         gorm.DeletedAt `gorm:"precision:6"`
+	ID                uuid.UUID                   `gorm:"type:char(36);not null;primaryKey"`
+	BotUserID         uuid.UUID                   `gorm:"type:char(36);not null;unique"`
+	Description       string                      `gorm:"type:text;not null"`
+	VerificationToken string                      `gorm:"type:varchar(30);not null"`
+	AccessTokenID     uuid.UUID                   `gorm:"type:char(36);not null"`
+	PostURL           string                      `gorm:"type:text;not null"`
+	SubscribeEvents   BotEventTypes               `gorm:"type:text;not null"`
+	Permissions       optional.Of[BotPermissions] `gorm:"type:text"`
+	Privileged        bool                        `gorm:"type:boolean;not null;default:false"`
+	Mode              BotMode                     `gorm:"type:varchar(30);not null"`
+	State             BotState                    `gorm:"type:tinyint;not null;default:0"`
+	BotCode           string                      `gorm:"type:varchar(30);not null;unique"`
+	CreatorID         uuid.UUID                   `gorm:"type:char(36);not null"`
+	CreatedAt         time.Time                   `gorm:"precision:6"`
+	UpdatedAt         time.Time                   `gorm:"precision:6"`
+	DeletedAt         gorm.DeletedAt              `gorm:"precision:6"`
 
 	BotUser *User `gorm:"constraint:bots_bot_user_id_users_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE;foreignKey:BotUserID"`
 	Creator *User `gorm:"constraint:bots_creator_id_users_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE;foreignKey:CreatorID"`
@@ -93,6 +97,28 @@ func (*BotEventLog) TableName() string {
 	return "bot_event_logs"
 }
 
+// BotEventDelivery Botイベント配送キュー
+//
+// 配送に失敗したイベントは再試行されるまでこのキューに保持されます。
+// 再試行回数の上限に達したイベントはDeadとなり、再配送されるか一定期間が経過するまで保持されます。
+type BotEventDelivery struct {
+	ID            uuid.UUID    `gorm:"type:char(36);not null;primaryKey"`
+	BotID         uuid.UUID    `gorm:"type:char(36);not null;index:bot_id_dead_idx"`
+	Event         BotEventType `gorm:"type:varchar(30);not null"`
+	Body          string       `gorm:"type:text"`
+	Attempts      int          `gorm:"not null;default:0"`
+	LastError     string       `gorm:"type:text"`
+	Dead          bool         `gorm:"type:boolean;not null;default:false;index:bot_id_dead_idx"`
+	NextAttemptAt time.Time    `gorm:"precision:6;index"`
+	CreatedAt     time.Time    `gorm:"precision:6"`
+	UpdatedAt     time.Time    `gorm:"precision:6"`
+}
+
+// TableName BotEventDeliveryのテーブル名
+func (*BotEventDelivery) TableName() string {
+	return "bot_event_deliveries"
+}
+
 // BotEventType Botイベントタイプ
 type BotEventType string
 
@@ -181,3 +207,82 @@ func (set *BotEventTypes) Scan(src interface{}) error {
 	}
 	return nil
 }
+
+// IsPermitted Botに指定した権限が許可されているかどうか
+//
+// Permissionsが無効値の場合、Botロールの全ての権限が許可されます。
+func (b *Bot) IsPermitted(perm string) bool {
+	return !b.Permissions.Valid || b.Permissions.V.Contains(perm)
+}
+
+// BotPermissions Botに許可する権限名のSet
+//
+// 空の場合、Botには権限が許可されません。Botロールの全ての権限を許可する場合は、Bot.Permissionsを無効値にします。
+type BotPermissions map[string]struct{}
+
+func BotPermissionsFromArray(arr []string) BotPermissions {
+	res := BotPermissions{}
+	for _, v := range arr {
+		if len(v) > 0 {
+			res[v] = struct{}{}
+		}
+	}
+	return res
+}
+
+// String BotPermissionsをスペース区切りで文字列に出力します
+func (set BotPermissions) String() string {
+	return strings.Join(set.Array(), " ")
+}
+
+// Contains 指定した権限が含まれているかどうか
+func (set BotPermissions) Contains(perm string) bool {
+	_, ok := set[perm]
+	return ok
+}
+
+// Array BotPermissionsをstringの配列に変換します
+func (set BotPermissions) Array() (r []string) {
+	r = make([]string, 0, len(set))
+	for s := range set {
+		r = append(r, s)
+	}
+	sort.Strings(r)
+	return r
+}
+
+// MarshalJSON encoding/json.Marshaler 実装
+func (set BotPermissions) MarshalJSON() ([]byte, error) {
+	return jsonIter.ConfigFastest.Marshal(set.Array())
+}
+
+// UnmarshalJSON encoding/json.Unmarshaler 実装
+func (set *BotPermissions) UnmarshalJSON(data []byte) error {
+	var arr []string
+	err := jsonIter.ConfigFastest.Unmarshal(data, &arr)
+	if err != nil {
+		return err
+	}
+	*set = BotPermissionsFromArray(arr)
+	return nil
+}
+
+// Value database/sql/driver.Valuer 実装
+func (set BotPermissions) Value() (driver.Value, error) {
+	return set.String(), nil
+}
+
+// Scan database/sql.Scanner 実装
+func (set *BotPermissions) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case nil:
+		*set = BotPermissions{}
+	case string:
+		*set = BotPermissionsFromArray(strings.Split(s, " "))
+	case []byte:
+		*set = BotPermissionsFromArray(strings.Split(string(s), " "))
+	default:
+		return errors.New("failed to scan BotPermissions")
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package model
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/gofrs/uuid"
+)
+
+// BotSlashCommandNameRegex スラッシュコマンド名の正規表現
+var BotSlashCommandNameRegex = regexp.MustCompile(`^[a-zA-Z0-9_-]{1,32}$`)
+
+// BotSlashCommandOptionType スラッシュコマンド引数の型
+type BotSlashCommandOptionType string
+
+const (
+	// BotSlashCommandOptionTypeString 文字列
+	BotSlashCommandOptionTypeString BotSlashCommandOptionType = "string"
+	// BotSlashCommandOptionTypeInteger 整数
+	BotSlashCommandOptionTypeInteger BotSlashCommandOptionType = "integer"
+	// BotSlashCommandOptionTypeBoolean 真偽値
+	BotSlashCommandOptionTypeBoolean BotSlashCommandOptionType = "boolean"
+)
+
+// Valid 有効な型かどうか
+func (t BotSlashCommandOptionType) Valid() bool {
+	switch t {
+	case BotSlashCommandOptionTypeString, BotSlashCommandOptionTypeInteger, BotSlashCommandOptionTypeBoolean:
+		return true
+	default:
+		return false
+	}
+}
+
+// BotSlashCommandOption スラッシュコマンドの引数定義
+type BotSlashCommandOption struct {
+	Name        string                    `json:"name"`
+	Description string                    `json:"description"`
+	Type        BotSlashCommandOptionType `json:"type"`
+	Required    bool                      `json:"required"`
+}
+
+// BotSlashCommandOptions スラッシュコマンドの引数定義の配列
+type BotSlashCommandOptions []*BotSlashCommandOption
+
+// Value database/sql/driver.Valuer 実装
+func (opts BotSlashCommandOptions) Value() (driver.Value, error) {
+	if opts == nil {
+		opts = BotSlashCommandOptions{}
+	}
+	return json.MarshalToString(opts)
+}
+
+// Scan database/sql.Scanner 実装
+func (opts *BotSlashCommandOptions) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case nil:
+		*opts = BotSlashCommandOptions{}
+		return nil
+	case string:
+		return json.Unmarshal([]byte(s), opts)
+	case []byte:
+		return json.Unmarshal(s, opts)
+	default:
+		return errors.New("failed to scan BotSlashCommandOptions")
+	}
+}
+
+// BotSlashCommand BOTのスラッシュコマンド構造体
+type BotSlashCommand struct {
+	ID          uuid.UUID              `gorm:"type:char(36);not null;primaryKey"`
+	BotID       uuid.UUID              `gorm:"type:char(36);not null;uniqueIndex:bot_id_name"`
+	Name        string                 `gorm:"type:varchar(32);not null;uniqueIndex:bot_id_name"`
+	Description string                 `gorm:"type:text;not null"`
+	Options     BotSlashCommandOptions `gorm:"type:text;not null"`
+	CreatedAt   time.Time              `gorm:"precision:6"`
+	UpdatedAt   time.Time              `gorm:"precision:6"`
+
+	Bot *Bot `gorm:"constraint:bot_slash_commands_bot_id_bots_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE;foreignKey:BotID"`
+}
+
+// TableName BotSlashCommandのテーブル名
+func (*BotSlashCommand) TableName() string {
+	return "bot_slash_commands"
+}
+
+// ParseArguments 引数文字列をコマンドの引数定義に従って解析します
+//
+// 引数は空白区切りで前から順に割り当てられます。最後の引数が文字列型の場合、残りの文字列が全て割り当てられます。
+func (cmd *BotSlashCommand) ParseArguments(args string) (map[string]interface{}, error) {
+	fields := strings.Fields(args)
+	result := make(map[string]interface{}, len(cmd.Options))
+	for i, opt := range cmd.Options {
+		if i >= len(fields) {
+			if opt.Required {
+				return nil, fmt.Errorf("argument '%s' is required", opt.Name)
+			}
+			continue
+		}
+
+		v := fields[i]
+		switch opt.Type {
+		case BotSlashCommandOptionTypeInteger:
+			n, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("argument '%s' must be an integer", opt.Name)
+			}
+			result[opt.Name] = n
+		case BotSlashCommandOptionTypeBoolean:
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return nil, fmt.Errorf("argument '%s' must be a boolean", opt.Name)
+			}
+			result[opt.Name] = b
+		default:
+			if i == len(cmd.Options)-1 {
+				v = strings.Join(fields[i:], " ")
+			}
+			result[opt.Name] = v
+		}
+	}
+	if len(cmd.Options) > 0 && len(fields) > len(cmd.Options) && cmd.Options[len(cmd.Options)-1].Type != BotSlashCommandOptionTypeString {
+		return nil, errors.New("too many arguments")
+	}
+	return result, nil
+}
+
+// ParseSlashCommand メッセージ本文をスラッシュコマンドとして解析します
+//
+// 本文が`/コマンド名`で始まる場合、コマンド名と残りの引数文字列、trueを返します。
+func ParseSlashCommand(text string) (name string, args string, ok bool) {
+	if !strings.HasPrefix(text, "/") {
+		return "", "", false
+	}
+	name = text[1:]
+	if i := strings.IndexFunc(name, unicode.IsSpace); i >= 0 {
+		name, args = name[:i], name[i:]
+	}
+	if !BotSlashCommandNameRegex.MatchString(name) {
+		return "", "", false
+	}
+	return name, strings.TrimSpace(args), true
+}
@@ -0,0 +1,120 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBotSlashCommand_TableName(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "bot_slash_commands", (&BotSlashCommand{}).TableName())
+}
+
+func TestBotSlashCommandOptions_ValueScan(t *testing.T) {
+	t.Parallel()
+
+	opts := BotSlashCommandOptions{
+		{Name: "count", Type: BotSlashCommandOptionTypeInteger, Required: true},
+		{Name: "text", Type: BotSlashCommandOptionTypeString},
+	}
+	v, err := opts.Value()
+	if assert.NoError(t, err) {
+		var scanned BotSlashCommandOptions
+		assert.NoError(t, scanned.Scan(v))
+		assert.Equal(t, opts, scanned)
+	}
+
+	v, err = BotSlashCommandOptions(nil).Value()
+	if assert.NoError(t, err) {
+		assert.Equal(t, "[]", v)
+	}
+}
+
+func TestParseSlashCommand(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		text string
+		name string
+		args string
+		ok   bool
+	}{
+		{"/deploy", "deploy", "", true},
+		{"/deploy  prod now ", "deploy", "prod now", true},
+		{"/deploy\nprod", "deploy", "prod", true},
+		{"deploy", "", "", false},
+		{"/", "", "", false},
+		{"/ deploy", "", "", false},
+		{"/あいう", "", "", false},
+		{"//comment", "", "", false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.text, func(t *testing.T) {
+			t.Parallel()
+			name, args, ok := ParseSlashCommand(tt.text)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.name, name)
+			assert.Equal(t, tt.args, args)
+		})
+	}
+}
+
+func TestBotSlashCommand_ParseArguments(t *testing.T) {
+	t.Parallel()
+
+	cmd := &BotSlashCommand{
+		Name: "roll",
+		Options: BotSlashCommandOptions{
+			{Name: "count", Type: BotSlashCommandOptionTypeInteger, Required: true},
+			{Name: "secret", Type: BotSlashCommandOptionTypeBoolean},
+			{Name: "comment", Type: BotSlashCommandOptionTypeString},
+		},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		res, err := cmd.ParseArguments("3 true good luck")
+		if assert.NoError(t, err) {
+			assert.Equal(t, map[string]interface{}{
+				"count":   int64(3),
+				"secret":  true,
+				"comment": "good luck",
+			}, res)
+		}
+	})
+
+	t.Run("optional omitted", func(t *testing.T) {
+		t.Parallel()
+		res, err := cmd.ParseArguments("3")
+		if assert.NoError(t, err) {
+			assert.Equal(t, map[string]interface{}{"count": int64(3)}, res)
+		}
+	})
+
+	t.Run("required missing", func(t *testing.T) {
+		t.Parallel()
+		_, err := cmd.ParseArguments("")
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid integer", func(t *testing.T) {
+		t.Parallel()
+		_, err := cmd.ParseArguments("three")
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid boolean", func(t *testing.T) {
+		t.Parallel()
+		_, err := cmd.ParseArguments("3 maybe")
+		assert.Error(t, err)
+	})
+
+	t.Run("too many arguments", func(t *testing.T) {
+		t.Parallel()
+		c := &BotSlashCommand{Options: BotSlashCommandOptions{{Name: "n", Type: BotSlashCommandOptionTypeInteger}}}
+		_, err := c.ParseArguments("1 2")
+		assert.Error(t, err)
+	})
+}
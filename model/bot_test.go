@@ -5,6 +5,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/traPtitech/traQ/utils/optional"
 )
 
 func TestBotMode_String(t *testing.T) {
@@ -120,3 +122,79 @@ func TestBotEventTypes_MarshalJSON(t *testing.T) {
 	assert.NoError(t, err)
 	assert.ElementsMatch(t, []string{`"PING"`, `"PONG"`}, strings.Split(strings.Trim(string(b), "[]"), ","))
 }
+
+func TestBotPermissions_Value(t *testing.T) {
+	t.Parallel()
+	ps := BotPermissionsFromArray([]string{"post_message", "get_channel"})
+	v, err := ps.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "get_channel post_message", v)
+}
+
+func TestBotPermissions_Scan(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil", func(t *testing.T) {
+		t.Parallel()
+
+		s := BotPermissions{}
+		assert.NoError(t, s.Scan(nil))
+		assert.EqualValues(t, BotPermissions{}, s)
+	})
+
+	t.Run("string", func(t *testing.T) {
+		t.Parallel()
+
+		s := BotPermissions{}
+		assert.NoError(t, s.Scan("a b c c  "))
+		assert.Equal(t, []string{"a", "b", "c"}, s.Array())
+	})
+
+	t.Run("empty string", func(t *testing.T) {
+		t.Parallel()
+
+		s := BotPermissions{}
+		assert.NoError(t, s.Scan(""))
+		assert.Len(t, s, 0)
+	})
+
+	t.Run("other", func(t *testing.T) {
+		t.Parallel()
+
+		s := BotPermissions{}
+		assert.Error(t, s.Scan(123))
+	})
+}
+
+func TestBotPermissions_UnmarshalJSON(t *testing.T) {
+	t.Parallel()
+	var ps BotPermissions
+	assert.NoError(t, ps.UnmarshalJSON([]byte(`["post_message","get_channel","post_message"]`)))
+	assert.True(t, ps.Contains("post_message"))
+	assert.True(t, ps.Contains("get_channel"))
+	assert.False(t, ps.Contains("edit_me"))
+	assert.Len(t, ps, 2)
+}
+
+func TestBot_IsPermitted(t *testing.T) {
+	t.Parallel()
+
+	t.Run("unrestricted", func(t *testing.T) {
+		t.Parallel()
+		b := &Bot{}
+		assert.True(t, b.IsPermitted("post_message"))
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		t.Parallel()
+		b := &Bot{Permissions: optional.From(BotPermissions{})}
+		assert.False(t, b.IsPermitted("post_message"))
+	})
+
+	t.Run("restricted", func(t *testing.T) {
+		t.Parallel()
+		b := &Bot{Permissions: optional.From(BotPermissionsFromArray([]string{"post_message"}))}
+		assert.True(t, b.IsPermitted("post_message"))
+		assert.False(t, b.IsPermitted("get_channel"))
+	})
+}
@@ -108,6 +108,10 @@ func (t ThumbnailType) String() string {
 		return "image"
 	case ThumbnailTypeWaveform:
 		return "waveform"
+	case ThumbnailTypeImageWebP:
+		return "image_webp"
+	case ThumbnailTypeImageAVIF:
+		return "image_avif"
 	default:
 		return "null"
 	}
@@ -120,6 +124,10 @@ func (t ThumbnailType) Suffix() string {
 		return "thumb"
 	case ThumbnailTypeWaveform:
 		return "waveform"
+	case ThumbnailTypeImageWebP:
+		return "thumb_webp"
+	case ThumbnailTypeImageAVIF:
+		return "thumb_avif"
 	default:
 		return "null"
 	}
@@ -131,6 +139,10 @@ func ThumbnailTypeFromString(s string) (ThumbnailType, error) {
 		return ThumbnailTypeImage, nil
 	case "waveform":
 		return ThumbnailTypeWaveform, nil
+	case "image_webp":
+		return ThumbnailTypeImageWebP, nil
+	case "image_avif":
+		return ThumbnailTypeImageAVIF, nil
 	default:
 		return 0, errors.New("unknown ThumbnailType")
 	}
@@ -141,6 +153,10 @@ const (
 	ThumbnailTypeImage ThumbnailType = iota + 1 // NOTE: 0にするとgormにゼロ値扱いされてinsertされない
 	// ThumbnailTypeWaveform 波形画像
 	ThumbnailTypeWaveform
+	// ThumbnailTypeImageWebP 通常サムネイル画像のWebP版
+	ThumbnailTypeImageWebP
+	// ThumbnailTypeImageAVIF 通常サムネイル画像のAVIF版
+	ThumbnailTypeImageAVIF
 )
 
 type File interface {
@@ -115,6 +115,8 @@ func TestThumbnailType_Value(t *testing.T) {
 		}{
 			{ThumbnailTypeImage, "image"},
 			{ThumbnailTypeWaveform, "waveform"},
+			{ThumbnailTypeImageWebP, "image_webp"},
+			{ThumbnailTypeImageAVIF, "image_avif"},
 		}
 
 		for _, c := range cases {
@@ -134,6 +136,8 @@ func TestThumbnailType_Scan(t *testing.T) {
 	}{
 		{"image", ThumbnailTypeImage},
 		{"waveform", ThumbnailTypeWaveform},
+		{"image_webp", ThumbnailTypeImageWebP},
+		{"image_avif", ThumbnailTypeImageAVIF},
 	}
 
 	t.Run("error (string)", func(t *testing.T) {
@@ -177,6 +181,8 @@ func TestThumbnailType_Suffix(t *testing.T) {
 	}{
 		{ThumbnailTypeImage, "thumb"},
 		{ThumbnailTypeWaveform, "waveform"},
+		{ThumbnailTypeImageWebP, "thumb_webp"},
+		{ThumbnailTypeImageAVIF, "thumb_avif"},
 		{ThumbnailType(-1), "null"},
 	}
 	for _, c := range cases {
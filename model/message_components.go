@@ -0,0 +1,213 @@
+package model
+
+import (
+	"database/sql/driver"
+	"errors"
+	"regexp"
+	"time"
+
+	vd "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/gofrs/uuid"
+)
+
+// MessageComponentType メッセージコンポーネントの種類
+type MessageComponentType string
+
+const (
+	// MessageComponentTypeButton ボタン
+	MessageComponentTypeButton MessageComponentType = "button"
+	// MessageComponentTypeSelect セレクトメニュー
+	MessageComponentTypeSelect MessageComponentType = "select"
+)
+
+// MessageButtonStyle ボタンの見た目
+type MessageButtonStyle string
+
+const (
+	// MessageButtonStyleDefault 通常
+	MessageButtonStyleDefault MessageButtonStyle = "default"
+	// MessageButtonStylePrimary 強調
+	MessageButtonStylePrimary MessageButtonStyle = "primary"
+	// MessageButtonStyleDanger 危険な操作
+	MessageButtonStyleDanger MessageButtonStyle = "danger"
+)
+
+// MessageComponentCustomIDRegex コンポーネントのカスタムIDの正規表現
+var MessageComponentCustomIDRegex = regexp.MustCompile(`^[a-zA-Z0-9_:.-]{1,100}$`)
+
+// MessageSelectOption セレクトメニューの選択肢
+type MessageSelectOption struct {
+	Label string `json:"label"`
+	Value string `json:"value"`
+}
+
+// Validate github.com/go-ozzo/ozzo-validation.Validatable 実装
+func (o MessageSelectOption) Validate() error {
+	return vd.ValidateStruct(&o,
+		vd.Field(&o.Label, vd.Required, vd.RuneLength(1, 100)),
+		vd.Field(&o.Value, vd.Required, vd.RuneLength(1, 100)),
+	)
+}
+
+// MessageComponent メッセージに添付されるインタラクティブなコンポーネント
+type MessageComponent struct {
+	Type        MessageComponentType   `json:"type"`
+	CustomID    string                 `json:"customId"`
+	Label       string                 `json:"label,omitempty"`
+	Style       MessageButtonStyle     `json:"style,omitempty"`
+	Placeholder string                 `json:"placeholder,omitempty"`
+	Options     []*MessageSelectOption `json:"options,omitempty"`
+	Multiple    bool                   `json:"multiple,omitempty"`
+	Disabled    bool                   `json:"disabled"`
+}
+
+// Validate github.com/go-ozzo/ozzo-validation.Validatable 実装
+func (c MessageComponent) Validate() error {
+	isButton := c.Type == MessageComponentTypeButton
+	isSelect := c.Type == MessageComponentTypeSelect
+	return vd.ValidateStruct(&c,
+		vd.Field(&c.Type, vd.Required, vd.In(MessageComponentTypeButton, MessageComponentTypeSelect)),
+		vd.Field(&c.CustomID, vd.Required, vd.Match(MessageComponentCustomIDRegex)),
+		vd.Field(&c.Label, vd.When(isButton, vd.Required), vd.RuneLength(0, 80)),
+		vd.Field(&c.Style, vd.When(isButton, vd.In(MessageButtonStyleDefault, MessageButtonStylePrimary, MessageButtonStyleDanger)).Else(vd.Empty)),
+		vd.Field(&c.Placeholder, vd.When(isButton, vd.Empty), vd.RuneLength(0, 100)),
+		vd.Field(&c.Options, vd.When(isSelect, vd.Required, vd.Length(1, 25)).Else(vd.Empty), vd.By(func(value interface{}) error {
+			values := make(map[string]bool, len(c.Options))
+			for _, o := range c.Options {
+				if o == nil {
+					return errors.New("option must not be null")
+				}
+				if values[o.Value] {
+					return errors.New("option values must be unique")
+				}
+				values[o.Value] = true
+			}
+			return nil
+		})),
+		vd.Field(&c.Multiple, vd.When(isButton, vd.Empty)),
+	)
+}
+
+// HasOption 指定した値の選択肢があるかどうか
+func (c *MessageComponent) HasOption(value string) bool {
+	for _, o := range c.Options {
+		if o.Value == value {
+			return true
+		}
+	}
+	return false
+}
+
+// MessageComponentList メッセージコンポーネントの配列
+type MessageComponentList []*MessageComponent
+
+// Validate github.com/go-ozzo/ozzo-validation.Validatable 実装
+func (l MessageComponentList) Validate() error {
+	if len(l) > 25 {
+		return errors.New("the length must be no more than 25")
+	}
+	ids := make(map[string]bool, len(l))
+	for _, c := range l {
+		if c == nil {
+			return errors.New("component must not be null")
+		}
+		if err := c.Validate(); err != nil {
+			return err
+		}
+		if ids[c.CustomID] {
+			return errors.New("customId must be unique")
+		}
+		ids[c.CustomID] = true
+	}
+	return nil
+}
+
+// Find 指定したカスタムIDのコンポーネントを返します。存在しない場合はnilを返します。
+func (l MessageComponentList) Find(customID string) *MessageComponent {
+	for _, c := range l {
+		if c.CustomID == customID {
+			return c
+		}
+	}
+	return nil
+}
+
+// Value database/sql/driver.Valuer 実装
+func (l MessageComponentList) Value() (driver.Value, error) {
+	if l == nil {
+		l = MessageComponentList{}
+	}
+	return json.MarshalToString(l)
+}
+
+// Scan database/sql.Scanner 実装
+func (l *MessageComponentList) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case nil:
+		*l = MessageComponentList{}
+		return nil
+	case string:
+		return json.Unmarshal([]byte(s), l)
+	case []byte:
+		return json.Unmarshal(s, l)
+	default:
+		return errors.New("failed to scan MessageComponentList")
+	}
+}
+
+// MessageComponents メッセージのコンポーネント構造体
+type MessageComponents struct {
+	MessageID  uuid.UUID            `gorm:"type:char(36);not null;primaryKey"`
+	Components MessageComponentList `gorm:"type:text;not null"`
+	CreatedAt  time.Time            `gorm:"precision:6"`
+	UpdatedAt  time.Time            `gorm:"precision:6"`
+}
+
+// TableName MessageComponentsのテーブル名
+func (*MessageComponents) TableName() string {
+	return "message_components"
+}
+
+// MessageInteractionValues インタラクションで選択された値の配列
+type MessageInteractionValues []string
+
+// Value database/sql/driver.Valuer 実装
+func (v MessageInteractionValues) Value() (driver.Value, error) {
+	if v == nil {
+		v = MessageInteractionValues{}
+	}
+	return json.MarshalToString(v)
+}
+
+// Scan database/sql.Scanner 実装
+func (v *MessageInteractionValues) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case nil:
+		*v = MessageInteractionValues{}
+		return nil
+	case string:
+		return json.Unmarshal([]byte(s), v)
+	case []byte:
+		return json.Unmarshal(s, v)
+	default:
+		return errors.New("failed to scan MessageInteractionValues")
+	}
+}
+
+// MessageInteraction メッセージコンポーネントに対するユーザーの操作記録
+type MessageInteraction struct {
+	ID        uuid.UUID                `gorm:"type:char(36);not null;primaryKey"`
+	MessageID uuid.UUID                `gorm:"type:char(36);not null;index"`
+	UserID    uuid.UUID                `gorm:"type:char(36);not null"`
+	CustomID  string                   `gorm:"type:varchar(100);not null"`
+	Values    MessageInteractionValues `gorm:"type:text;not null"`
+	CreatedAt time.Time                `gorm:"precision:6"`
+
+	Message *Message `gorm:"constraint:message_interactions_message_id_messages_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE"`
+	User    *User    `gorm:"constraint:message_interactions_user_id_users_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+// TableName MessageInteractionのテーブル名
+func (*MessageInteraction) TableName() string {
+	return "message_interactions"
+}
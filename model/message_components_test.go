@@ -0,0 +1,84 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMessageComponents_TableName(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "message_components", (&MessageComponents{}).TableName())
+}
+
+func TestMessageInteraction_TableName(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "message_interactions", (&MessageInteraction{}).TableName())
+}
+
+func TestMessageComponentList_Validate(t *testing.T) {
+	t.Parallel()
+
+	options := []*MessageSelectOption{{Label: "A", Value: "a"}, {Label: "B", Value: "b"}}
+	tests := []struct {
+		name  string
+		list  MessageComponentList
+		valid bool
+	}{
+		{"empty", MessageComponentList{}, true},
+		{"button", MessageComponentList{{Type: MessageComponentTypeButton, CustomID: "ok", Label: "OK", Style: MessageButtonStyleDanger}}, true},
+		{"select", MessageComponentList{{Type: MessageComponentTypeSelect, CustomID: "choice", Options: options, Multiple: true}}, true},
+		{"unknown type", MessageComponentList{{Type: "link", CustomID: "ok", Label: "OK"}}, false},
+		{"invalid custom id", MessageComponentList{{Type: MessageComponentTypeButton, CustomID: "o k", Label: "OK"}}, false},
+		{"button without label", MessageComponentList{{Type: MessageComponentTypeButton, CustomID: "ok"}}, false},
+		{"button with options", MessageComponentList{{Type: MessageComponentTypeButton, CustomID: "ok", Label: "OK", Options: options}}, false},
+		{"select without options", MessageComponentList{{Type: MessageComponentTypeSelect, CustomID: "choice"}}, false},
+		{"select with style", MessageComponentList{{Type: MessageComponentTypeSelect, CustomID: "choice", Options: options, Style: MessageButtonStylePrimary}}, false},
+		{"duplicate option values", MessageComponentList{{Type: MessageComponentTypeSelect, CustomID: "choice", Options: []*MessageSelectOption{{Label: "A", Value: "a"}, {Label: "A2", Value: "a"}}}}, false},
+		{"duplicate custom ids", MessageComponentList{
+			{Type: MessageComponentTypeButton, CustomID: "ok", Label: "OK"},
+			{Type: MessageComponentTypeButton, CustomID: "ok", Label: "OK2"},
+		}, false},
+		{"null component", MessageComponentList{nil}, false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if tt.valid {
+				assert.NoError(t, tt.list.Validate())
+			} else {
+				assert.Error(t, tt.list.Validate())
+			}
+		})
+	}
+}
+
+func TestMessageComponentList_ValueScan(t *testing.T) {
+	t.Parallel()
+
+	list := MessageComponentList{
+		{Type: MessageComponentTypeButton, CustomID: "ok", Label: "OK"},
+	}
+	v, err := list.Value()
+	if assert.NoError(t, err) {
+		var scanned MessageComponentList
+		assert.NoError(t, scanned.Scan(v))
+		assert.Equal(t, list, scanned)
+		assert.NotNil(t, scanned.Find("ok"))
+		assert.Nil(t, scanned.Find("ng"))
+	}
+
+	v, err = MessageComponentList(nil).Value()
+	if assert.NoError(t, err) {
+		assert.Equal(t, "[]", v)
+	}
+}
+
+func TestMessage_GetComponents(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, MessageComponentList{}, (&Message{}).GetComponents())
+	list := MessageComponentList{{Type: MessageComponentTypeButton, CustomID: "ok", Label: "OK"}}
+	assert.Equal(t, list, (&Message{Components: &MessageComponents{Components: list}}).GetComponents())
+}
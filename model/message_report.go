@@ -5,16 +5,45 @@ import (
 
 	"github.com/gofrs/uuid"
 	"gorm.io/gorm"
+
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+// MessageReportState メッセージ通報の対応状態
+type MessageReportState string
+
+// Valid 有効な値かどうか
+func (s MessageReportState) Valid() bool {
+	return messageReportStates[s]
+}
+
+const (
+	// MessageReportStateOpen メッセージ通報の対応状態: 未対応
+	MessageReportStateOpen MessageReportState = "open"
+	// MessageReportStateResolved メッセージ通報の対応状態: 対応済み
+	MessageReportStateResolved MessageReportState = "resolved"
+	// MessageReportStateDismissed メッセージ通報の対応状態: 却下
+	MessageReportStateDismissed MessageReportState = "dismissed"
 )
 
+var messageReportStates = map[MessageReportState]bool{
+	MessageReportStateOpen:      true,
+	MessageReportStateResolved:  true,
+	MessageReportStateDismissed: true,
+}
+
 // MessageReport メッセージレポート構造体
 type MessageReport struct {
-	ID        uuid.UUID      `gorm:"type:char(36);not null;primaryKey"                   json:"id"`
-	MessageID uuid.UUID      `gorm:"type:char(36);not null;uniqueIndex:message_reporter" json:"messageId"`
-	Reporter  uuid.UUID      `gorm:"type:char(36);not null;uniqueIndex:message_reporter" json:"reporter"`
-	Reason    string         `gorm:"type:TEXT COLLATE utf8mb4_bin NOT NULL"                json:"reason"`
-	CreatedAt time.Time      `gorm:"precision:6;index"                                    json:"createdAt"`
-	DeletedAt gorm.DeletedAt `gorm:"precision:6"                                          json:"-"`
+	ID         uuid.UUID              `gorm:"type:char(36);not null;primaryKey"                   json:"id"`
+	MessageID  uuid.UUID              `gorm:"type:char(36);not null;uniqueIndex:message_reporter" json:"messageId"`
+	Reporter   uuid.UUID              `gorm:"type:char(36);not null;uniqueIndex:message_reporter" json:"reporter"`
+	Reason     string                 `gorm:"type:TEXT COLLATE utf8mb4_bin NOT NULL"                json:"reason"`
+	State      MessageReportState     `gorm:"type:varchar(16);not null;default:open;index"        json:"state"`
+	ResolverID optional.Of[uuid.UUID] `gorm:"type:char(36)"                                        json:"resolverId"`
+	Note       string                 `gorm:"type:TEXT COLLATE utf8mb4_bin NOT NULL"                json:"note"`
+	CreatedAt  time.Time              `gorm:"precision:6;index"                                    json:"createdAt"`
+	ResolvedAt optional.Of[time.Time] `gorm:"precision:6"                                          json:"resolvedAt"`
+	DeletedAt  gorm.DeletedAt         `gorm:"precision:6"                                          json:"-"`
 }
 
 // TableName MessageReport構造体のテーブル名
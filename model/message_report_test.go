@@ -10,3 +10,13 @@ func TestMessageReport_TableName(t *testing.T) {
 	t.Parallel()
 	assert.Equal(t, "message_reports", (&MessageReport{}).TableName())
 }
+
+func TestMessageReportState_Valid(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, MessageReportStateOpen.Valid())
+	assert.True(t, MessageReportStateResolved.Valid())
+	assert.True(t, MessageReportStateDismissed.Valid())
+	assert.False(t, MessageReportState("").Valid())
+	assert.False(t, MessageReportState("closed").Valid())
+}
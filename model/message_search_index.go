@@ -0,0 +1,29 @@
+package model
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// MessageSearchIndex DB検索エンジン用のメッセージインデックス構造体
+type MessageSearchIndex struct {
+	MessageID      uuid.UUID `gorm:"type:char(36);not null;primaryKey"`
+	UserID         uuid.UUID `gorm:"type:char(36);not null"`
+	ChannelID      uuid.UUID `gorm:"type:char(36);not null;index"`
+	IsPublic       bool      `gorm:"type:boolean;not null"`
+	Bot            bool      `gorm:"type:boolean;not null"`
+	Tokens         string    `gorm:"type:mediumtext;not null;index:idx_message_search_index_tokens,class:FULLTEXT"`
+	HasURL         bool      `gorm:"type:boolean;not null"`
+	HasAttachments bool      `gorm:"type:boolean;not null"`
+	HasImage       bool      `gorm:"type:boolean;not null"`
+	HasVideo       bool      `gorm:"type:boolean;not null"`
+	HasAudio       bool      `gorm:"type:boolean;not null"`
+	CreatedAt      time.Time `gorm:"precision:6;index"`
+	UpdatedAt      time.Time `gorm:"precision:6;index"`
+}
+
+// TableName MessageSearchIndex構造体のテーブル名
+func (*MessageSearchIndex) TableName() string {
+	return "message_search_indices"
+}
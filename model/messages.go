@@ -5,22 +5,28 @@ import (
 
 	"github.com/gofrs/uuid"
 	"gorm.io/gorm"
+
+	"github.com/traPtitech/traQ/utils/optional"
 )
 
 // Message データベースに格納するmessageの構造体
 type Message struct {
-	ID        uuid.UUID      `gorm:"type:char(36);not null;primaryKey"`
-	UserID    uuid.UUID      `gorm:"type:char(36);not null;"`
-	ChannelID uuid.UUID      `gorm:"type:char(36);not null;index:idx_messages_channel_id_deleted_at_created_at,priority:1"`
-	Text      string         `gorm:"type:TEXT COLLATE utf8mb4_bin NOT NULL"`
-	CreatedAt time.Time      `gorm:"precision:6;index;index:idx_messages_channel_id_deleted_at_created_at,priority:3;index:idx_messages_deleted_at_created_at,priority:2"`
-	UpdatedAt time.Time      `gorm:"precision:6;index:idx_messages_deleted_at_updated_at,priority:2"`
-	DeletedAt gorm.DeletedAt `gorm:"precision:6;index:idx_messages_channel_id_deleted_at_created_at,priority:2;index:idx_messages_deleted_at_created_at,priority:1;index:idx_messages_deleted_at_updated_at,priority:1"`
+	ID            uuid.UUID              `gorm:"type:char(36);not null;primaryKey"`
+	UserID        uuid.UUID              `gorm:"type:char(36);not null;"`
+	ChannelID     uuid.UUID              `gorm:"type:char(36);not null;index:idx_messages_channel_id_deleted_at_created_at,priority:1"`
+	Text          string                 `gorm:"type:TEXT COLLATE utf8mb4_bin NOT NULL"`
+	ParentID      optional.Of[uuid.UUID] `gorm:"type:char(36);index:idx_messages_parent_id_deleted_at_created_at,priority:1"` // スレッドの親メッセージのID
+	RevisionCount int                    `gorm:"type:int;not null;default:0"`                                                 // 編集回数
+	CreatedAt     time.Time              `gorm:"precision:6;index;index:idx_messages_channel_id_deleted_at_created_at,priority:3;index:idx_messages_deleted_at_created_at,priority:2;index:idx_messages_parent_id_deleted_at_created_at,priority:3"`
+	UpdatedAt     time.Time              `gorm:"precision:6;index:idx_messages_deleted_at_updated_at,priority:2"`
+	DeletedAt     gorm.DeletedAt         `gorm:"precision:6;index:idx_messages_channel_id_deleted_at_created_at,priority:2;index:idx_messages_deleted_at_created_at,priority:1;index:idx_messages_deleted_at_updated_at,priority:1;index:idx_messages_parent_id_deleted_at_created_at,priority:2"`
 
 	User    *User          `gorm:"constraint:messages_user_id_users_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE"`
 	Channel *Channel       `gorm:"constraint:messages_channel_id_channels_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE"`
 	Stamps  []MessageStamp `gorm:"constraint:messages_stamps_message_id_messages_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE;foreignkey:MessageID"`
 	Pin     *Pin           `gorm:"constraint:pins_message_id_messages_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE"`
+
+	Components *MessageComponents `gorm:"constraint:message_components_message_id_messages_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE;foreignKey:MessageID"`
 }
 
 // TableName DBの名前を指定するメソッド
@@ -28,6 +34,14 @@ func (m Message) TableName() string {
 	return "messages"
 }
 
+// GetComponents メッセージのコンポーネントを返します。コンポーネントが無い場合は空の配列を返します。
+func (m *Message) GetComponents() MessageComponentList {
+	if m.Components == nil || m.Components.Components == nil {
+		return MessageComponentList{}
+	}
+	return m.Components.Components
+}
+
 // ChannelLatestMessage チャンネル別最新メッセージ
 type ChannelLatestMessage struct {
 	ChannelID uuid.UUID `gorm:"type:char(36);not null;primaryKey"`
@@ -56,7 +70,7 @@ func (unread *Unread) TableName() string {
 	return "unreads"
 }
 
-// ArchivedMessage 編集前のアーカイブ化されたメッセージの構造体
+// ArchivedMessage 編集前のアーカイブ化されたメッセージ(メッセージの編集履歴)の構造体
 type ArchivedMessage struct {
 	ID        uuid.UUID `gorm:"type:char(36);not null;primaryKey"`
 	MessageID uuid.UUID `gorm:"type:char(36);not null;index"`
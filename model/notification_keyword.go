@@ -0,0 +1,21 @@
+package model
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// NotificationKeyword ユーザーの通知キーワード構造体
+type NotificationKeyword struct {
+	UserID    uuid.UUID `gorm:"type:char(36);not null;primaryKey" json:"-"`
+	Keyword   string    `gorm:"type:varchar(50);not null;primaryKey" json:"keyword"`
+	CreatedAt time.Time `gorm:"precision:6" json:"createdAt"`
+
+	User *User `gorm:"constraint:user_notification_keywords_user_id_users_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+}
+
+// TableName NotificationKeyword構造体のテーブル名
+func (*NotificationKeyword) TableName() string {
+	return "user_notification_keywords"
+}
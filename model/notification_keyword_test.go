@@ -0,0 +1,12 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotificationKeyword_TableName(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "user_notification_keywords", (&NotificationKeyword{}).TableName())
+}
@@ -0,0 +1,125 @@
+package model
+
+import (
+	"database/sql/driver"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// OutgoingWebhookEventType 送信Webhookのイベントタイプ
+type OutgoingWebhookEventType string
+
+const (
+	// OutgoingWebhookEventMessageCreated メッセージが投稿された
+	OutgoingWebhookEventMessageCreated OutgoingWebhookEventType = "MESSAGE_CREATED"
+	// OutgoingWebhookEventMessageUpdated メッセージが編集された
+	OutgoingWebhookEventMessageUpdated OutgoingWebhookEventType = "MESSAGE_UPDATED"
+	// OutgoingWebhookEventMessageDeleted メッセージが削除された
+	OutgoingWebhookEventMessageDeleted OutgoingWebhookEventType = "MESSAGE_DELETED"
+	// OutgoingWebhookEventMessageStamped メッセージにスタンプが押された
+	OutgoingWebhookEventMessageStamped OutgoingWebhookEventType = "MESSAGE_STAMPED"
+	// OutgoingWebhookEventMessagePinned メッセージがピン留めされた
+	OutgoingWebhookEventMessagePinned OutgoingWebhookEventType = "MESSAGE_PINNED"
+)
+
+var outgoingWebhookEventTypes = map[OutgoingWebhookEventType]bool{
+	OutgoingWebhookEventMessageCreated: true,
+	OutgoingWebhookEventMessageUpdated: true,
+	OutgoingWebhookEventMessageDeleted: true,
+	OutgoingWebhookEventMessageStamped: true,
+	OutgoingWebhookEventMessagePinned:  true,
+}
+
+// Valid 有効なイベントタイプかどうか
+func (t OutgoingWebhookEventType) Valid() bool {
+	return outgoingWebhookEventTypes[t]
+}
+
+// OutgoingWebhookEventTypes 送信Webhookのイベントタイプの配列
+type OutgoingWebhookEventTypes []OutgoingWebhookEventType
+
+// Contains 指定したイベントタイプが含まれているかどうか
+func (ts OutgoingWebhookEventTypes) Contains(t OutgoingWebhookEventType) bool {
+	for _, v := range ts {
+		if v == t {
+			return true
+		}
+	}
+	return false
+}
+
+// Value database/sql/driver.Valuer 実装
+func (ts OutgoingWebhookEventTypes) Value() (driver.Value, error) {
+	arr := make([]string, len(ts))
+	for i, t := range ts {
+		arr[i] = string(t)
+	}
+	sort.Strings(arr)
+	return strings.Join(arr, " "), nil
+}
+
+// Scan database/sql.Scanner 実装
+func (ts *OutgoingWebhookEventTypes) Scan(src interface{}) error {
+	var s string
+	switch v := src.(type) {
+	case nil:
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	default:
+		return errors.New("failed to scan OutgoingWebhookEventTypes")
+	}
+	*ts = OutgoingWebhookEventTypes{}
+	for _, t := range strings.Fields(s) {
+		*ts = append(*ts, OutgoingWebhookEventType(t))
+	}
+	return nil
+}
+
+// OutgoingWebhook 送信Webhook構造体
+//
+// チャンネルで発生したイベントを指定したURLにPOSTします。
+type OutgoingWebhook struct {
+	ID        uuid.UUID                 `gorm:"type:char(36);not null;primaryKey"`
+	ChannelID uuid.UUID                 `gorm:"type:char(36);not null;index"`
+	CreatorID uuid.UUID                 `gorm:"type:char(36);not null"`
+	URL       string                    `gorm:"type:text;not null"`
+	Secret    string                    `gorm:"type:varchar(50);not null"`
+	Events    OutgoingWebhookEventTypes `gorm:"type:text;not null"`
+	CreatedAt time.Time                 `gorm:"precision:6"`
+	UpdatedAt time.Time                 `gorm:"precision:6"`
+
+	Channel *Channel `gorm:"constraint:outgoing_webhooks_channel_id_channels_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Creator *User    `gorm:"constraint:outgoing_webhooks_creator_id_users_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+// TableName OutgoingWebhookのテーブル名
+func (*OutgoingWebhook) TableName() string {
+	return "outgoing_webhooks"
+}
+
+// OutgoingWebhookDelivery 送信Webhookの配送ログ
+type OutgoingWebhookDelivery struct {
+	ID        uuid.UUID                `gorm:"type:char(36);not null;primaryKey"`
+	WebhookID uuid.UUID                `gorm:"type:char(36);not null;index:webhook_id_date_time_idx"`
+	Event     OutgoingWebhookEventType `gorm:"type:varchar(30);not null"`
+	Body      string                   `gorm:"type:text"`
+	Result    string                   `gorm:"type:char(2);not null"`
+	Error     string                   `gorm:"type:text"`
+	Code      int                      `gorm:"not null;default:0"`
+	Attempts  int                      `gorm:"not null;default:0"`
+	Latency   int64                    `gorm:"not null;default:0"`
+	DateTime  time.Time                `gorm:"precision:6;index:webhook_id_date_time_idx"`
+
+	Webhook *OutgoingWebhook `gorm:"constraint:outgoing_webhook_deliveries_webhook_id_outgoing_webhooks_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+// TableName OutgoingWebhookDeliveryのテーブル名
+func (*OutgoingWebhookDelivery) TableName() string {
+	return "outgoing_webhook_deliveries"
+}
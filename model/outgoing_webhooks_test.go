@@ -0,0 +1,45 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutgoingWebhook_TableName(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "outgoing_webhooks", (&OutgoingWebhook{}).TableName())
+}
+
+func TestOutgoingWebhookDelivery_TableName(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "outgoing_webhook_deliveries", (&OutgoingWebhookDelivery{}).TableName())
+}
+
+func TestOutgoingWebhookEventType_Valid(t *testing.T) {
+	t.Parallel()
+	assert.True(t, OutgoingWebhookEventMessageCreated.Valid())
+	assert.True(t, OutgoingWebhookEventMessagePinned.Valid())
+	assert.False(t, OutgoingWebhookEventType("BOT_JOINED").Valid())
+}
+
+func TestOutgoingWebhookEventTypes_ValueScan(t *testing.T) {
+	t.Parallel()
+
+	ts := OutgoingWebhookEventTypes{OutgoingWebhookEventMessageStamped, OutgoingWebhookEventMessageCreated}
+	v, err := ts.Value()
+	if assert.NoError(t, err) {
+		assert.Equal(t, "MESSAGE_CREATED MESSAGE_STAMPED", v)
+	}
+
+	var scanned OutgoingWebhookEventTypes
+	if assert.NoError(t, scanned.Scan(v)) {
+		assert.Equal(t, OutgoingWebhookEventTypes{OutgoingWebhookEventMessageCreated, OutgoingWebhookEventMessageStamped}, scanned)
+		assert.True(t, scanned.Contains(OutgoingWebhookEventMessageCreated))
+		assert.False(t, scanned.Contains(OutgoingWebhookEventMessagePinned))
+	}
+
+	assert.NoError(t, scanned.Scan(nil))
+	assert.Empty(t, scanned)
+	assert.Error(t, scanned.Scan(1))
+}
@@ -0,0 +1,12 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSavedSearch_TableName(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "saved_searches", (&SavedSearch{}).TableName())
+}
@@ -0,0 +1,30 @@
+package model
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+// ScheduledMessage 予約投稿メッセージの構造体
+type ScheduledMessage struct {
+	ID          uuid.UUID `gorm:"type:char(36);not null;primaryKey"`
+	UserID      uuid.UUID `gorm:"type:char(36);not null;index"`
+	ChannelID   uuid.UUID `gorm:"type:char(36);not null"`
+	Text        string    `gorm:"type:TEXT COLLATE utf8mb4_bin NOT NULL"`
+	ScheduledAt time.Time `gorm:"precision:6;index"`
+	// ClaimedUntil 投稿処理中の場合、処理が中断されたとみなす日時
+	ClaimedUntil optional.Of[time.Time] `gorm:"precision:6"`
+	CreatedAt    time.Time              `gorm:"precision:6"`
+	UpdatedAt    time.Time              `gorm:"precision:6"`
+
+	User    *User    `gorm:"constraint:scheduled_messages_user_id_users_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Channel *Channel `gorm:"constraint:scheduled_messages_channel_id_channels_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+// TableName ScheduledMessage構造体のテーブル名
+func (*ScheduledMessage) TableName() string {
+	return "scheduled_messages"
+}
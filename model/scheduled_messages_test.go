@@ -0,0 +1,12 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScheduledMessage_TableName(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "scheduled_messages", (&ScheduledMessage{}).TableName())
+}
@@ -0,0 +1,26 @@
+package model
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// SearchEntityIndex ファイル・チャンネル・ユーザー検索用のインデックス構造体
+type SearchEntityIndex struct {
+	Type      string    `gorm:"type:varchar(16);not null;primaryKey"`
+	EntityID  uuid.UUID `gorm:"type:char(36);not null;primaryKey"`
+	Tokens    string    `gorm:"type:mediumtext;not null;index:idx_search_entity_indices_tokens,class:FULLTEXT"`
+	Mime      string    `gorm:"type:varchar(255);not null"`
+	CreatorID uuid.UUID `gorm:"type:char(36);not null"`
+	ChannelID uuid.UUID `gorm:"type:char(36);not null"`
+	IsPublic  bool      `gorm:"type:boolean;not null"`
+	CreatedAt time.Time `gorm:"precision:6;index"`
+	// UpdatedAt 元データの更新日時 (差分同期に用いる)
+	UpdatedAt time.Time `gorm:"precision:6;index"`
+}
+
+// TableName SearchEntityIndex構造体のテーブル名
+func (*SearchEntityIndex) TableName() string {
+	return "search_entity_indices"
+}
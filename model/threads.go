@@ -0,0 +1,22 @@
+package model
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// ThreadFollow スレッドのフォロー構造体
+type ThreadFollow struct {
+	UserID    uuid.UUID `gorm:"type:char(36);not null;primaryKey"`
+	MessageID uuid.UUID `gorm:"type:char(36);not null;primaryKey;index"`
+	CreatedAt time.Time `gorm:"precision:6"`
+
+	User    *User    `gorm:"constraint:users_follow_threads_user_id_users_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Message *Message `gorm:"constraint:users_follow_threads_message_id_messages_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+// TableName ThreadFollow構造体のテーブル名
+func (*ThreadFollow) TableName() string {
+	return "users_follow_threads"
+}
@@ -0,0 +1,12 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestThreadFollow_TableName(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "users_follow_threads", (&ThreadFollow{}).TableName())
+}
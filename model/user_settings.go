@@ -1,11 +1,45 @@
 package model
 
-import "github.com/gofrs/uuid"
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/utils/optional"
+)
 
 // UserSettings ユーザー設定の構造体
 type UserSettings struct {
 	UserID         uuid.UUID `gorm:"type:char(36);not null;primaryKey;" json:"id"`
 	NotifyCitation bool      `gorm:"type:boolean" json:"notifyCitation"`
+	// DNDSchedules おやすみモード(Do Not Disturb)の曜日・時間帯
+	DNDSchedules DNDSchedules `gorm:"type:TEXT COLLATE utf8mb4_bin NOT NULL" json:"dndSchedules"`
+	// DNDTimezone DNDSchedulesを解釈するタイムゾーン (IANA Time Zone名)
+	DNDTimezone string `gorm:"type:varchar(64);not null;default:''" json:"dndTimezone"`
+	// DNDDigest おやすみモード終了時に保留した通知のまとめを送信するかどうか
+	DNDDigest bool `gorm:"type:boolean;not null;default:false" json:"dndDigest"`
+	// SnoozeUntil 指定日時まで一時的に通知を止める
+	SnoozeUntil optional.Of[time.Time] `gorm:"precision:6" json:"snoozeUntil"`
+	// EmailAddress メール通知の宛先アドレス (空の場合はメール通知しない)
+	EmailAddress string `gorm:"type:varchar(254);not null;default:''" json:"emailAddress"`
+	// EmailImmediate DM・メンションをメールで即時通知するかどうか
+	EmailImmediate bool `gorm:"type:boolean;not null;default:false" json:"emailImmediate"`
+	// EmailDigest 未読メンションのまとめメールの送信間隔
+	EmailDigest EmailDigestInterval `gorm:"type:varchar(10);not null;default:''" json:"emailDigest"`
+	// EmailDigestSentAt 最後にまとめメールを送信した日時
+	EmailDigestSentAt optional.Of[time.Time] `gorm:"precision:6" json:"-"`
+	// EmailUnsubscribeToken メール配信停止用トークン
+	EmailUnsubscribeToken string `gorm:"type:varchar(64);not null;default:'';index" json:"-"`
+	// PendingEmailAddress 確認待ちの宛先アドレス (確認されるとEmailAddressになる)
+	PendingEmailAddress string `gorm:"type:varchar(254);not null;default:''" json:"pendingEmailAddress"`
+	// EmailVerificationToken 宛先アドレス確認用トークン
+	EmailVerificationToken string `gorm:"type:varchar(64);not null;default:'';index" json:"-"`
+	// EmailVerificationSentAt 宛先アドレス確認用トークンを発行した日時
+	EmailVerificationSentAt optional.Of[time.Time] `gorm:"precision:6" json:"-"`
 
 	User *User `gorm:"constraint:user_settings_user_id_users_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
 }
@@ -19,3 +53,182 @@ func (us *UserSettings) TableName() string {
 func (us *UserSettings) IsNotifyCitationEnabled() bool {
 	return us.NotifyCitation
 }
+
+// IsDND 指定した時刻がおやすみモード中かどうかを返します
+func (us *UserSettings) IsDND(now time.Time) bool {
+	if us.SnoozeUntil.Valid && now.Before(us.SnoozeUntil.V) {
+		return true
+	}
+	if len(us.DNDSchedules) == 0 {
+		return false
+	}
+	return us.DNDSchedules.Contains(now.In(loadLocation(us.DNDTimezone)))
+}
+
+// locations タイムゾーン名から*time.Locationへのキャッシュ
+var locations sync.Map
+
+// loadLocation 指定したタイムゾーンを返します。不正なタイムゾーンの場合はUTCを返します
+//
+// time.LoadLocationは呼び出しの度にtzdataを読み込むため、結果をキャッシュします
+func loadLocation(name string) *time.Location {
+	if loc, ok := locations.Load(name); ok {
+		return loc.(*time.Location)
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		loc = time.UTC
+	}
+	locations.Store(name, loc)
+	return loc
+}
+
+// IsEmailEnabled メール通知の宛先が設定されているかどうかを返します
+func (us *UserSettings) IsEmailEnabled() bool {
+	return len(us.EmailAddress) > 0
+}
+
+// IsEmailDigestDue 指定した時刻にまとめメールを送信すべきかどうかを返します
+func (us *UserSettings) IsEmailDigestDue(now time.Time) bool {
+	interval := us.EmailDigest.Duration()
+	if !us.IsEmailEnabled() || interval == 0 {
+		return false
+	}
+	return !us.EmailDigestSentAt.Valid || !now.Before(us.EmailDigestSentAt.V.Add(interval))
+}
+
+// EmailDigestInterval まとめメールの送信間隔
+type EmailDigestInterval string
+
+const (
+	// EmailDigestNone まとめメールを送信しない
+	EmailDigestNone EmailDigestInterval = ""
+	// EmailDigestHourly 1時間ごと
+	EmailDigestHourly EmailDigestInterval = "hourly"
+	// EmailDigestDaily 1日ごと
+	EmailDigestDaily EmailDigestInterval = "daily"
+)
+
+// Valid 有効な送信間隔かどうか
+func (i EmailDigestInterval) Valid() bool {
+	switch i {
+	case EmailDigestNone, EmailDigestHourly, EmailDigestDaily:
+		return true
+	default:
+		return false
+	}
+}
+
+// Duration 送信間隔を返します。送信しない場合は0を返します
+func (i EmailDigestInterval) Duration() time.Duration {
+	switch i {
+	case EmailDigestHourly:
+		return time.Hour
+	case EmailDigestDaily:
+		return 24 * time.Hour
+	default:
+		return 0
+	}
+}
+
+// DNDSchedule おやすみモードの時間帯
+//
+// StartがEndより後の場合は日をまたぐ時間帯(翌日のEndまで)を表します。
+type DNDSchedule struct {
+	// Weekday 開始曜日
+	Weekday time.Weekday `json:"weekday"`
+	// Start 開始時刻 (HH:MM)
+	Start string `json:"start"`
+	// End 終了時刻 (HH:MM)
+	End string `json:"end"`
+}
+
+// Validate 有効な時間帯かどうかを検証します
+func (s DNDSchedule) Validate() error {
+	if s.Weekday < time.Sunday || s.Weekday > time.Saturday {
+		return errors.New("invalid weekday")
+	}
+	if _, err := parseClock(s.Start); err != nil {
+		return err
+	}
+	if _, err := parseClock(s.End); err != nil {
+		return err
+	}
+	if s.Start == s.End {
+		return errors.New("start and end must be different")
+	}
+	return nil
+}
+
+// contains tの曜日・時刻が時間帯に含まれるかどうか
+func (s DNDSchedule) contains(t time.Time) bool {
+	start, err := parseClock(s.Start)
+	if err != nil {
+		return false
+	}
+	end, err := parseClock(s.End)
+	if err != nil {
+		return false
+	}
+	now := t.Hour()*60 + t.Minute()
+	if start < end {
+		return t.Weekday() == s.Weekday && start <= now && now < end
+	}
+	// 日をまたぐ時間帯
+	next := (s.Weekday + 1) % 7
+	return (t.Weekday() == s.Weekday && start <= now) || (t.Weekday() == next && now < end)
+}
+
+// parseClock "HH:MM"形式の時刻を0時からの経過分に変換します
+func parseClock(s string) (int, error) {
+	var h, m int
+	if n, err := fmt.Sscanf(s, "%d:%d", &h, &m); err != nil || n != 2 || len(s) != 5 {
+		return 0, fmt.Errorf("invalid time format: %q", s)
+	}
+	if h < 0 || h > 23 || m < 0 || m > 59 {
+		return 0, fmt.Errorf("invalid time: %q", s)
+	}
+	return h*60 + m, nil
+}
+
+// DNDSchedules おやすみモードの時間帯の配列
+type DNDSchedules []DNDSchedule
+
+// Contains tがいずれかの時間帯に含まれるかどうか
+func (ss DNDSchedules) Contains(t time.Time) bool {
+	for _, s := range ss {
+		if s.contains(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// Value database/sql/driver.Valuer 実装
+func (ss DNDSchedules) Value() (driver.Value, error) {
+	if ss == nil {
+		ss = DNDSchedules{}
+	}
+	return json.MarshalToString(ss)
+}
+
+// Scan database/sql.Scanner 実装
+func (ss *DNDSchedules) Scan(src interface{}) error {
+	*ss = DNDSchedules{}
+	switch s := src.(type) {
+	case nil:
+		return nil
+	case string:
+		if len(s) == 0 {
+			return nil
+		}
+		return json.Unmarshal([]byte(s), ss)
+	case []byte:
+		if len(s) == 0 {
+			return nil
+		}
+		return json.Unmarshal(s, ss)
+	default:
+		return errors.New("failed to scan DNDSchedules")
+	}
+}
@@ -0,0 +1,113 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+func TestUserSettings_TableName(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "user_settings", (&UserSettings{}).TableName())
+}
+
+func TestUserSettings_IsDND(t *testing.T) {
+	t.Parallel()
+
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	// 2023-01-02 は月曜日
+	monday := func(h, m int) time.Time { return time.Date(2023, 1, 2, h, m, 0, 0, jst) }
+	tuesday := func(h, m int) time.Time { return time.Date(2023, 1, 3, h, m, 0, 0, jst) }
+
+	us := &UserSettings{
+		DNDSchedules: DNDSchedules{
+			{Weekday: time.Monday, Start: "12:00", End: "13:00"},
+			{Weekday: time.Monday, Start: "22:00", End: "07:00"},
+		},
+		DNDTimezone: "Asia/Tokyo",
+	}
+
+	tests := []struct {
+		name string
+		now  time.Time
+		want bool
+	}{
+		{"before window", monday(11, 59), false},
+		{"in window", monday(12, 0), true},
+		{"window end is exclusive", monday(13, 0), false},
+		{"overnight window start", monday(22, 30), true},
+		{"overnight window next day", tuesday(6, 59), true},
+		{"overnight window ended", tuesday(7, 0), false},
+		{"other timezone", monday(13, 30).In(time.UTC), false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, us.IsDND(tt.now))
+		})
+	}
+
+	t.Run("snooze", func(t *testing.T) {
+		t.Parallel()
+		now := time.Now()
+		assert.True(t, (&UserSettings{SnoozeUntil: optional.From(now.Add(time.Minute))}).IsDND(now))
+		assert.False(t, (&UserSettings{SnoozeUntil: optional.From(now.Add(-time.Minute))}).IsDND(now))
+		assert.False(t, (&UserSettings{}).IsDND(now))
+	})
+}
+
+func TestLoadLocation(t *testing.T) {
+	t.Parallel()
+
+	loc := loadLocation("Asia/Tokyo")
+	assert.Equal(t, "Asia/Tokyo", loc.String())
+	assert.Same(t, loc, loadLocation("Asia/Tokyo"))
+	assert.Equal(t, time.UTC, loadLocation("Invalid/Zone"))
+}
+
+func TestDNDSchedule_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, DNDSchedule{Weekday: time.Sunday, Start: "00:00", End: "23:59"}.Validate())
+	assert.Error(t, DNDSchedule{Weekday: 7, Start: "00:00", End: "01:00"}.Validate())
+	assert.Error(t, DNDSchedule{Weekday: time.Sunday, Start: "24:00", End: "01:00"}.Validate())
+	assert.Error(t, DNDSchedule{Weekday: time.Sunday, Start: "1:00", End: "02:00"}.Validate())
+	assert.Error(t, DNDSchedule{Weekday: time.Sunday, Start: "01:00", End: "01:00"}.Validate())
+}
+
+func TestUserSettings_IsEmailDigestDue(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	tests := []struct {
+		name string
+		us   UserSettings
+		want bool
+	}{
+		{"no address", UserSettings{EmailDigest: EmailDigestHourly}, false},
+		{"digest disabled", UserSettings{EmailAddress: "a@example.com"}, false},
+		{"never sent", UserSettings{EmailAddress: "a@example.com", EmailDigest: EmailDigestDaily}, true},
+		{"hourly not yet", UserSettings{EmailAddress: "a@example.com", EmailDigest: EmailDigestHourly, EmailDigestSentAt: optional.From(now.Add(-30 * time.Minute))}, false},
+		{"hourly due", UserSettings{EmailAddress: "a@example.com", EmailDigest: EmailDigestHourly, EmailDigestSentAt: optional.From(now.Add(-time.Hour))}, true},
+		{"daily not yet", UserSettings{EmailAddress: "a@example.com", EmailDigest: EmailDigestDaily, EmailDigestSentAt: optional.From(now.Add(-23 * time.Hour))}, false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.us.IsEmailDigestDue(now))
+		})
+	}
+}
+
+func TestEmailDigestInterval_Valid(t *testing.T) {
+	t.Parallel()
+	assert.True(t, EmailDigestNone.Valid())
+	assert.True(t, EmailDigestHourly.Valid())
+	assert.True(t, EmailDigestDaily.Valid())
+	assert.False(t, EmailDigestInterval("weekly").Valid())
+}
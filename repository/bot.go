@@ -19,6 +19,10 @@ type UpdateBotArgs struct {
 	Privileged      optional.Of[bool]
 	CreatorID       optional.Of[uuid.UUID]
 	SubscribeEvents model.BotEventTypes
+	// Permissions Botに許可する権限
+	Permissions optional.Of[model.BotPermissions]
+	// UnrestrictPermissions trueの場合、Botの権限の制限を解除します
+	UnrestrictPermissions bool
 }
 
 // BotsQuery Bot情報取得用クエリ
@@ -85,9 +89,10 @@ func (q BotsQuery) BotUserID(id uuid.UUID) BotsQuery {
 type BotRepository interface {
 	// CreateBot Botを作成します
 	//
+	// permissionsが無効値の場合、BotにはBotロールの全ての権限が許可されます。
 	// 成功した場合、Botとnilを返します。
 	// DBによるエラーを返すことがあります。
-	CreateBot(name, displayName, description string, iconFileID, creatorID uuid.UUID, mode model.BotMode, state model.BotState, webhookURL string) (*model.Bot, error)
+	CreateBot(name, displayName, description string, iconFileID, creatorID uuid.UUID, mode model.BotMode, state model.BotState, webhookURL string, permissions optional.Of[model.BotPermissions]) (*model.Bot, error)
 	// UpdateBot 指定したBotの情報を更新します
 	//
 	// 成功した場合、nilを返します。
@@ -0,0 +1,65 @@
+//go:generate mockgen -source=$GOFILE -destination=mock_$GOPACKAGE/mock_$GOFILE
+package repository
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+// UpdateBotEventDeliveryArgs Botイベント配送キュー更新引数
+type UpdateBotEventDeliveryArgs struct {
+	Attempts      optional.Of[int]
+	LastError     optional.Of[string]
+	Dead          optional.Of[bool]
+	NextAttemptAt optional.Of[time.Time]
+}
+
+// BotEventDeliveryRepository Botイベント配送キューリポジトリ
+type BotEventDeliveryRepository interface {
+	// CreateBotEventDelivery Botイベント配送キューにイベントを追加します
+	//
+	// 成功した場合、nilを返します。
+	// IDまたはBotIDがuuid.Nilの場合、ErrNilIDを返します。
+	// DBによるエラーを返すことがあります。
+	CreateBotEventDelivery(delivery *model.BotEventDelivery) error
+	// UpdateBotEventDelivery 指定したBotイベント配送を更新します
+	//
+	// 成功した場合、nilを返します。
+	// 存在しない配送を指定した場合、ErrNotFoundを返します。
+	// 引数にuuid.Nilを指定するとErrNilIDを返します。
+	// DBによるエラーを返すことがあります。
+	UpdateBotEventDelivery(id uuid.UUID, args UpdateBotEventDeliveryArgs) error
+	// DeleteBotEventDelivery 指定したBotイベント配送をキューから削除します
+	//
+	// 成功した場合、nilを返します。
+	// 存在しない配送を指定した場合、ErrNotFoundを返します。
+	// 引数にuuid.Nilを指定するとErrNilIDを返します。
+	// DBによるエラーを返すことがあります。
+	DeleteBotEventDelivery(id uuid.UUID) error
+	// GetBotEventDelivery 指定したBotイベント配送を取得します
+	//
+	// 成功した場合、配送とnilを返します。
+	// 存在しない配送を指定した場合、ErrNotFoundを返します。
+	// DBによるエラーを返すことがあります。
+	GetBotEventDelivery(id uuid.UUID) (*model.BotEventDelivery, error)
+	// GetDueBotEventDeliveries 次回試行日時が指定した日時以前のDeadでないBotイベント配送を取得します
+	//
+	// 成功した場合、次回試行日時で昇順ソートされた配送の配列とnilを返します。
+	// 負のlimitは無視されます。
+	// DBによるエラーを返すことがあります。
+	GetDueBotEventDeliveries(until time.Time, limit int) ([]*model.BotEventDelivery, error)
+	// GetDeadBotEventDeliveries 指定したBotのDeadなBotイベント配送を取得します
+	//
+	// 成功した場合、最終更新日時で降順ソートされた配送の配列とnilを返します。
+	// 負のlimit, offsetは無視されます。
+	// DBによるエラーを返すことがあります。
+	GetDeadBotEventDeliveries(botID uuid.UUID, limit, offset int) ([]*model.BotEventDelivery, error)
+	// PurgeDeadBotEventDeliveries 最終更新日時が指定した日時以前のDeadなBotイベント配送を全て消去します
+	//
+	// DBによるエラーを返すことがあります。
+	PurgeDeadBotEventDeliveries(before time.Time) error
+}
@@ -0,0 +1,31 @@
+//go:generate mockgen -source=$GOFILE -destination=mock_$GOPACKAGE/mock_$GOFILE
+package repository
+
+import (
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/model"
+)
+
+// BotSlashCommandRepository BOTスラッシュコマンドリポジトリ
+type BotSlashCommandRepository interface {
+	// SetBotSlashCommands 指定したBotのスラッシュコマンドを全て置き換えます
+	//
+	// 成功した場合、nilを返します。
+	// 引数にuuid.Nilを指定するとErrNilIDを返します。
+	// DBによるエラーを返すことがあります。
+	SetBotSlashCommands(botID uuid.UUID, commands []*model.BotSlashCommand) error
+	// GetBotSlashCommands 指定したBotのスラッシュコマンドを全て取得します
+	//
+	// 成功した場合、名前順のスラッシュコマンドの配列とnilを返します。
+	// 存在しないBotを指定した場合、空配列とnilを返します。
+	// DBによるエラーを返すことがあります。
+	GetBotSlashCommands(botID uuid.UUID) ([]*model.BotSlashCommand, error)
+	// GetChannelSlashCommands 指定したチャンネルで使用可能なスラッシュコマンドを全て取得します
+	//
+	// チャンネルに参加している有効なBotのスラッシュコマンドが対象です。
+	// 成功した場合、名前順のスラッシュコマンドの配列とnilを返します。
+	// 存在しないチャンネルを指定した場合、空配列とnilを返します。
+	// DBによるエラーを返すことがあります。
+	GetChannelSlashCommands(channelID uuid.UUID) ([]*model.BotSlashCommand, error)
+}
@@ -1,6 +1,7 @@
 package gorm
 
 import (
+	"context"
 	"math"
 	"time"
 
@@ -13,11 +14,12 @@ import (
 	"github.com/traPtitech/traQ/repository"
 	"github.com/traPtitech/traQ/service/rbac/role"
 	"github.com/traPtitech/traQ/utils/gormUtil"
+	"github.com/traPtitech/traQ/utils/optional"
 	"github.com/traPtitech/traQ/utils/random"
 )
 
 // CreateBot implements BotRepository interface.
-func (repo *Repository) CreateBot(name, displayName, description string, iconFileID, creatorID uuid.UUID, mode model.BotMode, state model.BotState, webhookURL string) (*model.Bot, error) {
+func (repo *Repository) CreateBot(name, displayName, description string, iconFileID, creatorID uuid.UUID, mode model.BotMode, state model.BotState, webhookURL string, permissions optional.Of[model.BotPermissions]) (*model.Bot, error) {
 	uid := uuid.Must(uuid.NewV4())
 	bid := uuid.Must(uuid.NewV4())
 	tid := uuid.Must(uuid.NewV4())
@@ -39,6 +41,7 @@ func (repo *Repository) CreateBot(name, displayName, description string, iconFil
 		PostURL:           webhookURL,
 		AccessTokenID:     tid,
 		SubscribeEvents:   model.BotEventTypes{},
+		Permissions:       permissions,
 		Privileged:        false,
 		Mode:              mode,
 		State:             state,
@@ -126,6 +129,11 @@ func (repo *Repository) UpdateBot(id uuid.UUID, args repository.UpdateBotArgs) e
 		if args.SubscribeEvents != nil {
 			changes["subscribe_events"] = args.SubscribeEvents
 		}
+		if args.UnrestrictPermissions {
+			changes["permissions"] = optional.Of[model.BotPermissions]{}
+		} else if args.Permissions.Valid {
+			changes["permissions"] = args.Permissions
+		}
 
 		if len(changes) > 0 {
 			if err := tx.Model(&b).Updates(changes).Error; err != nil {
@@ -145,6 +153,7 @@ func (repo *Repository) UpdateBot(id uuid.UUID, args repository.UpdateBotArgs) e
 	if err != nil {
 		return err
 	}
+	repo.botsByUserID.Forget(b.BotUserID)
 
 	if userUpdated {
 		repo.hub.Publish(hub.Message{
@@ -222,10 +231,21 @@ func (repo *Repository) GetBotByID(id uuid.UUID) (*model.Bot, error) {
 }
 
 // GetBotByBotUserID implements BotRepository interface.
+//
+// リクエスト毎に呼ばれるため、キャッシュを使用します。
 func (repo *Repository) GetBotByBotUserID(id uuid.UUID) (*model.Bot, error) {
 	if id == uuid.Nil {
 		return nil, repository.ErrNotFound
 	}
+	b, err := repo.botsByUserID.Get(context.Background(), id)
+	if err != nil {
+		return nil, err
+	}
+	c := *b
+	return &c, nil
+}
+
+func (repo *Repository) getBotByBotUserID(_ context.Context, id uuid.UUID) (*model.Bot, error) {
 	return getBot(repo.db, &model.Bot{BotUserID: id})
 }
 
@@ -250,10 +270,11 @@ func (repo *Repository) ChangeBotState(id uuid.UUID, state model.BotState) error
 	if id == uuid.Nil {
 		return repository.ErrNilID
 	}
-	var changed bool
-
+	var (
+		b       model.Bot
+		changed bool
+	)
 	err := repo.db.Transaction(func(tx *gorm.DB) error {
-		var b model.Bot
 		if err := tx.Take(&b, &model.Bot{ID: id}).Error; err != nil {
 			return convertError(err)
 		}
@@ -267,6 +288,7 @@ func (repo *Repository) ChangeBotState(id uuid.UUID, state model.BotState) error
 		return err
 	}
 	if changed {
+		repo.botsByUserID.Forget(b.BotUserID)
 		repo.hub.Publish(hub.Message{
 			Name: event.BotStateChanged,
 			Fields: hub.Fields{
@@ -325,6 +347,7 @@ func (repo *Repository) ReissueBotTokens(id uuid.UUID) (*model.Bot, error) {
 	if err != nil {
 		return nil, err
 	}
+	repo.botsByUserID.Forget(bot.BotUserID)
 	repo.hub.Publish(hub.Message{
 		Name: event.BotStateChanged,
 		Fields: hub.Fields{
@@ -340,8 +363,8 @@ func (repo *Repository) DeleteBot(id uuid.UUID) error {
 	if id == uuid.Nil {
 		return repository.ErrNilID
 	}
+	var b model.Bot
 	err := repo.db.Transaction(func(tx *gorm.DB) error {
-		var b model.Bot
 		if err := tx.First(&b, &model.Bot{ID: id}).Error; err != nil {
 			return convertError(err)
 		}
@@ -367,6 +390,7 @@ func (repo *Repository) DeleteBot(id uuid.UUID) error {
 	if err != nil {
 		return err
 	}
+	repo.botsByUserID.Forget(b.BotUserID)
 	repo.hub.Publish(hub.Message{
 		Name: event.BotDeleted,
 		Fields: hub.Fields{
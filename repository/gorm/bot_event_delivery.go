@@ -0,0 +1,112 @@
+package gorm
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/utils/gormUtil"
+)
+
+// CreateBotEventDelivery implements BotEventDeliveryRepository interface.
+func (repo *Repository) CreateBotEventDelivery(delivery *model.BotEventDelivery) error {
+	if delivery.ID == uuid.Nil || delivery.BotID == uuid.Nil {
+		return repository.ErrNilID
+	}
+	return repo.db.Create(delivery).Error
+}
+
+// UpdateBotEventDelivery implements BotEventDeliveryRepository interface.
+func (repo *Repository) UpdateBotEventDelivery(id uuid.UUID, args repository.UpdateBotEventDeliveryArgs) error {
+	if id == uuid.Nil {
+		return repository.ErrNilID
+	}
+
+	changes := map[string]interface{}{}
+	if args.Attempts.Valid {
+		changes["attempts"] = args.Attempts.V
+	}
+	if args.LastError.Valid {
+		changes["last_error"] = args.LastError.V
+	}
+	if args.Dead.Valid {
+		changes["dead"] = args.Dead.V
+	}
+	if args.NextAttemptAt.Valid {
+		changes["next_attempt_at"] = args.NextAttemptAt.V
+	}
+
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		var d model.BotEventDelivery
+		if err := tx.First(&d, &model.BotEventDelivery{ID: id}).Error; err != nil {
+			return convertError(err)
+		}
+		if len(changes) > 0 {
+			return tx.Model(&d).Updates(changes).Error
+		}
+		return nil
+	})
+}
+
+// DeleteBotEventDelivery implements BotEventDeliveryRepository interface.
+func (repo *Repository) DeleteBotEventDelivery(id uuid.UUID) error {
+	if id == uuid.Nil {
+		return repository.ErrNilID
+	}
+	result := repo.db.Delete(&model.BotEventDelivery{ID: id})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// GetBotEventDelivery implements BotEventDeliveryRepository interface.
+func (repo *Repository) GetBotEventDelivery(id uuid.UUID) (*model.BotEventDelivery, error) {
+	if id == uuid.Nil {
+		return nil, repository.ErrNotFound
+	}
+	var d model.BotEventDelivery
+	if err := repo.db.First(&d, &model.BotEventDelivery{ID: id}).Error; err != nil {
+		return nil, convertError(err)
+	}
+	return &d, nil
+}
+
+// GetDueBotEventDeliveries implements BotEventDeliveryRepository interface.
+func (repo *Repository) GetDueBotEventDeliveries(until time.Time, limit int) ([]*model.BotEventDelivery, error) {
+	ds := make([]*model.BotEventDelivery, 0)
+	return ds, repo.db.
+		Where("dead = ? AND next_attempt_at <= ?", false, until).
+		Order("next_attempt_at").
+		Scopes(gormUtil.LimitAndOffset(limit, 0)).
+		Find(&ds).
+		Error
+}
+
+// GetDeadBotEventDeliveries implements BotEventDeliveryRepository interface.
+func (repo *Repository) GetDeadBotEventDeliveries(botID uuid.UUID, limit, offset int) ([]*model.BotEventDelivery, error) {
+	ds := make([]*model.BotEventDelivery, 0)
+	if botID == uuid.Nil {
+		return ds, nil
+	}
+	return ds, repo.db.
+		Where("bot_id = ? AND dead = ?", botID, true).
+		Order("updated_at DESC").
+		Scopes(gormUtil.LimitAndOffset(limit, offset)).
+		Find(&ds).
+		Error
+}
+
+// PurgeDeadBotEventDeliveries implements BotEventDeliveryRepository interface.
+func (repo *Repository) PurgeDeadBotEventDeliveries(before time.Time) error {
+	return repo.db.
+		Where("dead = ? AND updated_at < ?", true, before).
+		Delete(&model.BotEventDelivery{}).
+		Error
+}
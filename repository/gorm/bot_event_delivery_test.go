@@ -0,0 +1,164 @@
+package gorm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+func mustMakeBotEventDelivery(t *testing.T, repo repository.Repository, botID uuid.UUID, dead bool, nextAttemptAt time.Time) *model.BotEventDelivery {
+	t.Helper()
+	d := &model.BotEventDelivery{
+		ID:            uuid.Must(uuid.NewV4()),
+		BotID:         botID,
+		Event:         "PING",
+		Body:          "{}",
+		Attempts:      1,
+		Dead:          dead,
+		NextAttemptAt: nextAttemptAt,
+	}
+	if err := repo.CreateBotEventDelivery(d); err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+func TestRepositoryImpl_CreateBotEventDelivery(t *testing.T) {
+	t.Parallel()
+	repo, assert, _, user := setupWithUser(t, common2)
+	b := mustMakeBot(t, repo, rand, user.GetID())
+
+	assert.EqualError(repo.CreateBotEventDelivery(&model.BotEventDelivery{BotID: b.ID}), repository.ErrNilID.Error())
+	assert.EqualError(repo.CreateBotEventDelivery(&model.BotEventDelivery{ID: uuid.Must(uuid.NewV4())}), repository.ErrNilID.Error())
+
+	d := &model.BotEventDelivery{
+		ID:            uuid.Must(uuid.NewV4()),
+		BotID:         b.ID,
+		Event:         "PING",
+		Body:          "{}",
+		Attempts:      1,
+		NextAttemptAt: time.Now(),
+	}
+	if assert.NoError(repo.CreateBotEventDelivery(d)) {
+		assert.Equal(1, count(t, getDB(repo).Model(model.BotEventDelivery{}).Where(model.BotEventDelivery{BotID: b.ID})))
+	}
+}
+
+func TestRepositoryImpl_UpdateBotEventDelivery(t *testing.T) {
+	t.Parallel()
+	repo, assert, require, user := setupWithUser(t, common2)
+	b := mustMakeBot(t, repo, rand, user.GetID())
+	d := mustMakeBotEventDelivery(t, repo, b.ID, false, time.Now())
+
+	assert.EqualError(repo.UpdateBotEventDelivery(uuid.Nil, repository.UpdateBotEventDeliveryArgs{}), repository.ErrNilID.Error())
+	assert.EqualError(repo.UpdateBotEventDelivery(uuid.Must(uuid.NewV4()), repository.UpdateBotEventDeliveryArgs{}), repository.ErrNotFound.Error())
+
+	if assert.NoError(repo.UpdateBotEventDelivery(d.ID, repository.UpdateBotEventDeliveryArgs{
+		Attempts:  optional.From(2),
+		LastError: optional.From("error"),
+		Dead:      optional.From(true),
+	})) {
+		res, err := repo.GetBotEventDelivery(d.ID)
+		require.NoError(err)
+		assert.Equal(2, res.Attempts)
+		assert.Equal("error", res.LastError)
+		assert.True(res.Dead)
+	}
+}
+
+func TestRepositoryImpl_DeleteBotEventDelivery(t *testing.T) {
+	t.Parallel()
+	repo, assert, _, user := setupWithUser(t, common2)
+	b := mustMakeBot(t, repo, rand, user.GetID())
+	d := mustMakeBotEventDelivery(t, repo, b.ID, false, time.Now())
+
+	assert.EqualError(repo.DeleteBotEventDelivery(uuid.Nil), repository.ErrNilID.Error())
+	assert.EqualError(repo.DeleteBotEventDelivery(uuid.Must(uuid.NewV4())), repository.ErrNotFound.Error())
+	if assert.NoError(repo.DeleteBotEventDelivery(d.ID)) {
+		_, err := repo.GetBotEventDelivery(d.ID)
+		assert.EqualError(err, repository.ErrNotFound.Error())
+	}
+}
+
+func TestRepositoryImpl_GetBotEventDelivery(t *testing.T) {
+	t.Parallel()
+	repo, assert, _, user := setupWithUser(t, common2)
+	b := mustMakeBot(t, repo, rand, user.GetID())
+	d := mustMakeBotEventDelivery(t, repo, b.ID, false, time.Now())
+
+	_, err := repo.GetBotEventDelivery(uuid.Nil)
+	assert.EqualError(err, repository.ErrNotFound.Error())
+	_, err = repo.GetBotEventDelivery(uuid.Must(uuid.NewV4()))
+	assert.EqualError(err, repository.ErrNotFound.Error())
+
+	res, err := repo.GetBotEventDelivery(d.ID)
+	if assert.NoError(err) {
+		assert.Equal(d.ID, res.ID)
+		assert.Equal(b.ID, res.BotID)
+		assert.EqualValues("PING", res.Event)
+		assert.Equal("{}", res.Body)
+	}
+}
+
+func TestRepositoryImpl_GetDueBotEventDeliveries(t *testing.T) {
+	t.Parallel()
+	repo, assert, _, user := setupWithUser(t, common2)
+	b := mustMakeBot(t, repo, rand, user.GetID())
+	now := time.Now()
+	d1 := mustMakeBotEventDelivery(t, repo, b.ID, false, now.Add(-2*time.Hour))
+	d2 := mustMakeBotEventDelivery(t, repo, b.ID, false, now.Add(-time.Hour))
+	mustMakeBotEventDelivery(t, repo, b.ID, true, now.Add(-time.Hour))
+	mustMakeBotEventDelivery(t, repo, b.ID, false, now.Add(time.Hour))
+
+	ds, err := repo.GetDueBotEventDeliveries(now, -1)
+	if assert.NoError(err) {
+		ids := make([]uuid.UUID, 0, len(ds))
+		for _, d := range ds {
+			if d.BotID == b.ID {
+				ids = append(ids, d.ID)
+			}
+		}
+		assert.Equal([]uuid.UUID{d1.ID, d2.ID}, ids)
+	}
+}
+
+func TestRepositoryImpl_GetDeadBotEventDeliveries(t *testing.T) {
+	t.Parallel()
+	repo, assert, _, user := setupWithUser(t, common2)
+	b := mustMakeBot(t, repo, rand, user.GetID())
+	mustMakeBotEventDelivery(t, repo, b.ID, false, time.Now())
+	d := mustMakeBotEventDelivery(t, repo, b.ID, true, time.Now())
+
+	ds, err := repo.GetDeadBotEventDeliveries(uuid.Nil, 10, 0)
+	if assert.NoError(err) {
+		assert.Len(ds, 0)
+	}
+
+	ds, err = repo.GetDeadBotEventDeliveries(b.ID, 10, 0)
+	if assert.NoError(err) && assert.Len(ds, 1) {
+		assert.Equal(d.ID, ds[0].ID)
+	}
+}
+
+func TestRepositoryImpl_PurgeDeadBotEventDeliveries(t *testing.T) {
+	t.Parallel()
+	repo, assert, require, user := setupWithUser(t, common2)
+	b := mustMakeBot(t, repo, rand, user.GetID())
+	alive := mustMakeBotEventDelivery(t, repo, b.ID, false, time.Now())
+	dead := mustMakeBotEventDelivery(t, repo, b.ID, true, time.Now())
+
+	require.NoError(repo.PurgeDeadBotEventDeliveries(time.Now().Add(-time.Hour)))
+	_, err := repo.GetBotEventDelivery(dead.ID)
+	assert.NoError(err)
+
+	require.NoError(repo.PurgeDeadBotEventDeliveries(time.Now().Add(time.Hour)))
+	_, err = repo.GetBotEventDelivery(dead.ID)
+	assert.ErrorIs(err, repository.ErrNotFound)
+	_, err = repo.GetBotEventDelivery(alive.ID)
+	assert.NoError(err)
+}
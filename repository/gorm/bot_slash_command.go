@@ -0,0 +1,60 @@
+package gorm
+
+import (
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+)
+
+// SetBotSlashCommands implements BotSlashCommandRepository interface.
+func (repo *Repository) SetBotSlashCommands(botID uuid.UUID, commands []*model.BotSlashCommand) error {
+	if botID == uuid.Nil {
+		return repository.ErrNilID
+	}
+
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where(&model.BotSlashCommand{BotID: botID}).Delete(&model.BotSlashCommand{}).Error; err != nil {
+			return err
+		}
+		if len(commands) == 0 {
+			return nil
+		}
+		for _, cmd := range commands {
+			cmd.ID = uuid.Must(uuid.NewV4())
+			cmd.BotID = botID
+			if cmd.Options == nil {
+				cmd.Options = model.BotSlashCommandOptions{}
+			}
+		}
+		return convertError(tx.Create(commands).Error)
+	})
+}
+
+// GetBotSlashCommands implements BotSlashCommandRepository interface.
+func (repo *Repository) GetBotSlashCommands(botID uuid.UUID) ([]*model.BotSlashCommand, error) {
+	commands := make([]*model.BotSlashCommand, 0)
+	if botID == uuid.Nil {
+		return commands, nil
+	}
+	return commands, repo.db.
+		Where(&model.BotSlashCommand{BotID: botID}).
+		Order("name").
+		Find(&commands).
+		Error
+}
+
+// GetChannelSlashCommands implements BotSlashCommandRepository interface.
+func (repo *Repository) GetChannelSlashCommands(channelID uuid.UUID) ([]*model.BotSlashCommand, error) {
+	commands := make([]*model.BotSlashCommand, 0)
+	if channelID == uuid.Nil {
+		return commands, nil
+	}
+	return commands, repo.db.
+		Joins("INNER JOIN bot_join_channels ON bot_join_channels.bot_id = bot_slash_commands.bot_id AND bot_join_channels.channel_id = ?", channelID).
+		Joins("INNER JOIN bots ON bots.id = bot_slash_commands.bot_id AND bots.state = ? AND bots.deleted_at IS NULL", model.BotActive).
+		Order("bot_slash_commands.name").
+		Find(&commands).
+		Error
+}
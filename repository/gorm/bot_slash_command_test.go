@@ -0,0 +1,91 @@
+package gorm
+
+import (
+	"testing"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+)
+
+func TestRepositoryImpl_SetBotSlashCommands(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user := setupWithUser(t, common3)
+
+	t.Run("nil id", func(t *testing.T) {
+		t.Parallel()
+		assert, _ := assertAndRequire(t)
+
+		assert.EqualError(repo.SetBotSlashCommands(uuid.Nil, nil), repository.ErrNilID.Error())
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		assert, require := assertAndRequire(t)
+		b := mustMakeBot(t, repo, rand, user.GetID())
+
+		require.NoError(repo.SetBotSlashCommands(b.ID, []*model.BotSlashCommand{
+			{Name: "roll", Description: "dice", Options: model.BotSlashCommandOptions{{Name: "count", Type: model.BotSlashCommandOptionTypeInteger, Required: true}}},
+			{Name: "help", Description: "show help"},
+		}))
+		commands, err := repo.GetBotSlashCommands(b.ID)
+		require.NoError(err)
+		if assert.Len(commands, 2) {
+			assert.Equal("help", commands[0].Name)
+			assert.Empty(commands[0].Options)
+			assert.Equal("roll", commands[1].Name)
+			assert.Len(commands[1].Options, 1)
+			assert.Equal(b.ID, commands[1].BotID)
+		}
+
+		// 置き換え
+		require.NoError(repo.SetBotSlashCommands(b.ID, []*model.BotSlashCommand{{Name: "ping"}}))
+		commands, err = repo.GetBotSlashCommands(b.ID)
+		require.NoError(err)
+		if assert.Len(commands, 1) {
+			assert.Equal("ping", commands[0].Name)
+		}
+
+		// 全削除
+		require.NoError(repo.SetBotSlashCommands(b.ID, nil))
+		commands, err = repo.GetBotSlashCommands(b.ID)
+		require.NoError(err)
+		assert.Empty(commands)
+	})
+}
+
+func TestRepositoryImpl_GetChannelSlashCommands(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user, ch := setupWithUserAndChannel(t, common3)
+
+	t.Run("nil id", func(t *testing.T) {
+		t.Parallel()
+		assert, require := assertAndRequire(t)
+
+		commands, err := repo.GetChannelSlashCommands(uuid.Nil)
+		require.NoError(err)
+		assert.Empty(commands)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		assert, require := assertAndRequire(t)
+		joined := mustMakeBot(t, repo, rand, user.GetID())
+		inactive := mustMakeBot(t, repo, rand, user.GetID())
+		notJoined := mustMakeBot(t, repo, rand, user.GetID())
+		require.NoError(repo.AddBotToChannel(joined.ID, ch.ID))
+		require.NoError(repo.AddBotToChannel(inactive.ID, ch.ID))
+		require.NoError(repo.ChangeBotState(inactive.ID, model.BotInactive))
+		require.NoError(repo.SetBotSlashCommands(joined.ID, []*model.BotSlashCommand{{Name: "joined"}}))
+		require.NoError(repo.SetBotSlashCommands(inactive.ID, []*model.BotSlashCommand{{Name: "inactive"}}))
+		require.NoError(repo.SetBotSlashCommands(notJoined.ID, []*model.BotSlashCommand{{Name: "notjoined"}}))
+
+		commands, err := repo.GetChannelSlashCommands(ch.ID)
+		require.NoError(err)
+		if assert.Len(commands, 1) {
+			assert.Equal("joined", commands[0].Name)
+			assert.Equal(joined.ID, commands[0].BotID)
+		}
+	})
+}
@@ -12,7 +12,9 @@ import (
 	"github.com/traPtitech/traQ/event"
 	"github.com/traPtitech/traQ/model"
 	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/utils/gormUtil"
 	"github.com/traPtitech/traQ/utils/message"
+	"github.com/traPtitech/traQ/utils/optional"
 )
 
 // CreateMessage implements MessageRepository interface.
@@ -20,19 +22,84 @@ func (repo *Repository) CreateMessage(userID, channelID uuid.UUID, text string)
 	if userID == uuid.Nil || channelID == uuid.Nil {
 		return nil, repository.ErrNilID
 	}
+	return repo.createMessage(userID, channelID, optional.Of[uuid.UUID]{}, text, nil)
+}
+
+// CreateThreadMessage implements MessageRepository interface.
+func (repo *Repository) CreateThreadMessage(userID, channelID, parentID uuid.UUID, text string, followers []uuid.UUID) (*model.Message, error) {
+	if userID == uuid.Nil || channelID == uuid.Nil || parentID == uuid.Nil {
+		return nil, repository.ErrNilID
+	}
+	for _, id := range followers {
+		if id == uuid.Nil {
+			return nil, repository.ErrNilID
+		}
+	}
+	return repo.createMessage(userID, channelID, optional.From(parentID), text, followers)
+}
 
+// ImportMessage implements MessageRepository interface.
+func (repo *Repository) ImportMessage(m *model.Message) error {
+	if m == nil || m.ID == uuid.Nil || m.UserID == uuid.Nil || m.ChannelID == uuid.Nil {
+		return repository.ErrNilID
+	}
+	if m.Stamps == nil {
+		m.Stamps = []model.MessageStamp{}
+	}
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if exists, err := gormUtil.RecordExists(tx.Unscoped(), &model.Message{ID: m.ID}); err != nil {
+			return err
+		} else if exists {
+			return repository.ErrAlreadyExists
+		}
+		if err := tx.Create(m).Error; err != nil {
+			return err
+		}
+
+		// 取り込んだメッセージの方が新しい場合のみ最新メッセージを更新
+		var clm model.ChannelLatestMessage
+		if err := tx.Where(&model.ChannelLatestMessage{ChannelID: m.ChannelID}).Take(&clm).Error; err != nil {
+			if err != gorm.ErrRecordNotFound {
+				return err
+			}
+		} else if !clm.DateTime.Before(m.CreatedAt) {
+			return nil
+		}
+		return tx.
+			Clauses(clause.OnConflict{UpdateAll: true}).
+			Create(&model.ChannelLatestMessage{ChannelID: m.ChannelID, MessageID: m.ID, DateTime: m.CreatedAt}).
+			Error
+	})
+}
+
+func (repo *Repository) createMessage(userID, channelID uuid.UUID, parentID optional.Of[uuid.UUID], text string, followers []uuid.UUID) (*model.Message, error) {
 	m := &model.Message{
 		ID:        uuid.Must(uuid.NewV4()),
 		UserID:    userID,
 		ChannelID: channelID,
 		Text:      text,
+		ParentID:  parentID,
 		Stamps:    []model.MessageStamp{},
 	}
+	followed := make([]uuid.UUID, 0, len(followers))
 	err := repo.db.Transaction(func(tx *gorm.DB) error {
 		if err := tx.Create(m).Error; err != nil {
 			return err
 		}
 
+		// 通知でフォロワーを参照するため、メッセージ作成イベントの発行前にフォローさせる
+		for _, id := range followers {
+			result := tx.
+				Clauses(clause.OnConflict{DoNothing: true}).
+				Create(&model.ThreadFollow{UserID: id, MessageID: parentID.V})
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected > 0 {
+				followed = append(followed, id)
+			}
+		}
+
 		clm := &model.ChannelLatestMessage{
 			ChannelID: m.ChannelID,
 			MessageID: m.ID,
@@ -48,6 +115,15 @@ func (repo *Repository) CreateMessage(userID, channelID uuid.UUID, text string)
 		return nil, err
 	}
 
+	for _, id := range followed {
+		repo.hub.Publish(hub.Message{
+			Name: event.ThreadFollowed,
+			Fields: hub.Fields{
+				"user_id":    id,
+				"message_id": parentID.V,
+			},
+		})
+	}
 	parseResult := message.Parse(text)
 	repo.hub.Publish(hub.Message{
 		Name: event.MessageCreated,
@@ -97,7 +173,10 @@ func (repo *Repository) UpdateMessage(messageID uuid.UUID, text string) error {
 		}
 
 		// update
-		if err := tx.Model(&old).Update("text", text).Error; err != nil {
+		if err := tx.Model(&old).Updates(map[string]interface{}{
+			"text":           text,
+			"revision_count": gorm.Expr("revision_count + 1"),
+		}).Error; err != nil {
 			return err
 		}
 
@@ -176,6 +255,18 @@ func (repo *Repository) GetMessageByID(messageID uuid.UUID) (*model.Message, err
 	return message, nil
 }
 
+// GetMessageUserID implements MessageRepository interface.
+func (repo *Repository) GetMessageUserID(messageID uuid.UUID) (uuid.UUID, error) {
+	if messageID == uuid.Nil {
+		return uuid.Nil, repository.ErrNotFound
+	}
+	var m model.Message
+	if err := repo.db.Unscoped().Select("user_id").Where(&model.Message{ID: messageID}).Take(&m).Error; err != nil {
+		return uuid.Nil, convertError(err)
+	}
+	return m.UserID, nil
+}
+
 // GetMessages implements MessageRepository interface.
 func (repo *Repository) GetMessages(query repository.MessagesQuery) (messages []*model.Message, more bool, err error) {
 	messages = make([]*model.Message, 0)
@@ -185,10 +276,11 @@ func (repo *Repository) GetMessages(query repository.MessagesQuery) (messages []
 		tx = tx.Scopes(messagePreloads)
 	}
 
+	// NOTE: 同じ日時のメッセージの順番を確定させるため、idでも並べる (インデックスには主キーが含まれる)
 	if query.Asc {
-		tx = tx.Order("messages.created_at")
+		tx = tx.Order("messages.created_at, messages.id")
 	} else {
-		tx = tx.Order("messages.created_at DESC")
+		tx = tx.Order("messages.created_at DESC, messages.id DESC")
 	}
 
 	if query.Offset > 0 {
@@ -207,6 +299,9 @@ func (repo *Repository) GetMessages(query repository.MessagesQuery) (messages []
 	if query.User != uuid.Nil {
 		tx = tx.Where("messages.user_id = ?", query.User)
 	}
+	if query.Thread != uuid.Nil {
+		tx = tx.Where("messages.parent_id = ?", query.Thread)
+	}
 	if query.ChannelsSubscribedByUser != uuid.Nil {
 		tx = tx.Where("channels.is_forced = TRUE OR channels.id IN (SELECT s.channel_id FROM users_subscribe_channels s WHERE s.user_id = ?)", query.ChannelsSubscribedByUser)
 	}
@@ -227,6 +322,15 @@ func (repo *Repository) GetMessages(query repository.MessagesQuery) (messages []
 		}
 	}
 
+	if query.Cursor.Valid {
+		op := "<"
+		if query.Asc {
+			op = ">"
+		}
+		createdAt := query.Cursor.V.CreatedAt.Truncate(time.Microsecond)
+		tx = tx.Where(fmt.Sprintf("(messages.created_at %[1]s ? OR (messages.created_at = ? AND messages.id %[1]s ?))", op), createdAt, createdAt, query.Cursor.V.ID)
+	}
+
 	if query.ExcludeDMs {
 		tx = tx.Where("channels.is_public = true")
 	}
@@ -242,6 +346,46 @@ func (repo *Repository) GetMessages(query repository.MessagesQuery) (messages []
 	return messages, false, err
 }
 
+// GetThreadReplyCounts implements MessageRepository interface.
+func (repo *Repository) GetThreadReplyCounts(parentIDs []uuid.UUID) (map[uuid.UUID]int, error) {
+	counts := make(map[uuid.UUID]int, len(parentIDs))
+	if len(parentIDs) == 0 {
+		return counts, nil
+	}
+
+	var rows []struct {
+		ParentID uuid.UUID
+		Count    int
+	}
+	err := repo.db.
+		Model(&model.Message{}).
+		Select("parent_id, COUNT(*) AS count").
+		Where("parent_id IN ?", parentIDs).
+		Group("parent_id").
+		Scan(&rows).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		counts[row.ParentID] = row.Count
+	}
+	return counts, nil
+}
+
+// GetArchivedMessagesByMessageID implements MessageRepository interface.
+func (repo *Repository) GetArchivedMessagesByMessageID(messageID uuid.UUID) ([]*model.ArchivedMessage, error) {
+	ams := make([]*model.ArchivedMessage, 0)
+	if messageID == uuid.Nil {
+		return ams, nil
+	}
+	return ams, repo.db.
+		Where(&model.ArchivedMessage{MessageID: messageID}).
+		Order("date_time").
+		Find(&ams).
+		Error
+}
+
 // GetUpdatedMessagesAfter implements MessageRepository interface.
 func (repo *Repository) GetUpdatedMessagesAfter(after time.Time, limit int) (messages []*model.Message, more bool, err error) {
 	err = repo.db.
@@ -439,5 +583,6 @@ func (repo *Repository) RemoveStampFromMessage(messageID, stampID, userID uuid.U
 func messagePreloads(db *gorm.DB) *gorm.DB {
 	return db.
 		Preload("Stamps").
-		Preload("Pin")
+		Preload("Pin").
+		Preload("Components")
 }
@@ -0,0 +1,89 @@
+package gorm
+
+import (
+	"github.com/gofrs/uuid"
+	"github.com/leandro-lugaresi/hub"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/traPtitech/traQ/event"
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+)
+
+// SetMessageComponents implements MessageComponentRepository interface.
+func (repo *Repository) SetMessageComponents(messageID uuid.UUID, components model.MessageComponentList) error {
+	if messageID == uuid.Nil {
+		return repository.ErrNilID
+	}
+
+	var m model.Message
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&m, &model.Message{ID: messageID}).Error; err != nil {
+			return convertError(err)
+		}
+
+		if len(components) == 0 {
+			return tx.Delete(&model.MessageComponents{MessageID: messageID}).Error
+		}
+		return tx.
+			Clauses(clause.OnConflict{DoUpdates: clause.AssignmentColumns([]string{"components", "updated_at"})}).
+			Create(&model.MessageComponents{MessageID: messageID, Components: components}).
+			Error
+	})
+	if err != nil {
+		return err
+	}
+	repo.hub.Publish(hub.Message{
+		Name: event.MessageComponentsUpdated,
+		Fields: hub.Fields{
+			"message_id": messageID,
+			"message":    &m,
+		},
+	})
+	return nil
+}
+
+// CreateMessageInteraction implements MessageComponentRepository interface.
+func (repo *Repository) CreateMessageInteraction(messageID, userID uuid.UUID, customID string, values []string) (*model.MessageInteraction, error) {
+	if messageID == uuid.Nil || userID == uuid.Nil {
+		return nil, repository.ErrNilID
+	}
+
+	var m model.Message
+	if err := repo.db.First(&m, &model.Message{ID: messageID}).Error; err != nil {
+		return nil, convertError(err)
+	}
+
+	i := &model.MessageInteraction{
+		ID:        uuid.Must(uuid.NewV4()),
+		MessageID: messageID,
+		UserID:    userID,
+		CustomID:  customID,
+		Values:    values,
+	}
+	if err := repo.db.Create(i).Error; err != nil {
+		return nil, err
+	}
+	repo.hub.Publish(hub.Message{
+		Name: event.MessageInteractionCreated,
+		Fields: hub.Fields{
+			"message_id":  messageID,
+			"message":     &m,
+			"interaction": i,
+		},
+	})
+	return i, nil
+}
+
+// GetMessageInteraction implements MessageComponentRepository interface.
+func (repo *Repository) GetMessageInteraction(id uuid.UUID) (*model.MessageInteraction, error) {
+	if id == uuid.Nil {
+		return nil, repository.ErrNotFound
+	}
+	var i model.MessageInteraction
+	if err := repo.db.First(&i, &model.MessageInteraction{ID: id}).Error; err != nil {
+		return nil, convertError(err)
+	}
+	return &i, nil
+}
@@ -0,0 +1,123 @@
+package gorm
+
+import (
+	"testing"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+)
+
+func TestRepositoryImpl_SetMessageComponents(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user, channel := setupWithUserAndChannel(t, common3)
+
+	components := model.MessageComponentList{
+		{Type: model.MessageComponentTypeButton, CustomID: "ok", Label: "OK"},
+	}
+
+	t.Run("nil id", func(t *testing.T) {
+		t.Parallel()
+		assert, _ := assertAndRequire(t)
+
+		assert.EqualError(repo.SetMessageComponents(uuid.Nil, components), repository.ErrNilID.Error())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+		assert, _ := assertAndRequire(t)
+
+		assert.EqualError(repo.SetMessageComponents(uuid.Must(uuid.NewV4()), components), repository.ErrNotFound.Error())
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		assert, require := assertAndRequire(t)
+		m := mustMakeMessage(t, repo, user.GetID(), channel.ID)
+
+		require.NoError(repo.SetMessageComponents(m.ID, components))
+		got, err := repo.GetMessageByID(m.ID)
+		require.NoError(err)
+		if assert.NotNil(got.Components) {
+			assert.Equal(components, got.Components.Components)
+		}
+
+		// 更新
+		updated := model.MessageComponentList{
+			{Type: model.MessageComponentTypeButton, CustomID: "ok", Label: "OK", Disabled: true},
+		}
+		require.NoError(repo.SetMessageComponents(m.ID, updated))
+		got, err = repo.GetMessageByID(m.ID)
+		require.NoError(err)
+		if assert.NotNil(got.Components) {
+			assert.True(got.Components.Components[0].Disabled)
+		}
+
+		// 削除
+		require.NoError(repo.SetMessageComponents(m.ID, nil))
+		got, err = repo.GetMessageByID(m.ID)
+		require.NoError(err)
+		assert.Nil(got.Components)
+	})
+}
+
+func TestRepositoryImpl_CreateMessageInteraction(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user, channel := setupWithUserAndChannel(t, common3)
+
+	t.Run("nil id", func(t *testing.T) {
+		t.Parallel()
+		assert, _ := assertAndRequire(t)
+
+		_, err := repo.CreateMessageInteraction(uuid.Nil, user.GetID(), "ok", nil)
+		assert.EqualError(err, repository.ErrNilID.Error())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+		assert, _ := assertAndRequire(t)
+
+		_, err := repo.CreateMessageInteraction(uuid.Must(uuid.NewV4()), user.GetID(), "ok", nil)
+		assert.EqualError(err, repository.ErrNotFound.Error())
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		assert, require := assertAndRequire(t)
+		m := mustMakeMessage(t, repo, user.GetID(), channel.ID)
+
+		i, err := repo.CreateMessageInteraction(m.ID, user.GetID(), "choice", []string{"a", "b"})
+		require.NoError(err)
+		assert.NotEqual(uuid.Nil, i.ID)
+		assert.Equal(m.ID, i.MessageID)
+		assert.Equal(user.GetID(), i.UserID)
+		assert.EqualValues([]string{"a", "b"}, i.Values)
+
+		saved, err := repo.GetMessageInteraction(i.ID)
+		require.NoError(err)
+		assert.Equal("choice", saved.CustomID)
+		assert.EqualValues([]string{"a", "b"}, saved.Values)
+	})
+}
+
+func TestRepositoryImpl_GetMessageInteraction(t *testing.T) {
+	t.Parallel()
+	repo, _, _ := setup(t, common3)
+
+	t.Run("nil id", func(t *testing.T) {
+		t.Parallel()
+		assert, _ := assertAndRequire(t)
+
+		_, err := repo.GetMessageInteraction(uuid.Nil)
+		assert.EqualError(err, repository.ErrNotFound.Error())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+		assert, _ := assertAndRequire(t)
+
+		_, err := repo.GetMessageInteraction(uuid.Must(uuid.NewV4()))
+		assert.EqualError(err, repository.ErrNotFound.Error())
+	})
+}
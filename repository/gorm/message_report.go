@@ -1,11 +1,18 @@
 package gorm
 
 import (
+	"time"
+
 	"github.com/gofrs/uuid"
+	"github.com/leandro-lugaresi/hub"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
+	"github.com/traPtitech/traQ/event"
 	"github.com/traPtitech/traQ/model"
 	"github.com/traPtitech/traQ/repository"
 	"github.com/traPtitech/traQ/utils/gormUtil"
+	"github.com/traPtitech/traQ/utils/optional"
 )
 
 // CreateMessageReport implements MessageReportRepository interface.
@@ -21,6 +28,7 @@ func (repo *Repository) CreateMessageReport(messageID, reporterID uuid.UUID, rea
 		MessageID: messageID,
 		Reporter:  reporterID,
 		Reason:    reason,
+		State:     model.MessageReportStateOpen,
 	}
 	if err := repo.db.Create(r).Error; err != nil {
 		if gormUtil.IsMySQLDuplicatedRecordErr(err) {
@@ -28,13 +36,42 @@ func (repo *Repository) CreateMessageReport(messageID, reporterID uuid.UUID, rea
 		}
 		return err
 	}
+	repo.hub.Publish(hub.Message{
+		Name: event.MessageReportCreated,
+		Fields: hub.Fields{
+			"report_id": r.ID,
+			"report":    r,
+		},
+	})
 	return nil
 }
 
+// GetMessageReport implements MessageReportRepository interface.
+func (repo *Repository) GetMessageReport(reportID uuid.UUID) (*model.MessageReport, error) {
+	if reportID == uuid.Nil {
+		return nil, repository.ErrNotFound
+	}
+	var r model.MessageReport
+	if err := repo.db.First(&r, &model.MessageReport{ID: reportID}).Error; err != nil {
+		return nil, convertError(err)
+	}
+	return &r, nil
+}
+
 // GetMessageReports implements MessageReportRepository interface.
-func (repo *Repository) GetMessageReports(offset, limit int) (arr []*model.MessageReport, err error) {
+func (repo *Repository) GetMessageReports(query repository.MessageReportsQuery) (arr []*model.MessageReport, err error) {
 	arr = make([]*model.MessageReport, 0)
-	err = repo.db.Scopes(gormUtil.LimitAndOffset(limit, offset)).Order("created_at").Find(&arr).Error
+	tx := repo.db.Scopes(gormUtil.LimitAndOffset(query.Limit, query.Offset)).Order("created_at")
+	if query.State.Valid {
+		tx = tx.Where("state = ?", query.State.V)
+	}
+	if query.MessageID.Valid {
+		tx = tx.Where("message_id = ?", query.MessageID.V)
+	}
+	if query.ReporterID.Valid {
+		tx = tx.Where("reporter = ?", query.ReporterID.V)
+	}
+	err = tx.Find(&arr).Error
 	return arr, err
 }
 
@@ -57,3 +94,47 @@ func (repo *Repository) GetMessageReportsByReporterID(reporterID uuid.UUID) (arr
 	err = repo.db.Where(&model.MessageReport{Reporter: reporterID}).Order("created_at").Find(&arr).Error
 	return arr, err
 }
+
+// ResolveMessageReport implements MessageReportRepository interface.
+func (repo *Repository) ResolveMessageReport(reportID uuid.UUID, args repository.ResolveMessageReportArgs) error {
+	if reportID == uuid.Nil || args.ResolverID == uuid.Nil {
+		return repository.ErrNilID
+	}
+	if !args.State.Valid() {
+		return repository.ArgError("State", "invalid state")
+	}
+
+	var r model.MessageReport
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&r, &model.MessageReport{ID: reportID}).Error; err != nil {
+			return convertError(err)
+		}
+
+		changes := map[string]interface{}{
+			"state": args.State,
+			"note":  args.Note,
+		}
+		if args.State == model.MessageReportStateOpen {
+			changes["resolver_id"] = optional.Of[uuid.UUID]{}
+			changes["resolved_at"] = optional.Of[time.Time]{}
+		} else {
+			changes["resolver_id"] = optional.From(args.ResolverID)
+			changes["resolved_at"] = optional.From(time.Now())
+		}
+		if err := tx.Model(&r).Updates(changes).Error; err != nil {
+			return err
+		}
+		return tx.First(&r, &model.MessageReport{ID: reportID}).Error
+	})
+	if err != nil {
+		return err
+	}
+	repo.hub.Publish(hub.Message{
+		Name: event.MessageReportResolved,
+		Fields: hub.Fields{
+			"report_id": reportID,
+			"report":    &r,
+		},
+	})
+	return nil
+}
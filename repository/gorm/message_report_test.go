@@ -0,0 +1,80 @@
+package gorm
+
+import (
+	"testing"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+func TestRepositoryImpl_CreateMessageReport(t *testing.T) {
+	t.Parallel()
+	repo, assert, _, user, channel := setupWithUserAndChannel(t, common2)
+	m := mustMakeMessage(t, repo, user.GetID(), channel.ID)
+
+	assert.EqualError(repo.CreateMessageReport(uuid.Nil, user.GetID(), "a"), repository.ErrNilID.Error())
+	assert.EqualError(repo.CreateMessageReport(m.ID, uuid.Nil, "a"), repository.ErrNilID.Error())
+	if assert.NoError(repo.CreateMessageReport(m.ID, user.GetID(), "a")) {
+		assert.Equal(1, count(t, getDB(repo).Model(model.MessageReport{}).Where(&model.MessageReport{MessageID: m.ID, State: model.MessageReportStateOpen})))
+	}
+	assert.EqualError(repo.CreateMessageReport(m.ID, user.GetID(), "a"), repository.ErrAlreadyExists.Error())
+}
+
+func TestRepositoryImpl_GetMessageReports(t *testing.T) {
+	t.Parallel()
+	repo, assert, require, user, channel := setupWithUserAndChannel(t, common2)
+	m1 := mustMakeMessage(t, repo, user.GetID(), channel.ID)
+	m2 := mustMakeMessage(t, repo, user.GetID(), channel.ID)
+	require.NoError(repo.CreateMessageReport(m1.ID, user.GetID(), "a"))
+	require.NoError(repo.CreateMessageReport(m2.ID, user.GetID(), "b"))
+
+	reports, err := repo.GetMessageReports(repository.MessageReportsQuery{MessageID: optional.From(m1.ID)})
+	if assert.NoError(err) && assert.Len(reports, 1) {
+		assert.Equal("a", reports[0].Reason)
+	}
+
+	reports, err = repo.GetMessageReports(repository.MessageReportsQuery{ReporterID: optional.From(user.GetID()), State: optional.From(model.MessageReportStateOpen)})
+	if assert.NoError(err) {
+		assert.Len(reports, 2)
+	}
+
+	reports, err = repo.GetMessageReports(repository.MessageReportsQuery{ReporterID: optional.From(user.GetID()), State: optional.From(model.MessageReportStateResolved)})
+	if assert.NoError(err) {
+		assert.Len(reports, 0)
+	}
+}
+
+func TestRepositoryImpl_ResolveMessageReport(t *testing.T) {
+	t.Parallel()
+	repo, assert, require, user, channel := setupWithUserAndChannel(t, common2)
+	m := mustMakeMessage(t, repo, user.GetID(), channel.ID)
+	require.NoError(repo.CreateMessageReport(m.ID, user.GetID(), "a"))
+	reports, err := repo.GetMessageReportsByMessageID(m.ID)
+	require.NoError(err)
+	r := reports[0]
+
+	args := repository.ResolveMessageReportArgs{State: model.MessageReportStateResolved, ResolverID: user.GetID(), Note: "done"}
+	assert.EqualError(repo.ResolveMessageReport(uuid.Nil, args), repository.ErrNilID.Error())
+	assert.EqualError(repo.ResolveMessageReport(uuid.Must(uuid.NewV4()), args), repository.ErrNotFound.Error())
+	assert.Error(repo.ResolveMessageReport(r.ID, repository.ResolveMessageReportArgs{State: "closed", ResolverID: user.GetID()}))
+
+	if assert.NoError(repo.ResolveMessageReport(r.ID, args)) {
+		r, err := repo.GetMessageReport(r.ID)
+		require.NoError(err)
+		assert.Equal(model.MessageReportStateResolved, r.State)
+		assert.Equal(optional.From(user.GetID()), r.ResolverID)
+		assert.Equal("done", r.Note)
+		assert.True(r.ResolvedAt.Valid)
+	}
+
+	if assert.NoError(repo.ResolveMessageReport(r.ID, repository.ResolveMessageReportArgs{State: model.MessageReportStateOpen, ResolverID: user.GetID()})) {
+		r, err := repo.GetMessageReport(r.ID)
+		require.NoError(err)
+		assert.Equal(model.MessageReportStateOpen, r.State)
+		assert.False(r.ResolverID.Valid)
+		assert.False(r.ResolvedAt.Valid)
+	}
+}
@@ -74,6 +74,107 @@ func TestRepositoryImpl_CreateMessage(t *testing.T) {
 	})
 }
 
+func TestRepositoryImpl_CreateThreadMessage(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user, channel := setupWithUserAndChannel(t, common3)
+	parent := mustMakeMessage(t, repo, user.GetID(), channel.ID)
+
+	t.Run("failures", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := repo.CreateThreadMessage(user.GetID(), channel.ID, uuid.Nil, "a", nil)
+		assert.Error(t, err)
+		_, err = repo.CreateThreadMessage(user.GetID(), channel.ID, parent.ID, "a", []uuid.UUID{uuid.Nil})
+		assert.Error(t, err)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		assert := assert.New(t)
+
+		m, err := repo.CreateThreadMessage(user.GetID(), channel.ID, parent.ID, "test", []uuid.UUID{user.GetID()})
+		if assert.NoError(err) {
+			assert.NotZero(m.ID)
+			assert.Equal(user.GetID(), m.UserID)
+			assert.Equal(channel.ID, m.ChannelID)
+			assert.Equal(optional.From(parent.ID), m.ParentID)
+			assert.Equal("test", m.Text)
+			assert.Equal(1, count(t, getDB(repo).Model(model.ThreadFollow{}).Where(model.ThreadFollow{UserID: user.GetID(), MessageID: parent.ID})))
+		}
+
+		// 既にフォローしている場合も作成できる
+		_, err = repo.CreateThreadMessage(user.GetID(), channel.ID, parent.ID, "test", []uuid.UUID{user.GetID()})
+		assert.NoError(err)
+	})
+}
+
+func TestRepositoryImpl_ImportMessage(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user, channel := setupWithUserAndChannel(t, common3)
+	stamp := mustMakeStamp(t, repo, rand, uuid.Nil)
+
+	t.Run("nil", func(t *testing.T) {
+		t.Parallel()
+
+		assert.EqualError(t, repo.ImportMessage(nil), repository.ErrNilID.Error())
+		assert.EqualError(t, repo.ImportMessage(&model.Message{UserID: user.GetID(), ChannelID: channel.ID}), repository.ErrNilID.Error())
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		assert, require := assertAndRequire(t)
+
+		at := time.Date(2015, 4, 1, 12, 0, 0, 0, time.UTC)
+		m := &model.Message{
+			ID:        uuid.Must(uuid.NewV4()),
+			UserID:    user.GetID(),
+			ChannelID: channel.ID,
+			Text:      "imported",
+			CreatedAt: at,
+			UpdatedAt: at,
+			Stamps: []model.MessageStamp{
+				{StampID: stamp.ID, UserID: user.GetID(), Count: 1, CreatedAt: at, UpdatedAt: at},
+			},
+		}
+		require.NoError(repo.ImportMessage(m))
+
+		actual, err := repo.GetMessageByID(m.ID)
+		require.NoError(err)
+		assert.Equal("imported", actual.Text)
+		assert.True(actual.CreatedAt.Equal(at))
+		assert.Len(actual.Stamps, 1)
+
+		assert.EqualError(repo.ImportMessage(m), repository.ErrAlreadyExists.Error())
+	})
+}
+
+func TestRepositoryImpl_GetThreadReplyCounts(t *testing.T) {
+	t.Parallel()
+	repo, assert, require, user, channel := setupWithUserAndChannel(t, common3)
+	m1 := mustMakeMessage(t, repo, user.GetID(), channel.ID)
+	m2 := mustMakeMessage(t, repo, user.GetID(), channel.ID)
+	m3 := mustMakeMessage(t, repo, user.GetID(), channel.ID)
+
+	for i := 0; i < 3; i++ {
+		_, err := repo.CreateThreadMessage(user.GetID(), channel.ID, m1.ID, "a", nil)
+		require.NoError(err)
+	}
+	r, err := repo.CreateThreadMessage(user.GetID(), channel.ID, m2.ID, "a", nil)
+	require.NoError(err)
+	require.NoError(repo.DeleteMessage(r.ID))
+
+	counts, err := repo.GetThreadReplyCounts([]uuid.UUID{m1.ID, m2.ID, m3.ID})
+	if assert.NoError(err) {
+		assert.Len(counts, 1)
+		assert.Equal(3, counts[m1.ID])
+	}
+
+	counts, err = repo.GetThreadReplyCounts(nil)
+	if assert.NoError(err) {
+		assert.Len(counts, 0)
+	}
+}
+
 func TestRepositoryImpl_UpdateMessage(t *testing.T) {
 	t.Parallel()
 	repo, assert, _, user, channel := setupWithUserAndChannel(t, common3)
@@ -88,10 +189,38 @@ func TestRepositoryImpl_UpdateMessage(t *testing.T) {
 	m, err := repo.GetMessageByID(m.ID)
 	if assert.NoError(err) {
 		assert.Equal("new message", m.Text)
+		assert.Equal(1, m.RevisionCount)
 		assert.Equal(1, count(t, getDB(repo).Model(&model.ArchivedMessage{}).Where(&model.ArchivedMessage{MessageID: m.ID, Text: originalText})))
 	}
 }
 
+func TestRepositoryImpl_GetArchivedMessagesByMessageID(t *testing.T) {
+	t.Parallel()
+	repo, assert, require, user, channel := setupWithUserAndChannel(t, common3)
+
+	m := mustMakeMessage(t, repo, user.GetID(), channel.ID)
+	originalText := m.Text
+	require.NoError(repo.UpdateMessage(m.ID, "a"))
+	require.NoError(repo.UpdateMessage(m.ID, "b"))
+
+	ams, err := repo.GetArchivedMessagesByMessageID(m.ID)
+	if assert.NoError(err) && assert.Len(ams, 2) {
+		assert.Equal(originalText, ams[0].Text)
+		assert.Equal("a", ams[1].Text)
+		assert.Equal(user.GetID(), ams[0].UserID)
+	}
+
+	m, err = repo.GetMessageByID(m.ID)
+	if assert.NoError(err) {
+		assert.Equal(2, m.RevisionCount)
+	}
+
+	ams, err = repo.GetArchivedMessagesByMessageID(uuid.Nil)
+	if assert.NoError(err) {
+		assert.Empty(ams)
+	}
+}
+
 func TestRepositoryImpl_DeleteMessage(t *testing.T) {
 	t.Parallel()
 	repo, assert, _, user, channel := setupWithUserAndChannel(t, common3)
@@ -125,6 +254,26 @@ func TestRepositoryImpl_GetMessageByID(t *testing.T) {
 	assert.Error(err)
 }
 
+func TestRepositoryImpl_GetMessageUserID(t *testing.T) {
+	t.Parallel()
+	repo, assert, require, user, channel := setupWithUserAndChannel(t, common3)
+
+	m := mustMakeMessage(t, repo, user.GetID(), channel.ID)
+	require.NoError(repo.DeleteMessage(m.ID))
+
+	// 削除されたメッセージからも取得できる
+	id, err := repo.GetMessageUserID(m.ID)
+	if assert.NoError(err) {
+		assert.Equal(user.GetID(), id)
+	}
+
+	_, err = repo.GetMessageUserID(uuid.Nil)
+	assert.EqualError(err, repository.ErrNotFound.Error())
+
+	_, err = repo.GetMessageUserID(uuid.Must(uuid.NewV4()))
+	assert.EqualError(err, repository.ErrNotFound.Error())
+}
+
 func TestRepositoryImpl_GetMessages(t *testing.T) {
 	t.Parallel()
 	repo, _, require, user := setupWithUser(t, ex3)
@@ -191,6 +340,20 @@ func TestRepositoryImpl_GetMessages(t *testing.T) {
 		}
 	})
 
+	t.Run("thread", func(t *testing.T) {
+		t.Parallel()
+
+		messages, more, err := repo.GetMessages(repository.MessagesQuery{
+			Thread: m6.ID,
+			Limit:  50,
+		})
+
+		if assert.NoError(t, err) {
+			assert.False(t, more)
+			assert.EqualValues(t, 0, len(messages))
+		}
+	})
+
 	t.Run("activity subscription", func(t *testing.T) {
 		t.Parallel()
 
@@ -0,0 +1,161 @@
+package gorm
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/utils/gormUtil"
+)
+
+// CreateOutgoingWebhook implements OutgoingWebhookRepository interface.
+func (repo *Repository) CreateOutgoingWebhook(args repository.CreateOutgoingWebhookArgs) (*model.OutgoingWebhook, error) {
+	if args.ChannelID == uuid.Nil || args.CreatorID == uuid.Nil {
+		return nil, repository.ErrNilID
+	}
+	if args.Events == nil {
+		args.Events = model.OutgoingWebhookEventTypes{}
+	}
+
+	w := &model.OutgoingWebhook{
+		ID:        uuid.Must(uuid.NewV4()),
+		ChannelID: args.ChannelID,
+		CreatorID: args.CreatorID,
+		URL:       args.URL,
+		Secret:    args.Secret,
+		Events:    args.Events,
+	}
+	if err := repo.db.Create(w).Error; err != nil {
+		return nil, convertError(err)
+	}
+	return w, nil
+}
+
+// UpdateOutgoingWebhook implements OutgoingWebhookRepository interface.
+func (repo *Repository) UpdateOutgoingWebhook(id uuid.UUID, args repository.UpdateOutgoingWebhookArgs) error {
+	if id == uuid.Nil {
+		return repository.ErrNilID
+	}
+
+	changes := map[string]interface{}{}
+	if args.ChannelID.Valid {
+		changes["channel_id"] = args.ChannelID.V
+	}
+	if args.URL.Valid {
+		changes["url"] = args.URL.V
+	}
+	if args.Secret.Valid {
+		changes["secret"] = args.Secret.V
+	}
+	if args.Events.Valid {
+		changes["events"] = args.Events.V
+	}
+
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		var w model.OutgoingWebhook
+		if err := tx.First(&w, &model.OutgoingWebhook{ID: id}).Error; err != nil {
+			return convertError(err)
+		}
+		if len(changes) > 0 {
+			return tx.Model(&w).Updates(changes).Error
+		}
+		return nil
+	})
+}
+
+// DeleteOutgoingWebhook implements OutgoingWebhookRepository interface.
+func (repo *Repository) DeleteOutgoingWebhook(id uuid.UUID) error {
+	if id == uuid.Nil {
+		return repository.ErrNilID
+	}
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&model.OutgoingWebhookDelivery{}, &model.OutgoingWebhookDelivery{WebhookID: id}).Error; err != nil {
+			return err
+		}
+		result := tx.Delete(&model.OutgoingWebhook{ID: id})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return repository.ErrNotFound
+		}
+		return nil
+	})
+}
+
+// GetOutgoingWebhook implements OutgoingWebhookRepository interface.
+func (repo *Repository) GetOutgoingWebhook(id uuid.UUID) (*model.OutgoingWebhook, error) {
+	if id == uuid.Nil {
+		return nil, repository.ErrNotFound
+	}
+	var w model.OutgoingWebhook
+	if err := repo.db.First(&w, &model.OutgoingWebhook{ID: id}).Error; err != nil {
+		return nil, convertError(err)
+	}
+	return &w, nil
+}
+
+// GetOutgoingWebhooksByChannelID implements OutgoingWebhookRepository interface.
+func (repo *Repository) GetOutgoingWebhooksByChannelID(channelID uuid.UUID) ([]*model.OutgoingWebhook, error) {
+	ws := make([]*model.OutgoingWebhook, 0)
+	if channelID == uuid.Nil {
+		return ws, nil
+	}
+	return ws, repo.db.Where(&model.OutgoingWebhook{ChannelID: channelID}).Order("created_at").Find(&ws).Error
+}
+
+// GetOutgoingWebhooksByCreatorID implements OutgoingWebhookRepository interface.
+func (repo *Repository) GetOutgoingWebhooksByCreatorID(creatorID uuid.UUID) ([]*model.OutgoingWebhook, error) {
+	ws := make([]*model.OutgoingWebhook, 0)
+	if creatorID == uuid.Nil {
+		return ws, nil
+	}
+	return ws, repo.db.Where(&model.OutgoingWebhook{CreatorID: creatorID}).Order("created_at").Find(&ws).Error
+}
+
+// GetAllOutgoingWebhooks implements OutgoingWebhookRepository interface.
+func (repo *Repository) GetAllOutgoingWebhooks() ([]*model.OutgoingWebhook, error) {
+	ws := make([]*model.OutgoingWebhook, 0)
+	return ws, repo.db.Order("created_at").Find(&ws).Error
+}
+
+// WriteOutgoingWebhookDelivery implements OutgoingWebhookRepository interface.
+func (repo *Repository) WriteOutgoingWebhookDelivery(delivery *model.OutgoingWebhookDelivery) error {
+	if delivery == nil || delivery.ID == uuid.Nil {
+		return nil
+	}
+	return repo.db.Create(delivery).Error
+}
+
+// GetOutgoingWebhookDelivery implements OutgoingWebhookRepository interface.
+func (repo *Repository) GetOutgoingWebhookDelivery(id uuid.UUID) (*model.OutgoingWebhookDelivery, error) {
+	if id == uuid.Nil {
+		return nil, repository.ErrNotFound
+	}
+	var d model.OutgoingWebhookDelivery
+	if err := repo.db.First(&d, &model.OutgoingWebhookDelivery{ID: id}).Error; err != nil {
+		return nil, convertError(err)
+	}
+	return &d, nil
+}
+
+// GetOutgoingWebhookDeliveries implements OutgoingWebhookRepository interface.
+func (repo *Repository) GetOutgoingWebhookDeliveries(webhookID uuid.UUID, limit, offset int) ([]*model.OutgoingWebhookDelivery, error) {
+	ds := make([]*model.OutgoingWebhookDelivery, 0)
+	if webhookID == uuid.Nil {
+		return ds, nil
+	}
+	return ds, repo.db.Where(&model.OutgoingWebhookDelivery{WebhookID: webhookID}).
+		Order("date_time DESC").
+		Scopes(gormUtil.LimitAndOffset(limit, offset)).
+		Find(&ds).
+		Error
+}
+
+// PurgeOutgoingWebhookDeliveries implements OutgoingWebhookRepository interface.
+func (repo *Repository) PurgeOutgoingWebhookDeliveries(before time.Time) error {
+	return repo.db.Delete(&model.OutgoingWebhookDelivery{}, "date_time < ?", before).Error
+}
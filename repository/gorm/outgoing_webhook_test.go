@@ -0,0 +1,148 @@
+package gorm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/utils/optional"
+	"github.com/traPtitech/traQ/utils/random"
+)
+
+func TestRepositoryImpl_CreateOutgoingWebhook(t *testing.T) {
+	t.Parallel()
+	repo, assert, require, user, channel := setupWithUserAndChannel(t, common3)
+
+	_, err := repo.CreateOutgoingWebhook(repository.CreateOutgoingWebhookArgs{CreatorID: user.GetID()})
+	assert.EqualError(err, repository.ErrNilID.Error())
+
+	w, err := repo.CreateOutgoingWebhook(repository.CreateOutgoingWebhookArgs{
+		ChannelID: channel.ID,
+		CreatorID: user.GetID(),
+		URL:       "https://example.com/hook",
+		Secret:    "secret",
+		Events:    model.OutgoingWebhookEventTypes{model.OutgoingWebhookEventMessageCreated},
+	})
+	require.NoError(err)
+	assert.NotEqual(uuid.Nil, w.ID)
+
+	got, err := repo.GetOutgoingWebhook(w.ID)
+	require.NoError(err)
+	assert.Equal(channel.ID, got.ChannelID)
+	assert.Equal("https://example.com/hook", got.URL)
+	assert.Equal("secret", got.Secret)
+	assert.Equal(model.OutgoingWebhookEventTypes{model.OutgoingWebhookEventMessageCreated}, got.Events)
+
+	_, err = repo.GetOutgoingWebhook(uuid.Must(uuid.NewV4()))
+	assert.EqualError(err, repository.ErrNotFound.Error())
+}
+
+func TestRepositoryImpl_UpdateOutgoingWebhook(t *testing.T) {
+	t.Parallel()
+	repo, assert, require, user, channel := setupWithUserAndChannel(t, common3)
+
+	w, err := repo.CreateOutgoingWebhook(repository.CreateOutgoingWebhookArgs{
+		ChannelID: channel.ID,
+		CreatorID: user.GetID(),
+		URL:       "https://example.com/hook",
+	})
+	require.NoError(err)
+
+	assert.EqualError(repo.UpdateOutgoingWebhook(uuid.Nil, repository.UpdateOutgoingWebhookArgs{}), repository.ErrNilID.Error())
+	assert.EqualError(repo.UpdateOutgoingWebhook(uuid.Must(uuid.NewV4()), repository.UpdateOutgoingWebhookArgs{}), repository.ErrNotFound.Error())
+
+	require.NoError(repo.UpdateOutgoingWebhook(w.ID, repository.UpdateOutgoingWebhookArgs{
+		URL:    optional.From("https://example.com/hook2"),
+		Events: optional.From(model.OutgoingWebhookEventTypes{model.OutgoingWebhookEventMessagePinned}),
+	}))
+	got, err := repo.GetOutgoingWebhook(w.ID)
+	require.NoError(err)
+	assert.Equal("https://example.com/hook2", got.URL)
+	assert.Equal(model.OutgoingWebhookEventTypes{model.OutgoingWebhookEventMessagePinned}, got.Events)
+}
+
+func TestRepositoryImpl_GetOutgoingWebhooks(t *testing.T) {
+	t.Parallel()
+	repo, assert, require, user, channel := setupWithUserAndChannel(t, common3)
+	other := mustMakeUser(t, repo, rand)
+
+	for _, creator := range []uuid.UUID{user.GetID(), user.GetID(), other.GetID()} {
+		_, err := repo.CreateOutgoingWebhook(repository.CreateOutgoingWebhookArgs{
+			ChannelID: channel.ID,
+			CreatorID: creator,
+			URL:       "https://example.com/hook",
+		})
+		require.NoError(err)
+	}
+
+	ws, err := repo.GetOutgoingWebhooksByChannelID(channel.ID)
+	require.NoError(err)
+	assert.Len(ws, 3)
+
+	ws, err = repo.GetOutgoingWebhooksByCreatorID(user.GetID())
+	require.NoError(err)
+	assert.Len(ws, 2)
+
+	ws, err = repo.GetOutgoingWebhooksByChannelID(uuid.Nil)
+	require.NoError(err)
+	assert.Empty(ws)
+
+	ws, err = repo.GetAllOutgoingWebhooks()
+	require.NoError(err)
+	assert.GreaterOrEqual(len(ws), 3)
+}
+
+func TestRepositoryImpl_OutgoingWebhookDeliveries(t *testing.T) {
+	t.Parallel()
+	repo, assert, require, user, channel := setupWithUserAndChannel(t, common3)
+
+	w, err := repo.CreateOutgoingWebhook(repository.CreateOutgoingWebhookArgs{
+		ChannelID: channel.ID,
+		CreatorID: user.GetID(),
+		URL:       "https://example.com/hook",
+	})
+	require.NoError(err)
+
+	now := time.Now()
+	ids := make([]uuid.UUID, 3)
+	for i := range ids {
+		ids[i] = uuid.Must(uuid.NewV4())
+		require.NoError(repo.WriteOutgoingWebhookDelivery(&model.OutgoingWebhookDelivery{
+			ID:        ids[i],
+			WebhookID: w.ID,
+			Event:     model.OutgoingWebhookEventMessageCreated,
+			Body:      random.AlphaNumeric(10),
+			Result:    "ok",
+			Code:      200,
+			Attempts:  1,
+			DateTime:  now.Add(time.Duration(i-3) * time.Hour),
+		}))
+	}
+
+	d, err := repo.GetOutgoingWebhookDelivery(ids[0])
+	require.NoError(err)
+	assert.Equal(w.ID, d.WebhookID)
+	_, err = repo.GetOutgoingWebhookDelivery(uuid.Must(uuid.NewV4()))
+	assert.EqualError(err, repository.ErrNotFound.Error())
+
+	ds, err := repo.GetOutgoingWebhookDeliveries(w.ID, 2, 0)
+	require.NoError(err)
+	if assert.Len(ds, 2) {
+		assert.Equal(ids[2], ds[0].ID)
+		assert.Equal(ids[1], ds[1].ID)
+	}
+
+	require.NoError(repo.PurgeOutgoingWebhookDeliveries(now.Add(-150 * time.Minute)))
+	ds, err = repo.GetOutgoingWebhookDeliveries(w.ID, 0, 0)
+	require.NoError(err)
+	assert.Len(ds, 2)
+
+	require.NoError(repo.DeleteOutgoingWebhook(w.ID))
+	assert.EqualError(repo.DeleteOutgoingWebhook(w.ID), repository.ErrNotFound.Error())
+	ds, err = repo.GetOutgoingWebhookDeliveries(w.ID, 0, 0)
+	require.NoError(err)
+	assert.Empty(ds)
+}
@@ -1,11 +1,16 @@
 package gorm
 
 import (
+	"time"
+
+	"github.com/gofrs/uuid"
 	"github.com/leandro-lugaresi/hub"
+	"github.com/motoki317/sc"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
 
 	"github.com/traPtitech/traQ/migration"
+	"github.com/traPtitech/traQ/model"
 	"github.com/traPtitech/traQ/repository"
 )
 
@@ -14,6 +19,8 @@ type Repository struct {
 	db     *gorm.DB
 	hub    *hub.Hub
 	logger *zap.Logger
+	// botsByUserID BotユーザーIDをキーとしたBotのキャッシュ
+	botsByUserID *sc.Cache[uuid.UUID, *model.Bot]
 	repository.StampRepository
 	repository.UserRepository
 }
@@ -21,13 +28,15 @@ type Repository struct {
 // NewGormRepository リポジトリ実装を初期化して生成します。
 // スキーマが初期化された場合、init: true を返します。
 func NewGormRepository(db *gorm.DB, hub *hub.Hub, logger *zap.Logger, doMigration bool) (repo repository.Repository, init bool, err error) {
-	repo = &Repository{
+	r := &Repository{
 		db:              db,
 		hub:             hub,
 		logger:          logger.Named("repository"),
 		StampRepository: makeStampRepository(db, hub),
 		UserRepository:  makeUserRepository(db, hub),
 	}
+	r.botsByUserID = sc.NewMust(r.getBotByBotUserID, 1*time.Hour, 1*time.Hour)
+	repo = r
 	if doMigration {
 		if init, err = migration.Migrate(db); err != nil {
 			return nil, false, err
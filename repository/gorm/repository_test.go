@@ -20,6 +20,7 @@ import (
 	"github.com/traPtitech/traQ/model"
 	"github.com/traPtitech/traQ/repository"
 	"github.com/traPtitech/traQ/service/rbac/role"
+	"github.com/traPtitech/traQ/utils/optional"
 	"github.com/traPtitech/traQ/utils/random"
 )
 
@@ -273,6 +274,16 @@ func mustMakeWebhook(t *testing.T, repo repository.Repository, name string, chan
 	return w
 }
 
+func mustMakeBot(t *testing.T, repo repository.Repository, name string, creatorID uuid.UUID) *model.Bot {
+	t.Helper()
+	if name == rand {
+		name = random.AlphaNumeric(20)
+	}
+	b, err := repo.CreateBot(name, "po", "totally a desc", mustMakeDummyFile(t, repo).ID, creatorID, model.BotModeHTTP, model.BotActive, "https://example.com", optional.Of[model.BotPermissions]{})
+	require.NoError(t, err)
+	return b
+}
+
 func mustChangeChannelSubscription(t *testing.T, repo repository.Repository, channelID, userID uuid.UUID) {
 	t.Helper()
 	_, _, err := repo.ChangeChannelSubscription(channelID, repository.ChangeChannelSubscriptionArgs{Subscription: map[uuid.UUID]model.ChannelSubscribeLevel{userID: model.ChannelSubscribeLevelMarkAndNotify}})
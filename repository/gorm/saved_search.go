@@ -0,0 +1,115 @@
+package gorm
+
+import (
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+)
+
+// CreateSavedSearch implements SavedSearchRepository interface.
+func (repo *Repository) CreateSavedSearch(userID uuid.UUID, name, query string, limit int) (*model.SavedSearch, error) {
+	if userID == uuid.Nil {
+		return nil, repository.ErrNilID
+	}
+
+	ss := &model.SavedSearch{
+		ID:     uuid.Must(uuid.NewV4()),
+		UserID: userID,
+		Name:   name,
+		Query:  query,
+	}
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		// 同時に作成された場合に上限を超えないよう、ユーザー単位でロックする
+		var u model.User
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&u, &model.User{ID: userID}).Error; err != nil {
+			return convertError(err)
+		}
+		var count int64
+		if err := tx.Model(&model.SavedSearch{}).Where(&model.SavedSearch{UserID: userID}).Count(&count).Error; err != nil {
+			return err
+		}
+		if count >= int64(limit) {
+			return repository.ErrForbidden
+		}
+		return tx.Create(ss).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ss, nil
+}
+
+// UpdateSavedSearch implements SavedSearchRepository interface.
+func (repo *Repository) UpdateSavedSearch(id uuid.UUID, args repository.UpdateSavedSearchArgs) error {
+	if id == uuid.Nil {
+		return repository.ErrNilID
+	}
+
+	changes := map[string]interface{}{}
+	if args.Name.Valid {
+		changes["name"] = args.Name.V
+	}
+	if args.Query.Valid {
+		changes["query"] = args.Query.V
+	}
+
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		var ss model.SavedSearch
+		if err := tx.First(&ss, &model.SavedSearch{ID: id}).Error; err != nil {
+			return convertError(err)
+		}
+		if len(changes) > 0 {
+			return tx.Model(&ss).Updates(changes).Error
+		}
+		return nil
+	})
+}
+
+// DeleteSavedSearch implements SavedSearchRepository interface.
+func (repo *Repository) DeleteSavedSearch(id uuid.UUID) error {
+	if id == uuid.Nil {
+		return repository.ErrNilID
+	}
+	result := repo.db.Delete(&model.SavedSearch{ID: id})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// GetSavedSearch implements SavedSearchRepository interface.
+func (repo *Repository) GetSavedSearch(id uuid.UUID) (*model.SavedSearch, error) {
+	if id == uuid.Nil {
+		return nil, repository.ErrNotFound
+	}
+	var ss model.SavedSearch
+	if err := repo.db.First(&ss, &model.SavedSearch{ID: id}).Error; err != nil {
+		return nil, convertError(err)
+	}
+	return &ss, nil
+}
+
+// GetSavedSearchesByUserID implements SavedSearchRepository interface.
+func (repo *Repository) GetSavedSearchesByUserID(userID uuid.UUID) ([]*model.SavedSearch, error) {
+	sss := make([]*model.SavedSearch, 0)
+	if userID == uuid.Nil {
+		return sss, nil
+	}
+	return sss, repo.db.
+		Where(&model.SavedSearch{UserID: userID}).
+		Order("created_at").
+		Find(&sss).
+		Error
+}
+
+// GetAllSavedSearches implements SavedSearchRepository interface.
+func (repo *Repository) GetAllSavedSearches() ([]*model.SavedSearch, error) {
+	sss := make([]*model.SavedSearch, 0)
+	return sss, repo.db.Find(&sss).Error
+}
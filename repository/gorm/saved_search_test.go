@@ -0,0 +1,96 @@
+package gorm
+
+import (
+	"testing"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+func TestRepositoryImpl_CreateSavedSearch(t *testing.T) {
+	t.Parallel()
+	repo, assert, _, user := setupWithUser(t, common2)
+
+	_, err := repo.CreateSavedSearch(uuid.Nil, "a", "{}", 20)
+	assert.Error(err)
+
+	ss, err := repo.CreateSavedSearch(user.GetID(), "a", `{"word":"incident"}`, 20)
+	if assert.NoError(err) {
+		assert.NotEmpty(ss.ID)
+		assert.Equal(user.GetID(), ss.UserID)
+		assert.Equal("a", ss.Name)
+		assert.Equal(`{"word":"incident"}`, ss.Query)
+		assert.Equal(1, count(t, getDB(repo).Model(model.SavedSearch{}).Where(model.SavedSearch{UserID: user.GetID()})))
+	}
+
+	_, err = repo.CreateSavedSearch(user.GetID(), "b", "{}", 1)
+	assert.EqualError(err, repository.ErrForbidden.Error())
+}
+
+func TestRepositoryImpl_UpdateSavedSearch(t *testing.T) {
+	t.Parallel()
+	repo, assert, require, user := setupWithUser(t, common2)
+
+	ss, err := repo.CreateSavedSearch(user.GetID(), "a", "{}", 20)
+	require.NoError(err)
+
+	assert.EqualError(repo.UpdateSavedSearch(uuid.Nil, repository.UpdateSavedSearchArgs{}), repository.ErrNilID.Error())
+	assert.EqualError(repo.UpdateSavedSearch(uuid.Must(uuid.NewV4()), repository.UpdateSavedSearchArgs{}), repository.ErrNotFound.Error())
+
+	if assert.NoError(repo.UpdateSavedSearch(ss.ID, repository.UpdateSavedSearchArgs{Name: optional.From("b")})) {
+		res, err := repo.GetSavedSearch(ss.ID)
+		require.NoError(err)
+		assert.Equal("b", res.Name)
+		assert.Equal("{}", res.Query)
+	}
+}
+
+func TestRepositoryImpl_DeleteSavedSearch(t *testing.T) {
+	t.Parallel()
+	repo, assert, require, user := setupWithUser(t, common2)
+
+	ss, err := repo.CreateSavedSearch(user.GetID(), "a", "{}", 20)
+	require.NoError(err)
+
+	assert.EqualError(repo.DeleteSavedSearch(uuid.Nil), repository.ErrNilID.Error())
+	assert.EqualError(repo.DeleteSavedSearch(uuid.Must(uuid.NewV4())), repository.ErrNotFound.Error())
+	if assert.NoError(repo.DeleteSavedSearch(ss.ID)) {
+		_, err := repo.GetSavedSearch(ss.ID)
+		assert.EqualError(err, repository.ErrNotFound.Error())
+	}
+	assert.EqualError(repo.DeleteSavedSearch(ss.ID), repository.ErrNotFound.Error())
+}
+
+func TestRepositoryImpl_GetSavedSearchesByUserID(t *testing.T) {
+	t.Parallel()
+	repo, assert, require, user := setupWithUser(t, common2)
+
+	ss1, err := repo.CreateSavedSearch(user.GetID(), "a", "{}", 20)
+	require.NoError(err)
+	ss2, err := repo.CreateSavedSearch(user.GetID(), "b", "{}", 20)
+	require.NoError(err)
+
+	sss, err := repo.GetSavedSearchesByUserID(user.GetID())
+	if assert.NoError(err) && assert.Len(sss, 2) {
+		assert.Equal(ss1.ID, sss[0].ID)
+		assert.Equal(ss2.ID, sss[1].ID)
+	}
+
+	sss, err = repo.GetSavedSearchesByUserID(uuid.Nil)
+	if assert.NoError(err) {
+		assert.Empty(sss)
+	}
+
+	sss, err = repo.GetAllSavedSearches()
+	if assert.NoError(err) {
+		ids := make([]uuid.UUID, 0, len(sss))
+		for _, ss := range sss {
+			ids = append(ids, ss.ID)
+		}
+		assert.Contains(ids, ss1.ID)
+		assert.Contains(ids, ss2.ID)
+	}
+}
@@ -0,0 +1,126 @@
+package gorm
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/utils/gormUtil"
+)
+
+// CreateScheduledMessage implements ScheduledMessageRepository interface.
+func (repo *Repository) CreateScheduledMessage(userID, channelID uuid.UUID, text string, scheduledAt time.Time) (*model.ScheduledMessage, error) {
+	if userID == uuid.Nil || channelID == uuid.Nil {
+		return nil, repository.ErrNilID
+	}
+
+	sm := &model.ScheduledMessage{
+		ID:          uuid.Must(uuid.NewV4()),
+		UserID:      userID,
+		ChannelID:   channelID,
+		Text:        text,
+		ScheduledAt: scheduledAt,
+	}
+	if err := repo.db.Create(sm).Error; err != nil {
+		return nil, err
+	}
+	return sm, nil
+}
+
+// UpdateScheduledMessage implements ScheduledMessageRepository interface.
+func (repo *Repository) UpdateScheduledMessage(id uuid.UUID, args repository.UpdateScheduledMessageArgs) error {
+	if id == uuid.Nil {
+		return repository.ErrNilID
+	}
+
+	changes := map[string]interface{}{}
+	if args.Text.Valid {
+		changes["text"] = args.Text.V
+	}
+	if args.ScheduledAt.Valid {
+		changes["scheduled_at"] = args.ScheduledAt.V
+	}
+
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		var sm model.ScheduledMessage
+		if err := tx.First(&sm, &model.ScheduledMessage{ID: id}).Error; err != nil {
+			return convertError(err)
+		}
+		if len(changes) > 0 {
+			return tx.Model(&sm).Updates(changes).Error
+		}
+		return nil
+	})
+}
+
+// DeleteScheduledMessage implements ScheduledMessageRepository interface.
+func (repo *Repository) DeleteScheduledMessage(id uuid.UUID) error {
+	if id == uuid.Nil {
+		return repository.ErrNilID
+	}
+	result := repo.db.Delete(&model.ScheduledMessage{ID: id})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// GetScheduledMessage implements ScheduledMessageRepository interface.
+func (repo *Repository) GetScheduledMessage(id uuid.UUID) (*model.ScheduledMessage, error) {
+	if id == uuid.Nil {
+		return nil, repository.ErrNotFound
+	}
+	var sm model.ScheduledMessage
+	if err := repo.db.First(&sm, &model.ScheduledMessage{ID: id}).Error; err != nil {
+		return nil, convertError(err)
+	}
+	return &sm, nil
+}
+
+// GetScheduledMessagesByUserID implements ScheduledMessageRepository interface.
+func (repo *Repository) GetScheduledMessagesByUserID(userID uuid.UUID) ([]*model.ScheduledMessage, error) {
+	sms := make([]*model.ScheduledMessage, 0)
+	if userID == uuid.Nil {
+		return sms, nil
+	}
+	return sms, repo.db.
+		Where(&model.ScheduledMessage{UserID: userID}).
+		Order("scheduled_at").
+		Find(&sms).
+		Error
+}
+
+// GetDueScheduledMessages implements ScheduledMessageRepository interface.
+func (repo *Repository) GetDueScheduledMessages(until time.Time, limit int) ([]*model.ScheduledMessage, error) {
+	sms := make([]*model.ScheduledMessage, 0)
+	return sms, repo.db.
+		Where("scheduled_at <= ? AND (claimed_until IS NULL OR claimed_until <= ?)", until, until).
+		Order("scheduled_at").
+		Scopes(gormUtil.LimitAndOffset(limit, 0)).
+		Find(&sms).
+		Error
+}
+
+// ClaimScheduledMessage implements ScheduledMessageRepository interface.
+func (repo *Repository) ClaimScheduledMessage(id uuid.UUID, now, claimUntil time.Time) error {
+	if id == uuid.Nil {
+		return repository.ErrNilID
+	}
+	result := repo.db.
+		Model(&model.ScheduledMessage{}).
+		Where("id = ? AND (claimed_until IS NULL OR claimed_until <= ?)", id, now).
+		UpdateColumn("claimed_until", claimUntil)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package gorm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+func TestRepositoryImpl_CreateScheduledMessage(t *testing.T) {
+	t.Parallel()
+	repo, assert, _, user, channel := setupWithUserAndChannel(t, common2)
+
+	_, err := repo.CreateScheduledMessage(uuid.Nil, channel.ID, "a", time.Now().Add(time.Hour))
+	assert.Error(err)
+	_, err = repo.CreateScheduledMessage(user.GetID(), uuid.Nil, "a", time.Now().Add(time.Hour))
+	assert.Error(err)
+
+	sm, err := repo.CreateScheduledMessage(user.GetID(), channel.ID, "a", time.Now().Add(time.Hour))
+	if assert.NoError(err) {
+		assert.NotEmpty(sm.ID)
+		assert.Equal(user.GetID(), sm.UserID)
+		assert.Equal(channel.ID, sm.ChannelID)
+		assert.Equal("a", sm.Text)
+		assert.Equal(1, count(t, getDB(repo).Model(model.ScheduledMessage{}).Where(model.ScheduledMessage{UserID: user.GetID()})))
+	}
+}
+
+func TestRepositoryImpl_UpdateScheduledMessage(t *testing.T) {
+	t.Parallel()
+	repo, assert, require, user, channel := setupWithUserAndChannel(t, common2)
+
+	sm, err := repo.CreateScheduledMessage(user.GetID(), channel.ID, "a", time.Now().Add(time.Hour))
+	require.NoError(err)
+
+	assert.EqualError(repo.UpdateScheduledMessage(uuid.Nil, repository.UpdateScheduledMessageArgs{}), repository.ErrNilID.Error())
+	assert.EqualError(repo.UpdateScheduledMessage(uuid.Must(uuid.NewV4()), repository.UpdateScheduledMessageArgs{}), repository.ErrNotFound.Error())
+
+	if assert.NoError(repo.UpdateScheduledMessage(sm.ID, repository.UpdateScheduledMessageArgs{Text: optional.From("b")})) {
+		res, err := repo.GetScheduledMessage(sm.ID)
+		require.NoError(err)
+		assert.Equal("b", res.Text)
+	}
+}
+
+func TestRepositoryImpl_DeleteScheduledMessage(t *testing.T) {
+	t.Parallel()
+	repo, assert, require, user, channel := setupWithUserAndChannel(t, common2)
+
+	sm, err := repo.CreateScheduledMessage(user.GetID(), channel.ID, "a", time.Now().Add(time.Hour))
+	require.NoError(err)
+
+	assert.EqualError(repo.DeleteScheduledMessage(uuid.Nil), repository.ErrNilID.Error())
+	assert.EqualError(repo.DeleteScheduledMessage(uuid.Must(uuid.NewV4())), repository.ErrNotFound.Error())
+	if assert.NoError(repo.DeleteScheduledMessage(sm.ID)) {
+		_, err := repo.GetScheduledMessage(sm.ID)
+		assert.EqualError(err, repository.ErrNotFound.Error())
+	}
+	assert.EqualError(repo.DeleteScheduledMessage(sm.ID), repository.ErrNotFound.Error())
+}
+
+func TestRepositoryImpl_GetDueScheduledMessages(t *testing.T) {
+	t.Parallel()
+	repo, assert, require, user, channel := setupWithUserAndChannel(t, common2)
+
+	now := time.Now()
+	past, err := repo.CreateScheduledMessage(user.GetID(), channel.ID, "a", now.Add(-time.Minute))
+	require.NoError(err)
+	_, err = repo.CreateScheduledMessage(user.GetID(), channel.ID, "b", now.Add(time.Hour))
+	require.NoError(err)
+
+	sms, err := repo.GetDueScheduledMessages(now, 100)
+	if assert.NoError(err) {
+		ids := make([]uuid.UUID, 0, len(sms))
+		for _, sm := range sms {
+			ids = append(ids, sm.ID)
+		}
+		assert.Contains(ids, past.ID)
+	}
+
+	sms, err = repo.GetScheduledMessagesByUserID(user.GetID())
+	if assert.NoError(err) {
+		assert.Len(sms, 2)
+	}
+}
+
+func TestRepositoryImpl_ClaimScheduledMessage(t *testing.T) {
+	t.Parallel()
+	repo, assert, require, user, channel := setupWithUserAndChannel(t, common2)
+
+	now := time.Now()
+	sm, err := repo.CreateScheduledMessage(user.GetID(), channel.ID, "a", now.Add(-time.Minute))
+	require.NoError(err)
+
+	assert.EqualError(repo.ClaimScheduledMessage(uuid.Nil, now, now.Add(time.Minute)), repository.ErrNilID.Error())
+	assert.EqualError(repo.ClaimScheduledMessage(uuid.Must(uuid.NewV4()), now, now.Add(time.Minute)), repository.ErrNotFound.Error())
+
+	if assert.NoError(repo.ClaimScheduledMessage(sm.ID, now, now.Add(time.Minute))) {
+		// 確保中は取得・確保できない
+		assert.EqualError(repo.ClaimScheduledMessage(sm.ID, now, now.Add(time.Minute)), repository.ErrNotFound.Error())
+		sms, err := repo.GetDueScheduledMessages(now, 100)
+		if assert.NoError(err) {
+			for _, s := range sms {
+				assert.NotEqual(sm.ID, s.ID)
+			}
+		}
+
+		// 確保期限を過ぎると再度確保できる
+		later := now.Add(2 * time.Minute)
+		assert.NoError(repo.ClaimScheduledMessage(sm.ID, later, later.Add(time.Minute)))
+	}
+}
@@ -0,0 +1,74 @@
+package gorm
+
+import (
+	"github.com/gofrs/uuid"
+	"github.com/leandro-lugaresi/hub"
+
+	"github.com/traPtitech/traQ/event"
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/utils/gormUtil"
+)
+
+// FollowThread implements ThreadRepository interface.
+func (repo *Repository) FollowThread(userID, messageID uuid.UUID) error {
+	if userID == uuid.Nil || messageID == uuid.Nil {
+		return repository.ErrNilID
+	}
+	var f model.ThreadFollow
+	result := repo.db.FirstOrCreate(&f, &model.ThreadFollow{UserID: userID, MessageID: messageID})
+	if result.Error != nil {
+		if gormUtil.IsMySQLDuplicatedRecordErr(result.Error) {
+			return nil
+		}
+		return result.Error
+	}
+	if result.RowsAffected > 0 {
+		repo.hub.Publish(hub.Message{
+			Name: event.ThreadFollowed,
+			Fields: hub.Fields{
+				"user_id":    userID,
+				"message_id": messageID,
+			},
+		})
+	}
+	return nil
+}
+
+// UnfollowThread implements ThreadRepository interface.
+func (repo *Repository) UnfollowThread(userID, messageID uuid.UUID) error {
+	if userID == uuid.Nil || messageID == uuid.Nil {
+		return repository.ErrNilID
+	}
+	result := repo.db.Delete(&model.ThreadFollow{}, &model.ThreadFollow{UserID: userID, MessageID: messageID})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected > 0 {
+		repo.hub.Publish(hub.Message{
+			Name: event.ThreadUnfollowed,
+			Fields: hub.Fields{
+				"user_id":    userID,
+				"message_id": messageID,
+			},
+		})
+	}
+	return nil
+}
+
+// IsThreadFollowed implements ThreadRepository interface.
+func (repo *Repository) IsThreadFollowed(userID, messageID uuid.UUID) (bool, error) {
+	if userID == uuid.Nil || messageID == uuid.Nil {
+		return false, nil
+	}
+	return gormUtil.RecordExists(repo.db, &model.ThreadFollow{UserID: userID, MessageID: messageID})
+}
+
+// GetFollowingThreadIDs implements ThreadRepository interface.
+func (repo *Repository) GetFollowingThreadIDs(userID uuid.UUID) (ids []uuid.UUID, err error) {
+	ids = make([]uuid.UUID, 0)
+	if userID == uuid.Nil {
+		return ids, nil
+	}
+	return ids, repo.db.Model(&model.ThreadFollow{}).Where(&model.ThreadFollow{UserID: userID}).Order("created_at DESC").Pluck("message_id", &ids).Error
+}
@@ -0,0 +1,102 @@
+package gorm
+
+import (
+	"testing"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+)
+
+func TestRepositoryImpl_FollowThread(t *testing.T) {
+	t.Parallel()
+	repo, assert, _, user, channel := setupWithUserAndChannel(t, common2)
+	m := mustMakeMessage(t, repo, user.GetID(), channel.ID)
+
+	assert.Error(repo.FollowThread(user.GetID(), uuid.Nil))
+	assert.Error(repo.FollowThread(uuid.Nil, m.ID))
+	if assert.NoError(repo.FollowThread(user.GetID(), m.ID)) {
+		assert.Equal(1, count(t, getDB(repo).Model(model.ThreadFollow{}).Where(model.ThreadFollow{UserID: user.GetID()})))
+	}
+	if assert.NoError(repo.FollowThread(user.GetID(), m.ID)) {
+		assert.Equal(1, count(t, getDB(repo).Model(model.ThreadFollow{}).Where(model.ThreadFollow{UserID: user.GetID()})))
+	}
+}
+
+func TestRepositoryImpl_UnfollowThread(t *testing.T) {
+	t.Parallel()
+	repo, assert, require, user, channel := setupWithUserAndChannel(t, common2)
+	m := mustMakeMessage(t, repo, user.GetID(), channel.ID)
+
+	require.NoError(repo.FollowThread(user.GetID(), m.ID))
+
+	assert.Error(repo.UnfollowThread(uuid.Nil, m.ID))
+	assert.Error(repo.UnfollowThread(user.GetID(), uuid.Nil))
+
+	if assert.NoError(repo.UnfollowThread(user.GetID(), m.ID)) {
+		assert.Equal(0, count(t, getDB(repo).Model(model.ThreadFollow{}).Where(model.ThreadFollow{UserID: user.GetID(), MessageID: m.ID})))
+	}
+	if assert.NoError(repo.UnfollowThread(user.GetID(), m.ID)) {
+		assert.Equal(0, count(t, getDB(repo).Model(model.ThreadFollow{}).Where(model.ThreadFollow{UserID: user.GetID(), MessageID: m.ID})))
+	}
+}
+
+func TestRepositoryImpl_IsThreadFollowed(t *testing.T) {
+	t.Parallel()
+	repo, assert, require, user, channel := setupWithUserAndChannel(t, common2)
+	m := mustMakeMessage(t, repo, user.GetID(), channel.ID)
+
+	ok, err := repo.IsThreadFollowed(user.GetID(), m.ID)
+	if assert.NoError(err) {
+		assert.False(ok)
+	}
+
+	require.NoError(repo.FollowThread(user.GetID(), m.ID))
+
+	ok, err = repo.IsThreadFollowed(user.GetID(), m.ID)
+	if assert.NoError(err) {
+		assert.True(ok)
+	}
+
+	ok, err = repo.IsThreadFollowed(uuid.Nil, m.ID)
+	if assert.NoError(err) {
+		assert.False(ok)
+	}
+}
+
+func TestRepositoryImpl_GetFollowingThreadIDs(t *testing.T) {
+	t.Parallel()
+	repo, assert, require, user, channel := setupWithUserAndChannel(t, common2)
+
+	n := 5
+	for i := 0; i < n; i++ {
+		m := mustMakeMessage(t, repo, user.GetID(), channel.ID)
+		require.NoError(repo.FollowThread(user.GetID(), m.ID))
+	}
+
+	ids, err := repo.GetFollowingThreadIDs(user.GetID())
+	if assert.NoError(err) {
+		assert.Len(ids, n)
+	}
+
+	ids, err = repo.GetFollowingThreadIDs(uuid.Nil)
+	if assert.NoError(err) {
+		assert.Len(ids, 0)
+	}
+}
+
+func TestRepositoryImpl_GetUserIDs_ThreadFollowerOf(t *testing.T) {
+	t.Parallel()
+	repo, assert, require, user, channel := setupWithUserAndChannel(t, common2)
+	user2 := mustMakeUser(t, repo, rand)
+	m := mustMakeMessage(t, repo, user.GetID(), channel.ID)
+
+	require.NoError(repo.FollowThread(user.GetID(), m.ID))
+	require.NoError(repo.FollowThread(user2.GetID(), m.ID))
+
+	ids, err := repo.GetUserIDs(repository.UsersQuery{}.ThreadFollowerOf(m.ID))
+	if assert.NoError(err) {
+		assert.ElementsMatch([]uuid.UUID{user.GetID(), user2.GetID()}, ids)
+	}
+}
@@ -186,12 +186,18 @@ func (r *userRepository) makeGetUsersTx(query repository.UsersQuery) *gorm.DB {
 	if query.IsBot.Valid {
 		tx = tx.Where("users.bot = ?", query.IsBot.V)
 	}
+	if query.Role.Valid {
+		tx = tx.Where("users.role = ?", query.Role.V)
+	}
 	if query.IsSubscriberAtMarkLevelOf.Valid {
 		tx = tx.Joins("INNER JOIN users_subscribe_channels ON users_subscribe_channels.user_id = users.id AND users_subscribe_channels.channel_id = ? AND users_subscribe_channels.mark = true", query.IsSubscriberAtMarkLevelOf.V)
 	}
 	if query.IsSubscriberAtNotifyLevelOf.Valid {
 		tx = tx.Joins("INNER JOIN users_subscribe_channels ON users_subscribe_channels.user_id = users.id AND users_subscribe_channels.channel_id = ? AND users_subscribe_channels.notify = true", query.IsSubscriberAtNotifyLevelOf.V)
 	}
+	if query.IsThreadFollowerOf.Valid {
+		tx = tx.Joins("INNER JOIN users_follow_threads ON users_follow_threads.user_id = users.id AND users_follow_threads.message_id = ?", query.IsThreadFollowerOf.V)
+	}
 	if query.IsCMemberOf.Valid {
 		tx = tx.Joins("INNER JOIN users_private_channels ON users_private_channels.user_id = users.id AND users_private_channels.channel_id = ?", query.IsCMemberOf.V)
 	}
@@ -219,14 +225,18 @@ func (r *userRepository) UpdateUser(id uuid.UUID, args repository.UpdateUserArgs
 		return repository.ErrNilID
 	}
 	var (
-		changed bool
-		count   int
+		changed       bool
+		statusChanged bool
+		oldStatus     model.UserAccountStatus
+		count         int
 	)
 	err := r.db.Transaction(func(tx *gorm.DB) error {
 		var u model.User
 		if err := tx.Preload("Profile").First(&u, model.User{ID: id}).Error; err != nil {
 			return convertError(err)
 		}
+		oldStatus = u.Status
+		statusChanged = args.UserState.Valid && u.Status != args.UserState.V
 
 		changes := map[string]interface{}{}
 		if args.DisplayName.Valid {
@@ -290,6 +300,16 @@ func (r *userRepository) UpdateUser(id uuid.UUID, args repository.UpdateUserArgs
 		return err
 	}
 
+	if statusChanged {
+		r.hub.Publish(hub.Message{
+			Name: event.UserAccountStatusUpdated,
+			Fields: hub.Fields{
+				"user_id":    id,
+				"status":     args.UserState.V,
+				"old_status": oldStatus,
+			},
+		})
+	}
 	if args.Password.Valid && count == 2 {
 		return nil // パスワードのみの変更の時はUserUpdatedイベントを発生させない
 	}
@@ -1,13 +1,30 @@
 package gorm
 
 import (
+	"time"
+
 	"github.com/gofrs/uuid"
+	"github.com/leandro-lugaresi/hub"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
+	"github.com/traPtitech/traQ/event"
 	"github.com/traPtitech/traQ/model"
 	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/utils/gormUtil"
+	"github.com/traPtitech/traQ/utils/optional"
+	"github.com/traPtitech/traQ/utils/random"
 )
 
-const defaultNotifyCitation = false
+const (
+	defaultNotifyCitation        = false
+	emailUnsubscribeTokenLength  = 48
+	emailVerificationTokenLength = 48
+	// emailVerificationExpiry 宛先アドレス確認用トークンの有効期間
+	emailVerificationExpiry = 24 * time.Hour
+	// emailVerificationResendInterval 同じアドレスに確認用トークンを再発行しない期間
+	emailVerificationResendInterval = 10 * time.Minute
+)
 
 // UpdateNotifyCitation implements UserSettingsRepository interface
 func (repo *Repository) UpdateNotifyCitation(userID uuid.UUID, isEnable bool) error {
@@ -71,6 +88,7 @@ func (repo *Repository) GetUserSettings(userID uuid.UUID) (*model.UserSettings,
 		dus := &model.UserSettings{
 			UserID:         userID,
 			NotifyCitation: defaultNotifyCitation,
+			DNDSchedules:   model.DNDSchedules{},
 		}
 		if err == repository.ErrNotFound {
 			return dus, nil
@@ -80,3 +98,276 @@ func (repo *Repository) GetUserSettings(userID uuid.UUID) (*model.UserSettings,
 
 	return &settings, nil
 }
+
+// UpdateDNDSettings implements UserSettingsRepository interface
+func (repo *Repository) UpdateDNDSettings(userID uuid.UUID, args repository.UpdateDNDSettingsArgs) error {
+	if userID == uuid.Nil {
+		return repository.ErrNilID
+	}
+	if args.Schedules == nil {
+		args.Schedules = model.DNDSchedules{}
+	}
+	return upsertUserSettings(repo.db, userID, map[string]interface{}{
+		"dnd_schedules": args.Schedules,
+		"dnd_timezone":  args.Timezone,
+		"dnd_digest":    args.Digest,
+	})
+}
+
+// UpdateSnoozeUntil implements UserSettingsRepository interface
+func (repo *Repository) UpdateSnoozeUntil(userID uuid.UUID, until optional.Of[time.Time]) error {
+	if userID == uuid.Nil {
+		return repository.ErrNilID
+	}
+	return upsertUserSettings(repo.db, userID, map[string]interface{}{
+		"snooze_until": until,
+	})
+}
+
+// UpdateEmailSettings implements UserSettingsRepository interface
+func (repo *Repository) UpdateEmailSettings(userID uuid.UUID, args repository.UpdateEmailSettingsArgs) error {
+	if userID == uuid.Nil {
+		return repository.ErrNilID
+	}
+	var verificationToken string
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		changes := map[string]interface{}{
+			"email_immediate": args.Immediate,
+			"email_digest":    args.Digest,
+		}
+		var current model.UserSettings
+		if err := tx.Where("user_id = ?", userID).Take(&current).Error; err != nil {
+			if err := convertError(err); err != repository.ErrNotFound {
+				return err
+			}
+		}
+
+		switch {
+		case len(args.Address) == 0 || args.Address == current.EmailAddress:
+			// 宛先を削除した、または変更していない場合は確認待ちのアドレスを破棄する
+			changes["email_address"] = args.Address
+			changes["pending_email_address"] = ""
+			changes["email_verification_token"] = ""
+			changes["email_verification_sent_at"] = optional.Of[time.Time]{}
+		case args.Address == current.PendingEmailAddress && current.EmailVerificationSentAt.Valid && time.Since(current.EmailVerificationSentAt.V) < emailVerificationResendInterval:
+			// 第三者のアドレスに確認メールを繰り返し送信できないようにする
+		default:
+			// 確認されるまでは変更前のアドレスを使用する
+			verificationToken = random.SecureAlphaNumeric(emailVerificationTokenLength)
+			changes["pending_email_address"] = args.Address
+			changes["email_verification_token"] = verificationToken
+			changes["email_verification_sent_at"] = time.Now()
+		}
+		if len(current.EmailUnsubscribeToken) == 0 {
+			changes["email_unsubscribe_token"] = random.SecureAlphaNumeric(emailUnsubscribeTokenLength)
+		}
+		return upsertUserSettings(tx, userID, changes)
+	})
+	if err != nil {
+		return err
+	}
+	if len(verificationToken) > 0 {
+		repo.hub.Publish(hub.Message{
+			Name: event.UserEmailVerificationRequested,
+			Fields: hub.Fields{
+				"user_id": userID,
+				"address": args.Address,
+				"token":   verificationToken,
+			},
+		})
+	}
+	return nil
+}
+
+// VerifyEmailAddressByToken implements UserSettingsRepository interface
+func (repo *Repository) VerifyEmailAddressByToken(token string) error {
+	if len(token) == 0 {
+		return repository.ErrNotFound
+	}
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		var us model.UserSettings
+		if err := tx.
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("email_verification_token = ? AND email_verification_sent_at > ?", token, time.Now().Add(-emailVerificationExpiry)).
+			Take(&us).
+			Error; err != nil {
+			return convertError(err)
+		}
+		return tx.Model(&model.UserSettings{}).Where("user_id = ?", us.UserID).Updates(map[string]interface{}{
+			"email_address":              us.PendingEmailAddress,
+			"pending_email_address":      "",
+			"email_verification_token":   "",
+			"email_verification_sent_at": optional.Of[time.Time]{},
+		}).Error
+	})
+}
+
+// ExistsEmailVerificationToken implements UserSettingsRepository interface
+func (repo *Repository) ExistsEmailVerificationToken(token string) (bool, error) {
+	if len(token) == 0 {
+		return false, nil
+	}
+	var count int64
+	if err := repo.db.
+		Model(&model.UserSettings{}).
+		Where("email_verification_token = ? AND email_verification_sent_at > ?", token, time.Now().Add(-emailVerificationExpiry)).
+		Count(&count).
+		Error; err != nil {
+		return false, convertError(err)
+	}
+	return count > 0, nil
+}
+
+// UpdateEmailDigestSentAt implements UserSettingsRepository interface
+func (repo *Repository) UpdateEmailDigestSentAt(userID uuid.UUID, sentAt time.Time) error {
+	if userID == uuid.Nil {
+		return repository.ErrNilID
+	}
+	return upsertUserSettings(repo.db, userID, map[string]interface{}{
+		"email_digest_sent_at": sentAt,
+	})
+}
+
+// GetEmailDigestTargets implements UserSettingsRepository interface
+func (repo *Repository) GetEmailDigestTargets() ([]*model.UserSettings, error) {
+	settings := make([]*model.UserSettings, 0)
+	if err := repo.db.Where("email_address <> '' AND email_digest <> ''").Find(&settings).Error; err != nil {
+		return nil, convertError(err)
+	}
+	return settings, nil
+}
+
+// DisableEmailNotificationByToken implements UserSettingsRepository interface
+func (repo *Repository) DisableEmailNotificationByToken(token string) error {
+	if len(token) == 0 {
+		return repository.ErrNotFound
+	}
+	result := repo.db.Model(&model.UserSettings{}).
+		Where("email_unsubscribe_token = ?", token).
+		Updates(map[string]interface{}{
+			"email_immediate": false,
+			"email_digest":    model.EmailDigestNone,
+		})
+	if result.Error != nil {
+		return convertError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		// 既に無効化済みの場合も成功とする
+		ok, err := gormUtil.RecordExists(repo.db, &model.UserSettings{EmailUnsubscribeToken: token})
+		if err != nil {
+			return convertError(err)
+		}
+		if !ok {
+			return repository.ErrNotFound
+		}
+	}
+	return nil
+}
+
+// ExistsEmailUnsubscribeToken implements UserSettingsRepository interface
+func (repo *Repository) ExistsEmailUnsubscribeToken(token string) (bool, error) {
+	if len(token) == 0 {
+		return false, nil
+	}
+	ok, err := gormUtil.RecordExists(repo.db, &model.UserSettings{EmailUnsubscribeToken: token})
+	if err != nil {
+		return false, convertError(err)
+	}
+	return ok, nil
+}
+
+func upsertUserSettings(tx *gorm.DB, userID uuid.UUID, changes map[string]interface{}) error {
+	var settings model.UserSettings
+	if err := tx.First(&settings, "user_id=?", userID).Error; err != nil {
+		err = convertError(err)
+		if err != repository.ErrNotFound {
+			return err
+		}
+		settings = model.UserSettings{
+			UserID:         userID,
+			NotifyCitation: defaultNotifyCitation,
+			DNDSchedules:   model.DNDSchedules{},
+		}
+		if err := tx.Create(&settings).Error; err != nil {
+			return convertError(err)
+		}
+	}
+	if err := tx.Model(&settings).Updates(changes).Error; err != nil {
+		return convertError(err)
+	}
+	return nil
+}
+
+// GetUserSettingsByUserIDs implements UserSettingsRepository interface
+func (repo *Repository) GetUserSettingsByUserIDs(userIDs []uuid.UUID) ([]*model.UserSettings, error) {
+	settings := make([]*model.UserSettings, 0)
+	if len(userIDs) == 0 {
+		return settings, nil
+	}
+	if err := repo.db.Where("user_id IN ?", userIDs).Find(&settings).Error; err != nil {
+		return nil, convertError(err)
+	}
+	return settings, nil
+}
+
+// AddNotificationKeyword implements UserSettingsRepository interface
+func (repo *Repository) AddNotificationKeyword(userID uuid.UUID, keyword string) error {
+	if userID == uuid.Nil {
+		return repository.ErrNilID
+	}
+	if err := repo.db.Create(&model.NotificationKeyword{UserID: userID, Keyword: keyword}).Error; err != nil {
+		if gormUtil.IsMySQLDuplicatedRecordErr(err) {
+			return repository.ErrAlreadyExists
+		}
+		return convertError(err)
+	}
+	repo.hub.Publish(hub.Message{
+		Name: event.UserKeywordsUpdated,
+		Fields: hub.Fields{
+			"user_id": userID,
+		},
+	})
+	return nil
+}
+
+// RemoveNotificationKeyword implements UserSettingsRepository interface
+func (repo *Repository) RemoveNotificationKeyword(userID uuid.UUID, keyword string) error {
+	if userID == uuid.Nil {
+		return repository.ErrNilID
+	}
+	result := repo.db.Delete(&model.NotificationKeyword{}, &model.NotificationKeyword{UserID: userID, Keyword: keyword})
+	if result.Error != nil {
+		return convertError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+	repo.hub.Publish(hub.Message{
+		Name: event.UserKeywordsUpdated,
+		Fields: hub.Fields{
+			"user_id": userID,
+		},
+	})
+	return nil
+}
+
+// GetNotificationKeywords implements UserSettingsRepository interface
+func (repo *Repository) GetNotificationKeywords(userID uuid.UUID) ([]*model.NotificationKeyword, error) {
+	keywords := make([]*model.NotificationKeyword, 0)
+	if userID == uuid.Nil {
+		return keywords, nil
+	}
+	if err := repo.db.Where(&model.NotificationKeyword{UserID: userID}).Order("created_at").Find(&keywords).Error; err != nil {
+		return nil, convertError(err)
+	}
+	return keywords, nil
+}
+
+// GetAllNotificationKeywords implements UserSettingsRepository interface
+func (repo *Repository) GetAllNotificationKeywords() ([]*model.NotificationKeyword, error) {
+	keywords := make([]*model.NotificationKeyword, 0)
+	if err := repo.db.Find(&keywords).Error; err != nil {
+		return nil, convertError(err)
+	}
+	return keywords, nil
+}
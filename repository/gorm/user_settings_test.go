@@ -0,0 +1,192 @@
+package gorm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+func TestRepositoryImpl_UpdateDNDSettings(t *testing.T) {
+	t.Parallel()
+	repo, assert, require, user := setupWithUser(t, common3)
+
+	assert.EqualError(repo.UpdateDNDSettings(uuid.Nil, repository.UpdateDNDSettingsArgs{}), repository.ErrNilID.Error())
+
+	schedules := model.DNDSchedules{{Weekday: time.Monday, Start: "22:00", End: "07:00"}}
+	require.NoError(repo.UpdateDNDSettings(user.GetID(), repository.UpdateDNDSettingsArgs{
+		Schedules: schedules,
+		Timezone:  "Asia/Tokyo",
+		Digest:    true,
+	}))
+
+	us, err := repo.GetUserSettings(user.GetID())
+	require.NoError(err)
+	assert.Equal(schedules, us.DNDSchedules)
+	assert.Equal("Asia/Tokyo", us.DNDTimezone)
+	assert.True(us.DNDDigest)
+	assert.False(us.NotifyCitation)
+}
+
+func TestRepositoryImpl_UpdateSnoozeUntil(t *testing.T) {
+	t.Parallel()
+	repo, assert, require, user := setupWithUser(t, common3)
+
+	until := time.Now().Add(time.Hour).Truncate(time.Microsecond)
+	require.NoError(repo.UpdateSnoozeUntil(user.GetID(), optional.From(until)))
+	us, err := repo.GetUserSettings(user.GetID())
+	require.NoError(err)
+	if assert.True(us.SnoozeUntil.Valid) {
+		assert.True(us.SnoozeUntil.V.Equal(until))
+	}
+
+	require.NoError(repo.UpdateSnoozeUntil(user.GetID(), optional.Of[time.Time]{}))
+	us, err = repo.GetUserSettings(user.GetID())
+	require.NoError(err)
+	assert.False(us.SnoozeUntil.Valid)
+
+	settings, err := repo.GetUserSettingsByUserIDs([]uuid.UUID{user.GetID(), uuid.Must(uuid.NewV4())})
+	require.NoError(err)
+	assert.Len(settings, 1)
+}
+
+func TestRepositoryImpl_NotificationKeywords(t *testing.T) {
+	t.Parallel()
+	repo, assert, require, user := setupWithUser(t, common3)
+
+	assert.EqualError(repo.AddNotificationKeyword(uuid.Nil, "traQ"), repository.ErrNilID.Error())
+	require.NoError(repo.AddNotificationKeyword(user.GetID(), "traQ"))
+	require.NoError(repo.AddNotificationKeyword(user.GetID(), "project"))
+	assert.EqualError(repo.AddNotificationKeyword(user.GetID(), "traQ"), repository.ErrAlreadyExists.Error())
+
+	keywords, err := repo.GetNotificationKeywords(user.GetID())
+	require.NoError(err)
+	if assert.Len(keywords, 2) {
+		assert.Equal("traQ", keywords[0].Keyword)
+		assert.Equal("project", keywords[1].Keyword)
+	}
+
+	all, err := repo.GetAllNotificationKeywords()
+	require.NoError(err)
+	assert.GreaterOrEqual(len(all), 2)
+
+	require.NoError(repo.RemoveNotificationKeyword(user.GetID(), "traQ"))
+	assert.EqualError(repo.RemoveNotificationKeyword(user.GetID(), "traQ"), repository.ErrNotFound.Error())
+	keywords, err = repo.GetNotificationKeywords(user.GetID())
+	require.NoError(err)
+	assert.Len(keywords, 1)
+}
+
+func TestRepositoryImpl_EmailSettings(t *testing.T) {
+	t.Parallel()
+	repo, assert, require, user := setupWithUser(t, common3)
+
+	assert.EqualError(repo.UpdateEmailSettings(uuid.Nil, repository.UpdateEmailSettingsArgs{}), repository.ErrNilID.Error())
+	require.NoError(repo.UpdateEmailSettings(user.GetID(), repository.UpdateEmailSettingsArgs{
+		Address:   "test@example.com",
+		Immediate: true,
+		Digest:    model.EmailDigestDaily,
+	}))
+
+	// 宛先は確認されるまで設定されない
+	us, err := repo.GetUserSettings(user.GetID())
+	require.NoError(err)
+	assert.Empty(us.EmailAddress)
+	assert.Equal("test@example.com", us.PendingEmailAddress)
+	assert.True(us.EmailImmediate)
+	assert.Equal(model.EmailDigestDaily, us.EmailDigest)
+	assert.NotEmpty(us.EmailUnsubscribeToken)
+	assert.NotEmpty(us.EmailVerificationToken)
+	token := us.EmailUnsubscribeToken
+	verificationToken := us.EmailVerificationToken
+
+	// 短時間に確認用トークンは再発行されない
+	require.NoError(repo.UpdateEmailSettings(user.GetID(), repository.UpdateEmailSettingsArgs{
+		Address:   "test@example.com",
+		Immediate: true,
+		Digest:    model.EmailDigestDaily,
+	}))
+	us, err = repo.GetUserSettings(user.GetID())
+	require.NoError(err)
+	assert.Equal(verificationToken, us.EmailVerificationToken)
+
+	ok, err := repo.ExistsEmailVerificationToken(verificationToken)
+	require.NoError(err)
+	assert.True(ok)
+	ok, err = repo.ExistsEmailVerificationToken("invalid")
+	require.NoError(err)
+	assert.False(ok)
+
+	assert.EqualError(repo.VerifyEmailAddressByToken("invalid"), repository.ErrNotFound.Error())
+	assert.EqualError(repo.VerifyEmailAddressByToken(""), repository.ErrNotFound.Error())
+	require.NoError(repo.VerifyEmailAddressByToken(verificationToken))
+	assert.EqualError(repo.VerifyEmailAddressByToken(verificationToken), repository.ErrNotFound.Error())
+	us, err = repo.GetUserSettings(user.GetID())
+	require.NoError(err)
+	assert.Equal("test@example.com", us.EmailAddress)
+	assert.Empty(us.PendingEmailAddress)
+	assert.Empty(us.EmailVerificationToken)
+
+	// 確認されるまでは変更前の宛先を使用する
+	require.NoError(repo.UpdateEmailSettings(user.GetID(), repository.UpdateEmailSettingsArgs{
+		Address:   "new@example.com",
+		Immediate: true,
+		Digest:    model.EmailDigestDaily,
+	}))
+	us, err = repo.GetUserSettings(user.GetID())
+	require.NoError(err)
+	assert.Equal("test@example.com", us.EmailAddress)
+	assert.Equal("new@example.com", us.PendingEmailAddress)
+
+	// トークンは再発行されない
+	require.NoError(repo.UpdateEmailSettings(user.GetID(), repository.UpdateEmailSettingsArgs{
+		Address: "test@example.com",
+		Digest:  model.EmailDigestHourly,
+	}))
+	us, err = repo.GetUserSettings(user.GetID())
+	require.NoError(err)
+	assert.Equal(token, us.EmailUnsubscribeToken)
+	assert.Equal(model.EmailDigestHourly, us.EmailDigest)
+	// 変更前の宛先に戻した場合は確認待ちの宛先を破棄する
+	assert.Empty(us.PendingEmailAddress)
+
+	targets, err := repo.GetEmailDigestTargets()
+	require.NoError(err)
+	found := false
+	for _, s := range targets {
+		found = found || s.UserID == user.GetID()
+	}
+	assert.True(found)
+
+	sentAt := time.Now().Truncate(time.Microsecond)
+	require.NoError(repo.UpdateEmailDigestSentAt(user.GetID(), sentAt))
+	us, err = repo.GetUserSettings(user.GetID())
+	require.NoError(err)
+	if assert.True(us.EmailDigestSentAt.Valid) {
+		assert.True(us.EmailDigestSentAt.V.Equal(sentAt))
+	}
+
+	ok, err = repo.ExistsEmailUnsubscribeToken(token)
+	require.NoError(err)
+	assert.True(ok)
+	ok, err = repo.ExistsEmailUnsubscribeToken("invalid")
+	require.NoError(err)
+	assert.False(ok)
+	ok, err = repo.ExistsEmailUnsubscribeToken("")
+	require.NoError(err)
+	assert.False(ok)
+
+	assert.EqualError(repo.DisableEmailNotificationByToken("invalid"), repository.ErrNotFound.Error())
+	assert.EqualError(repo.DisableEmailNotificationByToken(""), repository.ErrNotFound.Error())
+	require.NoError(repo.DisableEmailNotificationByToken(token))
+	require.NoError(repo.DisableEmailNotificationByToken(token))
+	us, err = repo.GetUserSettings(user.GetID())
+	require.NoError(err)
+	assert.False(us.EmailImmediate)
+	assert.Equal(model.EmailDigestNone, us.EmailDigest)
+	assert.Equal("test@example.com", us.EmailAddress)
+}
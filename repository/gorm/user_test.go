@@ -120,6 +120,15 @@ func TestRepositoryImpl_GetUsers(t *testing.T) {
 			assert.Len(users, len(us))
 		}
 	})
+
+	t.Run("RoleOf", func(t *testing.T) {
+		t.Parallel()
+
+		uids, err := repo.GetUserIDs(repository.UsersQuery{}.RoleOf("no_such_role"))
+		if assert.NoError(err) {
+			assert.Len(uids, 0)
+		}
+	})
 }
 
 func TestRepositoryImpl_GetUser(t *testing.T) {
@@ -17,14 +17,24 @@ type MessagesQuery struct {
 	Channel uuid.UUID
 	// ChannelsSubscribedByUser 指定したユーザーが購読しているチャンネルのメッセージを指定
 	ChannelsSubscribedByUser uuid.UUID
-	Since                    optional.Of[time.Time]
-	Until                    optional.Of[time.Time]
-	Inclusive                bool
-	Limit                    int
-	Offset                   int
-	Asc                      bool
-	ExcludeDMs               bool
-	DisablePreload           bool
+	// Thread 指定したメッセージを親とするスレッドのメッセージを指定
+	Thread    uuid.UUID
+	Since     optional.Of[time.Time]
+	Until     optional.Of[time.Time]
+	Inclusive bool
+	Limit     int
+	Offset    int
+	Asc       bool
+	// Cursor 並び順で指定した位置より後のメッセージを指定
+	Cursor         optional.Of[MessageCursor]
+	ExcludeDMs     bool
+	DisablePreload bool
+}
+
+// MessageCursor メッセージの並び順における位置
+type MessageCursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
 }
 
 // ChannelLatestMessagesQuery GetChannelLatestMessages用クエリ
@@ -43,6 +53,21 @@ type MessageRepository interface {
 	// 引数にuuid.Nilを指定するとErrNilIDを返します。
 	// DBによるエラーを返すことがあります。
 	CreateMessage(userID, channelID uuid.UUID, text string) (*model.Message, error)
+	// CreateThreadMessage 指定したメッセージを親とするスレッドにメッセージを作成します
+	//
+	// followersに指定したユーザーは、メッセージの作成と同じトランザクションでスレッドをフォローします。
+	// 成功した場合、メッセージとnilを返します。
+	// 引数にuuid.Nilを指定するとErrNilIDを返します。
+	// DBによるエラーを返すことがあります。
+	CreateThreadMessage(userID, channelID, parentID uuid.UUID, text string, followers []uuid.UUID) (*model.Message, error)
+	// ImportMessage 外部サービスから取り込んだメッセージを作成します
+	//
+	// ID・作成日時・スタンプは引数の値をそのまま使用し、イベントは発行しません。
+	// 成功した場合、nilを返します。
+	// 既に同じIDのメッセージが存在する場合、ErrAlreadyExistsを返します。
+	// 引数にnilまたはuuid.Nilを指定するとErrNilIDを返します。
+	// DBによるエラーを返すことがあります。
+	ImportMessage(m *model.Message) error
 	// UpdateMessage 指定したメッセージを更新します
 	//
 	// 成功した場合、nilを返します。
@@ -63,12 +88,30 @@ type MessageRepository interface {
 	// 存在しないメッセージを指定した場合、ErrNotFoundを返します。
 	// DBによるエラーを返すことがあります。
 	GetMessageByID(messageID uuid.UUID) (*model.Message, error)
+	// GetMessageUserID 削除されたメッセージを含めて、指定したメッセージの投稿者のIDを取得します
+	//
+	// 成功した場合、投稿者のIDとnilを返します。
+	// 存在しないメッセージを指定した場合、ErrNotFoundを返します。
+	// DBによるエラーを返すことがあります。
+	GetMessageUserID(messageID uuid.UUID) (uuid.UUID, error)
 	// GetMessages 指定したクエリでメッセージを取得します
 	//
 	// 成功した場合、メッセージの配列を返します。負のoffset, limitは無視されます。
 	// 指定した範囲内にlimitを超えてメッセージが存在していた場合、trueを返します。
 	// DBによるエラーを返すことがあります。
 	GetMessages(query MessagesQuery) (messages []*model.Message, more bool, err error)
+	// GetThreadReplyCounts 指定したメッセージを親とするスレッドの返信数を取得します
+	//
+	// 成功した場合、親メッセージIDをキーとする返信数のマップとnilを返します。
+	// 返信が存在しないメッセージはマップに含まれません。
+	// DBによるエラーを返すことがあります。
+	GetThreadReplyCounts(parentIDs []uuid.UUID) (map[uuid.UUID]int, error)
+	// GetArchivedMessagesByMessageID 指定したメッセージの編集履歴を取得します
+	//
+	// 成功した場合、編集前のメッセージを古い順に並べた配列とnilを返します。
+	// 存在しないメッセージを指定した場合は空配列とnilを返します。
+	// DBによるエラーを返すことがあります。
+	GetArchivedMessagesByMessageID(messageID uuid.UUID) ([]*model.ArchivedMessage, error)
 	// GetUpdatedMessagesAfter 指定した時間より後に更新されたメッセージを取得します
 	//
 	// 成功した場合、updatedAtで昇順ソートされたメッセージの配列を返します。
@@ -0,0 +1,33 @@
+//go:generate mockgen -source=$GOFILE -destination=mock_$GOPACKAGE/mock_$GOFILE
+package repository
+
+import (
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/model"
+)
+
+// MessageComponentRepository メッセージコンポーネントリポジトリ
+type MessageComponentRepository interface {
+	// SetMessageComponents 指定したメッセージのコンポーネントを設定します
+	//
+	// 空の配列を指定した場合、コンポーネントを削除します。
+	// 成功した場合、nilを返します。
+	// 存在しないメッセージを指定した場合、ErrNotFoundを返します。
+	// 引数にuuid.Nilを指定するとErrNilIDを返します。
+	// DBによるエラーを返すことがあります。
+	SetMessageComponents(messageID uuid.UUID, components model.MessageComponentList) error
+	// CreateMessageInteraction メッセージコンポーネントの操作を記録します
+	//
+	// 成功した場合、操作記録とnilを返します。
+	// 存在しないメッセージを指定した場合、ErrNotFoundを返します。
+	// 引数にuuid.Nilを指定するとErrNilIDを返します。
+	// DBによるエラーを返すことがあります。
+	CreateMessageInteraction(messageID, userID uuid.UUID, customID string, values []string) (*model.MessageInteraction, error)
+	// GetMessageInteraction 指定したIDのメッセージコンポーネントの操作記録を取得します
+	//
+	// 成功した場合、操作記録とnilを返します。
+	// 存在しない場合、ErrNotFoundを返します。
+	// DBによるエラーを返すことがあります。
+	GetMessageInteraction(id uuid.UUID) (*model.MessageInteraction, error)
+}
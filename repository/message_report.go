@@ -4,8 +4,25 @@ import (
 	"github.com/gofrs/uuid"
 
 	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/utils/optional"
 )
 
+// MessageReportsQuery GetMessageReports用クエリ
+type MessageReportsQuery struct {
+	State      optional.Of[model.MessageReportState]
+	MessageID  optional.Of[uuid.UUID]
+	ReporterID optional.Of[uuid.UUID]
+	Offset     int
+	Limit      int
+}
+
+// ResolveMessageReportArgs メッセージ通報対応引数
+type ResolveMessageReportArgs struct {
+	State      model.MessageReportState
+	ResolverID uuid.UUID
+	Note       string
+}
+
 // MessageReportRepository メッセージ通報リポジトリ
 type MessageReportRepository interface {
 	// CreateMessageReport 指定したユーザーによる指定したメッセージの通報を登録します
@@ -15,11 +32,17 @@ type MessageReportRepository interface {
 	// 引数にuuid.Nilを指定するとErrNilIDを返します。
 	// DBによるエラーを返すことがあります。
 	CreateMessageReport(messageID, reporterID uuid.UUID, reason string) error
-	// GetMessageReports メッセージ通報を通報日時の昇順で取得します
+	// GetMessageReport 指定したメッセージ通報を取得します
+	//
+	// 成功した場合、メッセージ通報とnilを返します。
+	// 存在しない通報を指定した場合、ErrNotFoundを返します。
+	// DBによるエラーを返すことがあります。
+	GetMessageReport(reportID uuid.UUID) (*model.MessageReport, error)
+	// GetMessageReports 指定したクエリでメッセージ通報を通報日時の昇順で取得します
 	//
 	// 成功した場合、メッセージ通報の配列とnilを返します。負のoffset, limitは無視されます。
 	// DBによるエラーを返すことがあります。
-	GetMessageReports(offset, limit int) ([]*model.MessageReport, error)
+	GetMessageReports(query MessageReportsQuery) ([]*model.MessageReport, error)
 	// GetMessageReportsByMessageID 指定したメッセージのメッセージ通報を全て取得します
 	//
 	// 成功した場合、メッセージ通報の配列とnilを返します。
@@ -32,4 +55,12 @@ type MessageReportRepository interface {
 	// 存在しないユーザーを指定した場合は空配列とnilを返します。
 	// DBによるエラーを返すことがあります。
 	GetMessageReportsByReporterID(reporterID uuid.UUID) ([]*model.MessageReport, error)
+	// ResolveMessageReport 指定したメッセージ通報の対応状態を更新します
+	//
+	// 成功した場合、nilを返します。
+	// 存在しない通報を指定した場合、ErrNotFoundを返します。
+	// 引数にuuid.Nilを指定するとErrNilIDを返します。
+	// 無効な対応状態を指定するとArgumentErrorを返します。
+	// DBによるエラーを返すことがあります。
+	ResolveMessageReport(reportID uuid.UUID, args ResolveMessageReportArgs) error
 }
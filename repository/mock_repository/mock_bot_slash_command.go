@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: bot_slash_command.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	reflect "reflect"
+
+	uuid "github.com/gofrs/uuid"
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/traPtitech/traQ/model"
+)
+
+// MockBotSlashCommandRepository is a mock of BotSlashCommandRepository interface.
+type MockBotSlashCommandRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockBotSlashCommandRepositoryMockRecorder
+}
+
+// MockBotSlashCommandRepositoryMockRecorder is the mock recorder for MockBotSlashCommandRepository.
+type MockBotSlashCommandRepositoryMockRecorder struct {
+	mock *MockBotSlashCommandRepository
+}
+
+// NewMockBotSlashCommandRepository creates a new mock instance.
+func NewMockBotSlashCommandRepository(ctrl *gomock.Controller) *MockBotSlashCommandRepository {
+	mock := &MockBotSlashCommandRepository{ctrl: ctrl}
+	mock.recorder = &MockBotSlashCommandRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBotSlashCommandRepository) EXPECT() *MockBotSlashCommandRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetBotSlashCommands mocks base method.
+func (m *MockBotSlashCommandRepository) GetBotSlashCommands(botID uuid.UUID) ([]*model.BotSlashCommand, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBotSlashCommands", botID)
+	ret0, _ := ret[0].([]*model.BotSlashCommand)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBotSlashCommands indicates an expected call of GetBotSlashCommands.
+func (mr *MockBotSlashCommandRepositoryMockRecorder) GetBotSlashCommands(botID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBotSlashCommands", reflect.TypeOf((*MockBotSlashCommandRepository)(nil).GetBotSlashCommands), botID)
+}
+
+// GetChannelSlashCommands mocks base method.
+func (m *MockBotSlashCommandRepository) GetChannelSlashCommands(channelID uuid.UUID) ([]*model.BotSlashCommand, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChannelSlashCommands", channelID)
+	ret0, _ := ret[0].([]*model.BotSlashCommand)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChannelSlashCommands indicates an expected call of GetChannelSlashCommands.
+func (mr *MockBotSlashCommandRepositoryMockRecorder) GetChannelSlashCommands(channelID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChannelSlashCommands", reflect.TypeOf((*MockBotSlashCommandRepository)(nil).GetChannelSlashCommands), channelID)
+}
+
+// SetBotSlashCommands mocks base method.
+func (m *MockBotSlashCommandRepository) SetBotSlashCommands(botID uuid.UUID, commands []*model.BotSlashCommand) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetBotSlashCommands", botID, commands)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetBotSlashCommands indicates an expected call of SetBotSlashCommands.
+func (mr *MockBotSlashCommandRepositoryMockRecorder) SetBotSlashCommands(botID, commands interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBotSlashCommands", reflect.TypeOf((*MockBotSlashCommandRepository)(nil).SetBotSlashCommands), botID, commands)
+}
@@ -67,6 +67,21 @@ func (mr *MockMessageRepositoryMockRecorder) CreateMessage(userID, channelID, te
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMessage", reflect.TypeOf((*MockMessageRepository)(nil).CreateMessage), userID, channelID, text)
 }
 
+// CreateThreadMessage mocks base method.
+func (m *MockMessageRepository) CreateThreadMessage(userID, channelID, parentID uuid.UUID, text string, followers []uuid.UUID) (*model.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateThreadMessage", userID, channelID, parentID, text, followers)
+	ret0, _ := ret[0].(*model.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateThreadMessage indicates an expected call of CreateThreadMessage.
+func (mr *MockMessageRepositoryMockRecorder) CreateThreadMessage(userID, channelID, parentID, text, followers interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateThreadMessage", reflect.TypeOf((*MockMessageRepository)(nil).CreateThreadMessage), userID, channelID, parentID, text, followers)
+}
+
 // DeleteMessage mocks base method.
 func (m *MockMessageRepository) DeleteMessage(messageID uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -95,6 +110,21 @@ func (mr *MockMessageRepositoryMockRecorder) DeleteUnreadsByChannelID(channelID,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUnreadsByChannelID", reflect.TypeOf((*MockMessageRepository)(nil).DeleteUnreadsByChannelID), channelID, userID)
 }
 
+// GetArchivedMessagesByMessageID mocks base method.
+func (m *MockMessageRepository) GetArchivedMessagesByMessageID(messageID uuid.UUID) ([]*model.ArchivedMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArchivedMessagesByMessageID", messageID)
+	ret0, _ := ret[0].([]*model.ArchivedMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetArchivedMessagesByMessageID indicates an expected call of GetArchivedMessagesByMessageID.
+func (mr *MockMessageRepositoryMockRecorder) GetArchivedMessagesByMessageID(messageID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArchivedMessagesByMessageID", reflect.TypeOf((*MockMessageRepository)(nil).GetArchivedMessagesByMessageID), messageID)
+}
+
 // GetChannelLatestMessages mocks base method.
 func (m *MockMessageRepository) GetChannelLatestMessages(query repository.ChannelLatestMessagesQuery) ([]*model.Message, error) {
 	m.ctrl.T.Helper()
@@ -141,6 +171,21 @@ func (mr *MockMessageRepositoryMockRecorder) GetMessageByID(messageID interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMessageByID", reflect.TypeOf((*MockMessageRepository)(nil).GetMessageByID), messageID)
 }
 
+// GetMessageUserID mocks base method.
+func (m *MockMessageRepository) GetMessageUserID(messageID uuid.UUID) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMessageUserID", messageID)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMessageUserID indicates an expected call of GetMessageUserID.
+func (mr *MockMessageRepositoryMockRecorder) GetMessageUserID(messageID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMessageUserID", reflect.TypeOf((*MockMessageRepository)(nil).GetMessageUserID), messageID)
+}
+
 // GetMessages mocks base method.
 func (m *MockMessageRepository) GetMessages(query repository.MessagesQuery) ([]*model.Message, bool, error) {
 	m.ctrl.T.Helper()
@@ -157,6 +202,21 @@ func (mr *MockMessageRepositoryMockRecorder) GetMessages(query interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMessages", reflect.TypeOf((*MockMessageRepository)(nil).GetMessages), query)
 }
 
+// GetThreadReplyCounts mocks base method.
+func (m *MockMessageRepository) GetThreadReplyCounts(parentIDs []uuid.UUID) (map[uuid.UUID]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetThreadReplyCounts", parentIDs)
+	ret0, _ := ret[0].(map[uuid.UUID]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetThreadReplyCounts indicates an expected call of GetThreadReplyCounts.
+func (mr *MockMessageRepositoryMockRecorder) GetThreadReplyCounts(parentIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetThreadReplyCounts", reflect.TypeOf((*MockMessageRepository)(nil).GetThreadReplyCounts), parentIDs)
+}
+
 // GetUnreadMessagesByUserID mocks base method.
 func (m *MockMessageRepository) GetUnreadMessagesByUserID(userID uuid.UUID) ([]*model.Message, error) {
 	m.ctrl.T.Helper()
@@ -203,6 +263,20 @@ func (mr *MockMessageRepositoryMockRecorder) GetUserUnreadChannels(userID interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserUnreadChannels", reflect.TypeOf((*MockMessageRepository)(nil).GetUserUnreadChannels), userID)
 }
 
+// ImportMessage mocks base method.
+func (m_2 *MockMessageRepository) ImportMessage(m *model.Message) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "ImportMessage", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ImportMessage indicates an expected call of ImportMessage.
+func (mr *MockMessageRepositoryMockRecorder) ImportMessage(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportMessage", reflect.TypeOf((*MockMessageRepository)(nil).ImportMessage), m)
+}
+
 // RemoveStampFromMessage mocks base method.
 func (m *MockMessageRepository) RemoveStampFromMessage(messageID, stampID, userID uuid.UUID) error {
 	m.ctrl.T.Helper()
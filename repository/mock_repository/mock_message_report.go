@@ -0,0 +1,125 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: message_report.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	reflect "reflect"
+
+	uuid "github.com/gofrs/uuid"
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/traPtitech/traQ/model"
+	repository "github.com/traPtitech/traQ/repository"
+)
+
+// MockMessageReportRepository is a mock of MessageReportRepository interface.
+type MockMessageReportRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMessageReportRepositoryMockRecorder
+}
+
+// MockMessageReportRepositoryMockRecorder is the mock recorder for MockMessageReportRepository.
+type MockMessageReportRepositoryMockRecorder struct {
+	mock *MockMessageReportRepository
+}
+
+// NewMockMessageReportRepository creates a new mock instance.
+func NewMockMessageReportRepository(ctrl *gomock.Controller) *MockMessageReportRepository {
+	mock := &MockMessageReportRepository{ctrl: ctrl}
+	mock.recorder = &MockMessageReportRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMessageReportRepository) EXPECT() *MockMessageReportRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateMessageReport mocks base method.
+func (m *MockMessageReportRepository) CreateMessageReport(messageID, reporterID uuid.UUID, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMessageReport", messageID, reporterID, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateMessageReport indicates an expected call of CreateMessageReport.
+func (mr *MockMessageReportRepositoryMockRecorder) CreateMessageReport(messageID, reporterID, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMessageReport", reflect.TypeOf((*MockMessageReportRepository)(nil).CreateMessageReport), messageID, reporterID, reason)
+}
+
+// GetMessageReport mocks base method.
+func (m *MockMessageReportRepository) GetMessageReport(reportID uuid.UUID) (*model.MessageReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMessageReport", reportID)
+	ret0, _ := ret[0].(*model.MessageReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMessageReport indicates an expected call of GetMessageReport.
+func (mr *MockMessageReportRepositoryMockRecorder) GetMessageReport(reportID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMessageReport", reflect.TypeOf((*MockMessageReportRepository)(nil).GetMessageReport), reportID)
+}
+
+// GetMessageReports mocks base method.
+func (m *MockMessageReportRepository) GetMessageReports(query repository.MessageReportsQuery) ([]*model.MessageReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMessageReports", query)
+	ret0, _ := ret[0].([]*model.MessageReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMessageReports indicates an expected call of GetMessageReports.
+func (mr *MockMessageReportRepositoryMockRecorder) GetMessageReports(query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMessageReports", reflect.TypeOf((*MockMessageReportRepository)(nil).GetMessageReports), query)
+}
+
+// GetMessageReportsByMessageID mocks base method.
+func (m *MockMessageReportRepository) GetMessageReportsByMessageID(messageID uuid.UUID) ([]*model.MessageReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMessageReportsByMessageID", messageID)
+	ret0, _ := ret[0].([]*model.MessageReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMessageReportsByMessageID indicates an expected call of GetMessageReportsByMessageID.
+func (mr *MockMessageReportRepositoryMockRecorder) GetMessageReportsByMessageID(messageID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMessageReportsByMessageID", reflect.TypeOf((*MockMessageReportRepository)(nil).GetMessageReportsByMessageID), messageID)
+}
+
+// GetMessageReportsByReporterID mocks base method.
+func (m *MockMessageReportRepository) GetMessageReportsByReporterID(reporterID uuid.UUID) ([]*model.MessageReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMessageReportsByReporterID", reporterID)
+	ret0, _ := ret[0].([]*model.MessageReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMessageReportsByReporterID indicates an expected call of GetMessageReportsByReporterID.
+func (mr *MockMessageReportRepositoryMockRecorder) GetMessageReportsByReporterID(reporterID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMessageReportsByReporterID", reflect.TypeOf((*MockMessageReportRepository)(nil).GetMessageReportsByReporterID), reporterID)
+}
+
+// ResolveMessageReport mocks base method.
+func (m *MockMessageReportRepository) ResolveMessageReport(reportID uuid.UUID, args repository.ResolveMessageReportArgs) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveMessageReport", reportID, args)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResolveMessageReport indicates an expected call of ResolveMessageReport.
+func (mr *MockMessageReportRepositoryMockRecorder) ResolveMessageReport(reportID, args interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveMessageReport", reflect.TypeOf((*MockMessageReportRepository)(nil).ResolveMessageReport), reportID, args)
+}
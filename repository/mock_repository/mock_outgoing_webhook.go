@@ -0,0 +1,199 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: outgoing_webhook.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	reflect "reflect"
+	time "time"
+
+	uuid "github.com/gofrs/uuid"
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/traPtitech/traQ/model"
+	repository "github.com/traPtitech/traQ/repository"
+)
+
+// MockOutgoingWebhookRepository is a mock of OutgoingWebhookRepository interface.
+type MockOutgoingWebhookRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutgoingWebhookRepositoryMockRecorder
+}
+
+// MockOutgoingWebhookRepositoryMockRecorder is the mock recorder for MockOutgoingWebhookRepository.
+type MockOutgoingWebhookRepositoryMockRecorder struct {
+	mock *MockOutgoingWebhookRepository
+}
+
+// NewMockOutgoingWebhookRepository creates a new mock instance.
+func NewMockOutgoingWebhookRepository(ctrl *gomock.Controller) *MockOutgoingWebhookRepository {
+	mock := &MockOutgoingWebhookRepository{ctrl: ctrl}
+	mock.recorder = &MockOutgoingWebhookRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutgoingWebhookRepository) EXPECT() *MockOutgoingWebhookRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateOutgoingWebhook mocks base method.
+func (m *MockOutgoingWebhookRepository) CreateOutgoingWebhook(args repository.CreateOutgoingWebhookArgs) (*model.OutgoingWebhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOutgoingWebhook", args)
+	ret0, _ := ret[0].(*model.OutgoingWebhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOutgoingWebhook indicates an expected call of CreateOutgoingWebhook.
+func (mr *MockOutgoingWebhookRepositoryMockRecorder) CreateOutgoingWebhook(args interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOutgoingWebhook", reflect.TypeOf((*MockOutgoingWebhookRepository)(nil).CreateOutgoingWebhook), args)
+}
+
+// DeleteOutgoingWebhook mocks base method.
+func (m *MockOutgoingWebhookRepository) DeleteOutgoingWebhook(id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOutgoingWebhook", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOutgoingWebhook indicates an expected call of DeleteOutgoingWebhook.
+func (mr *MockOutgoingWebhookRepositoryMockRecorder) DeleteOutgoingWebhook(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOutgoingWebhook", reflect.TypeOf((*MockOutgoingWebhookRepository)(nil).DeleteOutgoingWebhook), id)
+}
+
+// GetAllOutgoingWebhooks mocks base method.
+func (m *MockOutgoingWebhookRepository) GetAllOutgoingWebhooks() ([]*model.OutgoingWebhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllOutgoingWebhooks")
+	ret0, _ := ret[0].([]*model.OutgoingWebhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllOutgoingWebhooks indicates an expected call of GetAllOutgoingWebhooks.
+func (mr *MockOutgoingWebhookRepositoryMockRecorder) GetAllOutgoingWebhooks() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllOutgoingWebhooks", reflect.TypeOf((*MockOutgoingWebhookRepository)(nil).GetAllOutgoingWebhooks))
+}
+
+// GetOutgoingWebhook mocks base method.
+func (m *MockOutgoingWebhookRepository) GetOutgoingWebhook(id uuid.UUID) (*model.OutgoingWebhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOutgoingWebhook", id)
+	ret0, _ := ret[0].(*model.OutgoingWebhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOutgoingWebhook indicates an expected call of GetOutgoingWebhook.
+func (mr *MockOutgoingWebhookRepositoryMockRecorder) GetOutgoingWebhook(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOutgoingWebhook", reflect.TypeOf((*MockOutgoingWebhookRepository)(nil).GetOutgoingWebhook), id)
+}
+
+// GetOutgoingWebhookDeliveries mocks base method.
+func (m *MockOutgoingWebhookRepository) GetOutgoingWebhookDeliveries(webhookID uuid.UUID, limit, offset int) ([]*model.OutgoingWebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOutgoingWebhookDeliveries", webhookID, limit, offset)
+	ret0, _ := ret[0].([]*model.OutgoingWebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOutgoingWebhookDeliveries indicates an expected call of GetOutgoingWebhookDeliveries.
+func (mr *MockOutgoingWebhookRepositoryMockRecorder) GetOutgoingWebhookDeliveries(webhookID, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOutgoingWebhookDeliveries", reflect.TypeOf((*MockOutgoingWebhookRepository)(nil).GetOutgoingWebhookDeliveries), webhookID, limit, offset)
+}
+
+// GetOutgoingWebhookDelivery mocks base method.
+func (m *MockOutgoingWebhookRepository) GetOutgoingWebhookDelivery(id uuid.UUID) (*model.OutgoingWebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOutgoingWebhookDelivery", id)
+	ret0, _ := ret[0].(*model.OutgoingWebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOutgoingWebhookDelivery indicates an expected call of GetOutgoingWebhookDelivery.
+func (mr *MockOutgoingWebhookRepositoryMockRecorder) GetOutgoingWebhookDelivery(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOutgoingWebhookDelivery", reflect.TypeOf((*MockOutgoingWebhookRepository)(nil).GetOutgoingWebhookDelivery), id)
+}
+
+// GetOutgoingWebhooksByChannelID mocks base method.
+func (m *MockOutgoingWebhookRepository) GetOutgoingWebhooksByChannelID(channelID uuid.UUID) ([]*model.OutgoingWebhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOutgoingWebhooksByChannelID", channelID)
+	ret0, _ := ret[0].([]*model.OutgoingWebhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOutgoingWebhooksByChannelID indicates an expected call of GetOutgoingWebhooksByChannelID.
+func (mr *MockOutgoingWebhookRepositoryMockRecorder) GetOutgoingWebhooksByChannelID(channelID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOutgoingWebhooksByChannelID", reflect.TypeOf((*MockOutgoingWebhookRepository)(nil).GetOutgoingWebhooksByChannelID), channelID)
+}
+
+// GetOutgoingWebhooksByCreatorID mocks base method.
+func (m *MockOutgoingWebhookRepository) GetOutgoingWebhooksByCreatorID(creatorID uuid.UUID) ([]*model.OutgoingWebhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOutgoingWebhooksByCreatorID", creatorID)
+	ret0, _ := ret[0].([]*model.OutgoingWebhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOutgoingWebhooksByCreatorID indicates an expected call of GetOutgoingWebhooksByCreatorID.
+func (mr *MockOutgoingWebhookRepositoryMockRecorder) GetOutgoingWebhooksByCreatorID(creatorID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOutgoingWebhooksByCreatorID", reflect.TypeOf((*MockOutgoingWebhookRepository)(nil).GetOutgoingWebhooksByCreatorID), creatorID)
+}
+
+// PurgeOutgoingWebhookDeliveries mocks base method.
+func (m *MockOutgoingWebhookRepository) PurgeOutgoingWebhookDeliveries(before time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeOutgoingWebhookDeliveries", before)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeOutgoingWebhookDeliveries indicates an expected call of PurgeOutgoingWebhookDeliveries.
+func (mr *MockOutgoingWebhookRepositoryMockRecorder) PurgeOutgoingWebhookDeliveries(before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeOutgoingWebhookDeliveries", reflect.TypeOf((*MockOutgoingWebhookRepository)(nil).PurgeOutgoingWebhookDeliveries), before)
+}
+
+// UpdateOutgoingWebhook mocks base method.
+func (m *MockOutgoingWebhookRepository) UpdateOutgoingWebhook(id uuid.UUID, args repository.UpdateOutgoingWebhookArgs) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOutgoingWebhook", id, args)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateOutgoingWebhook indicates an expected call of UpdateOutgoingWebhook.
+func (mr *MockOutgoingWebhookRepositoryMockRecorder) UpdateOutgoingWebhook(id, args interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOutgoingWebhook", reflect.TypeOf((*MockOutgoingWebhookRepository)(nil).UpdateOutgoingWebhook), id, args)
+}
+
+// WriteOutgoingWebhookDelivery mocks base method.
+func (m *MockOutgoingWebhookRepository) WriteOutgoingWebhookDelivery(delivery *model.OutgoingWebhookDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WriteOutgoingWebhookDelivery", delivery)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WriteOutgoingWebhookDelivery indicates an expected call of WriteOutgoingWebhookDelivery.
+func (mr *MockOutgoingWebhookRepositoryMockRecorder) WriteOutgoingWebhookDelivery(delivery interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteOutgoingWebhookDelivery", reflect.TypeOf((*MockOutgoingWebhookRepository)(nil).WriteOutgoingWebhookDelivery), delivery)
+}
@@ -0,0 +1,140 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: scheduled_message.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	reflect "reflect"
+	time "time"
+
+	uuid "github.com/gofrs/uuid"
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/traPtitech/traQ/model"
+	repository "github.com/traPtitech/traQ/repository"
+)
+
+// MockScheduledMessageRepository is a mock of ScheduledMessageRepository interface.
+type MockScheduledMessageRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockScheduledMessageRepositoryMockRecorder
+}
+
+// MockScheduledMessageRepositoryMockRecorder is the mock recorder for MockScheduledMessageRepository.
+type MockScheduledMessageRepositoryMockRecorder struct {
+	mock *MockScheduledMessageRepository
+}
+
+// NewMockScheduledMessageRepository creates a new mock instance.
+func NewMockScheduledMessageRepository(ctrl *gomock.Controller) *MockScheduledMessageRepository {
+	mock := &MockScheduledMessageRepository{ctrl: ctrl}
+	mock.recorder = &MockScheduledMessageRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockScheduledMessageRepository) EXPECT() *MockScheduledMessageRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ClaimScheduledMessage mocks base method.
+func (m *MockScheduledMessageRepository) ClaimScheduledMessage(id uuid.UUID, now, claimUntil time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimScheduledMessage", id, now, claimUntil)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClaimScheduledMessage indicates an expected call of ClaimScheduledMessage.
+func (mr *MockScheduledMessageRepositoryMockRecorder) ClaimScheduledMessage(id, now, claimUntil interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimScheduledMessage", reflect.TypeOf((*MockScheduledMessageRepository)(nil).ClaimScheduledMessage), id, now, claimUntil)
+}
+
+// CreateScheduledMessage mocks base method.
+func (m *MockScheduledMessageRepository) CreateScheduledMessage(userID, channelID uuid.UUID, text string, scheduledAt time.Time) (*model.ScheduledMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateScheduledMessage", userID, channelID, text, scheduledAt)
+	ret0, _ := ret[0].(*model.ScheduledMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateScheduledMessage indicates an expected call of CreateScheduledMessage.
+func (mr *MockScheduledMessageRepositoryMockRecorder) CreateScheduledMessage(userID, channelID, text, scheduledAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateScheduledMessage", reflect.TypeOf((*MockScheduledMessageRepository)(nil).CreateScheduledMessage), userID, channelID, text, scheduledAt)
+}
+
+// DeleteScheduledMessage mocks base method.
+func (m *MockScheduledMessageRepository) DeleteScheduledMessage(id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteScheduledMessage", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteScheduledMessage indicates an expected call of DeleteScheduledMessage.
+func (mr *MockScheduledMessageRepositoryMockRecorder) DeleteScheduledMessage(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteScheduledMessage", reflect.TypeOf((*MockScheduledMessageRepository)(nil).DeleteScheduledMessage), id)
+}
+
+// GetDueScheduledMessages mocks base method.
+func (m *MockScheduledMessageRepository) GetDueScheduledMessages(until time.Time, limit int) ([]*model.ScheduledMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDueScheduledMessages", until, limit)
+	ret0, _ := ret[0].([]*model.ScheduledMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDueScheduledMessages indicates an expected call of GetDueScheduledMessages.
+func (mr *MockScheduledMessageRepositoryMockRecorder) GetDueScheduledMessages(until, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDueScheduledMessages", reflect.TypeOf((*MockScheduledMessageRepository)(nil).GetDueScheduledMessages), until, limit)
+}
+
+// GetScheduledMessage mocks base method.
+func (m *MockScheduledMessageRepository) GetScheduledMessage(id uuid.UUID) (*model.ScheduledMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScheduledMessage", id)
+	ret0, _ := ret[0].(*model.ScheduledMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScheduledMessage indicates an expected call of GetScheduledMessage.
+func (mr *MockScheduledMessageRepositoryMockRecorder) GetScheduledMessage(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScheduledMessage", reflect.TypeOf((*MockScheduledMessageRepository)(nil).GetScheduledMessage), id)
+}
+
+// GetScheduledMessagesByUserID mocks base method.
+func (m *MockScheduledMessageRepository) GetScheduledMessagesByUserID(userID uuid.UUID) ([]*model.ScheduledMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScheduledMessagesByUserID", userID)
+	ret0, _ := ret[0].([]*model.ScheduledMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScheduledMessagesByUserID indicates an expected call of GetScheduledMessagesByUserID.
+func (mr *MockScheduledMessageRepositoryMockRecorder) GetScheduledMessagesByUserID(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScheduledMessagesByUserID", reflect.TypeOf((*MockScheduledMessageRepository)(nil).GetScheduledMessagesByUserID), userID)
+}
+
+// UpdateScheduledMessage mocks base method.
+func (m *MockScheduledMessageRepository) UpdateScheduledMessage(id uuid.UUID, args repository.UpdateScheduledMessageArgs) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateScheduledMessage", id, args)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateScheduledMessage indicates an expected call of UpdateScheduledMessage.
+func (mr *MockScheduledMessageRepositoryMockRecorder) UpdateScheduledMessage(id, args interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateScheduledMessage", reflect.TypeOf((*MockScheduledMessageRepository)(nil).UpdateScheduledMessage), id, args)
+}
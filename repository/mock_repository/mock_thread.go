@@ -0,0 +1,93 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: thread.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	reflect "reflect"
+
+	uuid "github.com/gofrs/uuid"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockThreadRepository is a mock of ThreadRepository interface.
+type MockThreadRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockThreadRepositoryMockRecorder
+}
+
+// MockThreadRepositoryMockRecorder is the mock recorder for MockThreadRepository.
+type MockThreadRepositoryMockRecorder struct {
+	mock *MockThreadRepository
+}
+
+// NewMockThreadRepository creates a new mock instance.
+func NewMockThreadRepository(ctrl *gomock.Controller) *MockThreadRepository {
+	mock := &MockThreadRepository{ctrl: ctrl}
+	mock.recorder = &MockThreadRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockThreadRepository) EXPECT() *MockThreadRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FollowThread mocks base method.
+func (m *MockThreadRepository) FollowThread(userID, messageID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FollowThread", userID, messageID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FollowThread indicates an expected call of FollowThread.
+func (mr *MockThreadRepositoryMockRecorder) FollowThread(userID, messageID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FollowThread", reflect.TypeOf((*MockThreadRepository)(nil).FollowThread), userID, messageID)
+}
+
+// GetFollowingThreadIDs mocks base method.
+func (m *MockThreadRepository) GetFollowingThreadIDs(userID uuid.UUID) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFollowingThreadIDs", userID)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFollowingThreadIDs indicates an expected call of GetFollowingThreadIDs.
+func (mr *MockThreadRepositoryMockRecorder) GetFollowingThreadIDs(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFollowingThreadIDs", reflect.TypeOf((*MockThreadRepository)(nil).GetFollowingThreadIDs), userID)
+}
+
+// IsThreadFollowed mocks base method.
+func (m *MockThreadRepository) IsThreadFollowed(userID, messageID uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsThreadFollowed", userID, messageID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsThreadFollowed indicates an expected call of IsThreadFollowed.
+func (mr *MockThreadRepositoryMockRecorder) IsThreadFollowed(userID, messageID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsThreadFollowed", reflect.TypeOf((*MockThreadRepository)(nil).IsThreadFollowed), userID, messageID)
+}
+
+// UnfollowThread mocks base method.
+func (m *MockThreadRepository) UnfollowThread(userID, messageID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnfollowThread", userID, messageID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnfollowThread indicates an expected call of UnfollowThread.
+func (mr *MockThreadRepositoryMockRecorder) UnfollowThread(userID, messageID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnfollowThread", reflect.TypeOf((*MockThreadRepository)(nil).UnfollowThread), userID, messageID)
+}
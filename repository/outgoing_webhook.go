@@ -0,0 +1,97 @@
+//go:generate mockgen -source=$GOFILE -destination=mock_$GOPACKAGE/mock_$GOFILE
+package repository
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+// CreateOutgoingWebhookArgs 送信Webhook作成引数
+type CreateOutgoingWebhookArgs struct {
+	ChannelID uuid.UUID
+	CreatorID uuid.UUID
+	URL       string
+	Secret    string
+	Events    model.OutgoingWebhookEventTypes
+}
+
+// UpdateOutgoingWebhookArgs 送信Webhook更新引数
+type UpdateOutgoingWebhookArgs struct {
+	ChannelID optional.Of[uuid.UUID]
+	URL       optional.Of[string]
+	Secret    optional.Of[string]
+	Events    optional.Of[model.OutgoingWebhookEventTypes]
+}
+
+// OutgoingWebhookRepository 送信Webhookリポジトリ
+type OutgoingWebhookRepository interface {
+	// CreateOutgoingWebhook 送信Webhookを作成します
+	//
+	// 成功した場合、送信Webhookとnilを返します。
+	// 引数にuuid.Nilを指定するとErrNilIDを返します。
+	// DBによるエラーを返すことがあります。
+	CreateOutgoingWebhook(args CreateOutgoingWebhookArgs) (*model.OutgoingWebhook, error)
+	// UpdateOutgoingWebhook 送信Webhookを更新します
+	//
+	// 成功した場合、nilを返します。
+	// 存在しない送信Webhookを指定した場合、ErrNotFoundを返します。
+	// 引数にuuid.Nilを指定するとErrNilIDを返します。
+	// DBによるエラーを返すことがあります。
+	UpdateOutgoingWebhook(id uuid.UUID, args UpdateOutgoingWebhookArgs) error
+	// DeleteOutgoingWebhook 送信Webhookを削除します
+	//
+	// 成功した場合、nilを返します。配送ログも削除されます。
+	// 存在しない送信Webhookを指定した場合、ErrNotFoundを返します。
+	// 引数にuuid.Nilを指定するとErrNilIDを返します。
+	// DBによるエラーを返すことがあります。
+	DeleteOutgoingWebhook(id uuid.UUID) error
+	// GetOutgoingWebhook 指定した送信Webhookを取得します
+	//
+	// 成功した場合、送信Webhookとnilを返します。
+	// 存在しない送信Webhookを指定した場合、ErrNotFoundを返します。
+	// DBによるエラーを返すことがあります。
+	GetOutgoingWebhook(id uuid.UUID) (*model.OutgoingWebhook, error)
+	// GetOutgoingWebhooksByChannelID 指定したチャンネルの送信Webhookを全て取得します
+	//
+	// 成功した場合、送信Webhookの配列とnilを返します。
+	// 存在しないチャンネルを指定した場合は空配列とnilを返します。
+	// DBによるエラーを返すことがあります。
+	GetOutgoingWebhooksByChannelID(channelID uuid.UUID) ([]*model.OutgoingWebhook, error)
+	// GetOutgoingWebhooksByCreatorID 指定したユーザーが作成した送信Webhookを全て取得します
+	//
+	// 成功した場合、送信Webhookの配列とnilを返します。
+	// 存在しないユーザーを指定した場合は空配列とnilを返します。
+	// DBによるエラーを返すことがあります。
+	GetOutgoingWebhooksByCreatorID(creatorID uuid.UUID) ([]*model.OutgoingWebhook, error)
+	// GetAllOutgoingWebhooks 送信Webhookを全て取得します
+	//
+	// 成功した場合、送信Webhookの配列とnilを返します。
+	// DBによるエラーを返すことがあります。
+	GetAllOutgoingWebhooks() ([]*model.OutgoingWebhook, error)
+	// WriteOutgoingWebhookDelivery 送信Webhookの配送ログを書き込みます
+	//
+	// 成功した場合、nilを返します。
+	// DBによるエラーを返すことがあります。
+	WriteOutgoingWebhookDelivery(delivery *model.OutgoingWebhookDelivery) error
+	// GetOutgoingWebhookDelivery 指定した配送ログを取得します
+	//
+	// 成功した場合、配送ログとnilを返します。
+	// 存在しない配送ログを指定した場合、ErrNotFoundを返します。
+	// DBによるエラーを返すことがあります。
+	GetOutgoingWebhookDelivery(id uuid.UUID) (*model.OutgoingWebhookDelivery, error)
+	// GetOutgoingWebhookDeliveries 指定した送信Webhookの配送ログを新しい順に取得します
+	//
+	// 成功した場合、配送ログの配列とnilを返します。負のoffset, limitは無視されます。
+	// 存在しない送信Webhookを指定した場合、空配列とnilを返します。
+	// DBによるエラーを返すことがあります。
+	GetOutgoingWebhookDeliveries(webhookID uuid.UUID, limit, offset int) ([]*model.OutgoingWebhookDelivery, error)
+	// PurgeOutgoingWebhookDeliveries 指定した時間以前の配送ログを全て消去します
+	//
+	// 成功した場合、nilを返します。
+	// DBによるエラーを返すことがあります。
+	PurgeOutgoingWebhookDeliveries(before time.Time) error
+}
@@ -10,15 +10,22 @@ type Repository interface {
 	ChannelRepository
 	MessageRepository
 	MessageReportRepository
+	MessageComponentRepository
 	StampRepository
 	StampPaletteRepository
 	StarRepository
+	ThreadRepository
+	ScheduledMessageRepository
+	SavedSearchRepository
 	PinRepository
 	DeviceRepository
 	FileRepository
 	WebhookRepository
+	OutgoingWebhookRepository
 	OAuth2Repository
 	BotRepository
+	BotEventDeliveryRepository
+	BotSlashCommandRepository
 	ClipRepository
 	OgpCacheRepository
 }
@@ -0,0 +1,56 @@
+//go:generate mockgen -source=$GOFILE -destination=mock_$GOPACKAGE/mock_$GOFILE
+package repository
+
+import (
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+// UpdateSavedSearchArgs 保存された検索情報更新引数
+type UpdateSavedSearchArgs struct {
+	Name  optional.Of[string]
+	Query optional.Of[string]
+}
+
+// SavedSearchRepository 保存された検索リポジトリ
+type SavedSearchRepository interface {
+	// CreateSavedSearch 検索を保存します
+	//
+	// 成功した場合、保存された検索とnilを返します。
+	// 既にlimit件以上の検索を保存している場合、ErrForbiddenを返します。
+	// 引数にuuid.Nilを指定するとErrNilIDを返します。
+	// DBによるエラーを返すことがあります。
+	CreateSavedSearch(userID uuid.UUID, name, query string, limit int) (*model.SavedSearch, error)
+	// UpdateSavedSearch 指定した保存された検索を更新します
+	//
+	// 成功した場合、nilを返します。
+	// 存在しない保存された検索を指定した場合、ErrNotFoundを返します。
+	// 引数にuuid.Nilを指定するとErrNilIDを返します。
+	// DBによるエラーを返すことがあります。
+	UpdateSavedSearch(id uuid.UUID, args UpdateSavedSearchArgs) error
+	// DeleteSavedSearch 指定した保存された検索を削除します
+	//
+	// 成功した場合、nilを返します。
+	// 存在しない保存された検索を指定した場合、ErrNotFoundを返します。
+	// 引数にuuid.Nilを指定するとErrNilIDを返します。
+	// DBによるエラーを返すことがあります。
+	DeleteSavedSearch(id uuid.UUID) error
+	// GetSavedSearch 指定した保存された検索を取得します
+	//
+	// 成功した場合、保存された検索とnilを返します。
+	// 存在しない保存された検索を指定した場合、ErrNotFoundを返します。
+	// DBによるエラーを返すことがあります。
+	GetSavedSearch(id uuid.UUID) (*model.SavedSearch, error)
+	// GetSavedSearchesByUserID 指定したユーザーの保存された検索を取得します
+	//
+	// 成功した場合、作成日時で昇順ソートされた保存された検索の配列とnilを返します。
+	// 存在しないユーザーを指定した場合は空配列とnilを返します。
+	// DBによるエラーを返すことがあります。
+	GetSavedSearchesByUserID(userID uuid.UUID) ([]*model.SavedSearch, error)
+	// GetAllSavedSearches 全ユーザーの保存された検索を取得します
+	//
+	// DBによるエラーを返すことがあります。
+	GetAllSavedSearches() ([]*model.SavedSearch, error)
+}
@@ -0,0 +1,67 @@
+//go:generate mockgen -source=$GOFILE -destination=mock_$GOPACKAGE/mock_$GOFILE
+package repository
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+// UpdateScheduledMessageArgs 予約投稿メッセージ情報更新引数
+type UpdateScheduledMessageArgs struct {
+	Text        optional.Of[string]
+	ScheduledAt optional.Of[time.Time]
+}
+
+// ScheduledMessageRepository 予約投稿メッセージリポジトリ
+type ScheduledMessageRepository interface {
+	// CreateScheduledMessage 予約投稿メッセージを作成します
+	//
+	// 成功した場合、予約投稿メッセージとnilを返します。
+	// 引数にuuid.Nilを指定するとErrNilIDを返します。
+	// DBによるエラーを返すことがあります。
+	CreateScheduledMessage(userID, channelID uuid.UUID, text string, scheduledAt time.Time) (*model.ScheduledMessage, error)
+	// UpdateScheduledMessage 指定した予約投稿メッセージを更新します
+	//
+	// 成功した場合、nilを返します。
+	// 存在しない予約投稿メッセージを指定した場合、ErrNotFoundを返します。
+	// 引数にuuid.Nilを指定するとErrNilIDを返します。
+	// DBによるエラーを返すことがあります。
+	UpdateScheduledMessage(id uuid.UUID, args UpdateScheduledMessageArgs) error
+	// DeleteScheduledMessage 指定した予約投稿メッセージを削除します
+	//
+	// 成功した場合、nilを返します。
+	// 存在しない予約投稿メッセージを指定した場合、ErrNotFoundを返します。
+	// 引数にuuid.Nilを指定するとErrNilIDを返します。
+	// DBによるエラーを返すことがあります。
+	DeleteScheduledMessage(id uuid.UUID) error
+	// GetScheduledMessage 指定した予約投稿メッセージを取得します
+	//
+	// 成功した場合、予約投稿メッセージとnilを返します。
+	// 存在しない予約投稿メッセージを指定した場合、ErrNotFoundを返します。
+	// DBによるエラーを返すことがあります。
+	GetScheduledMessage(id uuid.UUID) (*model.ScheduledMessage, error)
+	// GetScheduledMessagesByUserID 指定したユーザーの予約投稿メッセージを取得します
+	//
+	// 成功した場合、投稿予定日時で昇順ソートされた予約投稿メッセージの配列とnilを返します。
+	// 存在しないユーザーを指定した場合は空配列とnilを返します。
+	// DBによるエラーを返すことがあります。
+	GetScheduledMessagesByUserID(userID uuid.UUID) ([]*model.ScheduledMessage, error)
+	// GetDueScheduledMessages 投稿予定日時が指定した日時以前の予約投稿メッセージを取得します
+	//
+	// 成功した場合、投稿予定日時で昇順ソートされた予約投稿メッセージの配列とnilを返します。
+	// 投稿処理中として確保されているメッセージは、確保期限が指定した日時以前の場合のみ含まれます。
+	// 負のlimitは無視されます。
+	// DBによるエラーを返すことがあります。
+	GetDueScheduledMessages(until time.Time, limit int) ([]*model.ScheduledMessage, error)
+	// ClaimScheduledMessage 指定した予約投稿メッセージを期限claimUntilまで投稿処理中として確保します
+	//
+	// 成功した場合、nilを返します。
+	// 存在しない予約投稿メッセージ、またはnow時点で他に確保されている予約投稿メッセージを指定した場合、ErrNotFoundを返します。
+	// 引数にuuid.Nilを指定するとErrNilIDを返します。
+	// DBによるエラーを返すことがあります。
+	ClaimScheduledMessage(id uuid.UUID, now, claimUntil time.Time) error
+}
@@ -0,0 +1,32 @@
+//go:generate mockgen -source=$GOFILE -destination=mock_$GOPACKAGE/mock_$GOFILE
+package repository
+
+import "github.com/gofrs/uuid"
+
+// ThreadRepository スレッドフォローリポジトリ
+type ThreadRepository interface {
+	// FollowThread スレッドをフォローします
+	//
+	// 成功した、或いは既にフォローしていた場合にnilを返します。
+	// 引数にuuid.Nilを指定するとErrNilIDを返します。
+	// DBによるエラーを返すことがあります。
+	FollowThread(userID, messageID uuid.UUID) error
+	// UnfollowThread スレッドのフォローを解除します
+	//
+	// 成功した、或いは既に解除されていた場合にnilを返します。
+	// 引数にuuid.Nilを指定するとErrNilIDを返します。
+	// DBによるエラーを返すことがあります。
+	UnfollowThread(userID, messageID uuid.UUID) error
+	// IsThreadFollowed ユーザーがスレッドをフォローしているかどうかを返します
+	//
+	// フォローしている場合、trueとnilを返します。
+	// 引数にuuid.Nilを指定するとfalseとnilを返します。
+	// DBによるエラーを返すことがあります。
+	IsThreadFollowed(userID, messageID uuid.UUID) (bool, error)
+	// GetFollowingThreadIDs ユーザーがフォローしているスレッドの親メッセージIDを取得します
+	//
+	// 成功した場合、メッセージUUIDの配列とnilを返します。
+	// 存在しないユーザーを指定した場合は空配列とnilを返します。
+	// DBによるエラーを返すことがあります。
+	GetFollowingThreadIDs(userID uuid.UUID) ([]uuid.UUID, error)
+}
@@ -49,6 +49,8 @@ type UsersQuery struct {
 	IsGMemberOf                 optional.Of[uuid.UUID]
 	IsSubscriberAtMarkLevelOf   optional.Of[uuid.UUID]
 	IsSubscriberAtNotifyLevelOf optional.Of[uuid.UUID]
+	IsThreadFollowerOf          optional.Of[uuid.UUID]
+	Role                        optional.Of[string]
 	EnableProfileLoading        bool
 }
 
@@ -94,6 +96,18 @@ func (q UsersQuery) SubscriberAtNotifyLevelOf(channelID uuid.UUID) UsersQuery {
 	return q
 }
 
+// ThreadFollowerOf messageIDスレッドのフォロワーである
+func (q UsersQuery) ThreadFollowerOf(messageID uuid.UUID) UsersQuery {
+	q.IsThreadFollowerOf = optional.From(messageID)
+	return q
+}
+
+// RoleOf roleロールのユーザーである
+func (q UsersQuery) RoleOf(role string) UsersQuery {
+	q.Role = optional.From(role)
+	return q
+}
+
 // LoadProfile ユーザーの追加プロファイル情報を読み込むかどうか
 func (q UsersQuery) LoadProfile() UsersQuery {
 	q.EnableProfileLoading = true
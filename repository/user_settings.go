@@ -1,11 +1,28 @@
 package repository
 
 import (
+	"time"
+
 	"github.com/gofrs/uuid"
 
 	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/utils/optional"
 )
 
+// UpdateDNDSettingsArgs おやすみモード設定更新引数
+type UpdateDNDSettingsArgs struct {
+	Schedules model.DNDSchedules
+	Timezone  string
+	Digest    bool
+}
+
+// UpdateEmailSettingsArgs メール通知設定更新引数
+type UpdateEmailSettingsArgs struct {
+	Address   string
+	Immediate bool
+	Digest    model.EmailDigestInterval
+}
+
 // UserSettingsRepository ユーザセッティングレポジトリ
 type UserSettingsRepository interface {
 	// UpdateNotifyCitation メッセージ引用通知を設定します
@@ -20,7 +37,82 @@ type UserSettingsRepository interface {
 	// 返り値がfalseの場合、メッセージ引用通知が無効です
 	// DBによるエラーを返すことがあります
 	GetNotifyCitation(userID uuid.UUID) (bool, error)
+	// UpdateDNDSettings おやすみモードの時間帯設定を更新します
+	//
+	// 引数にuuid.Nilを指定するとErrNilIDを返します。
+	// DBによるエラーを返すことがあります
+	UpdateDNDSettings(userID uuid.UUID, args UpdateDNDSettingsArgs) error
+	// UpdateSnoozeUntil 一時的に通知を止める期限を設定します
+	//
+	// untilが無効値の場合、一時停止を解除します
+	// 引数にuuid.Nilを指定するとErrNilIDを返します。
+	// DBによるエラーを返すことがあります
+	UpdateSnoozeUntil(userID uuid.UUID, until optional.Of[time.Time]) error
+	// UpdateEmailSettings メール通知設定を更新します
+	//
+	// 宛先アドレスを変更した場合は確認用トークンを発行し、確認されるまで変更前のアドレスを使用します
+	// 配信停止用トークンが未発行の場合は発行します
+	// 引数にuuid.Nilを指定するとErrNilIDを返します。
+	// DBによるエラーを返すことがあります
+	UpdateEmailSettings(userID uuid.UUID, args UpdateEmailSettingsArgs) error
+	// UpdateEmailDigestSentAt まとめメールの最終送信日時を更新します
+	//
+	// 引数にuuid.Nilを指定するとErrNilIDを返します。
+	// DBによるエラーを返すことがあります
+	UpdateEmailDigestSentAt(userID uuid.UUID, sentAt time.Time) error
+	// GetEmailDigestTargets まとめメールが有効なユーザーのユーザー設定を返します
+	//
+	// DBによるエラーを返すことがあります
+	GetEmailDigestTargets() ([]*model.UserSettings, error)
+	// DisableEmailNotificationByToken 配信停止用トークンに対応するユーザーのメール通知を無効にします
+	//
+	// 成功した場合、nilを返します。
+	// トークンに対応するユーザーが存在しない場合、ErrNotFoundを返します。
+	// DBによるエラーを返すことがあります
+	DisableEmailNotificationByToken(token string) error
+	// VerifyEmailAddressByToken 確認用トークンに対応する確認待ちの宛先アドレスをメール通知の宛先にします
+	//
+	// 成功した場合、nilを返します。
+	// トークンに対応するユーザーが存在しない場合、または期限切れの場合、ErrNotFoundを返します。
+	// DBによるエラーを返すことがあります
+	VerifyEmailAddressByToken(token string) error
+	// ExistsEmailVerificationToken 有効な確認用トークンに対応するユーザーが存在するかどうかを返します
+	//
+	// DBによるエラーを返すことがあります
+	ExistsEmailVerificationToken(token string) (bool, error)
+	// ExistsEmailUnsubscribeToken 配信停止用トークンに対応するユーザーが存在するかどうかを返します
+	//
+	// DBによるエラーを返すことがあります
+	ExistsEmailUnsubscribeToken(token string) (bool, error)
 	// GetUserSettings ユーザー設定を返します
 	// DBによるエラーを返すことがあります
 	GetUserSettings(userID uuid.UUID) (*model.UserSettings, error)
+	// GetUserSettingsByUserIDs 指定したユーザーのユーザー設定を返します
+	//
+	// 設定が保存されていないユーザーは結果に含まれません
+	// DBによるエラーを返すことがあります
+	GetUserSettingsByUserIDs(userIDs []uuid.UUID) ([]*model.UserSettings, error)
+	// AddNotificationKeyword 通知キーワードを追加します
+	//
+	// 成功した場合、nilを返します。
+	// 既に同じキーワードが登録されている場合、ErrAlreadyExistsを返します。
+	// 引数にuuid.Nilを指定するとErrNilIDを返します。
+	// DBによるエラーを返すことがあります
+	AddNotificationKeyword(userID uuid.UUID, keyword string) error
+	// RemoveNotificationKeyword 通知キーワードを削除します
+	//
+	// 成功した場合、nilを返します。
+	// 登録されていないキーワードを指定した場合、ErrNotFoundを返します。
+	// 引数にuuid.Nilを指定するとErrNilIDを返します。
+	// DBによるエラーを返すことがあります
+	RemoveNotificationKeyword(userID uuid.UUID, keyword string) error
+	// GetNotificationKeywords 指定したユーザーの通知キーワードを取得します
+	//
+	// 登録日時の昇順で返します。
+	// DBによるエラーを返すことがあります
+	GetNotificationKeywords(userID uuid.UUID) ([]*model.NotificationKeyword, error)
+	// GetAllNotificationKeywords 全ユーザーの通知キーワードを取得します
+	//
+	// DBによるエラーを返すことがあります
+	GetAllNotificationKeywords() ([]*model.NotificationKeyword, error)
 }
@@ -12,5 +12,6 @@ const (
 	HeaderSignature         = "X-TRAQ-Signature"
 	HeaderChannelID         = "X-TRAQ-Channel-Id"
 	HeaderMore              = "X-TRAQ-More"
+	HeaderNextCursor        = "X-TRAQ-Next-Cursor"
 	HeaderVersion           = "X-TRAQ-VERSION"
 )
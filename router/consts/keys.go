@@ -1,20 +1,22 @@
 package consts
 
 const (
-	KeyUserID             = "userID"
-	KeyUser               = "user"
-	KeyOAuth2AccessScopes = "scopes"
-	KeyParamStamp         = "paramStamp"
-	KeyParamStampPalette  = "paramStampPalette"
-	KeyParamGroup         = "paramGroup"
-	KeyParamUser          = "paramUser"
-	KeyParamClient        = "paramClient"
-	KeyParamBot           = "paramBot"
-	KeyParamWebhook       = "paramWebhook"
-	KeyParamMessage       = "paramMessage"
-	KeyParamChannel       = "paramChannel"
-	KeyParamFile          = "paramFile"
-	KeyParamClipFolder    = "paramClipFolder"
-	KeyRepo               = "_repo"
-	KeyChannelManager     = "_cm"
+	KeyUserID               = "userID"
+	KeyUser                 = "user"
+	KeyOAuth2AccessScopes   = "scopes"
+	KeyBotPermissions       = "botPermissions"
+	KeyParamStamp           = "paramStamp"
+	KeyParamStampPalette    = "paramStampPalette"
+	KeyParamGroup           = "paramGroup"
+	KeyParamUser            = "paramUser"
+	KeyParamClient          = "paramClient"
+	KeyParamBot             = "paramBot"
+	KeyParamWebhook         = "paramWebhook"
+	KeyParamOutgoingWebhook = "paramOutgoingWebhook"
+	KeyParamMessage         = "paramMessage"
+	KeyParamChannel         = "paramChannel"
+	KeyParamFile            = "paramFile"
+	KeyParamClipFolder      = "paramClipFolder"
+	KeyRepo                 = "_repo"
+	KeyChannelManager       = "_cm"
 )
@@ -5,4 +5,6 @@ const (
 	MimeImageJPEG = "image/jpeg"
 	MimeImageGIF  = "image/gif"
 	MimeImageSVG  = "image/svg+xml"
+	MimeImageWebP = "image/webp"
+	MimeImageAVIF = "image/avif"
 )
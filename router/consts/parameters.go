@@ -1,21 +1,28 @@
 package consts
 
 const (
-	ParamChannelID      = "channelID"
-	ParamPinID          = "pinID"
-	ParamUserID         = "userID"
-	ParamUsername       = "username"
-	ParamGroupID        = "groupID"
-	ParamTagID          = "tagID"
-	ParamStampID        = "stampID"
-	ParamStampPaletteID = "paletteID"
-	ParamMessageID      = "messageID"
-	ParamReferenceID    = "referenceID"
-	ParamFileID         = "fileID"
-	ParamWebhookID      = "webhookID"
-	ParamTokenID        = "tokenID"
-	ParamBotID          = "botID"
-	ParamClientID       = "clientID"
-	ParamClipFolderID   = "folderID"
-	ParamURL            = "url"
+	ParamChannelID          = "channelID"
+	ParamPinID              = "pinID"
+	ParamUserID             = "userID"
+	ParamUsername           = "username"
+	ParamGroupID            = "groupID"
+	ParamTagID              = "tagID"
+	ParamStampID            = "stampID"
+	ParamStampPaletteID     = "paletteID"
+	ParamMessageID          = "messageID"
+	ParamReferenceID        = "referenceID"
+	ParamFileID             = "fileID"
+	ParamWebhookID          = "webhookID"
+	ParamOutgoingWebhookID  = "outgoingWebhookID"
+	ParamDeliveryID         = "deliveryID"
+	ParamTokenID            = "tokenID"
+	ParamBotID              = "botID"
+	ParamClientID           = "clientID"
+	ParamClipFolderID       = "folderID"
+	ParamScheduledMessageID = "scheduledMessageID"
+	ParamSavedSearchID      = "savedSearchID"
+	ParamReportID           = "reportID"
+	ParamInteractionID      = "interactionID"
+	ParamKeyword            = "keyword"
+	ParamURL                = "url"
 )
@@ -6,4 +6,6 @@ type ctxKey int
 const (
 	// UserID ユーザーUUIDキー
 	UserID ctxKey = iota
+	// BotID BotUUIDキー
+	BotID
 )
@@ -31,6 +31,16 @@ func AccessControlMiddlewareGenerator(r rbac.RBAC) func(p ...permission.Permissi
 					}
 				}
 
+				// Bot権限検証
+				if perms, ok := c.Get(consts.KeyBotPermissions).(model.BotPermissions); ok {
+					for _, v := range p {
+						if !perms.Contains(v.Name()) {
+							// NG
+							return echo.NewHTTPError(http.StatusForbidden, fmt.Sprintf("you are not permitted to request to '%s'", c.Request().URL.Path))
+						}
+					}
+				}
+
 				// ユーザー権限検証
 				user := c.Get(consts.KeyUser).(model.UserInfo)
 				for _, v := range p {
@@ -112,6 +122,23 @@ func CheckWebhookAccessPerm(rbac rbac.RBAC) echo.MiddlewareFunc {
 	}
 }
 
+// CheckOutgoingWebhookAccessPerm OutgoingWebhookアクセス権限を確認するミドルウェア
+func CheckOutgoingWebhookAccessPerm(rbac rbac.RBAC) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			user := c.Get(consts.KeyUser).(model.UserInfo)
+			w := c.Get(consts.KeyParamOutgoingWebhook).(*model.OutgoingWebhook)
+
+			// アクセス権確認
+			if !rbac.IsGranted(user.GetRole(), permission.AccessOthersWebhook) && w.CreatorID != user.GetID() {
+				return herror.Forbidden()
+			}
+
+			return next(c)
+		}
+	}
+}
+
 // CheckFileAccessPerm Fileアクセス権限を確認するミドルウェア
 func CheckFileAccessPerm(fm file.Manager) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
@@ -139,6 +139,13 @@ func (pr *ParamRetriever) WebhookID() echo.MiddlewareFunc {
 	})
 }
 
+// OutgoingWebhookID リクエストURLの`outgoingWebhookID`パラメータからOutgoingWebhookを取り出す
+func (pr *ParamRetriever) OutgoingWebhookID() echo.MiddlewareFunc {
+	return pr.byUUID(consts.ParamOutgoingWebhookID, consts.KeyParamOutgoingWebhook, func(c echo.Context, v uuid.UUID) (interface{}, error) {
+		return pr.repo.GetOutgoingWebhook(v)
+	})
+}
+
 // StampID リクエストURLの`stampID`パラメータからStampを取り出します
 func (pr *ParamRetriever) StampID(checkOnly bool) echo.MiddlewareFunc {
 	if checkOnly {
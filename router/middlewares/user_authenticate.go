@@ -72,9 +72,25 @@ func UserAuthenticate(repo repository.Repository, sessStore session.Store) echo.
 				return herror.Forbidden("this account is currently suspended")
 			}
 
+			// Botに設定された権限を取得
+			ctx := context.WithValue(c.Request().Context(), ctxKey.UserID, user.GetID()) // SSEストリーマーで使う
+			if user.IsBot() {
+				b, err := repo.GetBotByBotUserID(user.GetID())
+				if err != nil && err != repository.ErrNotFound {
+					return herror.InternalServerError(err)
+				}
+				if b != nil {
+					// 無効値の場合は制限なし
+					if b.Permissions.Valid {
+						c.Set(consts.KeyBotPermissions, b.Permissions.V)
+					}
+					ctx = context.WithValue(ctx, ctxKey.BotID, b.ID) // BOTストリーマーで使う
+				}
+			}
+
 			c.Set(consts.KeyUser, user)
 			c.Set(consts.KeyUserID, user.GetID())
-			c.SetRequest(c.Request().WithContext(context.WithValue(c.Request().Context(), ctxKey.UserID, user.GetID()))) // SSEストリーマーで使う
+			c.SetRequest(c.Request().WithContext(ctx))
 			return next(c)
 		}
 	}
@@ -43,6 +43,9 @@ func Setup(hub *hub.Hub, db *gorm.DB, repo repository.Repository, ss *service.Se
 	r.oauth2.Setup(api.Group("/oauth2"))
 	r.oauth2.Setup(api.Group("/v3/oauth2"))
 
+	// BOT WebSocketのJSONコマンドはAPIを経由して実行する
+	ss.BotWS.SetAPIHandler(r.e)
+
 	// 外部authハンドラ
 	extAuth := api.Group("/auth")
 	if config.ExternalAuth.GitHub.Valid() {
@@ -96,7 +99,7 @@ func newEcho(logger *zap.Logger, config *Config, repo repository.Repository, cm
 	e.Use(extension.Wrap(repo, cm))
 	e.Use(middlewares.RequestCounter())
 	e.Use(middleware.CORSWithConfig(middleware.CORSConfig{
-		ExposeHeaders: []string{consts.HeaderVersion, consts.HeaderCacheFile, consts.HeaderFileMetaType, consts.HeaderMore, echo.HeaderXRequestID},
+		ExposeHeaders: []string{consts.HeaderVersion, consts.HeaderCacheFile, consts.HeaderFileMetaType, consts.HeaderMore, consts.HeaderNextCursor, echo.HeaderXRequestID},
 		AllowHeaders:  []string{echo.HeaderContentType, echo.HeaderAuthorization, consts.HeaderSignature, consts.HeaderChannelID},
 		MaxAge:        3600,
 	}))
@@ -0,0 +1,80 @@
+package utils
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/traPtitech/traQ/model"
+)
+
+// thumbnailImageTypes Acceptヘッダーによるネゴシエーションの対象となる通常サムネイル画像のタイプ (優先度順)
+var thumbnailImageTypes = []model.ThumbnailType{
+	model.ThumbnailTypeImageAVIF,
+	model.ThumbnailTypeImageWebP,
+	model.ThumbnailTypeImage,
+}
+
+// negotiateThumbnailImage Acceptヘッダーacceptを元に、metaのファイルの通常サムネイル画像のうち返すもののタイプを選びます
+//
+// WebP・AVIFはAcceptヘッダーで明示された場合のみ選ばれます。
+// いずれも受け入れ可能でない場合は model.ThumbnailTypeImage を返します。
+func negotiateThumbnailImage(accept string, meta model.File) model.ThumbnailType {
+	if len(accept) == 0 {
+		return model.ThumbnailTypeImage
+	}
+
+	best, bestQ := model.ThumbnailTypeImage, 0.0
+	for _, t := range thumbnailImageTypes {
+		ok, thumb := meta.GetThumbnail(t)
+		if !ok {
+			continue
+		}
+		// image/* などでWebP・AVIFが選ばれると、対応していないクライアントが壊れる可能性がある
+		if q := acceptQuality(accept, thumb.Mime, t == model.ThumbnailTypeImage); q > bestQ {
+			best, bestQ = t, q
+		}
+	}
+	return best
+}
+
+// acceptQuality Acceptヘッダーacceptにおけるmimeの品質値(q)を返します
+//
+// wildcardがfalseの場合、image/* や */* にはマッチしません。
+// マッチするメディアレンジが複数ある場合は、最も詳細なものの品質値を返します。
+func acceptQuality(accept, mime string, wildcard bool) float64 {
+	q, specificity := 0.0, -1
+	for _, r := range strings.Split(accept, ",") {
+		params := strings.Split(r, ";")
+		mediaRange := strings.ToLower(strings.TrimSpace(params[0]))
+
+		var s int
+		switch {
+		case mediaRange == mime:
+			s = 2
+		case !wildcard:
+			continue
+		case mediaRange == "*/*":
+			s = 0
+		case strings.HasSuffix(mediaRange, "/*") && strings.HasPrefix(mime, strings.TrimSuffix(mediaRange, "*")):
+			s = 1
+		default:
+			continue
+		}
+		if s <= specificity {
+			continue
+		}
+
+		rq := 1.0
+		for _, p := range params[1:] {
+			k, v, ok := strings.Cut(strings.TrimSpace(p), "=")
+			if !ok || strings.TrimSpace(k) != "q" {
+				continue
+			}
+			if f, err := strconv.ParseFloat(strings.TrimSpace(v), 64); err == nil {
+				rq = f
+			}
+		}
+		q, specificity = rq, s
+	}
+	return q
+}
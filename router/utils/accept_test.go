@@ -0,0 +1,88 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/traPtitech/traQ/model"
+)
+
+type acceptTestFile struct {
+	model.File
+	thumbnails []model.FileThumbnail
+}
+
+func (f *acceptTestFile) GetThumbnail(thumbnailType model.ThumbnailType) (bool, model.FileThumbnail) {
+	for _, t := range f.thumbnails {
+		if t.Type == thumbnailType {
+			return true, t
+		}
+	}
+	return false, model.FileThumbnail{}
+}
+
+func TestAcceptQuality(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		accept   string
+		mime     string
+		wildcard bool
+		want     float64
+	}{
+		{"image/webp", "image/webp", false, 1},
+		{"image/webp;q=0.5", "image/webp", false, 0.5},
+		{"image/avif, image/webp ; q=0.8", "image/webp", false, 0.8},
+		{"image/*", "image/webp", false, 0},
+		{"image/*", "image/png", true, 1},
+		{"*/*;q=0.1", "image/png", true, 0.1},
+		{"image/png;q=0.3, image/*;q=0.9, */*", "image/png", true, 0.3},
+		{"text/html", "image/png", true, 0},
+		{"image/webp;q=invalid", "image/webp", false, 1},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, acceptQuality(tt.accept, tt.mime, tt.wildcard), tt.accept)
+	}
+}
+
+func TestNegotiateThumbnailImage(t *testing.T) {
+	t.Parallel()
+
+	all := &acceptTestFile{thumbnails: []model.FileThumbnail{
+		{Type: model.ThumbnailTypeImage, Mime: "image/png"},
+		{Type: model.ThumbnailTypeImageWebP, Mime: "image/webp"},
+		{Type: model.ThumbnailTypeImageAVIF, Mime: "image/avif"},
+	}}
+	pngOnly := &acceptTestFile{thumbnails: []model.FileThumbnail{
+		{Type: model.ThumbnailTypeImage, Mime: "image/png"},
+	}}
+	webp := &acceptTestFile{thumbnails: []model.FileThumbnail{
+		{Type: model.ThumbnailTypeImage, Mime: "image/png"},
+		{Type: model.ThumbnailTypeImageWebP, Mime: "image/webp"},
+	}}
+
+	const browser = "image/avif,image/webp,image/apng,image/svg+xml,image/*,*/*;q=0.8"
+	tests := []struct {
+		name   string
+		accept string
+		meta   model.File
+		want   model.ThumbnailType
+	}{
+		{"no accept", "", all, model.ThumbnailTypeImage},
+		{"any", "*/*", all, model.ThumbnailTypeImage},
+		{"browser", browser, all, model.ThumbnailTypeImageAVIF},
+		{"browser without avif variant", browser, webp, model.ThumbnailTypeImageWebP},
+		{"browser without variants", browser, pngOnly, model.ThumbnailTypeImage},
+		{"prefer png", "image/png, image/webp;q=0.5", all, model.ThumbnailTypeImage},
+		{"webp only", "image/webp", all, model.ThumbnailTypeImageWebP},
+		{"not acceptable", "text/html", all, model.ThumbnailTypeImage},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, negotiateThumbnailImage(tt.accept, tt.meta))
+		})
+	}
+}
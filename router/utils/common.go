@@ -42,6 +42,14 @@ func ServeUserIcon(c echo.Context, fm file.Manager, user model.UserInfo) error {
 		return herror.InternalServerError(err)
 	}
 
+	// 静止画のアイコンは、Acceptヘッダーに応じてWebP・AVIFのサムネイル画像を返す
+	c.Response().Header().Set(echo.HeaderVary, echo.HeaderAccept)
+	if !meta.IsAnimatedImage() {
+		if t := negotiateThumbnailImage(c.Request().Header.Get(echo.HeaderAccept), meta); t != model.ThumbnailTypeImage {
+			return serveUserIconThumbnail(c, meta, t)
+		}
+	}
+
 	// ファイルオープン
 	file, err := meta.Open()
 	if err != nil {
@@ -58,6 +66,26 @@ func ServeUserIcon(c echo.Context, fm file.Manager, user model.UserInfo) error {
 	return nil
 }
 
+// serveUserIconThumbnail アイコン画像ファイルmetaのthumbnailTypeのサムネイル画像をレスポンスとして返す
+func serveUserIconThumbnail(c echo.Context, meta model.File, thumbnailType model.ThumbnailType) error {
+	_, thumb := meta.GetThumbnail(thumbnailType)
+
+	// ファイルオープン
+	file, err := meta.OpenThumbnail(thumbnailType)
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+	defer file.Close()
+
+	// レスポンスヘッダ設定
+	c.Response().Header().Set(echo.HeaderContentType, thumb.Mime)
+	c.Response().Header().Set(consts.HeaderETag, strconv.Quote(meta.GetMD5Hash()+"-"+thumbnailType.String()))
+
+	// ファイル送信
+	http.ServeContent(c.Response(), c.Request(), meta.GetFileName(), meta.GetCreatedAt(), file)
+	return nil
+}
+
 // ChangeUserPassword userIDのユーザーのパスワードを変更する
 func ChangeUserPassword(c echo.Context, repo repository.Repository, seStore session.Store, userID uuid.UUID, newPassword string) error {
 	if err := repo.UpdateUser(userID, repository.UpdateUserArgs{Password: optional.From(newPassword)}); err != nil {
@@ -79,6 +107,11 @@ func ServeFileThumbnail(c echo.Context, meta model.File) error {
 	if err != nil {
 		return herror.BadRequest(err)
 	}
+	if thumbnailType == model.ThumbnailTypeImage {
+		// Acceptヘッダーに応じてWebP・AVIFのサムネイル画像を返す
+		c.Response().Header().Set(echo.HeaderVary, echo.HeaderAccept)
+		thumbnailType = negotiateThumbnailImage(c.Request().Header.Get(echo.HeaderAccept), meta)
+	}
 
 	hasThumb, thumb := meta.GetThumbnail(thumbnailType)
 	if !hasThumb {
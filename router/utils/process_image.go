@@ -2,11 +2,14 @@ package utils
 
 import (
 	"bytes"
+	"fmt"
 	"image/png"
 	"io"
 
 	"github.com/gofrs/uuid"
 	"github.com/labstack/echo/v4"
+	"github.com/sapphi-red/midec"
+	_ "github.com/sapphi-red/midec/webp" // midec.IsAnimated用
 
 	"github.com/traPtitech/traQ/model"
 	"github.com/traPtitech/traQ/router/consts"
@@ -56,12 +59,26 @@ func saveUploadImage(p imaging2.Processor, c echo.Context, m file.Manager, name
 		FileType: fType,
 	}
 
-	switch fh.Header.Get(echo.HeaderContentType) {
-	case consts.MimeImagePNG, consts.MimeImageJPEG:
+	switch mimeType := fh.Header.Get(echo.HeaderContentType); mimeType {
+	case consts.MimeImagePNG, consts.MimeImageJPEG, consts.MimeImageWebP, consts.MimeImageAVIF:
+		if mimeType == consts.MimeImageWebP {
+			// アニメーションWebPはWebPのままリサイズ
+			animated, _ := midec.IsAnimated(src)
+			if _, err := src.Seek(0, io.SeekStart); err != nil {
+				return uuid.Nil, herror.InternalServerError(err)
+			}
+			if animated {
+				if err := fitAnimationImage(p, src, imaging2.FormatWebP, maxImageSize, &args); err != nil {
+					return uuid.Nil, err
+				}
+				break
+			}
+		}
+
 		img, err := p.Fit(src, maxImageSize, maxImageSize)
 		if err != nil {
 			switch err {
-			case imaging2.ErrInvalidImageSrc:
+			case imaging2.ErrInvalidImageSrc, imaging2.ErrTimeout:
 				return uuid.Nil, herror.BadRequest(badImage)
 			case imaging2.ErrPixelLimitExceeded:
 				return uuid.Nil, herror.BadRequest(tooLargeImage)
@@ -82,31 +99,9 @@ func saveUploadImage(p imaging2.Processor, c echo.Context, m file.Manager, name
 		args.Thumbnail = img // サムネイル画像より小さいという前提
 
 	case consts.MimeImageGIF:
-		// リサイズ
-		b, err := p.FitAnimationGIF(src, maxImageSize, maxImageSize)
-		if err != nil {
-			switch err {
-			case imaging.ErrImageMagickUnavailable:
-				// gifは一時的にサポートされていない
-				return uuid.Nil, herror.BadRequest("gif file is temporarily unsupported")
-			case imaging2.ErrInvalidImageSrc, imaging2.ErrTimeout:
-				// 不正なgifである
-				return uuid.Nil, herror.BadRequest(badImage)
-			default:
-				// 予期しないエラー
-				return uuid.Nil, herror.InternalServerError(err)
-			}
-		}
-
-		args.Src = b
-		args.FileSize = b.Size()
-		args.MimeType = consts.MimeImageGIF
-
-		args.Thumbnail, err = p.Thumbnail(b)
-		if err != nil {
-			return uuid.Nil, herror.InternalServerError(err)
+		if err := fitAnimationImage(p, src, imaging2.FormatGIF, maxImageSize, &args); err != nil {
+			return uuid.Nil, err
 		}
-		_, _ = b.Seek(0, io.SeekStart)
 
 	default:
 		return uuid.Nil, herror.BadRequest(badImage)
@@ -120,3 +115,35 @@ func saveUploadImage(p imaging2.Processor, c echo.Context, m file.Manager, name
 
 	return f.GetID(), nil
 }
+
+// fitAnimationImage アニメーション画像srcをリサイズしてformatで出力し、argsに設定します
+func fitAnimationImage(p imaging2.Processor, src io.Reader, format imaging2.Format, maxImageSize int, args *file.SaveArgs) error {
+	const badImage = "bad image"
+
+	// リサイズ
+	b, err := p.FitAnimation(src, maxImageSize, maxImageSize, format)
+	if err != nil {
+		switch err {
+		case imaging.ErrImageMagickUnavailable:
+			// アニメーション画像は一時的にサポートされていない
+			return herror.BadRequest(fmt.Sprintf("%s file is temporarily unsupported", format))
+		case imaging2.ErrInvalidImageSrc, imaging2.ErrTimeout:
+			// 不正な画像である
+			return herror.BadRequest(badImage)
+		default:
+			// 予期しないエラー
+			return herror.InternalServerError(err)
+		}
+	}
+
+	args.Src = b
+	args.FileSize = b.Size()
+	args.MimeType = format.Mime()
+
+	args.Thumbnail, err = p.Thumbnail(b)
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+	_, _ = b.Seek(0, io.SeekStart)
+	return nil
+}
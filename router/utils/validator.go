@@ -13,6 +13,7 @@ import (
 	"github.com/traPtitech/traQ/router/consts"
 	"github.com/traPtitech/traQ/service/bot/event"
 	"github.com/traPtitech/traQ/service/channel"
+	"github.com/traPtitech/traQ/service/rbac/permission"
 	"github.com/traPtitech/traQ/utils/optional"
 )
 
@@ -200,3 +201,24 @@ var IsValidBotEvents = vd.By(func(value interface{}) error {
 	}
 	return nil
 })
+
+// IsValidBotPermissions 有効な権限名のセットである
+var IsValidBotPermissions = vd.By(func(value interface{}) error {
+	var s model.BotPermissions
+	switch v := value.(type) {
+	case model.BotPermissions:
+		s = v
+	case optional.Of[model.BotPermissions]:
+		s = v.V
+	}
+	if s == nil {
+		return nil
+	}
+	valid := permission.PermissionsFromArray(permission.List)
+	for v := range s {
+		if !valid.Contains(permission.Permission(v)) {
+			return errors.New("must be valid permission")
+		}
+	}
+	return nil
+})
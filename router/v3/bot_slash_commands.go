@@ -0,0 +1,231 @@
+package v3
+
+import (
+	"errors"
+	"net/http"
+
+	vd "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/leandro-lugaresi/hub"
+
+	"github.com/traPtitech/traQ/event"
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/router/extension/herror"
+)
+
+// BotSlashCommandOptionRequest スラッシュコマンドの引数定義
+type BotSlashCommandOptionRequest struct {
+	Name        string                          `json:"name"`
+	Description string                          `json:"description"`
+	Type        model.BotSlashCommandOptionType `json:"type"`
+	Required    bool                            `json:"required"`
+}
+
+func (r BotSlashCommandOptionRequest) Validate() error {
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.Name, vd.Required, vd.Match(model.BotSlashCommandNameRegex)),
+		vd.Field(&r.Description, vd.RuneLength(0, 100)),
+		vd.Field(&r.Type, vd.Required, vd.By(func(value interface{}) error {
+			if !value.(model.BotSlashCommandOptionType).Valid() {
+				return errors.New("invalid option type")
+			}
+			return nil
+		})),
+	)
+}
+
+// BotSlashCommandRequest スラッシュコマンド定義
+type BotSlashCommandRequest struct {
+	Name        string                          `json:"name"`
+	Description string                          `json:"description"`
+	Options     []*BotSlashCommandOptionRequest `json:"options"`
+}
+
+func (r BotSlashCommandRequest) Validate() error {
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.Name, vd.Required, vd.Match(model.BotSlashCommandNameRegex)),
+		vd.Field(&r.Description, vd.RuneLength(0, 100)),
+		vd.Field(&r.Options, vd.Length(0, 10), vd.By(func(value interface{}) error {
+			names := make(map[string]bool, len(r.Options))
+			optional := false
+			for _, opt := range r.Options {
+				if opt == nil {
+					return errors.New("option must not be null")
+				}
+				if names[opt.Name] {
+					return errors.New("option names must be unique")
+				}
+				names[opt.Name] = true
+				if opt.Required && optional {
+					return errors.New("required options must come before optional options")
+				}
+				optional = optional || !opt.Required
+			}
+			return nil
+		})),
+	)
+}
+
+// PutBotSlashCommandsRequest PUT /bots/:botID/commands リクエストボディ
+type PutBotSlashCommandsRequest struct {
+	Commands []*BotSlashCommandRequest `json:"commands"`
+}
+
+func (r PutBotSlashCommandsRequest) Validate() error {
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.Commands, vd.Length(0, 50), vd.By(func(value interface{}) error {
+			names := make(map[string]bool, len(r.Commands))
+			for _, cmd := range r.Commands {
+				if cmd == nil {
+					return errors.New("command must not be null")
+				}
+				if names[cmd.Name] {
+					return errors.New("command names must be unique")
+				}
+				names[cmd.Name] = true
+			}
+			return nil
+		})),
+	)
+}
+
+// GetBotSlashCommands GET /bots/:botID/commands
+func (h *Handlers) GetBotSlashCommands(c echo.Context) error {
+	b := getParamBot(c)
+
+	commands, err := h.Repo.GetBotSlashCommands(b.ID)
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+
+	return c.JSON(http.StatusOK, formatBotSlashCommands(commands))
+}
+
+// SetBotSlashCommands PUT /bots/:botID/commands
+func (h *Handlers) SetBotSlashCommands(c echo.Context) error {
+	b := getParamBot(c)
+
+	var req PutBotSlashCommandsRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	commands := make([]*model.BotSlashCommand, len(req.Commands))
+	for i, cmd := range req.Commands {
+		options := make(model.BotSlashCommandOptions, len(cmd.Options))
+		for j, opt := range cmd.Options {
+			options[j] = &model.BotSlashCommandOption{
+				Name:        opt.Name,
+				Description: opt.Description,
+				Type:        opt.Type,
+				Required:    opt.Required,
+			}
+		}
+		commands[i] = &model.BotSlashCommand{
+			Name:        cmd.Name,
+			Description: cmd.Description,
+			Options:     options,
+		}
+	}
+
+	if err := h.Repo.SetBotSlashCommands(b.ID, commands); err != nil {
+		return herror.InternalServerError(err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// GetChannelSlashCommands GET /channels/:channelID/commands
+func (h *Handlers) GetChannelSlashCommands(c echo.Context) error {
+	ch := getParamChannel(c)
+
+	commands, err := h.getAvailableSlashCommands(ch)
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+
+	return c.JSON(http.StatusOK, formatBotSlashCommands(commands))
+}
+
+// getAvailableSlashCommands 指定したチャンネルで使用可能なスラッシュコマンドを取得します
+//
+// DMチャンネルの場合は、相手のBotのスラッシュコマンドが使用可能です。
+func (h *Handlers) getAvailableSlashCommands(ch *model.Channel) ([]*model.BotSlashCommand, error) {
+	if !ch.IsDMChannel() {
+		return h.Repo.GetChannelSlashCommands(ch.ID)
+	}
+
+	members, err := h.ChannelManager.GetDMChannelMembers(ch.ID)
+	if err != nil {
+		return nil, err
+	}
+	commands := make([]*model.BotSlashCommand, 0)
+	for _, member := range members {
+		bots, err := h.Repo.GetBots(repository.BotsQuery{}.Active().BotUserID(member))
+		if err != nil {
+			return nil, err
+		}
+		for _, b := range bots {
+			cmds, err := h.Repo.GetBotSlashCommands(b.ID)
+			if err != nil {
+				return nil, err
+			}
+			commands = append(commands, cmds...)
+		}
+	}
+	return commands, nil
+}
+
+// dispatchSlashCommand 投稿内容がスラッシュコマンドの場合、メッセージを作成せずにコマンドを所有するBotに送信します
+//
+// スラッシュコマンドとして処理した場合はtrueを返します。
+func (h *Handlers) dispatchSlashCommand(ch *model.Channel, userID uuid.UUID, content string) (bool, error) {
+	name, args, ok := model.ParseSlashCommand(content)
+	if !ok {
+		return false, nil
+	}
+
+	commands, err := h.getAvailableSlashCommands(ch)
+	if err != nil {
+		return false, herror.InternalServerError(err)
+	}
+	var matched []*model.BotSlashCommand
+	for _, cmd := range commands {
+		if cmd.Name == name {
+			matched = append(matched, cmd)
+		}
+	}
+	switch len(matched) {
+	case 0:
+		// 該当するコマンドが無い場合は通常のメッセージとして投稿
+		return false, nil
+	case 1:
+	default:
+		return true, herror.BadRequest("ambiguous slash command: multiple bots in this channel provide /" + name)
+	}
+	cmd := matched[0]
+
+	if ch.IsArchived() {
+		return true, herror.BadRequest("this channel has been archived")
+	}
+
+	options, err := cmd.ParseArguments(args)
+	if err != nil {
+		return true, herror.BadRequest(err.Error())
+	}
+
+	h.Hub.Publish(hub.Message{
+		Name: event.BotSlashCommandInvoked,
+		Fields: hub.Fields{
+			"bot_id":     cmd.BotID,
+			"command":    cmd,
+			"arguments":  args,
+			"options":    options,
+			"user_id":    userID,
+			"channel_id": ch.ID,
+		},
+	})
+	return true, nil
+}
@@ -0,0 +1,184 @@
+package v3
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/router/session"
+	"github.com/traPtitech/traQ/service/message"
+)
+
+func TestHandlers_SetBotSlashCommands(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/bots/{botId}/commands"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	user2 := env.CreateUser(t, rand)
+	bot := env.CreateBot(t, rand, user.GetID())
+	s := env.S(t, user.GetID())
+	s2 := env.S(t, user2.GetID())
+
+	req := &PutBotSlashCommandsRequest{
+		Commands: []*BotSlashCommandRequest{
+			{
+				Name:        "roll",
+				Description: "roll dice",
+				Options: []*BotSlashCommandOptionRequest{
+					{Name: "count", Type: model.BotSlashCommandOptionTypeInteger, Required: true},
+				},
+			},
+		},
+	}
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path, bot.ID).
+			WithJSON(req).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path, bot.ID).
+			WithCookie(session.CookieName, s2).
+			WithJSON(req).
+			Expect().
+			Status(http.StatusForbidden)
+	})
+
+	t.Run("bad request (duplicated name)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path, bot.ID).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PutBotSlashCommandsRequest{Commands: []*BotSlashCommandRequest{{Name: "a"}, {Name: "a"}}}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path, bot.ID).
+			WithCookie(session.CookieName, s).
+			WithJSON(req).
+			Expect().
+			Status(http.StatusNoContent)
+
+		obj := e.GET(path, bot.ID).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Array()
+
+		obj.Length().Equal(1)
+		first := obj.First().Object()
+		first.Value("botId").String().Equal(bot.ID.String())
+		first.Value("name").String().Equal("roll")
+		first.Value("options").Array().Length().Equal(1)
+	})
+}
+
+func TestHandlers_GetChannelSlashCommands(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/channels/{channelId}/commands"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	ch := env.CreateChannel(t, rand)
+	bot := env.CreateBot(t, rand, user.GetID())
+	require.NoError(t, env.Repository.ChangeBotState(bot.ID, model.BotActive))
+	require.NoError(t, env.Repository.AddBotToChannel(bot.ID, ch.ID))
+	require.NoError(t, env.Repository.SetBotSlashCommands(bot.ID, []*model.BotSlashCommand{{Name: "deploy"}}))
+	s := env.S(t, user.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, ch.ID).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.GET(path, ch.ID).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Array()
+
+		obj.Length().Equal(1)
+		first := obj.First().Object()
+		first.Value("botId").String().Equal(bot.ID.String())
+		first.Value("name").String().Equal("deploy")
+	})
+}
+
+func TestHandlers_PostMessage_SlashCommand(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/channels/{channelId}/messages"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	ch := env.CreateChannel(t, rand)
+	bot := env.CreateBot(t, rand, user.GetID())
+	require.NoError(t, env.Repository.ChangeBotState(bot.ID, model.BotActive))
+	require.NoError(t, env.Repository.AddBotToChannel(bot.ID, ch.ID))
+	require.NoError(t, env.Repository.SetBotSlashCommands(bot.ID, []*model.BotSlashCommand{
+		{
+			Name: "roll",
+			Options: model.BotSlashCommandOptions{
+				{Name: "count", Type: model.BotSlashCommandOptionTypeInteger, Required: true},
+			},
+		},
+	}))
+	s := env.S(t, user.GetID())
+
+	t.Run("bad request (invalid argument)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, ch.ID).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostMessageRequest{Content: "/roll three"}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("unknown command is posted as message", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, ch.ID).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostMessageRequest{Content: "/unknown"}).
+			Expect().
+			Status(http.StatusCreated)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, ch.ID).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostMessageRequest{Content: "/roll 3"}).
+			Expect().
+			Status(http.StatusAccepted)
+
+		timeline, err := env.MM.GetTimeline(message.TimelineQuery{Channel: ch.ID})
+		require.NoError(t, err)
+		for _, m := range timeline.Records() {
+			assert.NotEqual(t, "/roll 3", m.GetText())
+		}
+	})
+}
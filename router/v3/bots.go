@@ -2,7 +2,10 @@ package v3
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"sort"
+	"time"
 
 	vd "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/go-ozzo/ozzo-validation/v4/is"
@@ -40,11 +43,12 @@ func (h *Handlers) GetBots(c echo.Context) error {
 
 // PostBotRequest POST /bots リクエストボディ
 type PostBotRequest struct {
-	Name        string `json:"name"`
-	DisplayName string `json:"displayName"`
-	Description string `json:"description"`
-	Mode        string `json:"mode"`
-	Endpoint    string `json:"endpoint"`
+	Name        string                            `json:"name"`
+	DisplayName string                            `json:"displayName"`
+	Description string                            `json:"description"`
+	Mode        string                            `json:"mode"`
+	Endpoint    string                            `json:"endpoint"`
+	Permissions optional.Of[model.BotPermissions] `json:"permissions"`
 }
 
 func (r PostBotRequest) Validate() error {
@@ -60,6 +64,7 @@ func (r PostBotRequest) Validate() error {
 		vd.Field(&r.Description, vd.Required, vd.RuneLength(0, 1000)),
 		vd.Field(&r.Mode, vd.Required, vd.In(model.BotModeHTTP.String(), model.BotModeWebSocket.String())),
 		vd.Field(&r.Endpoint, endpointRules...),
+		vd.Field(&r.Permissions, utils.IsValidBotPermissions),
 	)
 }
 
@@ -69,6 +74,9 @@ func (h *Handlers) CreateBot(c echo.Context) error {
 	if err := bindAndValidate(c, &req); err != nil {
 		return err
 	}
+	if err := h.checkBotPermissions(req.Permissions.V); err != nil {
+		return err
+	}
 
 	iconFileID, err := file.GenerateIconFile(h.FileManager, req.Name)
 	if err != nil {
@@ -89,7 +97,7 @@ func (h *Handlers) CreateBot(c echo.Context) error {
 		initialState = model.BotActive
 	}
 
-	b, err := h.Repo.CreateBot(req.Name, req.DisplayName, req.Description, iconFileID, getRequestUserID(c), model.BotMode(req.Mode), initialState, req.Endpoint)
+	b, err := h.Repo.CreateBot(req.Name, req.DisplayName, req.Description, iconFileID, getRequestUserID(c), model.BotMode(req.Mode), initialState, req.Endpoint, req.Permissions)
 	if err != nil {
 		return herror.InternalServerError(err)
 	}
@@ -99,7 +107,7 @@ func (h *Handlers) CreateBot(c echo.Context) error {
 		return herror.InternalServerError(err)
 	}
 
-	return c.JSON(http.StatusCreated, formatBotDetail(b, t, make([]uuid.UUID, 0)))
+	return c.JSON(http.StatusCreated, formatBotDetail(b, t, make([]uuid.UUID, 0), h.getBotEffectivePermissions(b)))
 }
 
 // GetBot GET /bots/:botID
@@ -129,7 +137,7 @@ func (h *Handlers) GetBot(c echo.Context) error {
 			return herror.InternalServerError(err)
 		}
 
-		return c.JSON(http.StatusOK, formatBotDetail(b, t, ids))
+		return c.JSON(http.StatusOK, formatBotDetail(b, t, ids, h.getBotEffectivePermissions(b)))
 	}
 
 	return c.JSON(http.StatusOK, formatBot(b))
@@ -137,13 +145,15 @@ func (h *Handlers) GetBot(c echo.Context) error {
 
 // PatchBotRequest PATCH /bots/:botID リクエストボディ
 type PatchBotRequest struct {
-	DisplayName     optional.Of[string]    `json:"displayName"`
-	Description     optional.Of[string]    `json:"description"`
-	Mode            optional.Of[string]    `json:"mode"`
-	Endpoint        optional.Of[string]    `json:"endpoint"`
-	Privileged      optional.Of[bool]      `json:"privileged"`
-	DeveloperID     optional.Of[uuid.UUID] `json:"developerId"`
-	SubscribeEvents model.BotEventTypes    `json:"subscribeEvents"`
+	DisplayName           optional.Of[string]               `json:"displayName"`
+	Description           optional.Of[string]               `json:"description"`
+	Mode                  optional.Of[string]               `json:"mode"`
+	Endpoint              optional.Of[string]               `json:"endpoint"`
+	Privileged            optional.Of[bool]                 `json:"privileged"`
+	DeveloperID           optional.Of[uuid.UUID]            `json:"developerId"`
+	SubscribeEvents       model.BotEventTypes               `json:"subscribeEvents"`
+	Permissions           optional.Of[model.BotPermissions] `json:"permissions"`
+	UnrestrictPermissions bool                              `json:"unrestrictPermissions"`
 }
 
 func (r PatchBotRequest) ValidateWithContext(ctx context.Context) error {
@@ -154,6 +164,7 @@ func (r PatchBotRequest) ValidateWithContext(ctx context.Context) error {
 		vd.Field(&r.Endpoint, is.URL, validator.NotInternalURL),
 		vd.Field(&r.DeveloperID, validator.NotNilUUID, utils.IsActiveHumanUserID),
 		vd.Field(&r.SubscribeEvents, utils.IsValidBotEvents),
+		vd.Field(&r.Permissions, utils.IsValidBotPermissions),
 	)
 }
 
@@ -169,6 +180,12 @@ func (h *Handlers) EditBot(c echo.Context) error {
 	if req.Privileged.Valid && getRequestUser(c).GetRole() != role.Admin {
 		return herror.Forbidden("you are not permitted to set privileged flag to bots")
 	}
+	if req.UnrestrictPermissions && req.Permissions.Valid {
+		return herror.BadRequest("permissions and unrestrictPermissions cannot be specified at the same time")
+	}
+	if err := h.checkBotPermissions(req.Permissions.V); err != nil {
+		return err
+	}
 
 	willBeHTTPMode := req.Mode.ValueOrZero() == model.BotModeHTTP.String() || !req.Mode.Valid && b.Mode == model.BotModeHTTP
 	willHaveNoEndpoint := b.PostURL == "" && !req.Endpoint.Valid || req.Endpoint.Valid && req.Endpoint.V == ""
@@ -177,13 +194,15 @@ func (h *Handlers) EditBot(c echo.Context) error {
 	}
 
 	args := repository.UpdateBotArgs{
-		DisplayName:     req.DisplayName,
-		Description:     req.Description,
-		Mode:            req.Mode,
-		WebhookURL:      req.Endpoint,
-		Privileged:      req.Privileged,
-		CreatorID:       req.DeveloperID,
-		SubscribeEvents: req.SubscribeEvents,
+		DisplayName:           req.DisplayName,
+		Description:           req.Description,
+		Mode:                  req.Mode,
+		WebhookURL:            req.Endpoint,
+		Privileged:            req.Privileged,
+		CreatorID:             req.DeveloperID,
+		SubscribeEvents:       req.SubscribeEvents,
+		Permissions:           req.Permissions,
+		UnrestrictPermissions: req.UnrestrictPermissions,
 	}
 
 	if err := h.Repo.UpdateBot(b.ID, args); err != nil {
@@ -193,6 +212,29 @@ func (h *Handlers) EditBot(c echo.Context) error {
 	return c.NoContent(http.StatusNoContent)
 }
 
+// checkBotPermissions 指定した権限が全てBotロールに与えられているかどうかを確認します
+func (h *Handlers) checkBotPermissions(perms model.BotPermissions) error {
+	granted := permission.PermissionsFromArray(h.RBAC.GetGrantedPermissions(role.Bot))
+	for p := range perms {
+		if !granted.Contains(permission.Permission(p)) {
+			return herror.BadRequest(fmt.Sprintf("permission '%s' cannot be granted to bots", p))
+		}
+	}
+	return nil
+}
+
+// getBotEffectivePermissions Botに実際に与えられている権限を取得します
+func (h *Handlers) getBotEffectivePermissions(b *model.Bot) []permission.Permission {
+	perms := make([]permission.Permission, 0)
+	for _, p := range h.RBAC.GetGrantedPermissions(role.Bot) {
+		if b.IsPermitted(p.Name()) {
+			perms = append(perms, p)
+		}
+	}
+	sort.Slice(perms, func(i, j int) bool { return perms[i] < perms[j] })
+	return perms
+}
+
 // DeleteBot DELETE /bots/:botID
 func (h *Handlers) DeleteBot(c echo.Context) error {
 	b := getParamBot(c)
@@ -224,8 +266,9 @@ func (h *Handlers) ChangeBotIcon(c echo.Context) error {
 
 // GetBotLogsRequest GET /bots/:botID/logs リクエストクエリ
 type GetBotLogsRequest struct {
-	Limit  int `query:"limit"`
-	Offset int `query:"offset"`
+	Limit      int  `query:"limit"`
+	Offset     int  `query:"offset"`
+	DeadLetter bool `query:"deadLetter"`
 }
 
 func (r *GetBotLogsRequest) Validate() error {
@@ -247,6 +290,14 @@ func (h *Handlers) GetBotLogs(c echo.Context) error {
 		return err
 	}
 
+	if req.DeadLetter {
+		deliveries, err := h.Repo.GetDeadBotEventDeliveries(b.ID, req.Limit, req.Offset)
+		if err != nil {
+			return herror.InternalServerError(err)
+		}
+		return c.JSON(http.StatusOK, formatBotEventDeliveries(deliveries))
+	}
+
 	logs, err := h.Repo.GetBotEventLogs(b.ID, req.Limit, req.Offset)
 	if err != nil {
 		return herror.InternalServerError(err)
@@ -255,6 +306,62 @@ func (h *Handlers) GetBotLogs(c echo.Context) error {
 	return c.JSON(http.StatusOK, formatBotEventLogs(logs))
 }
 
+// PostBotActionRedeliverRequest POST /bots/:botID/actions/redeliver リクエストボディ
+type PostBotActionRedeliverRequest struct {
+	DeliveryIDs []uuid.UUID `json:"deliveryIds"`
+}
+
+func (r PostBotActionRedeliverRequest) Validate() error {
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.DeliveryIDs, vd.Required, vd.Length(1, 100), vd.Each(validator.NotNilUUID)),
+	)
+}
+
+// RedeliverBotEvents POST /bots/:botID/actions/redeliver
+func (h *Handlers) RedeliverBotEvents(c echo.Context) error {
+	b := getParamBot(c)
+
+	var req PostBotActionRedeliverRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	if b.Mode != model.BotModeHTTP || b.State != model.BotActive {
+		return herror.BadRequest("this bot is not active")
+	}
+
+	deliveries := make([]*model.BotEventDelivery, 0, len(req.DeliveryIDs))
+	for _, id := range req.DeliveryIDs {
+		d, err := h.Repo.GetBotEventDelivery(id)
+		if err != nil {
+			switch err {
+			case repository.ErrNotFound:
+				return herror.BadRequest(fmt.Sprintf("delivery '%s' is not found", id))
+			default:
+				return herror.InternalServerError(err)
+			}
+		}
+		if d.BotID != b.ID || !d.Dead {
+			return herror.BadRequest(fmt.Sprintf("delivery '%s' is not in the dead letter queue", id))
+		}
+		deliveries = append(deliveries, d)
+	}
+
+	// 再試行キューに戻す
+	now := time.Now()
+	for _, d := range deliveries {
+		err := h.Repo.UpdateBotEventDelivery(d.ID, repository.UpdateBotEventDeliveryArgs{
+			Attempts:      optional.From(0),
+			Dead:          optional.From(false),
+			NextAttemptAt: optional.From(now),
+		})
+		if err != nil {
+			return herror.InternalServerError(err)
+		}
+	}
+	return c.NoContent(http.StatusAccepted)
+}
+
 // GetChannelBots GET /channels/:channelID/bots
 func (h *Handlers) GetChannelBots(c echo.Context) error {
 	channelID := getParamAsUUID(c, consts.ParamChannelID)
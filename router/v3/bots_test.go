@@ -8,11 +8,14 @@ import (
 
 	"github.com/gavv/httpexpect/v2"
 	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
 	"github.com/traPtitech/traQ/router/session"
 	"github.com/traPtitech/traQ/service/bot/event"
+	"github.com/traPtitech/traQ/service/rbac/permission"
 	"github.com/traPtitech/traQ/utils/optional"
 	"github.com/traPtitech/traQ/utils/random"
 )
@@ -88,6 +91,7 @@ func TestPostBotRequest_Validate(t *testing.T) {
 		Description string
 		Mode        string
 		Endpoint    string
+		Permissions optional.Of[model.BotPermissions]
 	}
 	tests := []struct {
 		name    string
@@ -154,11 +158,21 @@ func TestPostBotRequest_Validate(t *testing.T) {
 			fields{Name: "name", DisplayName: "po", Description: "desc", Mode: "HTTP", Endpoint: "https://0.0.0.0:3000"},
 			true,
 		},
+		{
+			"bad permissions",
+			fields{Name: "name", DisplayName: "po", Description: "desc", Mode: "HTTP", Endpoint: "https://example.com", Permissions: optional.From(model.BotPermissionsFromArray([]string{"non_existent_permission"}))},
+			true,
+		},
 		{
 			"success",
 			fields{Name: "name", DisplayName: "po", Description: "desc", Mode: "HTTP", Endpoint: "https://example.com"},
 			false,
 		},
+		{
+			"success with permissions",
+			fields{Name: "name", DisplayName: "po", Description: "desc", Mode: "HTTP", Endpoint: "https://example.com", Permissions: optional.From(model.BotPermissionsFromArray([]string{"get_channel", "post_message"}))},
+			false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -168,6 +182,7 @@ func TestPostBotRequest_Validate(t *testing.T) {
 				Description: tt.fields.Description,
 				Mode:        tt.fields.Mode,
 				Endpoint:    tt.fields.Endpoint,
+				Permissions: tt.fields.Permissions,
 			}
 			if err := r.Validate(); (err != nil) != tt.wantErr {
 				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
@@ -348,6 +363,7 @@ func TestHandlers_GetBot(t *testing.T) {
 		obj.Value("endpoint").String().Equal("https://example.com")
 		obj.Value("privileged").Boolean().False()
 		obj.Value("channels").Array().Length().Equal(0)
+		obj.Value("permissions").Array().NotEmpty()
 	})
 }
 
@@ -361,9 +377,9 @@ func TestHandlers_EditBot(t *testing.T) {
 	bot1 := env.CreateBot(t, rand, user1.GetID())
 	bot2 := env.CreateBot(t, rand, user1.GetID())
 	bot3 := env.CreateBot(t, rand, user2.GetID())
-	wsBotWithEndpoint, err := env.Repository.CreateBot(random.AlphaNumeric(16), "po", "po", uuid.Nil, user1.GetID(), model.BotModeWebSocket, model.BotActive, "https://example.com")
+	wsBotWithEndpoint, err := env.Repository.CreateBot(random.AlphaNumeric(16), "po", "po", uuid.Nil, user1.GetID(), model.BotModeWebSocket, model.BotActive, "https://example.com", optional.Of[model.BotPermissions]{})
 	require.NoError(t, err)
-	wsBotWithoutEndpoint, err := env.Repository.CreateBot(random.AlphaNumeric(16), "po", "po", uuid.Nil, user1.GetID(), model.BotModeWebSocket, model.BotActive, "")
+	wsBotWithoutEndpoint, err := env.Repository.CreateBot(random.AlphaNumeric(16), "po", "po", uuid.Nil, user1.GetID(), model.BotModeWebSocket, model.BotActive, "", optional.Of[model.BotPermissions]{})
 	require.NoError(t, err)
 
 	t.Run("not logged in", func(t *testing.T) {
@@ -466,6 +482,36 @@ func TestHandlers_EditBot(t *testing.T) {
 			Status(http.StatusBadRequest)
 	})
 
+	t.Run("bad request (permissions, non existent permission)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PATCH(path, bot1.ID.String()).
+			WithCookie(session.CookieName, commonSession).
+			WithJSON(&PatchBotRequest{Permissions: optional.From(model.BotPermissionsFromArray([]string{"non_existent_permission"}))}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("bad request (permissions, not granted to bot role)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PATCH(path, bot1.ID.String()).
+			WithCookie(session.CookieName, commonSession).
+			WithJSON(&PatchBotRequest{Permissions: optional.From(model.BotPermissionsFromArray([]string{permission.AccessOthersBot.Name()}))}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("bad request (permissions and unrestrictPermissions)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PATCH(path, bot1.ID.String()).
+			WithCookie(session.CookieName, commonSession).
+			WithJSON(&PatchBotRequest{Permissions: optional.From(model.BotPermissions{}), UnrestrictPermissions: true}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
 	t.Run("bad request (change mode to HTTP and endpoint not set)", func(t *testing.T) {
 		t.Parallel()
 		e := env.R(t)
@@ -532,9 +578,71 @@ func TestHandlers_EditBot(t *testing.T) {
 				SubscribeEvents: map[model.BotEventType]struct{}{
 					event.Ping: {},
 				},
+				Permissions: optional.From(model.BotPermissionsFromArray([]string{permission.GetChannel.Name()})),
 			}).
 			Expect().
 			Status(http.StatusNoContent)
+
+		b, err := env.Repository.GetBotByID(bot2.ID)
+		require.NoError(t, err)
+		assert.EqualValues(t, optional.From(model.BotPermissionsFromArray([]string{permission.GetChannel.Name()})), b.Permissions)
+	})
+
+	t.Run("success (unrestrict permissions)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PATCH(path, bot1.ID.String()).
+			WithCookie(session.CookieName, commonSession).
+			WithJSON(&PatchBotRequest{UnrestrictPermissions: true}).
+			Expect().
+			Status(http.StatusNoContent)
+
+		b, err := env.Repository.GetBotByID(bot1.ID)
+		require.NoError(t, err)
+		assert.False(t, b.Permissions.Valid)
+	})
+}
+
+func TestHandlers_BotPermissions(t *testing.T) {
+	t.Parallel()
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	bot := env.CreateBot(t, rand, user.GetID())
+	require.NoError(t, env.Repository.UpdateBot(bot.ID, repository.UpdateBotArgs{
+		Permissions: optional.From(model.BotPermissionsFromArray([]string{permission.GetMe.Name()})),
+	}))
+	s := env.S(t, bot.BotUserID)
+	noPermBot := env.CreateBot(t, rand, user.GetID())
+	require.NoError(t, env.Repository.UpdateBot(noPermBot.ID, repository.UpdateBotArgs{
+		Permissions: optional.From(model.BotPermissions{}),
+	}))
+	noPermSession := env.S(t, noPermBot.BotUserID)
+
+	t.Run("permitted", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET("/api/v3/users/me").
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusOK)
+	})
+
+	t.Run("not permitted", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET("/api/v3/channels").
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusForbidden)
+	})
+
+	t.Run("not permitted (empty permissions)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET("/api/v3/users/me").
+			WithCookie(session.CookieName, noPermSession).
+			Expect().
+			Status(http.StatusForbidden)
 	})
 }
 
@@ -689,6 +797,17 @@ func TestHandlers_GetBotLogs(t *testing.T) {
 		DateTime:  time.Now(),
 	}
 	require.NoError(t, env.Repository.WriteBotEventLog(log))
+	delivery := &model.BotEventDelivery{
+		ID:            uuid.Must(uuid.NewV4()),
+		BotID:         bot1.ID,
+		Event:         event.Ping,
+		Body:          "{}",
+		Attempts:      5,
+		LastError:     "unexpected status code: 500",
+		Dead:          true,
+		NextAttemptAt: time.Now(),
+	}
+	require.NoError(t, env.Repository.CreateBotEventDelivery(delivery))
 
 	t.Run("not logged in", func(t *testing.T) {
 		t.Parallel()
@@ -749,6 +868,132 @@ func TestHandlers_GetBotLogs(t *testing.T) {
 		first.Value("code").Number().Equal(log.Code)
 		first.Value("datetime").String().NotEmpty()
 	})
+
+	t.Run("success (dead letter)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.GET(path, bot1.ID.String()).
+			WithCookie(session.CookieName, commonSession).
+			WithQuery("deadLetter", true).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Array()
+
+		obj.Length().Equal(1)
+
+		first := obj.First().Object()
+		first.Value("id").String().Equal(delivery.ID.String())
+		first.Value("botId").String().Equal(bot1.ID.String())
+		first.Value("event").String().Equal(delivery.Event.String())
+		first.Value("attempts").Number().Equal(delivery.Attempts)
+		first.Value("lastError").String().Equal(delivery.LastError)
+	})
+}
+
+func TestHandlers_RedeliverBotEvents(t *testing.T) {
+	t.Parallel()
+	path := "/api/v3/bots/{botId}/actions/redeliver"
+	env := Setup(t, common1)
+	user1 := env.CreateUser(t, rand)
+	user2 := env.CreateUser(t, rand)
+	commonSession := env.S(t, user1.GetID())
+	bot1 := env.CreateBot(t, rand, user1.GetID())
+	require.NoError(t, env.Repository.ChangeBotState(bot1.ID, model.BotActive))
+	bot2 := env.CreateBot(t, rand, user2.GetID())
+	inactiveBot := env.CreateBot(t, rand, user1.GetID())
+
+	makeDelivery := func(botID uuid.UUID, dead bool) *model.BotEventDelivery {
+		d := &model.BotEventDelivery{
+			ID:            uuid.Must(uuid.NewV4()),
+			BotID:         botID,
+			Event:         event.Ping,
+			Body:          "{}",
+			Attempts:      5,
+			LastError:     "unexpected status code: 500",
+			Dead:          dead,
+			NextAttemptAt: time.Now(),
+		}
+		require.NoError(t, env.Repository.CreateBotEventDelivery(d))
+		return d
+	}
+	dead := makeDelivery(bot1.ID, true)
+	pending := makeDelivery(bot1.ID, false)
+	inactiveBotDead := makeDelivery(inactiveBot.ID, true)
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, bot1.ID.String()).
+			WithJSON(&PostBotActionRedeliverRequest{DeliveryIDs: []uuid.UUID{dead.ID}}).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("bad request (empty)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, bot1.ID.String()).
+			WithCookie(session.CookieName, commonSession).
+			WithJSON(&PostBotActionRedeliverRequest{}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("bad request (not dead)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, bot1.ID.String()).
+			WithCookie(session.CookieName, commonSession).
+			WithJSON(&PostBotActionRedeliverRequest{DeliveryIDs: []uuid.UUID{pending.ID}}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("bad request (unknown delivery)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, bot1.ID.String()).
+			WithCookie(session.CookieName, commonSession).
+			WithJSON(&PostBotActionRedeliverRequest{DeliveryIDs: []uuid.UUID{uuid.Must(uuid.NewV4())}}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("bad request (inactive bot)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, inactiveBot.ID.String()).
+			WithCookie(session.CookieName, commonSession).
+			WithJSON(&PostBotActionRedeliverRequest{DeliveryIDs: []uuid.UUID{inactiveBotDead.ID}}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, bot2.ID.String()).
+			WithCookie(session.CookieName, commonSession).
+			WithJSON(&PostBotActionRedeliverRequest{DeliveryIDs: []uuid.UUID{dead.ID}}).
+			Expect().
+			Status(http.StatusForbidden)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, bot1.ID.String()).
+			WithCookie(session.CookieName, commonSession).
+			WithJSON(&PostBotActionRedeliverRequest{DeliveryIDs: []uuid.UUID{dead.ID}}).
+			Expect().
+			Status(http.StatusAccepted)
+
+		d, err := env.Repository.GetBotEventDelivery(dead.ID)
+		require.NoError(t, err)
+		assert.False(t, d.Dead)
+		assert.Equal(t, 0, d.Attempts)
+	})
 }
 
 func TestHandlers_GetChannelBots(t *testing.T) {
@@ -1,6 +1,7 @@
 package v3
 
 import (
+	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
@@ -9,6 +10,7 @@ import (
 	vd "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/gofrs/uuid"
 	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
 
 	"github.com/traPtitech/traQ/model"
 	"github.com/traPtitech/traQ/repository"
@@ -16,6 +18,7 @@ import (
 	"github.com/traPtitech/traQ/router/extension"
 	"github.com/traPtitech/traQ/router/extension/herror"
 	"github.com/traPtitech/traQ/service/channel"
+	"github.com/traPtitech/traQ/service/export"
 	"github.com/traPtitech/traQ/service/viewer"
 	"github.com/traPtitech/traQ/utils/optional"
 	"github.com/traPtitech/traQ/utils/set"
@@ -272,6 +275,39 @@ func (h *Handlers) GetChannelEvents(c echo.Context) error {
 	return c.JSON(http.StatusOK, events)
 }
 
+type exportChannelQuery struct {
+	Since        optional.Of[time.Time] `query:"since"`
+	Until        optional.Of[time.Time] `query:"until"`
+	ExcludeFiles bool                   `query:"excludeFiles"`
+}
+
+// ExportChannel GET /channels/:channelID/export
+func (h *Handlers) ExportChannel(c echo.Context) error {
+	ch := getParamChannel(c)
+
+	var req exportChannelQuery
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+	if !h.ChannelManager.IsPublicChannel(ch.ID) {
+		return herror.BadRequest("the channel is not a public channel")
+	}
+
+	c.Response().Header().Set(echo.HeaderContentType, "application/zip")
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%s.zip", ch.ID))
+	c.Response().WriteHeader(http.StatusOK)
+	if err := export.NewExporter(h.Repo, h.ChannelManager, h.FileManager).Export(c.Response(), export.Query{
+		ChannelID:    ch.ID,
+		Since:        req.Since,
+		Until:        req.Until,
+		ExcludeFiles: req.ExcludeFiles,
+	}); err != nil {
+		// レスポンスの送信を開始しているため、ログに残すのみ
+		h.L(c).Error("failed to export channel", zap.Error(err), zap.Stringer("channelId", ch.ID))
+	}
+	return nil
+}
+
 // GetChannelSubscribers GET /channels/:channelID/subscribers
 func (h *Handlers) GetChannelSubscribers(c echo.Context) error {
 	ch := getParamChannel(c)
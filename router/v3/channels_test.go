@@ -1122,3 +1122,63 @@ func TestHandlers_GetUserDMChannel(t *testing.T) {
 		obj.Value("userId").String().Equal(user3.GetID().String())
 	})
 }
+
+func TestHandlers_ExportChannel(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/channels/{channelId}/export"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	admin := env.CreateAdmin(t, rand)
+	channel := env.CreateChannel(t, rand)
+	dm := env.CreateDMChannel(t, user.GetID(), admin.GetID())
+	env.CreateMessage(t, user.GetID(), channel.ID, "export test")
+	commonSession := env.S(t, user.GetID())
+	adminSession := env.S(t, admin.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, channel.ID).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, channel.ID).
+			WithCookie(session.CookieName, commonSession).
+			Expect().
+			Status(http.StatusForbidden)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, uuid.Must(uuid.NewV4())).
+			WithCookie(session.CookieName, adminSession).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("not public channel", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, dm.ID).
+			WithCookie(session.CookieName, adminSession).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		res := e.GET(path, channel.ID).
+			WithCookie(session.CookieName, adminSession).
+			Expect().
+			Status(http.StatusOK)
+		res.ContentType("application/zip")
+		res.Body().NotEmpty()
+	})
+}
@@ -0,0 +1,114 @@
+package v3
+
+import (
+	"net/http"
+	"time"
+
+	vd "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/leandro-lugaresi/hub"
+
+	"github.com/traPtitech/traQ/event"
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/router/extension/herror"
+	"github.com/traPtitech/traQ/utils/validator"
+)
+
+// PostEphemeralMessageRequest POST /channels/:channelID/ephemeral-messages リクエストボディ
+type PostEphemeralMessageRequest struct {
+	UserID  uuid.UUID `json:"userId"`
+	Content string    `json:"content"`
+	Embed   bool      `json:"embed"`
+	TTL     int       `json:"ttl"`
+}
+
+func (r PostEphemeralMessageRequest) Validate() error {
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.UserID, vd.Required, validator.NotNilUUID),
+		vd.Field(&r.Content, vd.Required, vd.RuneLength(1, 10000)),
+		vd.Field(&r.TTL, vd.Min(0), vd.Max(86400)),
+	)
+}
+
+// PostEphemeralMessage POST /channels/:channelID/ephemeral-messages
+func (h *Handlers) PostEphemeralMessage(c echo.Context) error {
+	userID := getRequestUserID(c)
+	ch := getParamChannel(c)
+
+	var req PostEphemeralMessageRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	b, err := h.Repo.GetBotByBotUserID(userID)
+	if err != nil {
+		switch err {
+		case repository.ErrNotFound:
+			return herror.Forbidden("only bots can post ephemeral messages")
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+
+	// 配信時にも確認されるが、受け付け前に拒否できるものはここで拒否する
+	joined, err := h.isBotJoinedChannel(b, ch.ID)
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+	if !joined {
+		return herror.Forbidden("the bot has not joined the channel")
+	}
+	if _, err := h.Repo.GetUser(req.UserID, false); err != nil {
+		switch err {
+		case repository.ErrNotFound:
+			return herror.BadRequest("the user was not found")
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+	accessible, err := h.ChannelManager.IsChannelAccessibleToUser(req.UserID, ch.ID)
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+	if !accessible {
+		return herror.BadRequest("the user cannot access the channel")
+	}
+
+	if req.Embed {
+		req.Content = h.Replacer.Replace(req.Content)
+	}
+
+	id := uuid.Must(uuid.NewV4())
+	h.Hub.Publish(hub.Message{
+		Name: event.BotEphemeralMessageRequested,
+		Fields: hub.Fields{
+			"bot_id":     b.ID,
+			"message_id": id,
+			"channel_id": ch.ID,
+			"user_id":    req.UserID,
+			"content":    req.Content,
+			"ttl":        time.Duration(req.TTL) * time.Second,
+		},
+	})
+	return c.JSON(http.StatusAccepted, echo.Map{"id": id})
+}
+
+// isBotJoinedChannel BOTがチャンネルに参加しているかどうか
+func (h *Handlers) isBotJoinedChannel(b *model.Bot, channelID uuid.UUID) (bool, error) {
+	if !h.ChannelManager.IsPublicChannel(channelID) {
+		// DM
+		return h.ChannelManager.IsChannelAccessibleToUser(b.BotUserID, channelID)
+	}
+	ids, err := h.Repo.GetParticipatingChannelIDsByBot(b.ID)
+	if err != nil {
+		return false, err
+	}
+	for _, id := range ids {
+		if id == channelID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
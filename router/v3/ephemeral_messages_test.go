@@ -0,0 +1,98 @@
+package v3
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/traPtitech/traQ/router/session"
+)
+
+func TestHandlers_PostEphemeralMessage(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/channels/{channelId}/ephemeral-messages"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	bot := env.CreateBot(t, rand, user.GetID())
+	ch := env.CreateChannel(t, rand)
+	notJoinedCh := env.CreateChannel(t, rand)
+	require.NoError(t, env.Repository.AddBotToChannel(bot.ID, ch.ID))
+	s := env.S(t, user.GetID())
+	bs := env.S(t, bot.BotUserID)
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, ch.ID).
+			WithJSON(&PostEphemeralMessageRequest{UserID: user.GetID(), Content: "hi"}).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("bad request", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, ch.ID).
+			WithCookie(session.CookieName, bs).
+			WithJSON(&PostEphemeralMessageRequest{UserID: user.GetID(), Content: "hi", TTL: -1}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("forbidden (not bot)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, ch.ID).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostEphemeralMessageRequest{UserID: user.GetID(), Content: "hi"}).
+			Expect().
+			Status(http.StatusForbidden)
+	})
+
+	t.Run("channel not found", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, uuid.Must(uuid.NewV4())).
+			WithCookie(session.CookieName, bs).
+			WithJSON(&PostEphemeralMessageRequest{UserID: user.GetID(), Content: "hi"}).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("forbidden (not joined)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, notJoinedCh.ID).
+			WithCookie(session.CookieName, bs).
+			WithJSON(&PostEphemeralMessageRequest{UserID: user.GetID(), Content: "hi"}).
+			Expect().
+			Status(http.StatusForbidden)
+	})
+
+	t.Run("bad request (user not found)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, ch.ID).
+			WithCookie(session.CookieName, bs).
+			WithJSON(&PostEphemeralMessageRequest{UserID: uuid.Must(uuid.NewV4()), Content: "hi"}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.POST(path, ch.ID).
+			WithCookie(session.CookieName, bs).
+			WithJSON(&PostEphemeralMessageRequest{UserID: user.GetID(), Content: "hi", TTL: 60}).
+			Expect().
+			Status(http.StatusAccepted).
+			JSON().
+			Object()
+
+		obj.Value("id").String().NotEmpty()
+	})
+}
@@ -0,0 +1,177 @@
+package v3
+
+import (
+	"errors"
+	"net/http"
+
+	vd "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/labstack/echo/v4"
+	"github.com/leandro-lugaresi/hub"
+
+	"github.com/traPtitech/traQ/event"
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/router/consts"
+	"github.com/traPtitech/traQ/router/extension/herror"
+	"github.com/traPtitech/traQ/service/message"
+)
+
+// PutMessageComponentsRequest PUT /messages/:messageID/components リクエストボディ
+type PutMessageComponentsRequest struct {
+	Components model.MessageComponentList `json:"components"`
+}
+
+func (r PutMessageComponentsRequest) Validate() error {
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.Components),
+	)
+}
+
+// SetMessageComponents PUT /messages/:messageID/components
+func (h *Handlers) SetMessageComponents(c echo.Context) error {
+	user := getRequestUser(c)
+	m := getParamMessage(c)
+
+	var req PutMessageComponentsRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	// 他人のメッセージには設定できない
+	if user.GetID() != m.GetUserID() {
+		return herror.Forbidden("This is not your message")
+	}
+	// BOTのみ設定可能
+	if !user.IsBot() {
+		return herror.Forbidden("only bots can set message components")
+	}
+
+	if err := h.MessageManager.SetComponents(m.GetID(), req.Components); err != nil {
+		switch err {
+		case message.ErrChannelArchived:
+			return herror.BadRequest("the channel of this message has been archived")
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// PostMessageInteractionRequest POST /messages/:messageID/interactions リクエストボディ
+type PostMessageInteractionRequest struct {
+	CustomID string   `json:"customId"`
+	Values   []string `json:"values"`
+}
+
+func (r PostMessageInteractionRequest) Validate() error {
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.CustomID, vd.Required, vd.Match(model.MessageComponentCustomIDRegex)),
+		vd.Field(&r.Values, vd.Length(0, 25)),
+	)
+}
+
+// validateFor 操作対象のコンポーネントに対してリクエストが妥当かどうかを検証します
+func (r PostMessageInteractionRequest) validateFor(component *model.MessageComponent) error {
+	switch component.Type {
+	case model.MessageComponentTypeButton:
+		if len(r.Values) > 0 {
+			return errors.New("values must be empty for button")
+		}
+	case model.MessageComponentTypeSelect:
+		if len(r.Values) == 0 {
+			return errors.New("values must not be empty for select")
+		}
+		if !component.Multiple && len(r.Values) > 1 {
+			return errors.New("this select accepts only one value")
+		}
+		selected := make(map[string]bool, len(r.Values))
+		for _, v := range r.Values {
+			if !component.HasOption(v) {
+				return errors.New("invalid value: " + v)
+			}
+			if selected[v] {
+				return errors.New("values must be unique")
+			}
+			selected[v] = true
+		}
+	}
+	return nil
+}
+
+// PostMessageInteraction POST /messages/:messageID/interactions
+func (h *Handlers) PostMessageInteraction(c echo.Context) error {
+	userID := getRequestUserID(c)
+	m := getParamMessage(c)
+
+	var req PostMessageInteractionRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	component := m.GetComponents().Find(req.CustomID)
+	if component == nil {
+		return herror.NotFound("component not found")
+	}
+	if component.Disabled {
+		return herror.BadRequest("this component is disabled")
+	}
+	if err := req.validateFor(component); err != nil {
+		return herror.BadRequest(err)
+	}
+
+	i, err := h.Repo.CreateMessageInteraction(m.GetID(), userID, req.CustomID, req.Values)
+	if err != nil {
+		switch err {
+		case repository.ErrNotFound:
+			return herror.NotFound("message not found")
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+	return c.JSON(http.StatusCreated, formatMessageInteraction(i))
+}
+
+// ReplyMessageInteraction POST /messages/:messageID/interactions/:interactionID/reply
+func (h *Handlers) ReplyMessageInteraction(c echo.Context) error {
+	userID := getRequestUserID(c)
+	m := getParamMessage(c)
+
+	var req PostMessageRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	// 自分のメッセージに対する操作にのみ返信できる
+	if userID != m.GetUserID() {
+		return herror.Forbidden("This is not your message")
+	}
+
+	i, err := h.Repo.GetMessageInteraction(getParamAsUUID(c, consts.ParamInteractionID))
+	if err != nil {
+		switch err {
+		case repository.ErrNotFound:
+			return herror.NotFound("interaction not found")
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+	if i.MessageID != m.GetID() {
+		return herror.NotFound("interaction not found")
+	}
+
+	if req.Embed {
+		req.Content = h.Replacer.Replace(req.Content)
+	}
+
+	h.Hub.Publish(hub.Message{
+		Name: event.MessageInteractionReplied,
+		Fields: hub.Fields{
+			"message_id":  m.GetID(),
+			"channel_id":  m.GetChannelID(),
+			"interaction": i,
+			"bot_user_id": userID,
+			"content":     req.Content,
+		},
+	})
+	return c.NoContent(http.StatusNoContent)
+}
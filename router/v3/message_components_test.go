@@ -0,0 +1,241 @@
+package v3
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/router/session"
+)
+
+func TestHandlers_SetMessageComponents(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/messages/{messageId}/components"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	bot := env.CreateBot(t, rand, user.GetID())
+	ch := env.CreateChannel(t, rand)
+	bm := env.CreateMessage(t, bot.BotUserID, ch.ID, rand)
+	um := env.CreateMessage(t, user.GetID(), ch.ID, rand)
+	s := env.S(t, user.GetID())
+	bs := env.S(t, bot.BotUserID)
+
+	req := &PutMessageComponentsRequest{
+		Components: model.MessageComponentList{
+			{Type: model.MessageComponentTypeButton, CustomID: "ok", Label: "OK", Style: model.MessageButtonStylePrimary},
+		},
+	}
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path, bm.GetID()).
+			WithJSON(req).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("bad request", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path, bm.GetID()).
+			WithCookie(session.CookieName, bs).
+			WithJSON(&PutMessageComponentsRequest{
+				Components: model.MessageComponentList{
+					{Type: model.MessageComponentTypeSelect, CustomID: "choice"},
+				},
+			}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("forbidden (not own message)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path, bm.GetID()).
+			WithCookie(session.CookieName, s).
+			WithJSON(req).
+			Expect().
+			Status(http.StatusForbidden)
+	})
+
+	t.Run("forbidden (not bot)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path, um.GetID()).
+			WithCookie(session.CookieName, s).
+			WithJSON(req).
+			Expect().
+			Status(http.StatusForbidden)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path, bm.GetID()).
+			WithCookie(session.CookieName, bs).
+			WithJSON(req).
+			Expect().
+			Status(http.StatusNoContent)
+
+		m, err := env.MM.Get(bm.GetID())
+		require.NoError(t, err)
+		assert.Equal(t, req.Components, m.GetComponents())
+	})
+}
+
+func TestHandlers_PostMessageInteraction(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/messages/{messageId}/interactions"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	bot := env.CreateBot(t, rand, user.GetID())
+	ch := env.CreateChannel(t, rand)
+	bm := env.CreateMessage(t, bot.BotUserID, ch.ID, rand)
+	require.NoError(t, env.MM.SetComponents(bm.GetID(), model.MessageComponentList{
+		{Type: model.MessageComponentTypeButton, CustomID: "ok", Label: "OK"},
+		{Type: model.MessageComponentTypeButton, CustomID: "disabled", Label: "NG", Disabled: true},
+		{Type: model.MessageComponentTypeSelect, CustomID: "choice", Options: []*model.MessageSelectOption{
+			{Label: "A", Value: "a"},
+			{Label: "B", Value: "b"},
+		}},
+	}))
+	s := env.S(t, user.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, bm.GetID()).
+			WithJSON(&PostMessageInteractionRequest{CustomID: "ok"}).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("component not found", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, bm.GetID()).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostMessageInteractionRequest{CustomID: "unknown"}).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("bad request (disabled)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, bm.GetID()).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostMessageInteractionRequest{CustomID: "disabled"}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("bad request (invalid value)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, bm.GetID()).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostMessageInteractionRequest{CustomID: "choice", Values: []string{"c"}}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("bad request (multiple values)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, bm.GetID()).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostMessageInteractionRequest{CustomID: "choice", Values: []string{"a", "b"}}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.POST(path, bm.GetID()).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostMessageInteractionRequest{CustomID: "choice", Values: []string{"b"}}).
+			Expect().
+			Status(http.StatusCreated).
+			JSON().
+			Object()
+
+		obj.Value("messageId").String().Equal(bm.GetID().String())
+		obj.Value("userId").String().Equal(user.GetID().String())
+		obj.Value("customId").String().Equal("choice")
+		obj.Value("values").Array().Equal([]string{"b"})
+	})
+}
+
+func TestHandlers_ReplyMessageInteraction(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/messages/{messageId}/interactions/{interactionId}/reply"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	bot := env.CreateBot(t, rand, user.GetID())
+	ch := env.CreateChannel(t, rand)
+	bm := env.CreateMessage(t, bot.BotUserID, ch.ID, rand)
+	bm2 := env.CreateMessage(t, bot.BotUserID, ch.ID, rand)
+	i, err := env.Repository.CreateMessageInteraction(bm.GetID(), user.GetID(), "ok", nil)
+	require.NoError(t, err)
+	s := env.S(t, user.GetID())
+	bs := env.S(t, bot.BotUserID)
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, bm.GetID(), i.ID).
+			WithJSON(&PostMessageRequest{Content: "thanks"}).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, bm.GetID(), i.ID).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostMessageRequest{Content: "thanks"}).
+			Expect().
+			Status(http.StatusForbidden)
+	})
+
+	t.Run("interaction not found", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, bm.GetID(), uuid.Must(uuid.NewV4())).
+			WithCookie(session.CookieName, bs).
+			WithJSON(&PostMessageRequest{Content: "thanks"}).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("interaction of another message", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, bm2.GetID(), i.ID).
+			WithCookie(session.CookieName, bs).
+			WithJSON(&PostMessageRequest{Content: "thanks"}).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, bm.GetID(), i.ID).
+			WithCookie(session.CookieName, bs).
+			WithJSON(&PostMessageRequest{Content: "thanks"}).
+			Expect().
+			Status(http.StatusNoContent)
+	})
+}
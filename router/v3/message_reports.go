@@ -0,0 +1,218 @@
+package v3
+
+import (
+	"net/http"
+
+	vd "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/router/consts"
+	"github.com/traPtitech/traQ/router/extension/herror"
+	"github.com/traPtitech/traQ/service/message"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+// PostMessageReportRequest POST /messages/:messageID/report リクエストボディ
+type PostMessageReportRequest struct {
+	Reason string `json:"reason"`
+}
+
+func (r PostMessageReportRequest) Validate() error {
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.Reason, vd.Required, vd.RuneLength(1, 1000)),
+	)
+}
+
+// ReportMessage POST /messages/:messageID/report
+func (h *Handlers) ReportMessage(c echo.Context) error {
+	userID := getRequestUserID(c)
+	m := getParamMessage(c)
+
+	var req PostMessageReportRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	if err := h.Repo.CreateMessageReport(m.GetID(), userID, req.Reason); err != nil {
+		switch err {
+		case repository.ErrAlreadyExists:
+			return herror.BadRequest("you have already reported this message")
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// GetMessageReportsRequest GET /message-reports 用クエリ
+type GetMessageReportsRequest struct {
+	State      string                 `query:"state"`
+	MessageID  optional.Of[uuid.UUID] `query:"messageId"`
+	ReporterID optional.Of[uuid.UUID] `query:"reporterId"`
+	Limit      int                    `query:"limit"`
+	Offset     int                    `query:"offset"`
+}
+
+func (r *GetMessageReportsRequest) Validate() error {
+	if r.Limit == 0 {
+		r.Limit = 50
+	}
+	return vd.ValidateStruct(r,
+		vd.Field(&r.State, vd.In(string(model.MessageReportStateOpen), string(model.MessageReportStateResolved), string(model.MessageReportStateDismissed))),
+		vd.Field(&r.Limit, vd.Min(1), vd.Max(200)),
+		vd.Field(&r.Offset, vd.Min(0)),
+	)
+}
+
+// GetMessageReports GET /message-reports
+func (h *Handlers) GetMessageReports(c echo.Context) error {
+	var req GetMessageReportsRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	q := repository.MessageReportsQuery{
+		MessageID:  req.MessageID,
+		ReporterID: req.ReporterID,
+		Limit:      req.Limit,
+		Offset:     req.Offset,
+	}
+	if len(req.State) > 0 {
+		q.State = optional.From(model.MessageReportState(req.State))
+	}
+
+	reports, err := h.Repo.GetMessageReports(q)
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+	return c.JSON(http.StatusOK, reports)
+}
+
+// GetMessageReport GET /message-reports/:reportID
+func (h *Handlers) GetMessageReport(c echo.Context) error {
+	r, err := h.getParamMessageReport(c)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, r)
+}
+
+// PatchMessageReportRequest PATCH /message-reports/:reportID リクエストボディ
+type PatchMessageReportRequest struct {
+	State         model.MessageReportState `json:"state"`
+	Note          string                   `json:"note"`
+	DeleteMessage bool                     `json:"deleteMessage"`
+	SuspendUser   bool                     `json:"suspendUser"`
+}
+
+func (r PatchMessageReportRequest) Validate() error {
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.State, vd.Required, vd.In(model.MessageReportStateResolved, model.MessageReportStateDismissed)),
+		vd.Field(&r.Note, vd.RuneLength(0, 1000)),
+		// 却下する場合は対応を行わない
+		vd.Field(&r.DeleteMessage, vd.When(r.State != model.MessageReportStateResolved, vd.Empty.Error("actions can only be taken when resolving"))),
+		vd.Field(&r.SuspendUser, vd.When(r.State != model.MessageReportStateResolved, vd.Empty.Error("actions can only be taken when resolving"))),
+	)
+}
+
+// EditMessageReport PATCH /message-reports/:reportID
+func (h *Handlers) EditMessageReport(c echo.Context) error {
+	userID := getRequestUserID(c)
+	r, err := h.getParamMessageReport(c)
+	if err != nil {
+		return err
+	}
+
+	var req PatchMessageReportRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	// 対応を行えるかどうかを先に確認
+	// メッセージが既に削除されている場合 (前回のリクエストで削除済みの場合を含む) は削除を行わない
+	var m message.Message
+	if req.DeleteMessage {
+		m, err = h.MessageManager.Get(r.MessageID)
+		switch err {
+		case nil:
+			if h.ChannelManager.IsPublicChannel(m.GetChannelID()) && h.ChannelManager.PublicChannelTree().IsArchivedChannel(m.GetChannelID()) {
+				return herror.BadRequest("the channel of this message has been archived")
+			}
+		case message.ErrNotFound:
+			m = nil
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+	// 投稿者は削除済みのメッセージからも取得する
+	var authorID uuid.UUID
+	if req.SuspendUser {
+		authorID, err = h.Repo.GetMessageUserID(r.MessageID)
+		if err != nil {
+			switch err {
+			case repository.ErrNotFound:
+				return herror.BadRequest("the reported message does not exist")
+			default:
+				return herror.InternalServerError(err)
+			}
+		}
+		if authorID == userID {
+			return herror.BadRequest("you cannot suspend yourself")
+		}
+	}
+
+	// 対応だけが行われて通報が未解決のまま残らないよう、先に解決状態を保存する
+	// 対応に失敗した場合は、再度リクエストすることでやり直せる
+	args := repository.ResolveMessageReportArgs{
+		State:      req.State,
+		ResolverID: userID,
+		Note:       req.Note,
+	}
+	if err := h.Repo.ResolveMessageReport(r.ID, args); err != nil {
+		switch err {
+		case repository.ErrNotFound:
+			return herror.NotFound()
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+
+	// 通報されたメッセージを削除
+	if m != nil {
+		if err := h.MessageManager.Delete(m.GetID()); err != nil {
+			switch err {
+			case message.ErrNotFound:
+				// 既に削除されている
+			case message.ErrChannelArchived:
+				return herror.BadRequest("the channel of this message has been archived")
+			default:
+				return herror.InternalServerError(err)
+			}
+		}
+	}
+
+	// メッセージの投稿者を一時停止
+	if req.SuspendUser {
+		if err := h.Repo.UpdateUser(authorID, repository.UpdateUserArgs{UserState: optional.From(model.UserAccountStatusSuspended)}); err != nil {
+			return herror.InternalServerError(err)
+		}
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// getParamMessageReport URLの:reportIDに対応するメッセージ通報を取得します
+func (h *Handlers) getParamMessageReport(c echo.Context) (*model.MessageReport, error) {
+	r, err := h.Repo.GetMessageReport(getParamAsUUID(c, consts.ParamReportID))
+	if err != nil {
+		switch err {
+		case repository.ErrNotFound:
+			return nil, herror.NotFound()
+		default:
+			return nil, herror.InternalServerError(err)
+		}
+	}
+	return r, nil
+}
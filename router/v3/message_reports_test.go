@@ -0,0 +1,289 @@
+package v3
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/router/session"
+)
+
+func TestHandlers_ReportMessage(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/messages/{messageId}/report"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	user2 := env.CreateUser(t, rand)
+	ch := env.CreateChannel(t, rand)
+	m := env.CreateMessage(t, user2.GetID(), ch.ID, rand)
+	m2 := env.CreateMessage(t, user2.GetID(), ch.ID, rand)
+	s := env.S(t, user.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, m.GetID()).
+			WithJSON(&PostMessageReportRequest{Reason: "spam"}).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("bad request", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, m.GetID()).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostMessageReportRequest{Reason: ""}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, uuid.Must(uuid.NewV4())).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostMessageReportRequest{Reason: "spam"}).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, m2.GetID()).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostMessageReportRequest{Reason: "spam"}).
+			Expect().
+			Status(http.StatusNoContent)
+
+		e.POST(path, m2.GetID()).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostMessageReportRequest{Reason: "spam"}).
+			Expect().
+			Status(http.StatusBadRequest)
+
+		reports, err := env.Repository.GetMessageReportsByMessageID(m2.GetID())
+		require.NoError(t, err)
+		if assert.Len(t, reports, 1) {
+			assert.Equal(t, user.GetID(), reports[0].Reporter)
+			assert.Equal(t, model.MessageReportStateOpen, reports[0].State)
+		}
+	})
+}
+
+func TestHandlers_GetMessageReports(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/message-reports"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	admin := env.CreateAdmin(t, rand)
+	ch := env.CreateChannel(t, rand)
+	m := env.CreateMessage(t, user.GetID(), ch.ID, rand)
+	require.NoError(t, env.Repository.CreateMessageReport(m.GetID(), user.GetID(), "spam"))
+	s := env.S(t, user.GetID())
+	adminSession := env.S(t, admin.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusForbidden)
+	})
+
+	t.Run("bad request", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path).
+			WithCookie(session.CookieName, adminSession).
+			WithQuery("state", "closed").
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.GET(path).
+			WithCookie(session.CookieName, adminSession).
+			WithQuery("state", "open").
+			WithQuery("messageId", m.GetID()).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Array()
+
+		obj.Length().Equal(1)
+		first := obj.Element(0).Object()
+		first.Value("messageId").String().Equal(m.GetID().String())
+		first.Value("reporter").String().Equal(user.GetID().String())
+		first.Value("state").String().Equal("open")
+	})
+}
+
+func TestHandlers_EditMessageReport(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/message-reports/{reportId}"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	user2 := env.CreateUser(t, rand)
+	admin := env.CreateAdmin(t, rand)
+	ch := env.CreateChannel(t, rand)
+	m := env.CreateMessage(t, user2.GetID(), ch.ID, rand)
+	m2 := env.CreateMessage(t, user2.GetID(), ch.ID, rand)
+	require.NoError(t, env.Repository.CreateMessageReport(m.GetID(), user.GetID(), "spam"))
+	require.NoError(t, env.Repository.CreateMessageReport(m2.GetID(), user.GetID(), "spam"))
+	reports, err := env.Repository.GetMessageReportsByMessageID(m.GetID())
+	require.NoError(t, err)
+	r := reports[0]
+	reports, err = env.Repository.GetMessageReportsByMessageID(m2.GetID())
+	require.NoError(t, err)
+	r2 := reports[0]
+	m3 := env.CreateMessage(t, admin.GetID(), ch.ID, rand)
+	require.NoError(t, env.Repository.CreateMessageReport(m3.GetID(), user.GetID(), "spam"))
+	reports, err = env.Repository.GetMessageReportsByMessageID(m3.GetID())
+	require.NoError(t, err)
+	r3 := reports[0]
+	user3 := env.CreateUser(t, rand)
+	m4 := env.CreateMessage(t, user3.GetID(), ch.ID, rand)
+	require.NoError(t, env.Repository.CreateMessageReport(m4.GetID(), user2.GetID(), "spam"))
+	reports, err = env.Repository.GetMessageReportsByMessageID(m4.GetID())
+	require.NoError(t, err)
+	r4 := reports[0]
+	s := env.S(t, user.GetID())
+	adminSession := env.S(t, admin.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PATCH(path, r.ID).
+			WithJSON(&PatchMessageReportRequest{State: model.MessageReportStateDismissed}).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PATCH(path, r.ID).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PatchMessageReportRequest{State: model.MessageReportStateDismissed}).
+			Expect().
+			Status(http.StatusForbidden)
+	})
+
+	t.Run("bad request", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PATCH(path, r.ID).
+			WithCookie(session.CookieName, adminSession).
+			WithJSON(&PatchMessageReportRequest{State: model.MessageReportStateOpen}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("bad request (dismiss with actions)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PATCH(path, r.ID).
+			WithCookie(session.CookieName, adminSession).
+			WithJSON(&PatchMessageReportRequest{State: model.MessageReportStateDismissed, SuspendUser: true}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("bad request (suspend self)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PATCH(path, r3.ID).
+			WithCookie(session.CookieName, adminSession).
+			WithJSON(&PatchMessageReportRequest{State: model.MessageReportStateResolved, SuspendUser: true}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PATCH(path, uuid.Must(uuid.NewV4())).
+			WithCookie(session.CookieName, adminSession).
+			WithJSON(&PatchMessageReportRequest{State: model.MessageReportStateDismissed}).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("dismiss", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PATCH(path, r.ID).
+			WithCookie(session.CookieName, adminSession).
+			WithJSON(&PatchMessageReportRequest{State: model.MessageReportStateDismissed, Note: "ok"}).
+			Expect().
+			Status(http.StatusNoContent)
+
+		report, err := env.Repository.GetMessageReport(r.ID)
+		require.NoError(t, err)
+		assert.Equal(t, model.MessageReportStateDismissed, report.State)
+		assert.Equal(t, "ok", report.Note)
+		assert.EqualValues(t, admin.GetID(), report.ResolverID.V)
+		assert.True(t, report.ResolvedAt.Valid)
+
+		_, err = env.MM.Get(m.GetID())
+		assert.NoError(t, err)
+	})
+
+	t.Run("resolve with actions", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PATCH(path, r2.ID).
+			WithCookie(session.CookieName, adminSession).
+			WithJSON(&PatchMessageReportRequest{State: model.MessageReportStateResolved, DeleteMessage: true, SuspendUser: true}).
+			Expect().
+			Status(http.StatusNoContent)
+
+		report, err := env.Repository.GetMessageReport(r2.ID)
+		require.NoError(t, err)
+		assert.Equal(t, model.MessageReportStateResolved, report.State)
+
+		_, err = env.Repository.GetMessageByID(m2.GetID())
+		assert.ErrorIs(t, err, repository.ErrNotFound)
+
+		u, err := env.Repository.GetUser(user2.GetID(), false)
+		require.NoError(t, err)
+		assert.Equal(t, model.UserAccountStatusSuspended, u.GetState())
+	})
+
+	t.Run("retry after the message was deleted", func(t *testing.T) {
+		t.Parallel()
+		require.NoError(t, env.MM.Delete(m4.GetID()))
+
+		e := env.R(t)
+		e.PATCH(path, r4.ID).
+			WithCookie(session.CookieName, adminSession).
+			WithJSON(&PatchMessageReportRequest{State: model.MessageReportStateResolved, DeleteMessage: true, SuspendUser: true}).
+			Expect().
+			Status(http.StatusNoContent)
+
+		u, err := env.Repository.GetUser(user3.GetID(), false)
+		require.NoError(t, err)
+		assert.Equal(t, model.UserAccountStatusSuspended, u.GetState())
+	})
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/traPtitech/traQ/router/extension/herror"
 	"github.com/traPtitech/traQ/service/message"
 	"github.com/traPtitech/traQ/service/search"
+	"github.com/traPtitech/traQ/utils/optional"
 )
 
 // GetMyUnreadChannels GET /users/me/unread
@@ -50,6 +51,20 @@ func (h *Handlers) SearchMessages(c echo.Context) error {
 		return err
 	}
 
+	// 検索クエリ言語
+	if text := c.QueryParam("q"); len(text) > 0 {
+		if err := search.ParseQuery(&q, text, h.Repo, h.ChannelManager.PublicChannelTree()); err != nil {
+			if errs, ok := err.(search.QueryParseErrors); ok {
+				return c.JSON(http.StatusBadRequest, echo.Map{"message": "invalid query", "errors": errs})
+			}
+			return herror.InternalServerError(err)
+		}
+		// 並び順が変わりカーソルと一致しなくなっている可能性がある
+		if err := q.Validate(); err != nil {
+			return herror.BadRequest(err)
+		}
+	}
+
 	if q.In.Valid {
 		// ユーザーが該当チャンネルへのアクセス権限があるかを確認
 		ok, err := h.ChannelManager.IsChannelAccessibleToUser(getRequestUserID(c), q.In.V)
@@ -68,12 +83,16 @@ func (h *Handlers) SearchMessages(c echo.Context) error {
 	}
 
 	type res struct {
-		TotalHits int64             `json:"totalHits"`
-		Hits      []message.Message `json:"hits"`
+		TotalHits  int64                    `json:"totalHits"`
+		Hits       []message.Message        `json:"hits"`
+		Highlights []MessageSearchHighlight `json:"highlights"`
+		NextCursor optional.Of[string]      `json:"nextCursor"`
 	}
 	response := res{
-		TotalHits: r.TotalHits(),
-		Hits:      r.Hits(),
+		TotalHits:  r.TotalHits(),
+		Hits:       r.Hits(),
+		Highlights: formatMessageSearchHighlights(r.Highlights()),
+		NextCursor: r.NextCursor(),
 	}
 	return c.JSON(http.StatusOK, response)
 }
@@ -125,6 +144,17 @@ func (h *Handlers) EditMessage(c echo.Context) error {
 	return c.NoContent(http.StatusNoContent)
 }
 
+// GetMessageRevisions GET /messages/:messageID/revisions
+func (h *Handlers) GetMessageRevisions(c echo.Context) error {
+	m := getParamMessage(c)
+
+	revisions, err := h.Repo.GetArchivedMessagesByMessageID(m.GetID())
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+	return c.JSON(http.StatusOK, formatMessageRevisions(revisions))
+}
+
 // DeleteMessage DELETE /messages/:messageID
 func (h *Handlers) DeleteMessage(c echo.Context) error {
 	userID := getRequestUserID(c)
@@ -324,6 +354,13 @@ func (h *Handlers) PostMessage(c echo.Context) error {
 		return err
 	}
 
+	// スラッシュコマンド
+	if dispatched, err := h.dispatchSlashCommand(ch, userID, req.Content); err != nil {
+		return err
+	} else if dispatched {
+		return c.NoContent(http.StatusAccepted)
+	}
+
 	if req.Embed {
 		req.Content = h.Replacer.Replace(req.Content)
 	}
@@ -369,6 +406,19 @@ func (h *Handlers) PostDirectMessage(c echo.Context) error {
 		return err
 	}
 
+	// スラッシュコマンド
+	if _, _, ok := model.ParseSlashCommand(req.Content); ok {
+		ch, err := h.ChannelManager.GetDMChannel(myID, targetID)
+		if err != nil {
+			return herror.InternalServerError(err)
+		}
+		if dispatched, err := h.dispatchSlashCommand(ch, myID, req.Content); err != nil {
+			return err
+		} else if dispatched {
+			return c.NoContent(http.StatusAccepted)
+		}
+	}
+
 	if req.Embed {
 		req.Content = h.Replacer.Replace(req.Content)
 	}
@@ -331,6 +331,76 @@ func TestHandlers_EditMessage(t *testing.T) {
 	}
 }
 
+func TestHandlers_GetMessageRevisions(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/messages/{messageId}/revisions"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	admin := env.CreateAdmin(t, rand)
+	ch := env.CreateChannel(t, rand)
+	m := env.CreateMessage(t, user.GetID(), ch.ID, "a")
+	require.NoError(t, env.MM.Edit(m.GetID(), "b"))
+	require.NoError(t, env.MM.Edit(m.GetID(), "c"))
+	s := env.S(t, user.GetID())
+	adminSession := env.S(t, admin.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, m.GetID()).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, m.GetID()).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusForbidden)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, uuid.Must(uuid.NewV4())).
+			WithCookie(session.CookieName, adminSession).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.GET(path, m.GetID()).
+			WithCookie(session.CookieName, adminSession).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Array()
+
+		obj.Length().Equal(2)
+		first := obj.Element(0).Object()
+		first.Value("userId").String().Equal(user.GetID().String())
+		first.Value("content").String().Equal("a")
+		obj.Element(1).Object().Value("content").String().Equal("b")
+	})
+
+	t.Run("revision count", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET("/api/v3/messages/{messageId}", m.GetID()).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Object().
+			Value("revisionCount").Number().Equal(2)
+	})
+}
+
 func TestHandlers_DeleteMessage(t *testing.T) {
 	t.Parallel()
 
@@ -1002,6 +1072,58 @@ func TestHandlers_GetMessages(t *testing.T) {
 		messageEquals(t, m2, obj.Element(0).Object())
 		messageEquals(t, m, obj.Element(1).Object())
 	})
+
+	t.Run("bad request (invalid cursor)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, ch.ID).
+			WithCookie(session.CookieName, s).
+			WithQuery("cursor", "invalid").
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("success (cursor)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		res := e.GET(path, ch.ID).
+			WithCookie(session.CookieName, s).
+			WithQuery("limit", 1).
+			Expect().
+			Status(http.StatusOK)
+		res.Header("X-TRAQ-More").Equal("true")
+		obj := res.JSON().Array()
+		obj.Length().Equal(1)
+		messageEquals(t, m2, obj.Element(0).Object())
+		next := res.Header("X-TRAQ-Next-Cursor").NotEmpty().Raw()
+
+		// offsetとは同時に使えない
+		e.GET(path, ch.ID).
+			WithCookie(session.CookieName, s).
+			WithQuery("cursor", next).
+			WithQuery("offset", 1).
+			Expect().
+			Status(http.StatusBadRequest)
+		// 並び順が異なる
+		e.GET(path, ch.ID).
+			WithCookie(session.CookieName, s).
+			WithQuery("cursor", next).
+			WithQuery("order", "asc").
+			Expect().
+			Status(http.StatusBadRequest)
+
+		res = e.GET(path, ch.ID).
+			WithCookie(session.CookieName, s).
+			WithQuery("limit", 1).
+			WithQuery("cursor", next).
+			Expect().
+			Status(http.StatusOK)
+		res.Header("X-TRAQ-More").Equal("false")
+		res.Header("X-TRAQ-Next-Cursor").Empty()
+		obj = res.JSON().Array()
+		obj.Length().Equal(1)
+		messageEquals(t, m, obj.Element(0).Object())
+	})
 }
 
 func TestHandlers_PostMessage(t *testing.T) {
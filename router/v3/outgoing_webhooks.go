@@ -0,0 +1,205 @@
+package v3
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"regexp"
+
+	vd "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/router/consts"
+	"github.com/traPtitech/traQ/router/extension/herror"
+	"github.com/traPtitech/traQ/router/utils"
+	"github.com/traPtitech/traQ/service/rbac/permission"
+	"github.com/traPtitech/traQ/utils/optional"
+	"github.com/traPtitech/traQ/utils/validator"
+)
+
+var httpURLRegex = regexp.MustCompile(`^https?://`)
+
+// validOutgoingWebhookEvents 有効な送信Webhookイベントタイプの配列である
+var validOutgoingWebhookEvents = vd.Each(vd.By(func(value interface{}) error {
+	if !value.(model.OutgoingWebhookEventType).Valid() {
+		return errors.New("invalid event type")
+	}
+	return nil
+}))
+
+// GetOutgoingWebhooks GET /outgoing-webhooks
+func (h *Handlers) GetOutgoingWebhooks(c echo.Context) error {
+	user := getRequestUser(c)
+
+	var (
+		list []*model.OutgoingWebhook
+		err  error
+	)
+	if isTrue(c.QueryParam("all")) && h.RBAC.IsGranted(user.GetRole(), permission.AccessOthersWebhook) {
+		list, err = h.Repo.GetAllOutgoingWebhooks()
+	} else {
+		list, err = h.Repo.GetOutgoingWebhooksByCreatorID(user.GetID())
+	}
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+
+	return c.JSON(http.StatusOK, formatOutgoingWebhooks(list))
+}
+
+// PostOutgoingWebhookRequest POST /outgoing-webhooks リクエストボディ
+type PostOutgoingWebhookRequest struct {
+	ChannelID uuid.UUID                       `json:"channelId"`
+	URL       string                          `json:"url"`
+	Secret    string                          `json:"secret"`
+	Events    model.OutgoingWebhookEventTypes `json:"events"`
+}
+
+func (r PostOutgoingWebhookRequest) ValidateWithContext(ctx context.Context) error {
+	return vd.ValidateStructWithContext(ctx, &r,
+		vd.Field(&r.ChannelID, vd.Required, validator.NotNilUUID, utils.IsPublicChannelID),
+		vd.Field(&r.URL, vd.Required, vd.RuneLength(1, 1000), is.URL, vd.Match(httpURLRegex), validator.NotInternalURL),
+		vd.Field(&r.Secret, vd.RuneLength(0, 50)),
+		vd.Field(&r.Events, vd.Required, validOutgoingWebhookEvents),
+	)
+}
+
+// CreateOutgoingWebhook POST /outgoing-webhooks
+func (h *Handlers) CreateOutgoingWebhook(c echo.Context) error {
+	userID := getRequestUserID(c)
+
+	var req PostOutgoingWebhookRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	w, err := h.Repo.CreateOutgoingWebhook(repository.CreateOutgoingWebhookArgs{
+		ChannelID: req.ChannelID,
+		CreatorID: userID,
+		URL:       req.URL,
+		Secret:    req.Secret,
+		Events:    req.Events,
+	})
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+
+	return c.JSON(http.StatusCreated, formatOutgoingWebhook(w))
+}
+
+// GetOutgoingWebhook GET /outgoing-webhooks/:outgoingWebhookID
+func (h *Handlers) GetOutgoingWebhook(c echo.Context) error {
+	return c.JSON(http.StatusOK, formatOutgoingWebhook(getParamOutgoingWebhook(c)))
+}
+
+// PatchOutgoingWebhookRequest PATCH /outgoing-webhooks/:outgoingWebhookID リクエストボディ
+type PatchOutgoingWebhookRequest struct {
+	ChannelID optional.Of[uuid.UUID]                       `json:"channelId"`
+	URL       optional.Of[string]                          `json:"url"`
+	Secret    optional.Of[string]                          `json:"secret"`
+	Events    optional.Of[model.OutgoingWebhookEventTypes] `json:"events"`
+}
+
+func (r PatchOutgoingWebhookRequest) ValidateWithContext(ctx context.Context) error {
+	return vd.ValidateStructWithContext(ctx, &r,
+		vd.Field(&r.ChannelID, validator.NotNilUUID, utils.IsPublicChannelID),
+		vd.Field(&r.URL, validator.RequiredIfValid, vd.RuneLength(1, 1000), is.URL, vd.Match(httpURLRegex), validator.NotInternalURL),
+		vd.Field(&r.Secret, vd.RuneLength(0, 50)),
+		vd.Field(&r.Events, validator.RequiredIfValid, validOutgoingWebhookEvents),
+	)
+}
+
+// EditOutgoingWebhook PATCH /outgoing-webhooks/:outgoingWebhookID
+func (h *Handlers) EditOutgoingWebhook(c echo.Context) error {
+	w := getParamOutgoingWebhook(c)
+
+	var req PatchOutgoingWebhookRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	if err := h.Repo.UpdateOutgoingWebhook(w.ID, repository.UpdateOutgoingWebhookArgs{
+		ChannelID: req.ChannelID,
+		URL:       req.URL,
+		Secret:    req.Secret,
+		Events:    req.Events,
+	}); err != nil {
+		return herror.InternalServerError(err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// DeleteOutgoingWebhook DELETE /outgoing-webhooks/:outgoingWebhookID
+func (h *Handlers) DeleteOutgoingWebhook(c echo.Context) error {
+	w := getParamOutgoingWebhook(c)
+
+	if err := h.Repo.DeleteOutgoingWebhook(w.ID); err != nil {
+		return herror.InternalServerError(err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// GetOutgoingWebhookDeliveriesRequest GET /outgoing-webhooks/:outgoingWebhookID/deliveries リクエストクエリ
+type GetOutgoingWebhookDeliveriesRequest struct {
+	Limit  int `query:"limit"`
+	Offset int `query:"offset"`
+}
+
+func (r *GetOutgoingWebhookDeliveriesRequest) Validate() error {
+	if r.Limit == 0 {
+		r.Limit = 30
+	}
+	return vd.ValidateStruct(r,
+		vd.Field(&r.Limit, vd.Min(1), vd.Max(200)),
+		vd.Field(&r.Offset, vd.Min(0)),
+	)
+}
+
+// GetOutgoingWebhookDeliveries GET /outgoing-webhooks/:outgoingWebhookID/deliveries
+func (h *Handlers) GetOutgoingWebhookDeliveries(c echo.Context) error {
+	w := getParamOutgoingWebhook(c)
+
+	var req GetOutgoingWebhookDeliveriesRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	ds, err := h.Repo.GetOutgoingWebhookDeliveries(w.ID, req.Limit, req.Offset)
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+
+	return c.JSON(http.StatusOK, formatOutgoingWebhookDeliveries(ds))
+}
+
+// RedeliverOutgoingWebhookDelivery POST /outgoing-webhooks/:outgoingWebhookID/deliveries/:deliveryID/redeliver
+func (h *Handlers) RedeliverOutgoingWebhookDelivery(c echo.Context) error {
+	w := getParamOutgoingWebhook(c)
+	deliveryID := getParamAsUUID(c, consts.ParamDeliveryID)
+
+	d, err := h.Repo.GetOutgoingWebhookDelivery(deliveryID)
+	if err != nil {
+		switch err {
+		case repository.ErrNotFound:
+			return herror.NotFound()
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+	if d.WebhookID != w.ID {
+		return herror.NotFound()
+	}
+
+	redelivered, err := h.OWH.Redeliver(d.ID)
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+
+	return c.JSON(http.StatusOK, formatOutgoingWebhookDelivery(redelivered))
+}
@@ -0,0 +1,349 @@
+package v3
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/router/session"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+func TestHandlers_GetOutgoingWebhooks(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/outgoing-webhooks"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	user2 := env.CreateUser(t, rand)
+	ch := env.CreateChannel(t, rand)
+	w := env.CreateOutgoingWebhook(t, user.GetID(), ch.ID)
+	env.CreateOutgoingWebhook(t, user2.GetID(), ch.ID)
+	s := env.S(t, user.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.GET(path).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Array()
+
+		obj.Length().Equal(1)
+		first := obj.First().Object()
+		first.Value("id").String().Equal(w.ID.String())
+		first.Value("secure").Boolean().True()
+		first.Value("events").Array().Elements(string(model.OutgoingWebhookEventMessagePinned))
+	})
+}
+
+func TestHandlers_CreateOutgoingWebhook(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/outgoing-webhooks"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	ch := env.CreateChannel(t, rand)
+	s := env.S(t, user.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path).
+			WithJSON(&PostOutgoingWebhookRequest{
+				ChannelID: ch.ID,
+				URL:       "https://example.com/hook",
+				Events:    model.OutgoingWebhookEventTypes{model.OutgoingWebhookEventMessagePinned},
+			}).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("bad request (invalid event)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostOutgoingWebhookRequest{
+				ChannelID: ch.ID,
+				URL:       "https://example.com/hook",
+				Events:    model.OutgoingWebhookEventTypes{"INVALID"},
+			}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("bad request (not http url)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostOutgoingWebhookRequest{
+				ChannelID: ch.ID,
+				URL:       "ftp://example.com/hook",
+				Events:    model.OutgoingWebhookEventTypes{model.OutgoingWebhookEventMessagePinned},
+			}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.POST(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostOutgoingWebhookRequest{
+				ChannelID: ch.ID,
+				URL:       "https://example.com/hook",
+				Events:    model.OutgoingWebhookEventTypes{model.OutgoingWebhookEventMessagePinned},
+			}).
+			Expect().
+			Status(http.StatusCreated).
+			JSON().
+			Object()
+
+		obj.Value("channelId").String().Equal(ch.ID.String())
+		obj.Value("ownerId").String().Equal(user.GetID().String())
+		obj.Value("url").String().Equal("https://example.com/hook")
+		obj.Value("secure").Boolean().False()
+
+		w, err := env.Repository.GetOutgoingWebhook(uuid.FromStringOrNil(obj.Value("id").String().Raw()))
+		require.NoError(t, err)
+		assert.EqualValues(t, model.OutgoingWebhookEventTypes{model.OutgoingWebhookEventMessagePinned}, w.Events)
+	})
+}
+
+func TestHandlers_GetOutgoingWebhook(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/outgoing-webhooks/{outgoingWebhookId}"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	user2 := env.CreateUser(t, rand)
+	ch := env.CreateChannel(t, rand)
+	w := env.CreateOutgoingWebhook(t, user.GetID(), ch.ID)
+	s := env.S(t, user.GetID())
+	s2 := env.S(t, user2.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, w.ID).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, w.ID).
+			WithCookie(session.CookieName, s2).
+			Expect().
+			Status(http.StatusForbidden)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, uuid.Must(uuid.NewV4())).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.GET(path, w.ID).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Object()
+
+		obj.Value("id").String().Equal(w.ID.String())
+		obj.Value("channelId").String().Equal(ch.ID.String())
+		obj.Value("secure").Boolean().True()
+	})
+}
+
+func TestHandlers_EditOutgoingWebhook(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/outgoing-webhooks/{outgoingWebhookId}"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	ch := env.CreateChannel(t, rand)
+	w := env.CreateOutgoingWebhook(t, user.GetID(), ch.ID)
+	s := env.S(t, user.GetID())
+
+	t.Run("bad request (empty events)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PATCH(path, w.ID).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PatchOutgoingWebhookRequest{Events: optional.From(model.OutgoingWebhookEventTypes{})}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PATCH(path, w.ID).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PatchOutgoingWebhookRequest{
+				Secret: optional.From(""),
+				Events: optional.From(model.OutgoingWebhookEventTypes{model.OutgoingWebhookEventMessagePinned, model.OutgoingWebhookEventMessageStamped}),
+			}).
+			Expect().
+			Status(http.StatusNoContent)
+
+		w, err := env.Repository.GetOutgoingWebhook(w.ID)
+		require.NoError(t, err)
+		assert.Empty(t, w.Secret)
+		assert.True(t, w.Events.Contains(model.OutgoingWebhookEventMessageStamped))
+	})
+}
+
+func TestHandlers_DeleteOutgoingWebhook(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/outgoing-webhooks/{outgoingWebhookId}"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	user2 := env.CreateUser(t, rand)
+	ch := env.CreateChannel(t, rand)
+	w := env.CreateOutgoingWebhook(t, user.GetID(), ch.ID)
+	w2 := env.CreateOutgoingWebhook(t, user.GetID(), ch.ID)
+	s := env.S(t, user.GetID())
+	s2 := env.S(t, user2.GetID())
+
+	t.Run("forbidden", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.DELETE(path, w2.ID).
+			WithCookie(session.CookieName, s2).
+			Expect().
+			Status(http.StatusForbidden)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.DELETE(path, w.ID).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusNoContent)
+
+		_, err := env.Repository.GetOutgoingWebhook(w.ID)
+		assert.ErrorIs(t, err, repository.ErrNotFound)
+	})
+}
+
+func TestHandlers_GetOutgoingWebhookDeliveries(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/outgoing-webhooks/{outgoingWebhookId}/deliveries"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	ch := env.CreateChannel(t, rand)
+	w := env.CreateOutgoingWebhook(t, user.GetID(), ch.ID)
+	s := env.S(t, user.GetID())
+
+	d := &model.OutgoingWebhookDelivery{
+		ID:        uuid.Must(uuid.NewV4()),
+		WebhookID: w.ID,
+		Event:     model.OutgoingWebhookEventMessagePinned,
+		Body:      "{}",
+		Result:    "ok",
+		Code:      http.StatusNoContent,
+		Attempts:  1,
+		DateTime:  time.Now(),
+	}
+	require.NoError(t, env.Repository.WriteOutgoingWebhookDelivery(d))
+
+	t.Run("bad request (limit)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, w.ID).
+			WithCookie(session.CookieName, s).
+			WithQuery("limit", 500).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.GET(path, w.ID).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Array()
+
+		obj.Length().Equal(1)
+		first := obj.First().Object()
+		first.Value("id").String().Equal(d.ID.String())
+		first.Value("result").String().Equal("ok")
+		first.Value("code").Number().Equal(http.StatusNoContent)
+	})
+}
+
+func TestHandlers_RedeliverOutgoingWebhookDelivery(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/outgoing-webhooks/{outgoingWebhookId}/deliveries/{deliveryId}/redeliver"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	ch := env.CreateChannel(t, rand)
+	w := env.CreateOutgoingWebhook(t, user.GetID(), ch.ID)
+	w2 := env.CreateOutgoingWebhook(t, user.GetID(), ch.ID)
+	s := env.S(t, user.GetID())
+
+	d := &model.OutgoingWebhookDelivery{
+		ID:        uuid.Must(uuid.NewV4()),
+		WebhookID: w2.ID,
+		Event:     model.OutgoingWebhookEventMessagePinned,
+		Body:      "{}",
+		Result:    "ng",
+		Attempts:  5,
+		DateTime:  time.Now(),
+	}
+	require.NoError(t, env.Repository.WriteOutgoingWebhookDelivery(d))
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, w.ID, uuid.Must(uuid.NewV4())).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("not found (other webhook's delivery)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, w.ID, d.ID).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+}
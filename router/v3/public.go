@@ -1,8 +1,10 @@
 package v3
 
 import (
+	"html/template"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/labstack/echo/v4"
 
@@ -67,3 +69,123 @@ func (h *Handlers) GetPublicUserIcon(c echo.Context) error {
 	http.ServeContent(c.Response(), c.Request(), meta.GetFileName(), meta.GetCreatedAt(), file)
 	return nil
 }
+
+// unsubscribeEmailConfirmTemplate メール配信停止の確認ページ
+var unsubscribeEmailConfirmTemplate = template.Must(template.New("unsubscribe").Parse(`<!DOCTYPE html>
+<html lang="ja">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>メール通知の配信停止</title>
+</head>
+<body>
+<p>traQからのメール通知(即時通知・まとめメール)の配信を停止しますか？</p>
+<form method="post" action="?token={{.}}">
+<button type="submit">配信を停止する</button>
+</form>
+</body>
+</html>
+`))
+
+// GetUnsubscribeEmail GET /public/email/unsubscribe
+//
+// メールクライアントやリンクスキャナによる先読みで配信停止されないように、確認ページのみを返します。
+func (h *Handlers) GetUnsubscribeEmail(c echo.Context) error {
+	token := c.QueryParam("token")
+	if len(token) == 0 {
+		return herror.BadRequest("token is required")
+	}
+
+	ok, err := h.Repo.ExistsEmailUnsubscribeToken(token)
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+	if !ok {
+		return herror.NotFound("invalid token")
+	}
+
+	var b strings.Builder
+	if err := unsubscribeEmailConfirmTemplate.Execute(&b, token); err != nil {
+		return herror.InternalServerError(err)
+	}
+	return c.HTML(http.StatusOK, b.String())
+}
+
+// UnsubscribeEmail POST /public/email/unsubscribe
+func (h *Handlers) UnsubscribeEmail(c echo.Context) error {
+	token := c.QueryParam("token")
+	if len(token) == 0 {
+		return herror.BadRequest("token is required")
+	}
+
+	if err := h.Repo.DisableEmailNotificationByToken(token); err != nil {
+		switch err {
+		case repository.ErrNotFound:
+			return herror.NotFound("invalid token")
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+
+	return c.String(http.StatusOK, "メール通知の配信を停止しました")
+}
+
+// verifyEmailConfirmTemplate メール通知の宛先確認ページ
+var verifyEmailConfirmTemplate = template.Must(template.New("verify").Parse(`<!DOCTYPE html>
+<html lang="ja">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>メール通知の宛先の確認</title>
+</head>
+<body>
+<p>このアドレスをtraQのメール通知の宛先に設定しますか？</p>
+<form method="post" action="?token={{.}}">
+<button type="submit">宛先に設定する</button>
+</form>
+</body>
+</html>
+`))
+
+// GetVerifyEmail GET /public/email/verify
+//
+// メールクライアントやリンクスキャナによる先読みで確認されないように、確認ページのみを返します。
+func (h *Handlers) GetVerifyEmail(c echo.Context) error {
+	token := c.QueryParam("token")
+	if len(token) == 0 {
+		return herror.BadRequest("token is required")
+	}
+
+	ok, err := h.Repo.ExistsEmailVerificationToken(token)
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+	if !ok {
+		return herror.NotFound("invalid token")
+	}
+
+	var b strings.Builder
+	if err := verifyEmailConfirmTemplate.Execute(&b, token); err != nil {
+		return herror.InternalServerError(err)
+	}
+	return c.HTML(http.StatusOK, b.String())
+}
+
+// VerifyEmail POST /public/email/verify
+func (h *Handlers) VerifyEmail(c echo.Context) error {
+	token := c.QueryParam("token")
+	if len(token) == 0 {
+		return herror.BadRequest("token is required")
+	}
+
+	if err := h.Repo.VerifyEmailAddressByToken(token); err != nil {
+		switch err {
+		case repository.ErrNotFound:
+			return herror.NotFound("invalid token")
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+
+	return c.String(http.StatusOK, "メール通知の宛先を設定しました")
+}
@@ -4,8 +4,10 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/traPtitech/traQ/model"
 	"github.com/traPtitech/traQ/repository"
 	file2 "github.com/traPtitech/traQ/service/file"
 	"github.com/traPtitech/traQ/service/rbac/role"
@@ -58,3 +60,133 @@ func TestHandlers_GetPublicUserIcon(t *testing.T) {
 		Status(http.StatusOK).
 		ContentType("image/png")
 }
+
+func TestHandlers_UnsubscribeEmail(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/public/email/unsubscribe"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	require.NoError(t, env.Repository.UpdateEmailSettings(user.GetID(), repository.UpdateEmailSettingsArgs{
+		Address:   "test@example.com",
+		Immediate: true,
+		Digest:    model.EmailDigestHourly,
+	}))
+	us, err := env.Repository.GetUserSettings(user.GetID())
+	require.NoError(t, err)
+
+	t.Run("bad request", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path).
+			WithQuery("token", "invalid").
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("not found (POST)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path).
+			WithQuery("token", "invalid").
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	// 配信停止されていないことを確認するため、successより先に実行する
+	t.Run("confirmation page", func(t *testing.T) {
+		e := env.R(t)
+		e.GET(path).
+			WithQuery("token", us.EmailUnsubscribeToken).
+			Expect().
+			Status(http.StatusOK).
+			ContentType("text/html")
+
+		// GETでは配信停止しない
+		us, err := env.Repository.GetUserSettings(user.GetID())
+		require.NoError(t, err)
+		assert.True(t, us.EmailImmediate)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path).
+			WithQuery("token", us.EmailUnsubscribeToken).
+			Expect().
+			Status(http.StatusOK)
+
+		us, err := env.Repository.GetUserSettings(user.GetID())
+		require.NoError(t, err)
+		assert.False(t, us.EmailImmediate)
+		assert.Equal(t, model.EmailDigestNone, us.EmailDigest)
+	})
+}
+
+func TestHandlers_VerifyEmail(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/public/email/verify"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	require.NoError(t, env.Repository.UpdateEmailSettings(user.GetID(), repository.UpdateEmailSettingsArgs{
+		Address:   "test@example.com",
+		Immediate: true,
+	}))
+	us, err := env.Repository.GetUserSettings(user.GetID())
+	require.NoError(t, err)
+
+	t.Run("bad request", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path).
+			WithQuery("token", "invalid").
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	// 確認されていないことを確認するため、successより先に実行する
+	t.Run("confirmation page", func(t *testing.T) {
+		e := env.R(t)
+		e.GET(path).
+			WithQuery("token", us.EmailVerificationToken).
+			Expect().
+			Status(http.StatusOK).
+			ContentType("text/html")
+
+		// GETでは確認しない
+		us, err := env.Repository.GetUserSettings(user.GetID())
+		require.NoError(t, err)
+		assert.Empty(t, us.EmailAddress)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path).
+			WithQuery("token", us.EmailVerificationToken).
+			Expect().
+			Status(http.StatusOK)
+
+		us, err := env.Repository.GetUserSettings(user.GetID())
+		require.NoError(t, err)
+		assert.Equal(t, "test@example.com", us.EmailAddress)
+		assert.Empty(t, us.PendingEmailAddress)
+	})
+}
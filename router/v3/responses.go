@@ -5,9 +5,12 @@ import (
 	"time"
 
 	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/service/rbac/permission"
+	"github.com/traPtitech/traQ/service/search"
 	"github.com/traPtitech/traQ/utils/optional"
 
 	"github.com/gofrs/uuid"
+	jsonIter "github.com/json-iterator/go"
 )
 
 type Channel struct {
@@ -209,28 +212,59 @@ func formatBots(bs []*model.Bot) []*Bot {
 	return res
 }
 
+type BotSlashCommand struct {
+	ID          uuid.UUID                    `json:"id"`
+	BotID       uuid.UUID                    `json:"botId"`
+	Name        string                       `json:"name"`
+	Description string                       `json:"description"`
+	Options     model.BotSlashCommandOptions `json:"options"`
+}
+
+func formatBotSlashCommand(cmd *model.BotSlashCommand) *BotSlashCommand {
+	options := cmd.Options
+	if options == nil {
+		options = model.BotSlashCommandOptions{}
+	}
+	return &BotSlashCommand{
+		ID:          cmd.ID,
+		BotID:       cmd.BotID,
+		Name:        cmd.Name,
+		Description: cmd.Description,
+		Options:     options,
+	}
+}
+
+func formatBotSlashCommands(cmds []*model.BotSlashCommand) []*BotSlashCommand {
+	res := make([]*BotSlashCommand, len(cmds))
+	for i, cmd := range cmds {
+		res[i] = formatBotSlashCommand(cmd)
+	}
+	return res
+}
+
 type BotTokens struct {
 	VerificationToken string `json:"verificationToken"`
 	AccessToken       string `json:"accessToken"`
 }
 
 type BotDetail struct {
-	ID              uuid.UUID           `json:"id"`
-	BotUserID       uuid.UUID           `json:"botUserId"`
-	Description     string              `json:"description"`
-	DeveloperID     uuid.UUID           `json:"developerId"`
-	SubscribeEvents model.BotEventTypes `json:"subscribeEvents"`
-	Mode            model.BotMode       `json:"mode"`
-	State           model.BotState      `json:"state"`
-	CreatedAt       time.Time           `json:"createdAt"`
-	UpdatedAt       time.Time           `json:"updatedAt"`
-	Tokens          BotTokens           `json:"tokens"`
-	Endpoint        string              `json:"endpoint"`
-	Privileged      bool                `json:"privileged"`
-	Channels        []uuid.UUID         `json:"channels"`
-}
-
-func formatBotDetail(b *model.Bot, t *model.OAuth2Token, channels []uuid.UUID) *BotDetail {
+	ID              uuid.UUID               `json:"id"`
+	BotUserID       uuid.UUID               `json:"botUserId"`
+	Description     string                  `json:"description"`
+	DeveloperID     uuid.UUID               `json:"developerId"`
+	SubscribeEvents model.BotEventTypes     `json:"subscribeEvents"`
+	Mode            model.BotMode           `json:"mode"`
+	State           model.BotState          `json:"state"`
+	CreatedAt       time.Time               `json:"createdAt"`
+	UpdatedAt       time.Time               `json:"updatedAt"`
+	Tokens          BotTokens               `json:"tokens"`
+	Endpoint        string                  `json:"endpoint"`
+	Privileged      bool                    `json:"privileged"`
+	Channels        []uuid.UUID             `json:"channels"`
+	Permissions     []permission.Permission `json:"permissions"`
+}
+
+func formatBotDetail(b *model.Bot, t *model.OAuth2Token, channels []uuid.UUID, permissions []permission.Permission) *BotDetail {
 	return &BotDetail{
 		ID:              b.ID,
 		BotUserID:       b.BotUserID,
@@ -245,9 +279,10 @@ func formatBotDetail(b *model.Bot, t *model.OAuth2Token, channels []uuid.UUID) *
 			VerificationToken: b.VerificationToken,
 			AccessToken:       t.AccessToken,
 		},
-		Endpoint:   b.PostURL,
-		Privileged: b.Privileged,
-		Channels:   channels,
+		Endpoint:    b.PostURL,
+		Privileged:  b.Privileged,
+		Channels:    channels,
+		Permissions: permissions,
 	}
 }
 
@@ -279,29 +314,230 @@ func formatBotEventLogs(logs []*model.BotEventLog) []*botEventLogResponse {
 	return res
 }
 
+type botEventDeliveryResponse struct {
+	ID        uuid.UUID          `json:"id"`
+	BotID     uuid.UUID          `json:"botId"`
+	Event     model.BotEventType `json:"event"`
+	Attempts  int                `json:"attempts"`
+	LastError string             `json:"lastError"`
+	CreatedAt time.Time          `json:"createdAt"`
+	UpdatedAt time.Time          `json:"updatedAt"`
+}
+
+func formatBotEventDelivery(d *model.BotEventDelivery) *botEventDeliveryResponse {
+	return &botEventDeliveryResponse{
+		ID:        d.ID,
+		BotID:     d.BotID,
+		Event:     d.Event,
+		Attempts:  d.Attempts,
+		LastError: d.LastError,
+		CreatedAt: d.CreatedAt,
+		UpdatedAt: d.UpdatedAt,
+	}
+}
+
+func formatBotEventDeliveries(ds []*model.BotEventDelivery) []*botEventDeliveryResponse {
+	res := make([]*botEventDeliveryResponse, len(ds))
+	for i, d := range ds {
+		res[i] = formatBotEventDelivery(d)
+	}
+	return res
+}
+
+type OutgoingWebhook struct {
+	ID        uuid.UUID                       `json:"id"`
+	ChannelID uuid.UUID                       `json:"channelId"`
+	OwnerID   uuid.UUID                       `json:"ownerId"`
+	URL       string                          `json:"url"`
+	Secure    bool                            `json:"secure"`
+	Events    model.OutgoingWebhookEventTypes `json:"events"`
+	CreatedAt time.Time                       `json:"createdAt"`
+	UpdatedAt time.Time                       `json:"updatedAt"`
+}
+
+func formatOutgoingWebhook(w *model.OutgoingWebhook) *OutgoingWebhook {
+	return &OutgoingWebhook{
+		ID:        w.ID,
+		ChannelID: w.ChannelID,
+		OwnerID:   w.CreatorID,
+		URL:       w.URL,
+		Secure:    len(w.Secret) > 0,
+		Events:    w.Events,
+		CreatedAt: w.CreatedAt,
+		UpdatedAt: w.UpdatedAt,
+	}
+}
+
+func formatOutgoingWebhooks(ws []*model.OutgoingWebhook) []*OutgoingWebhook {
+	res := make([]*OutgoingWebhook, len(ws))
+	for i, w := range ws {
+		res[i] = formatOutgoingWebhook(w)
+	}
+	return res
+}
+
+type outgoingWebhookDeliveryResponse struct {
+	ID        uuid.UUID                      `json:"id"`
+	WebhookID uuid.UUID                      `json:"webhookId"`
+	Event     model.OutgoingWebhookEventType `json:"event"`
+	Result    string                         `json:"result"`
+	Code      int                            `json:"code"`
+	Attempts  int                            `json:"attempts"`
+	DateTime  time.Time                      `json:"datetime"`
+}
+
+func formatOutgoingWebhookDelivery(d *model.OutgoingWebhookDelivery) *outgoingWebhookDeliveryResponse {
+	return &outgoingWebhookDeliveryResponse{
+		ID:        d.ID,
+		WebhookID: d.WebhookID,
+		Event:     d.Event,
+		Result:    d.Result,
+		Code:      d.Code,
+		Attempts:  d.Attempts,
+		DateTime:  d.DateTime,
+	}
+}
+
+func formatOutgoingWebhookDeliveries(ds []*model.OutgoingWebhookDelivery) []*outgoingWebhookDeliveryResponse {
+	res := make([]*outgoingWebhookDeliveryResponse, len(ds))
+	for i, d := range ds {
+		res[i] = formatOutgoingWebhookDelivery(d)
+	}
+	return res
+}
+
 type Message struct {
-	ID        uuid.UUID              `json:"id"`
-	UserID    uuid.UUID              `json:"userId"`
-	ChannelID uuid.UUID              `json:"channelId"`
-	Content   string                 `json:"content"`
-	CreatedAt time.Time              `json:"createdAt"`
-	UpdatedAt time.Time              `json:"updatedAt"`
-	Pinned    bool                   `json:"pinned"`
-	Stamps    []model.MessageStamp   `json:"stamps"`
-	ThreadID  optional.Of[uuid.UUID] `json:"threadId"` // TODO
+	ID            uuid.UUID                 `json:"id"`
+	UserID        uuid.UUID                 `json:"userId"`
+	ChannelID     uuid.UUID                 `json:"channelId"`
+	Content       string                    `json:"content"`
+	CreatedAt     time.Time                 `json:"createdAt"`
+	UpdatedAt     time.Time                 `json:"updatedAt"`
+	Pinned        bool                      `json:"pinned"`
+	Stamps        []model.MessageStamp      `json:"stamps"`
+	ThreadID      optional.Of[uuid.UUID]    `json:"threadId"`
+	RevisionCount int                       `json:"revisionCount"`
+	Components    []*model.MessageComponent `json:"components"`
 }
 
 func formatMessage(m *model.Message) *Message {
 	return &Message{
-		ID:        m.ID,
-		UserID:    m.UserID,
-		ChannelID: m.ChannelID,
-		Content:   m.Text,
-		CreatedAt: m.CreatedAt,
-		UpdatedAt: m.UpdatedAt,
-		Pinned:    m.Pin != nil,
-		Stamps:    m.Stamps,
+		ID:            m.ID,
+		UserID:        m.UserID,
+		ChannelID:     m.ChannelID,
+		Content:       m.Text,
+		CreatedAt:     m.CreatedAt,
+		UpdatedAt:     m.UpdatedAt,
+		Pinned:        m.Pin != nil,
+		Stamps:        m.Stamps,
+		ThreadID:      m.ParentID,
+		RevisionCount: m.RevisionCount,
+		Components:    m.GetComponents(),
+	}
+}
+
+type MessageInteraction struct {
+	ID        uuid.UUID `json:"id"`
+	MessageID uuid.UUID `json:"messageId"`
+	UserID    uuid.UUID `json:"userId"`
+	CustomID  string    `json:"customId"`
+	Values    []string  `json:"values"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func formatMessageInteraction(i *model.MessageInteraction) *MessageInteraction {
+	values := []string(i.Values)
+	if values == nil {
+		values = []string{}
+	}
+	return &MessageInteraction{
+		ID:        i.ID,
+		MessageID: i.MessageID,
+		UserID:    i.UserID,
+		CustomID:  i.CustomID,
+		Values:    values,
+		CreatedAt: i.CreatedAt,
+	}
+}
+
+type MessageRevision struct {
+	ID        uuid.UUID `json:"id"`
+	UserID    uuid.UUID `json:"userId"`
+	Content   string    `json:"content"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func formatMessageRevisions(ams []*model.ArchivedMessage) []*MessageRevision {
+	res := make([]*MessageRevision, len(ams))
+	for i, am := range ams {
+		res[i] = &MessageRevision{
+			ID:        am.ID,
+			UserID:    am.UserID,
+			Content:   am.Text,
+			CreatedAt: am.DateTime,
+		}
+	}
+	return res
+}
+
+type ScheduledMessage struct {
+	ID          uuid.UUID `json:"id"`
+	ChannelID   uuid.UUID `json:"channelId"`
+	Content     string    `json:"content"`
+	ScheduledAt time.Time `json:"scheduledAt"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+func formatScheduledMessage(sm *model.ScheduledMessage) *ScheduledMessage {
+	return &ScheduledMessage{
+		ID:          sm.ID,
+		ChannelID:   sm.ChannelID,
+		Content:     sm.Text,
+		ScheduledAt: sm.ScheduledAt,
+		CreatedAt:   sm.CreatedAt,
+		UpdatedAt:   sm.UpdatedAt,
+	}
+}
+
+func formatScheduledMessages(sms []*model.ScheduledMessage) []*ScheduledMessage {
+	res := make([]*ScheduledMessage, len(sms))
+	for i, sm := range sms {
+		res[i] = formatScheduledMessage(sm)
+	}
+	return res
+}
+
+type SavedSearch struct {
+	ID        uuid.UUID           `json:"id"`
+	Name      string              `json:"name"`
+	Query     jsonIter.RawMessage `json:"query"`
+	CreatedAt time.Time           `json:"createdAt"`
+	UpdatedAt time.Time           `json:"updatedAt"`
+}
+
+func formatSavedSearch(ss *model.SavedSearch) *SavedSearch {
+	return &SavedSearch{
+		ID:        ss.ID,
+		Name:      ss.Name,
+		Query:     jsonIter.RawMessage(ss.Query),
+		CreatedAt: ss.CreatedAt,
+		UpdatedAt: ss.UpdatedAt,
+	}
+}
+
+func formatSavedSearches(sss []*model.SavedSearch) []*SavedSearch {
+	res := make([]*SavedSearch, len(sss))
+	for i, ss := range sss {
+		res[i] = formatSavedSearch(ss)
 	}
+	return res
+}
+
+type Thread struct {
+	ID         uuid.UUID `json:"id"`
+	ReplyCount int       `json:"replyCount"`
+	Following  bool      `json:"following"`
 }
 
 type Pin struct {
@@ -623,3 +859,34 @@ func formatStampPalettes(cfs []*model.StampPalette) []*StampPalette {
 	sort.Slice(res, func(i, j int) bool { return res[i].ID.String() < res[j].ID.String() })
 	return res
 }
+
+type MessageSearchHighlight struct {
+	MessageID uuid.UUID               `json:"messageId"`
+	Fragments []MessageSearchFragment `json:"fragments"`
+}
+
+type MessageSearchFragment struct {
+	Text    string                    `json:"text"`
+	Matches []MessageSearchMatchRange `json:"matches"`
+}
+
+type MessageSearchMatchRange struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+func formatMessageSearchHighlights(hs []search.Highlight) []MessageSearchHighlight {
+	res := make([]MessageSearchHighlight, len(hs))
+	for i, h := range hs {
+		fragments := make([]MessageSearchFragment, len(h.Fragments))
+		for j, f := range h.Fragments {
+			matches := make([]MessageSearchMatchRange, len(f.Matches))
+			for k, m := range f.Matches {
+				matches[k] = MessageSearchMatchRange{Start: m.Start, End: m.End}
+			}
+			fragments[j] = MessageSearchFragment{Text: f.Text, Matches: matches}
+		}
+		res[i] = MessageSearchHighlight{MessageID: h.MessageID, Fragments: fragments}
+	}
+	return res
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/traPtitech/traQ/service/imaging"
 	"github.com/traPtitech/traQ/service/message"
 	"github.com/traPtitech/traQ/service/ogp"
+	"github.com/traPtitech/traQ/service/outgoingwebhook"
 	"github.com/traPtitech/traQ/service/rbac"
 	"github.com/traPtitech/traQ/service/rbac/permission"
 	"github.com/traPtitech/traQ/service/search"
@@ -34,11 +35,13 @@ type Handlers struct {
 	Logger         *zap.Logger
 	OC             *counter.OnlineCounter
 	OGP            ogp.Service
+	OWH            outgoingwebhook.Service
 	VM             *viewer.Manager
 	WebRTC         *webrtcv3.Manager
 	Imaging        imaging.Processor
 	SessStore      session.Store
 	SearchEngine   search.Engine
+	EntitySearch   search.EntityEngine
 	ChannelManager channel.Manager
 	MessageManager message.Manager
 	FileManager    file.Manager
@@ -72,6 +75,7 @@ func (h *Handlers) Setup(e *echo.Group) {
 
 	requiresBotAccessPerm := middlewares.CheckBotAccessPerm(h.RBAC)
 	requiresWebhookAccessPerm := middlewares.CheckWebhookAccessPerm(h.RBAC)
+	requiresOutgoingWebhookAccessPerm := middlewares.CheckOutgoingWebhookAccessPerm(h.RBAC)
 	requiresFileAccessPerm := middlewares.CheckFileAccessPerm(h.FileManager)
 	requiresClientAccessPerm := middlewares.CheckClientAccessPerm(h.RBAC)
 	requiresMessageAccessPerm := middlewares.CheckMessageAccessPerm(h.ChannelManager)
@@ -136,6 +140,21 @@ func (h *Handlers) Setup(e *echo.Group) {
 					apiUsersMeStars.POST("", h.PostStar, requires(permission.EditChannelStar))
 					apiUsersMeStars.DELETE("/:channelID", h.RemoveMyStar, requires(permission.EditChannelStar))
 				}
+				apiUsersMe.GET("/threads", h.GetMyFollowingThreads, requires(permission.GetChannelSubscription), blockBot)
+				apiUsersMeScheduledMessages := apiUsersMe.Group("/scheduled-messages")
+				{
+					apiUsersMeScheduledMessages.GET("", h.GetMyScheduledMessages, requires(permission.GetMessage))
+					apiUsersMeScheduledMessages.POST("", h.PostScheduledMessage, bodyLimit(100), requires(permission.PostMessage))
+					apiUsersMeScheduledMessages.PATCH("/:scheduledMessageID", h.EditScheduledMessage, bodyLimit(100), requires(permission.PostMessage))
+					apiUsersMeScheduledMessages.DELETE("/:scheduledMessageID", h.DeleteScheduledMessage, requires(permission.PostMessage))
+				}
+				apiUsersMeSavedSearches := apiUsersMe.Group("/saved-searches", blockBot)
+				{
+					apiUsersMeSavedSearches.GET("", h.GetMySavedSearches, requires(permission.GetMe))
+					apiUsersMeSavedSearches.POST("", h.PostSavedSearch, requires(permission.EditMe))
+					apiUsersMeSavedSearches.PATCH("/:savedSearchID", h.EditSavedSearch, requires(permission.EditMe))
+					apiUsersMeSavedSearches.DELETE("/:savedSearchID", h.DeleteSavedSearch, requires(permission.EditMe))
+				}
 				apiUsersMeUnread := apiUsersMe.Group("/unread", blockBot)
 				{
 					apiUsersMeUnread.GET("", h.GetMyUnreadChannels, requires(permission.GetUnread))
@@ -167,6 +186,12 @@ func (h *Handlers) Setup(e *echo.Group) {
 					apiUsersMeSettings.GET("", h.GetMySettings, requires(permission.GetMe))
 					apiUsersMeSettings.GET("/notify-citation", h.GetMyNotifyCitation, requires(permission.GetMe))
 					apiUsersMeSettings.PUT("/notify-citation", h.PutMyNotifyCitation, requires(permission.EditMe))
+					apiUsersMeSettings.PUT("/dnd", h.PutMyDNDSettings, requires(permission.EditMe))
+					apiUsersMeSettings.PUT("/snooze", h.PutMySnooze, requires(permission.EditMe))
+					apiUsersMeSettings.PUT("/email", h.PutMyEmailSettings, requires(permission.EditMe))
+					apiUsersMeSettings.GET("/keywords", h.GetMyNotificationKeywords, requires(permission.GetMe))
+					apiUsersMeSettings.POST("/keywords", h.PostMyNotificationKeyword, requires(permission.EditMe))
+					apiUsersMeSettings.DELETE("/keywords/:keyword", h.DeleteMyNotificationKeyword, requires(permission.EditMe))
 				}
 			}
 		}
@@ -180,6 +205,7 @@ func (h *Handlers) Setup(e *echo.Group) {
 				apiChannelsCID.PATCH("", h.EditChannel, requires(permission.EditChannel))
 				apiChannelsCID.GET("/messages", h.GetMessages, requires(permission.GetMessage))
 				apiChannelsCID.POST("/messages", h.PostMessage, bodyLimit(100), requires(permission.PostMessage))
+				apiChannelsCID.POST("/ephemeral-messages", h.PostEphemeralMessage, bodyLimit(100), requires(permission.PostMessage))
 				apiChannelsCID.GET("/stats", h.GetChannelStats, requires(permission.GetChannel))
 				apiChannelsCID.GET("/topic", h.GetChannelTopic, requires(permission.GetChannel))
 				apiChannelsCID.PUT("/topic", h.EditChannelTopic, requires(permission.EditChannelTopic))
@@ -189,9 +215,12 @@ func (h *Handlers) Setup(e *echo.Group) {
 				apiChannelsCID.PUT("/subscribers", h.SetChannelSubscribers, requires(permission.EditChannelSubscription))
 				apiChannelsCID.PATCH("/subscribers", h.EditChannelSubscribers, requires(permission.EditChannelSubscription))
 				apiChannelsCID.GET("/bots", h.GetChannelBots, requires(permission.GetChannel))
+				apiChannelsCID.GET("/commands", h.GetChannelSlashCommands, requires(permission.GetChannel))
 				apiChannelsCID.GET("/events", h.GetChannelEvents, requires(permission.GetChannel))
+				apiChannelsCID.GET("/export", h.ExportChannel, requires(permission.ExportChannel))
 			}
 		}
+		api.GET("/search", h.Search, requires(permission.GetUser, permission.GetChannel, permission.DownloadFile))
 		apiMessages := api.Group("/messages")
 		{
 			apiMessages.GET("", h.SearchMessages, requires(permission.GetMessage))
@@ -200,10 +229,23 @@ func (h *Handlers) Setup(e *echo.Group) {
 				apiMessagesMID.GET("", h.GetMessage, requires(permission.GetMessage))
 				apiMessagesMID.PUT("", h.EditMessage, bodyLimit(100), requires(permission.EditMessage))
 				apiMessagesMID.DELETE("", h.DeleteMessage, requires(permission.DeleteMessage))
+				apiMessagesMID.GET("/revisions", h.GetMessageRevisions, requires(permission.GetMessageRevisions))
 				apiMessagesMID.GET("/pin", h.GetPin, requires(permission.GetMessage))
 				apiMessagesMID.POST("/pin", h.CreatePin, requires(permission.CreateMessagePin))
 				apiMessagesMID.DELETE("/pin", h.RemovePin, requires(permission.DeleteMessagePin))
 				apiMessagesMID.GET("/clips", h.GetMessageClips, requires(permission.GetClipFolder))
+				apiMessagesMID.POST("/report", h.ReportMessage, bodyLimit(100), requires(permission.ReportMessage))
+				apiMessagesMID.PUT("/components", h.SetMessageComponents, bodyLimit(100), requires(permission.EditMessage))
+				apiMessagesMID.POST("/interactions", h.PostMessageInteraction, requires(permission.InteractMessage))
+				apiMessagesMID.POST("/interactions/:interactionID/reply", h.ReplyMessageInteraction, bodyLimit(100), requires(permission.PostMessage))
+				apiMessagesMIDThread := apiMessagesMID.Group("/thread")
+				{
+					apiMessagesMIDThread.GET("", h.GetThread, requires(permission.GetMessage))
+					apiMessagesMIDThread.GET("/messages", h.GetThreadMessages, requires(permission.GetMessage))
+					apiMessagesMIDThread.POST("/messages", h.PostThreadMessage, bodyLimit(100), requires(permission.PostMessage))
+					apiMessagesMIDThread.POST("/follow", h.FollowThread, requires(permission.EditChannelSubscription))
+					apiMessagesMIDThread.DELETE("/follow", h.UnfollowThread, requires(permission.EditChannelSubscription))
+				}
 				apiMessagesMIDStamps := apiMessagesMID.Group("/stamps")
 				{
 					apiMessagesMIDStamps.GET("", h.GetMessageStamps, requires(permission.GetMessage))
@@ -215,6 +257,15 @@ func (h *Handlers) Setup(e *echo.Group) {
 				}
 			}
 		}
+		apiMessageReports := api.Group("/message-reports")
+		{
+			apiMessageReports.GET("", h.GetMessageReports, requires(permission.GetMessageReports))
+			apiMessageReportsRID := apiMessageReports.Group("/:reportID")
+			{
+				apiMessageReportsRID.GET("", h.GetMessageReport, requires(permission.GetMessageReports))
+				apiMessageReportsRID.PATCH("", h.EditMessageReport, bodyLimit(100), requires(permission.ResolveMessageReports))
+			}
+		}
 		apiFiles := api.Group("/files")
 		{
 			apiFiles.GET("", h.GetFiles, requires(permission.DownloadFile))
@@ -273,6 +324,19 @@ func (h *Handlers) Setup(e *echo.Group) {
 				apiWebhooksWID.GET("/messages", h.GetWebhookMessages, requires(permission.GetWebhook))
 			}
 		}
+		apiOutgoingWebhooks := api.Group("/outgoing-webhooks", blockBot)
+		{
+			apiOutgoingWebhooks.GET("", h.GetOutgoingWebhooks, requires(permission.GetWebhook))
+			apiOutgoingWebhooks.POST("", h.CreateOutgoingWebhook, requires(permission.CreateWebhook))
+			apiOutgoingWebhooksWID := apiOutgoingWebhooks.Group("/:outgoingWebhookID", retrieve.OutgoingWebhookID(), requiresOutgoingWebhookAccessPerm)
+			{
+				apiOutgoingWebhooksWID.GET("", h.GetOutgoingWebhook, requires(permission.GetWebhook))
+				apiOutgoingWebhooksWID.PATCH("", h.EditOutgoingWebhook, requires(permission.EditWebhook))
+				apiOutgoingWebhooksWID.DELETE("", h.DeleteOutgoingWebhook, requires(permission.DeleteWebhook))
+				apiOutgoingWebhooksWID.GET("/deliveries", h.GetOutgoingWebhookDeliveries, requires(permission.GetWebhook))
+				apiOutgoingWebhooksWID.POST("/deliveries/:deliveryID/redeliver", h.RedeliverOutgoingWebhookDelivery, requires(permission.EditWebhook))
+			}
+		}
 		apiGroups := api.Group("/groups")
 		{
 			apiGroups.GET("", h.GetUserGroups, requires(permission.GetUserGroup))
@@ -332,11 +396,14 @@ func (h *Handlers) Setup(e *echo.Group) {
 				apiBotsBID.GET("/icon", h.GetBotIcon, requires(permission.GetBot))
 				apiBotsBID.PUT("/icon", h.ChangeBotIcon, requiresBotAccessPerm, requires(permission.EditBot))
 				apiBotsBID.GET("/logs", h.GetBotLogs, requiresBotAccessPerm, requires(permission.GetBot))
+				apiBotsBID.GET("/commands", h.GetBotSlashCommands, requires(permission.GetBot))
+				apiBotsBID.PUT("/commands", h.SetBotSlashCommands, requiresBotAccessPerm, requires(permission.EditBotSlashCommand))
 				apiBotsBIDActions := apiBotsBID.Group("/actions", requiresBotAccessPerm)
 				{
 					apiBotsBIDActions.POST("/activate", h.ActivateBot, requires(permission.EditBot))
 					apiBotsBIDActions.POST("/inactivate", h.InactivateBot, requires(permission.EditBot))
 					apiBotsBIDActions.POST("/reissue", h.ReissueBot, requires(permission.EditBot))
+					apiBotsBIDActions.POST("/redeliver", h.RedeliverBotEvents, requires(permission.EditBot))
 					apiBotsBIDActions.POST("/join", h.LetBotJoinChannel, requires(permission.BotActionJoinChannel))
 					apiBotsBIDActions.POST("/leave", h.LetBotLeaveChannel, requires(permission.BotActionLeaveChannel))
 				}
@@ -380,6 +447,10 @@ func (h *Handlers) Setup(e *echo.Group) {
 		apiNoAuthPublic := apiNoAuth.Group("/public")
 		{
 			apiNoAuthPublic.GET("/icon/:username", h.GetPublicUserIcon)
+			apiNoAuthPublic.GET("/email/unsubscribe", h.GetUnsubscribeEmail)
+			apiNoAuthPublic.POST("/email/unsubscribe", h.UnsubscribeEmail)
+			apiNoAuthPublic.GET("/email/verify", h.GetVerifyEmail)
+			apiNoAuthPublic.POST("/email/verify", h.VerifyEmail)
 		}
 	}
 }
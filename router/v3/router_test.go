@@ -29,6 +29,7 @@ import (
 	"github.com/traPtitech/traQ/service/file"
 	"github.com/traPtitech/traQ/service/imaging"
 	"github.com/traPtitech/traQ/service/message"
+	"github.com/traPtitech/traQ/service/outgoingwebhook"
 	"github.com/traPtitech/traQ/service/rbac"
 	"github.com/traPtitech/traQ/service/rbac/role"
 	"github.com/traPtitech/traQ/service/search"
@@ -112,6 +113,10 @@ func TestMain(m *testing.M) {
 			ImageMagickPath:  "",
 		})
 		env.FM, _ = file.InitFileManager(repo, storage.NewInMemoryFileStorage(), env.IP, l.Named("FM"))
+		env.ES, err = search.NewEntityEngine(engine, env.Hub, env.CM, repo, l.Named("ES"))
+		if err != nil {
+			panic(err)
+		}
 
 		// テスト用サーバー作成
 		e := echo.New()
@@ -132,7 +137,9 @@ func TestMain(m *testing.M) {
 			ChannelManager: env.CM,
 			MessageManager: env.MM,
 			FileManager:    env.FM,
+			EntitySearch:   env.ES,
 			Logger:         l,
+			OWH:            outgoingwebhook.NewService(repo, env.CM, env.Hub, l.Named("OWH")),
 			Imaging:        env.IP,
 			Config: Config{
 				Version:         "version",
@@ -156,6 +163,7 @@ func TestMain(m *testing.M) {
 	// 後始末
 	for _, env := range envs {
 		env.Server.Close()
+		_ = env.ES.Close()
 		db, _ := env.DB.DB()
 		_ = db.Close()
 		env.Hub.Close()
@@ -172,6 +180,7 @@ type Env struct {
 	FM         file.Manager
 	IP         imaging.Processor
 	SE         search.Engine
+	ES         search.EntityEngine
 	Hub        *hub.Hub
 	SessStore  session.Store
 }
@@ -306,6 +315,17 @@ func (env *Env) CreateMessage(t *testing.T, userID, channelID uuid.UUID, text st
 	return m
 }
 
+// CreateReply スレッドに返信を必ず作成します
+func (env *Env) CreateReply(t *testing.T, userID, parentID uuid.UUID, text string) message.Message {
+	t.Helper()
+	if text == rand {
+		text = random.AlphaNumeric(20)
+	}
+	m, err := env.MM.CreateReply(parentID, userID, text)
+	require.NoError(t, err)
+	return m
+}
+
 // MakeMessageUnread 指定したメッセージを未読にします
 func (env *Env) MakeMessageUnread(t *testing.T, userID, messageID uuid.UUID) {
 	t.Helper()
@@ -391,7 +411,7 @@ func (env *Env) CreateBot(t *testing.T, name string, creatorID uuid.UUID) *model
 		name = random.AlphaNumeric(20)
 	}
 	f := env.CreateFile(t, creatorID, uuid.Nil)
-	b, err := env.Repository.CreateBot(name, "po", "totally a desc", f.GetID(), creatorID, model.BotModeHTTP, model.BotInactive, "https://example.com")
+	b, err := env.Repository.CreateBot(name, "po", "totally a desc", f.GetID(), creatorID, model.BotModeHTTP, model.BotInactive, "https://example.com", optional.Of[model.BotPermissions]{})
 	require.NoError(t, err)
 	return b
 }
@@ -408,6 +428,20 @@ func (env *Env) CreateWebhook(t *testing.T, name string, creatorID, channelID uu
 	return w
 }
 
+// CreateOutgoingWebhook 送信Webhookを必ず作成します
+func (env *Env) CreateOutgoingWebhook(t *testing.T, creatorID, channelID uuid.UUID) *model.OutgoingWebhook {
+	t.Helper()
+	w, err := env.Repository.CreateOutgoingWebhook(repository.CreateOutgoingWebhookArgs{
+		ChannelID: channelID,
+		CreatorID: creatorID,
+		URL:       "https://example.com/hook",
+		Secret:    random.SecureAlphaNumeric(20),
+		Events:    model.OutgoingWebhookEventTypes{model.OutgoingWebhookEventMessagePinned},
+	})
+	require.NoError(t, err)
+	return w
+}
+
 // CreateOAuth2Client OAuth2クライアントを必ず作成します
 func (env *Env) CreateOAuth2Client(t *testing.T, name string, creatorID uuid.UUID) *model.OAuth2Client {
 	t.Helper()
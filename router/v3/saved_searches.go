@@ -0,0 +1,192 @@
+package v3
+
+import (
+	"errors"
+	"net/http"
+
+	vd "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/router/consts"
+	"github.com/traPtitech/traQ/router/extension/herror"
+	"github.com/traPtitech/traQ/service/search"
+	"github.com/traPtitech/traQ/utils/optional"
+	"github.com/traPtitech/traQ/utils/validator"
+)
+
+// maxSavedSearches ユーザーあたりの保存された検索の最大数
+const maxSavedSearches = 20
+
+// savedSearchQueryRule 保存する検索クエリのバリデーションルール
+var savedSearchQueryRule = vd.By(func(value interface{}) error {
+	q, ok := value.(search.Query)
+	if !ok {
+		if o := value.(optional.Of[search.Query]); o.Valid {
+			q = o.V
+		} else {
+			return nil
+		}
+	}
+	if err := q.Validate(); err != nil {
+		return err
+	}
+	if !q.HasNarrowingConditions() {
+		return errors.New("must have at least one of word, in, to, from and citation")
+	}
+	return nil
+})
+
+// GetMySavedSearches GET /users/me/saved-searches
+func (h *Handlers) GetMySavedSearches(c echo.Context) error {
+	sss, err := h.Repo.GetSavedSearchesByUserID(getRequestUserID(c))
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+	return c.JSON(http.StatusOK, formatSavedSearches(sss))
+}
+
+// PostSavedSearchRequest POST /users/me/saved-searches リクエストボディ
+type PostSavedSearchRequest struct {
+	Name  string       `json:"name"`
+	Query search.Query `json:"query"`
+}
+
+func (r PostSavedSearchRequest) Validate() error {
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.Name, vd.Required, vd.RuneLength(1, 50)),
+		vd.Field(&r.Query, savedSearchQueryRule),
+	)
+}
+
+// PostSavedSearch POST /users/me/saved-searches
+func (h *Handlers) PostSavedSearch(c echo.Context) error {
+	userID := getRequestUserID(c)
+
+	var req PostSavedSearchRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	if err := h.checkSavedSearchQuery(userID, req.Query); err != nil {
+		return err
+	}
+
+	query, err := search.EncodeSavedQuery(req.Query)
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+	ss, err := h.Repo.CreateSavedSearch(userID, req.Name, query, maxSavedSearches)
+	if err != nil {
+		switch err {
+		case repository.ErrForbidden:
+			return herror.BadRequest("too many saved searches")
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+	return c.JSON(http.StatusCreated, formatSavedSearch(ss))
+}
+
+// PatchSavedSearchRequest PATCH /users/me/saved-searches/:savedSearchID リクエストボディ
+type PatchSavedSearchRequest struct {
+	Name  optional.Of[string]       `json:"name"`
+	Query optional.Of[search.Query] `json:"query"`
+}
+
+func (r PatchSavedSearchRequest) Validate() error {
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.Name, validator.RequiredIfValid, vd.RuneLength(1, 50)),
+		vd.Field(&r.Query, savedSearchQueryRule),
+	)
+}
+
+// EditSavedSearch PATCH /users/me/saved-searches/:savedSearchID
+func (h *Handlers) EditSavedSearch(c echo.Context) error {
+	ss, err := h.getMySavedSearch(c)
+	if err != nil {
+		return err
+	}
+
+	var req PatchSavedSearchRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	args := repository.UpdateSavedSearchArgs{
+		Name: req.Name,
+	}
+	if req.Query.Valid {
+		if err := h.checkSavedSearchQuery(ss.UserID, req.Query.V); err != nil {
+			return err
+		}
+		query, err := search.EncodeSavedQuery(req.Query.V)
+		if err != nil {
+			return herror.InternalServerError(err)
+		}
+		args.Query = optional.From(query)
+	}
+
+	if err := h.Repo.UpdateSavedSearch(ss.ID, args); err != nil {
+		switch err {
+		case repository.ErrNotFound:
+			return herror.NotFound()
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// DeleteSavedSearch DELETE /users/me/saved-searches/:savedSearchID
+func (h *Handlers) DeleteSavedSearch(c echo.Context) error {
+	ss, err := h.getMySavedSearch(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.Repo.DeleteSavedSearch(ss.ID); err != nil {
+		switch err {
+		case repository.ErrNotFound:
+			return herror.NotFound()
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// getMySavedSearch リクエストユーザーの保存された検索をパスパラメータから取得します
+func (h *Handlers) getMySavedSearch(c echo.Context) (*model.SavedSearch, error) {
+	ss, err := h.Repo.GetSavedSearch(getParamAsUUID(c, consts.ParamSavedSearchID))
+	if err != nil {
+		switch err {
+		case repository.ErrNotFound:
+			return nil, herror.NotFound()
+		default:
+			return nil, herror.InternalServerError(err)
+		}
+	}
+	// 他人の保存された検索は存在しないものとして扱う
+	if ss.UserID != getRequestUserID(c) {
+		return nil, herror.NotFound()
+	}
+	return ss, nil
+}
+
+// checkSavedSearchQuery 検索対象のチャンネルにアクセス可能かどうかを確認します
+func (h *Handlers) checkSavedSearchQuery(userID uuid.UUID, q search.Query) error {
+	if !q.In.Valid {
+		return nil
+	}
+	ok, err := h.ChannelManager.IsChannelAccessibleToUser(userID, q.In.V)
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+	if !ok {
+		return herror.BadRequest("invalid channelId")
+	}
+	return nil
+}
@@ -0,0 +1,239 @@
+package v3
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/router/session"
+)
+
+func (env *Env) CreateSavedSearch(t *testing.T, userID uuid.UUID, name string) *model.SavedSearch {
+	t.Helper()
+	ss, err := env.Repository.CreateSavedSearch(userID, name, `{"word":"incident"}`, maxSavedSearches)
+	require.NoError(t, err)
+	return ss
+}
+
+func TestHandlers_GetMySavedSearches(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/users/me/saved-searches"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	user2 := env.CreateUser(t, rand)
+	ss := env.CreateSavedSearch(t, user.GetID(), "a")
+	env.CreateSavedSearch(t, user2.GetID(), "b")
+	s := env.S(t, user.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.GET(path).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Array()
+
+		obj.Length().Equal(1)
+		first := obj.First().Object()
+		first.Value("id").String().Equal(ss.ID.String())
+		first.Value("name").String().Equal("a")
+		first.Value("query").Object().Value("word").String().Equal("incident")
+	})
+}
+
+func TestHandlers_PostSavedSearch(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/users/me/saved-searches"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	user2 := env.CreateUser(t, rand)
+	user3 := env.CreateUser(t, rand)
+	ch := env.CreateChannel(t, rand)
+	dm := env.CreateDMChannel(t, user2.GetID(), user3.GetID())
+	full := env.CreateUser(t, rand)
+	for i := 0; i < maxSavedSearches; i++ {
+		env.CreateSavedSearch(t, full.GetID(), "a")
+	}
+	s := env.S(t, user.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path).
+			WithJSON(map[string]interface{}{"name": "a", "query": map[string]interface{}{"word": "incident"}}).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("bad request (empty name)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(map[string]interface{}{"name": "", "query": map[string]interface{}{"word": "incident"}}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("bad request (no conditions)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(map[string]interface{}{"name": "a", "query": map[string]interface{}{"hasURL": true}}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("bad request (inaccessible dm)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(map[string]interface{}{"name": "a", "query": map[string]interface{}{"in": dm.ID}}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("bad request (too many)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path).
+			WithCookie(session.CookieName, env.S(t, full.GetID())).
+			WithJSON(map[string]interface{}{"name": "a", "query": map[string]interface{}{"word": "incident"}}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.POST(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(map[string]interface{}{"name": "ops", "query": map[string]interface{}{"word": "incident", "in": ch.ID}}).
+			Expect().
+			Status(http.StatusCreated).
+			JSON().
+			Object()
+
+		obj.Value("id").String().NotEmpty()
+		obj.Value("name").String().Equal("ops")
+		query := obj.Value("query").Object()
+		query.Value("word").String().Equal("incident")
+		query.Value("in").String().Equal(ch.ID.String())
+	})
+}
+
+func TestHandlers_EditSavedSearch(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/users/me/saved-searches/{savedSearchId}"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	user2 := env.CreateUser(t, rand)
+	ss := env.CreateSavedSearch(t, user.GetID(), "a")
+	other := env.CreateSavedSearch(t, user2.GetID(), "b")
+	s := env.S(t, user.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PATCH(path, ss.ID).
+			WithJSON(map[string]interface{}{"name": "c"}).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PATCH(path, other.ID).
+			WithCookie(session.CookieName, s).
+			WithJSON(map[string]interface{}{"name": "c"}).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("bad request", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PATCH(path, ss.ID).
+			WithCookie(session.CookieName, s).
+			WithJSON(map[string]interface{}{"query": map[string]interface{}{}}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PATCH(path, ss.ID).
+			WithCookie(session.CookieName, s).
+			WithJSON(map[string]interface{}{"name": "c", "query": map[string]interface{}{"word": "outage"}}).
+			Expect().
+			Status(http.StatusNoContent)
+
+		res, err := env.Repository.GetSavedSearch(ss.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "c", res.Name)
+		assert.Contains(t, res.Query, "outage")
+	})
+}
+
+func TestHandlers_DeleteSavedSearch(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/users/me/saved-searches/{savedSearchId}"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	user2 := env.CreateUser(t, rand)
+	ss := env.CreateSavedSearch(t, user.GetID(), "a")
+	other := env.CreateSavedSearch(t, user2.GetID(), "b")
+	s := env.S(t, user.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.DELETE(path, ss.ID).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.DELETE(path, other.ID).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.DELETE(path, ss.ID).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusNoContent)
+
+		_, err := env.Repository.GetSavedSearch(ss.ID)
+		assert.ErrorIs(t, err, repository.ErrNotFound)
+	})
+}
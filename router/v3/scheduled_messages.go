@@ -0,0 +1,162 @@
+package v3
+
+import (
+	"net/http"
+	"time"
+
+	vd "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/router/consts"
+	"github.com/traPtitech/traQ/router/extension/herror"
+	"github.com/traPtitech/traQ/utils/optional"
+	"github.com/traPtitech/traQ/utils/validator"
+)
+
+// GetMyScheduledMessages GET /users/me/scheduled-messages
+func (h *Handlers) GetMyScheduledMessages(c echo.Context) error {
+	sms, err := h.Repo.GetScheduledMessagesByUserID(getRequestUserID(c))
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+	return c.JSON(http.StatusOK, formatScheduledMessages(sms))
+}
+
+// PostScheduledMessageRequest POST /users/me/scheduled-messages リクエストボディ
+type PostScheduledMessageRequest struct {
+	ChannelID   uuid.UUID `json:"channelId"`
+	Content     string    `json:"content"`
+	ScheduledAt time.Time `json:"scheduledAt"`
+	Embed       bool      `json:"embed"`
+}
+
+func (r PostScheduledMessageRequest) Validate() error {
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.ChannelID, vd.Required, validator.NotNilUUID),
+		vd.Field(&r.Content, vd.Required, vd.RuneLength(1, 10000)),
+		vd.Field(&r.ScheduledAt, vd.Required, vd.Min(time.Now()).Error("must be in the future")),
+	)
+}
+
+// PostScheduledMessage POST /users/me/scheduled-messages
+func (h *Handlers) PostScheduledMessage(c echo.Context) error {
+	userID := getRequestUserID(c)
+
+	var req PostScheduledMessageRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	if err := h.checkScheduledMessageChannel(userID, req.ChannelID); err != nil {
+		return err
+	}
+
+	if req.Embed {
+		req.Content = h.Replacer.Replace(req.Content)
+	}
+
+	sm, err := h.Repo.CreateScheduledMessage(userID, req.ChannelID, req.Content, req.ScheduledAt)
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+	return c.JSON(http.StatusCreated, formatScheduledMessage(sm))
+}
+
+// PatchScheduledMessageRequest PATCH /users/me/scheduled-messages/:scheduledMessageID リクエストボディ
+type PatchScheduledMessageRequest struct {
+	Content     optional.Of[string]    `json:"content"`
+	ScheduledAt optional.Of[time.Time] `json:"scheduledAt"`
+	Embed       bool                   `json:"embed"`
+}
+
+func (r PatchScheduledMessageRequest) Validate() error {
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.Content, validator.RequiredIfValid, vd.RuneLength(1, 10000)),
+		vd.Field(&r.ScheduledAt, validator.RequiredIfValid, vd.Min(time.Now()).Error("must be in the future")),
+	)
+}
+
+// EditScheduledMessage PATCH /users/me/scheduled-messages/:scheduledMessageID
+func (h *Handlers) EditScheduledMessage(c echo.Context) error {
+	sm, err := h.getMyScheduledMessage(c)
+	if err != nil {
+		return err
+	}
+
+	var req PatchScheduledMessageRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	if req.Embed && req.Content.Valid {
+		req.Content.V = h.Replacer.Replace(req.Content.V)
+	}
+
+	args := repository.UpdateScheduledMessageArgs{
+		Text:        req.Content,
+		ScheduledAt: req.ScheduledAt,
+	}
+	if err := h.Repo.UpdateScheduledMessage(sm.ID, args); err != nil {
+		switch err {
+		case repository.ErrNotFound:
+			return herror.NotFound("the scheduled message has already been posted or deleted")
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// DeleteScheduledMessage DELETE /users/me/scheduled-messages/:scheduledMessageID
+func (h *Handlers) DeleteScheduledMessage(c echo.Context) error {
+	sm, err := h.getMyScheduledMessage(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.Repo.DeleteScheduledMessage(sm.ID); err != nil {
+		switch err {
+		case repository.ErrNotFound:
+			return herror.NotFound("the scheduled message has already been posted or deleted")
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// getMyScheduledMessage リクエストユーザーの予約投稿メッセージをパスパラメータから取得します
+func (h *Handlers) getMyScheduledMessage(c echo.Context) (*model.ScheduledMessage, error) {
+	sm, err := h.Repo.GetScheduledMessage(getParamAsUUID(c, consts.ParamScheduledMessageID))
+	if err != nil {
+		switch err {
+		case repository.ErrNotFound:
+			return nil, herror.NotFound()
+		default:
+			return nil, herror.InternalServerError(err)
+		}
+	}
+	// 他人の予約投稿は存在しないものとして扱う
+	if sm.UserID != getRequestUserID(c) {
+		return nil, herror.NotFound()
+	}
+	return sm, nil
+}
+
+// checkScheduledMessageChannel 予約投稿先のチャンネルに投稿可能かどうかを確認します
+func (h *Handlers) checkScheduledMessageChannel(userID, channelID uuid.UUID) error {
+	ok, err := h.ChannelManager.IsChannelAccessibleToUser(userID, channelID)
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+	if !ok {
+		return herror.BadRequest("invalid channelId")
+	}
+	if h.ChannelManager.IsPublicChannel(channelID) && h.ChannelManager.PublicChannelTree().IsArchivedChannel(channelID) {
+		return herror.BadRequest("this channel has been archived")
+	}
+	return nil
+}
@@ -0,0 +1,238 @@
+package v3
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/router/session"
+)
+
+func (env *Env) CreateScheduledMessage(t *testing.T, userID, channelID uuid.UUID, text string) *model.ScheduledMessage {
+	t.Helper()
+	sm, err := env.Repository.CreateScheduledMessage(userID, channelID, text, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	return sm
+}
+
+func TestHandlers_GetMyScheduledMessages(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/users/me/scheduled-messages"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	user2 := env.CreateUser(t, rand)
+	ch := env.CreateChannel(t, rand)
+	sm := env.CreateScheduledMessage(t, user.GetID(), ch.ID, "a")
+	env.CreateScheduledMessage(t, user2.GetID(), ch.ID, "b")
+	s := env.S(t, user.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.GET(path).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Array()
+
+		obj.Length().Equal(1)
+		first := obj.First().Object()
+		first.Value("id").String().Equal(sm.ID.String())
+		first.Value("channelId").String().Equal(ch.ID.String())
+		first.Value("content").String().Equal("a")
+	})
+}
+
+func TestHandlers_PostScheduledMessage(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/users/me/scheduled-messages"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	user2 := env.CreateUser(t, rand)
+	user3 := env.CreateUser(t, rand)
+	ch := env.CreateChannel(t, rand)
+	archived := env.CreateChannel(t, rand)
+	require.NoError(t, env.CM.ArchiveChannel(archived.ID, user.GetID()))
+	dm := env.CreateDMChannel(t, user2.GetID(), user3.GetID())
+	s := env.S(t, user.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path).
+			WithJSON(&PostScheduledMessageRequest{ChannelID: ch.ID, Content: "a", ScheduledAt: time.Now().Add(time.Hour)}).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("bad request (past)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostScheduledMessageRequest{ChannelID: ch.ID, Content: "a", ScheduledAt: time.Now().Add(-time.Hour)}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("bad request (empty content)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostScheduledMessageRequest{ChannelID: ch.ID, Content: "", ScheduledAt: time.Now().Add(time.Hour)}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("bad request (archived)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostScheduledMessageRequest{ChannelID: archived.ID, Content: "a", ScheduledAt: time.Now().Add(time.Hour)}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("bad request (inaccessible dm)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostScheduledMessageRequest{ChannelID: dm.ID, Content: "a", ScheduledAt: time.Now().Add(time.Hour)}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.POST(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostScheduledMessageRequest{ChannelID: ch.ID, Content: "a", ScheduledAt: time.Now().Add(time.Hour)}).
+			Expect().
+			Status(http.StatusCreated).
+			JSON().
+			Object()
+
+		obj.Value("channelId").String().Equal(ch.ID.String())
+		obj.Value("content").String().Equal("a")
+		obj.Value("scheduledAt").String().NotEmpty()
+	})
+}
+
+func TestHandlers_EditScheduledMessage(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/users/me/scheduled-messages/{scheduledMessageId}"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	user2 := env.CreateUser(t, rand)
+	ch := env.CreateChannel(t, rand)
+	sm := env.CreateScheduledMessage(t, user.GetID(), ch.ID, "a")
+	other := env.CreateScheduledMessage(t, user2.GetID(), ch.ID, "b")
+	s := env.S(t, user.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PATCH(path, sm.ID).
+			WithJSON(map[string]interface{}{"content": "c"}).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PATCH(path, other.ID).
+			WithCookie(session.CookieName, s).
+			WithJSON(map[string]interface{}{"content": "c"}).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("bad request", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PATCH(path, sm.ID).
+			WithCookie(session.CookieName, s).
+			WithJSON(map[string]interface{}{"scheduledAt": time.Now().Add(-time.Hour)}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PATCH(path, sm.ID).
+			WithCookie(session.CookieName, s).
+			WithJSON(map[string]interface{}{"content": "c"}).
+			Expect().
+			Status(http.StatusNoContent)
+
+		res, err := env.Repository.GetScheduledMessage(sm.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "c", res.Text)
+	})
+}
+
+func TestHandlers_DeleteScheduledMessage(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/users/me/scheduled-messages/{scheduledMessageId}"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	user2 := env.CreateUser(t, rand)
+	ch := env.CreateChannel(t, rand)
+	sm := env.CreateScheduledMessage(t, user.GetID(), ch.ID, "a")
+	other := env.CreateScheduledMessage(t, user2.GetID(), ch.ID, "b")
+	s := env.S(t, user.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.DELETE(path, sm.ID).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.DELETE(path, other.ID).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.DELETE(path, sm.ID).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusNoContent)
+
+		_, err := env.Repository.GetScheduledMessage(sm.ID)
+		assert.ErrorIs(t, err, repository.ErrNotFound)
+	})
+}
@@ -0,0 +1,97 @@
+package v3
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/router/extension/herror"
+	"github.com/traPtitech/traQ/service/channel"
+	"github.com/traPtitech/traQ/service/file"
+	"github.com/traPtitech/traQ/service/search"
+)
+
+// SearchHit GET /search のヒット
+type SearchHit struct {
+	Type    string    `json:"type"`
+	File    *FileInfo `json:"file,omitempty"`
+	Channel *Channel  `json:"channel,omitempty"`
+	User    *User     `json:"user,omitempty"`
+}
+
+// Search GET /search
+func (h *Handlers) Search(c echo.Context) error {
+	if !h.EntitySearch.Available() {
+		return echo.NewHTTPError(http.StatusServiceUnavailable, "search service is currently unavailable")
+	}
+
+	userID := getRequestUserID(c)
+
+	var q search.EntityQuery
+	if err := bindAndValidate(c, &q); err != nil {
+		return err
+	}
+
+	if q.In.Valid {
+		// ユーザーが該当チャンネルへのアクセス権限があるかを確認
+		ok, err := h.ChannelManager.IsChannelAccessibleToUser(userID, q.In.V)
+		if err != nil {
+			return herror.InternalServerError(err)
+		}
+		if !ok {
+			return herror.Forbidden("invalid channelId")
+		}
+	}
+
+	r, err := h.EntitySearch.Do(userID, &q)
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+
+	hits := make([]SearchHit, 0, len(r.Hits()))
+	for _, hit := range r.Hits() {
+		// NOTE: N+1 の可能性
+		res := SearchHit{Type: hit.Type}
+		switch hit.Type {
+		case search.EntityTypeFile:
+			f, err := h.FileManager.Get(hit.ID)
+			if err != nil {
+				if err == file.ErrNotFound {
+					continue // インデックスへの反映前に削除された
+				}
+				return herror.InternalServerError(err)
+			}
+			res.File = formatFileInfo(f)
+		case search.EntityTypeChannel:
+			ch, err := h.ChannelManager.GetChannel(hit.ID)
+			if err != nil {
+				if err == channel.ErrChannelNotFound {
+					continue
+				}
+				return herror.InternalServerError(err)
+			}
+			res.Channel = formatChannel(ch, h.ChannelManager.PublicChannelTree().GetChildrenIDs(ch.ID))
+		case search.EntityTypeUser:
+			user, err := h.Repo.GetUser(hit.ID, false)
+			if err != nil {
+				if err == repository.ErrNotFound {
+					continue
+				}
+				return herror.InternalServerError(err)
+			}
+			res.User = &formatUsers([]model.UserInfo{user})[0]
+		}
+		hits = append(hits, res)
+	}
+
+	type response struct {
+		TotalHits int64       `json:"totalHits"`
+		Hits      []SearchHit `json:"hits"`
+	}
+	return c.JSON(http.StatusOK, response{
+		TotalHits: r.TotalHits(),
+		Hits:      hits,
+	})
+}
@@ -0,0 +1,111 @@
+package v3
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/traPtitech/traQ/router/session"
+	"github.com/traPtitech/traQ/service/search"
+	"github.com/traPtitech/traQ/utils/optional"
+	"github.com/traPtitech/traQ/utils/random"
+)
+
+func TestHandlers_Search(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/search"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	target := env.CreateUser(t, "search"+random.AlphaNumeric(20))
+	commonSession := env.S(t, user.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path).
+			WithQuery("word", "a").
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("bad request (no word)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path).
+			WithCookie(session.CookieName, commonSession).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("bad request (invalid type)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path).
+			WithCookie(session.CookieName, commonSession).
+			WithQuery("word", "a").
+			WithQuery("type", "message").
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("forbidden (inaccessible channel)", func(t *testing.T) {
+		t.Parallel()
+		user2 := env.CreateUser(t, rand)
+		dm := env.CreateDMChannel(t, user2.GetID(), user2.GetID())
+		e := env.R(t)
+		e.GET(path).
+			WithCookie(session.CookieName, commonSession).
+			WithQuery("word", "a").
+			WithQuery("in", dm.ID.String()).
+			Expect().
+			Status(http.StatusForbidden)
+	})
+
+	t.Run("success (user)", func(t *testing.T) {
+		t.Parallel()
+
+		q := &search.EntityQuery{Word: optional.From(target.GetName()), Type: optional.From(search.EntityTypeUser)}
+		assert.Eventually(t, func() bool {
+			r, err := env.ES.Do(user.GetID(), q)
+			return err == nil && r.TotalHits() == 1
+		}, 10*time.Second, 100*time.Millisecond)
+
+		e := env.R(t)
+		obj := e.GET(path).
+			WithCookie(session.CookieName, commonSession).
+			WithQuery("word", target.GetName()).
+			WithQuery("type", search.EntityTypeUser).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Object()
+
+		obj.Value("totalHits").Number().Equal(1)
+		hits := obj.Value("hits").Array()
+		hits.Length().Equal(1)
+		hit := hits.First().Object()
+		hit.Value("type").String().Equal(search.EntityTypeUser)
+		hit.Value("user").Object().Value("id").String().Equal(target.GetID().String())
+		hit.NotContainsKey("file")
+		hit.NotContainsKey("channel")
+	})
+
+	t.Run("success (no hits)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.GET(path).
+			WithCookie(session.CookieName, commonSession).
+			WithQuery("word", uuid.Must(uuid.NewV4()).String()).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Object()
+
+		obj.Value("totalHits").Number().Equal(0)
+		obj.Value("hits").Array().Length().Equal(0)
+	})
+}
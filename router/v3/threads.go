@@ -0,0 +1,111 @@
+package v3
+
+import (
+	"net/http"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+
+	"github.com/traPtitech/traQ/router/extension/herror"
+	"github.com/traPtitech/traQ/service/message"
+)
+
+// getThreadID スレッドの親メッセージのIDを返します
+func getThreadID(m message.Message) uuid.UUID {
+	if p := m.GetParentID(); p.Valid {
+		return p.V
+	}
+	return m.GetID()
+}
+
+// GetThread GET /messages/:messageID/thread
+func (h *Handlers) GetThread(c echo.Context) error {
+	userID := getRequestUserID(c)
+	threadID := getThreadID(getParamMessage(c))
+
+	counts, err := h.Repo.GetThreadReplyCounts([]uuid.UUID{threadID})
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+	following, err := h.Repo.IsThreadFollowed(userID, threadID)
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+
+	return c.JSON(http.StatusOK, &Thread{
+		ID:         threadID,
+		ReplyCount: counts[threadID],
+		Following:  following,
+	})
+}
+
+// GetThreadMessages GET /messages/:messageID/thread/messages
+func (h *Handlers) GetThreadMessages(c echo.Context) error {
+	threadID := getThreadID(getParamMessage(c))
+
+	var req MessagesQuery
+	if err := req.bind(c); err != nil {
+		return err
+	}
+
+	return serveMessages(c, h.MessageManager, req.convertT(threadID))
+}
+
+// PostThreadMessage POST /messages/:messageID/thread/messages
+func (h *Handlers) PostThreadMessage(c echo.Context) error {
+	userID := getRequestUserID(c)
+	m := getParamMessage(c)
+
+	var req PostMessageRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	if req.Embed {
+		req.Content = h.Replacer.Replace(req.Content)
+	}
+
+	reply, err := h.MessageManager.CreateReply(m.GetID(), userID, req.Content)
+	if err != nil {
+		switch err {
+		case message.ErrNotFound:
+			return herror.NotFound("the parent message of this thread was not found")
+		case message.ErrChannelArchived:
+			return herror.BadRequest("the channel of this message has been archived")
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+	return c.JSON(http.StatusCreated, reply)
+}
+
+// FollowThread POST /messages/:messageID/thread/follow
+func (h *Handlers) FollowThread(c echo.Context) error {
+	threadID := getThreadID(getParamMessage(c))
+
+	if err := h.Repo.FollowThread(getRequestUserID(c), threadID); err != nil {
+		return herror.InternalServerError(err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// UnfollowThread DELETE /messages/:messageID/thread/follow
+func (h *Handlers) UnfollowThread(c echo.Context) error {
+	threadID := getThreadID(getParamMessage(c))
+
+	if err := h.Repo.UnfollowThread(getRequestUserID(c), threadID); err != nil {
+		return herror.InternalServerError(err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// GetMyFollowingThreads GET /users/me/threads
+func (h *Handlers) GetMyFollowingThreads(c echo.Context) error {
+	ids, err := h.Repo.GetFollowingThreadIDs(getRequestUserID(c))
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+	return c.JSON(http.StatusOK, ids)
+}
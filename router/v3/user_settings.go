@@ -1,11 +1,21 @@
 package v3
 
 import (
+	"errors"
 	"net/http"
+	"net/url"
+	"strings"
+	"time"
 
+	vd "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
 	"github.com/labstack/echo/v4"
 
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/router/consts"
 	"github.com/traPtitech/traQ/router/extension/herror"
+	"github.com/traPtitech/traQ/utils/optional"
 )
 
 // PutMyNotifyCitationRequest PUT /user/me/settings/notify-citation リクエストボディ
@@ -56,3 +66,188 @@ func (h *Handlers) GetMyNotifyCitation(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, &res{NotifyCitation: nc})
 }
+
+// PutMyDNDSettingsRequest PUT /users/me/settings/dnd リクエストボディ
+type PutMyDNDSettingsRequest struct {
+	Schedules model.DNDSchedules `json:"schedules"`
+	Timezone  string             `json:"timezone"`
+	Digest    bool               `json:"digest"`
+}
+
+func (r PutMyDNDSettingsRequest) Validate() error {
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.Schedules, vd.Length(0, 50)),
+		vd.Field(&r.Timezone, vd.Required.When(len(r.Schedules) > 0), vd.By(func(value interface{}) error {
+			if _, err := time.LoadLocation(value.(string)); err != nil {
+				return errors.New("must be a valid time zone name")
+			}
+			return nil
+		})),
+	)
+}
+
+// PutMyDNDSettings PUT /users/me/settings/dnd
+func (h *Handlers) PutMyDNDSettings(c echo.Context) error {
+	id := getRequestUserID(c)
+
+	var req PutMyDNDSettingsRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	if err := h.Repo.UpdateDNDSettings(id, repository.UpdateDNDSettingsArgs{
+		Schedules: req.Schedules,
+		Timezone:  req.Timezone,
+		Digest:    req.Digest,
+	}); err != nil {
+		return herror.InternalServerError(err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// PutMySnoozeRequest PUT /users/me/settings/snooze リクエストボディ
+type PutMySnoozeRequest struct {
+	Until optional.Of[time.Time] `json:"until"`
+}
+
+func (r PutMySnoozeRequest) Validate() error {
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.Until, vd.By(func(value interface{}) error {
+			if until := value.(optional.Of[time.Time]); until.Valid && until.V.Before(time.Now()) {
+				return errors.New("must be a future time")
+			}
+			return nil
+		})),
+	)
+}
+
+// PutMySnooze PUT /users/me/settings/snooze
+func (h *Handlers) PutMySnooze(c echo.Context) error {
+	id := getRequestUserID(c)
+
+	var req PutMySnoozeRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	if err := h.Repo.UpdateSnoozeUntil(id, req.Until); err != nil {
+		return herror.InternalServerError(err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// PutMyEmailSettingsRequest PUT /users/me/settings/email リクエストボディ
+type PutMyEmailSettingsRequest struct {
+	Address   string                    `json:"address"`
+	Immediate bool                      `json:"immediate"`
+	Digest    model.EmailDigestInterval `json:"digest"`
+}
+
+func (r PutMyEmailSettingsRequest) Validate() error {
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.Address, vd.Required.When(r.Immediate || len(r.Digest) > 0), vd.RuneLength(0, 254), is.EmailFormat),
+		vd.Field(&r.Digest, vd.In(model.EmailDigestHourly, model.EmailDigestDaily)),
+	)
+}
+
+// PutMyEmailSettings PUT /users/me/settings/email
+func (h *Handlers) PutMyEmailSettings(c echo.Context) error {
+	id := getRequestUserID(c)
+
+	var req PutMyEmailSettingsRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	if err := h.Repo.UpdateEmailSettings(id, repository.UpdateEmailSettingsArgs{
+		Address:   req.Address,
+		Immediate: req.Immediate,
+		Digest:    req.Digest,
+	}); err != nil {
+		return herror.InternalServerError(err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// maxNotificationKeywords ユーザーあたりの通知キーワードの最大数
+const maxNotificationKeywords = 50
+
+// GetMyNotificationKeywords GET /users/me/settings/keywords
+func (h *Handlers) GetMyNotificationKeywords(c echo.Context) error {
+	id := getRequestUserID(c)
+
+	keywords, err := h.Repo.GetNotificationKeywords(id)
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+
+	return c.JSON(http.StatusOK, keywords)
+}
+
+// PostMyNotificationKeywordRequest POST /users/me/settings/keywords リクエストボディ
+type PostMyNotificationKeywordRequest struct {
+	Keyword string `json:"keyword"`
+}
+
+func (r PostMyNotificationKeywordRequest) Validate() error {
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.Keyword, vd.Required, vd.RuneLength(1, 50)),
+	)
+}
+
+// PostMyNotificationKeyword POST /users/me/settings/keywords
+func (h *Handlers) PostMyNotificationKeyword(c echo.Context) error {
+	id := getRequestUserID(c)
+
+	var req PostMyNotificationKeywordRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+	keyword := strings.TrimSpace(req.Keyword)
+	if len(keyword) == 0 {
+		return herror.BadRequest("keyword must not be blank")
+	}
+
+	keywords, err := h.Repo.GetNotificationKeywords(id)
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+	if len(keywords) >= maxNotificationKeywords {
+		return herror.BadRequest("too many keywords")
+	}
+
+	if err := h.Repo.AddNotificationKeyword(id, keyword); err != nil {
+		switch err {
+		case repository.ErrAlreadyExists:
+			return herror.Conflict("the keyword has already been registered")
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// DeleteMyNotificationKeyword DELETE /users/me/settings/keywords/:keyword
+func (h *Handlers) DeleteMyNotificationKeyword(c echo.Context) error {
+	id := getRequestUserID(c)
+
+	keyword, err := url.PathUnescape(c.Param(consts.ParamKeyword))
+	if err != nil {
+		return herror.BadRequest("invalid keyword")
+	}
+
+	if err := h.Repo.RemoveNotificationKeyword(id, keyword); err != nil {
+		switch err {
+		case repository.ErrNotFound:
+			return herror.NotFound()
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
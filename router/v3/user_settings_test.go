@@ -3,11 +3,14 @@ package v3
 import (
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/traPtitech/traQ/model"
 	"github.com/traPtitech/traQ/router/session"
+	"github.com/traPtitech/traQ/utils/optional"
 )
 
 func TestHandlers_PutMyNotifyCitation(t *testing.T) {
@@ -102,3 +105,278 @@ func TestHandlers_GetMyNotifyCitation(t *testing.T) {
 		obj.Value("notifyCitation").Boolean().False()
 	})
 }
+
+func TestHandlers_PutMyDNDSettings(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/users/me/settings/dnd"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	s := env.S(t, user.GetID())
+	schedules := model.DNDSchedules{{Weekday: time.Monday, Start: "22:00", End: "07:00"}}
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path).
+			WithJSON(&PutMyDNDSettingsRequest{Schedules: schedules, Timezone: "Asia/Tokyo"}).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("bad request (invalid schedule)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PutMyDNDSettingsRequest{Schedules: model.DNDSchedules{{Weekday: time.Monday, Start: "25:00", End: "07:00"}}, Timezone: "Asia/Tokyo"}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("bad request (invalid timezone)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PutMyDNDSettingsRequest{Schedules: schedules, Timezone: "Invalid/Zone"}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PutMyDNDSettingsRequest{Schedules: schedules, Timezone: "Asia/Tokyo", Digest: true}).
+			Expect().
+			Status(http.StatusNoContent)
+
+		us, err := env.Repository.GetUserSettings(user.GetID())
+		require.NoError(t, err)
+		assert.Equal(t, schedules, us.DNDSchedules)
+		assert.Equal(t, "Asia/Tokyo", us.DNDTimezone)
+		assert.True(t, us.DNDDigest)
+	})
+}
+
+func TestHandlers_PutMySnooze(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/users/me/settings/snooze"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	s := env.S(t, user.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path).
+			WithJSON(&PutMySnoozeRequest{}).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("bad request", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PutMySnoozeRequest{Until: optional.From(time.Now().Add(-time.Hour))}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PutMySnoozeRequest{Until: optional.From(time.Now().Add(time.Hour))}).
+			Expect().
+			Status(http.StatusNoContent)
+
+		us, err := env.Repository.GetUserSettings(user.GetID())
+		require.NoError(t, err)
+		assert.True(t, us.IsDND(time.Now()))
+	})
+}
+
+func TestHandlers_PutMyEmailSettings(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/users/me/settings/email"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	s := env.S(t, user.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path).
+			WithJSON(&PutMyEmailSettingsRequest{}).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("bad request (invalid address)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PutMyEmailSettingsRequest{Address: "invalid", Immediate: true}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("bad request (invalid digest)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PutMyEmailSettingsRequest{Address: "test@example.com", Digest: "weekly"}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PutMyEmailSettingsRequest{Address: "test@example.com", Immediate: true, Digest: model.EmailDigestDaily}).
+			Expect().
+			Status(http.StatusNoContent)
+
+		// 宛先は確認されるまで設定されない
+		us, err := env.Repository.GetUserSettings(user.GetID())
+		require.NoError(t, err)
+		assert.Empty(t, us.EmailAddress)
+		assert.Equal(t, "test@example.com", us.PendingEmailAddress)
+		assert.True(t, us.EmailImmediate)
+		assert.Equal(t, model.EmailDigestDaily, us.EmailDigest)
+		assert.NotEmpty(t, us.EmailUnsubscribeToken)
+	})
+}
+
+func TestHandlers_GetMyNotificationKeywords(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/users/me/settings/keywords"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	s := env.S(t, user.GetID())
+	require.NoError(t, env.Repository.AddNotificationKeyword(user.GetID(), "traQ"))
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.GET(path).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Array()
+
+		obj.Length().Equal(1)
+		obj.First().Object().Value("keyword").String().Equal("traQ")
+	})
+}
+
+func TestHandlers_PostMyNotificationKeyword(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/users/me/settings/keywords"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	s := env.S(t, user.GetID())
+	require.NoError(t, env.Repository.AddNotificationKeyword(user.GetID(), "existing"))
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path).
+			WithJSON(&PostMyNotificationKeywordRequest{Keyword: "traQ"}).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("bad request", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostMyNotificationKeywordRequest{Keyword: "   "}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("conflict", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostMyNotificationKeywordRequest{Keyword: "existing"}).
+			Expect().
+			Status(http.StatusConflict)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostMyNotificationKeywordRequest{Keyword: "トラQ"}).
+			Expect().
+			Status(http.StatusNoContent)
+	})
+}
+
+func TestHandlers_DeleteMyNotificationKeyword(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/users/me/settings/keywords/{keyword}"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	s := env.S(t, user.GetID())
+	require.NoError(t, env.Repository.AddNotificationKeyword(user.GetID(), "トラQ"))
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.DELETE(path, "トラQ").
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.DELETE(path, "unknown").
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.DELETE(path, "トラQ").
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusNoContent)
+
+		keywords, err := env.Repository.GetNotificationKeywords(user.GetID())
+		require.NoError(t, err)
+		assert.Len(t, keywords, 0)
+	})
+}
@@ -1,6 +1,7 @@
 package v3
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 	"strings"
@@ -11,10 +12,12 @@ import (
 	"github.com/labstack/echo/v4"
 
 	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
 	"github.com/traPtitech/traQ/router/consts"
 	"github.com/traPtitech/traQ/router/extension"
 	"github.com/traPtitech/traQ/router/extension/herror"
 	"github.com/traPtitech/traQ/service/message"
+	"github.com/traPtitech/traQ/utils/cursor"
 	"github.com/traPtitech/traQ/utils/optional"
 )
 
@@ -54,6 +57,11 @@ func getParamWebhook(c echo.Context) model.Webhook {
 	return c.Get(consts.KeyParamWebhook).(model.Webhook)
 }
 
+// getParamOutgoingWebhook URLの:outgoingWebhookIDに対応するOutgoingWebhookを取得
+func getParamOutgoingWebhook(c echo.Context) *model.OutgoingWebhook {
+	return c.Get(consts.KeyParamOutgoingWebhook).(*model.OutgoingWebhook)
+}
+
 // getParamBot URLの:botIDに対応するBotを取得
 func getParamBot(c echo.Context) *model.Bot {
 	return c.Get(consts.KeyParamBot).(*model.Bot)
@@ -111,6 +119,7 @@ type MessagesQuery struct {
 	Until     optional.Of[time.Time] `query:"until"`
 	Inclusive bool                   `query:"inclusive"`
 	Order     string                 `query:"order"`
+	Cursor    string                 `query:"cursor"`
 }
 
 func (q *MessagesQuery) bind(c echo.Context) error {
@@ -123,19 +132,43 @@ func (q *MessagesQuery) Validate() error {
 	}
 	return vd.ValidateStruct(q,
 		vd.Field(&q.Limit, vd.Min(1), vd.Max(200)),
-		vd.Field(&q.Offset, vd.Min(0)),
+		vd.Field(&q.Offset, vd.Min(0), vd.When(q.Cursor != "", vd.Empty.Error("cannot be used with cursor"))),
+		vd.Field(&q.Cursor, vd.By(func(interface{}) error {
+			if q.Cursor == "" {
+				return nil
+			}
+			c, err := cursor.Decode(q.Cursor)
+			if err != nil {
+				return err
+			}
+			if c.Key != messagesCursorKey || c.Desc != !q.isAsc() {
+				return errors.New("order does not match")
+			}
+			return nil
+		})),
 	)
 }
 
+// messagesCursorKey メッセージ一覧のカーソルのソートキー
+const messagesCursorKey = "createdAt"
+
+func (q *MessagesQuery) isAsc() bool {
+	return strings.ToLower(q.Order) == "asc"
+}
+
 func (q *MessagesQuery) convert() message.TimelineQuery {
-	return message.TimelineQuery{
+	r := message.TimelineQuery{
 		Since:     q.Since,
 		Until:     q.Until,
 		Inclusive: q.Inclusive,
 		Limit:     q.Limit,
 		Offset:    q.Offset,
-		Asc:       strings.ToLower(q.Order) == "asc",
+		Asc:       q.isAsc(),
+	}
+	if c, err := cursor.Decode(q.Cursor); err == nil {
+		r.Cursor = optional.From(repository.MessageCursor{CreatedAt: c.Value, ID: c.ID})
 	}
+	return r
 }
 
 func (q *MessagesQuery) convertC(cid uuid.UUID) message.TimelineQuery {
@@ -150,11 +183,26 @@ func (q *MessagesQuery) convertU(uid uuid.UUID) message.TimelineQuery {
 	return r
 }
 
+func (q *MessagesQuery) convertT(threadID uuid.UUID) message.TimelineQuery {
+	r := q.convert()
+	r.Thread = threadID
+	return r
+}
+
 func serveMessages(c echo.Context, mm message.Manager, query message.TimelineQuery) error {
 	timeline, err := mm.GetTimeline(query)
 	if err != nil {
 		return herror.InternalServerError(err)
 	}
 	c.Response().Header().Set(consts.HeaderMore, strconv.FormatBool(timeline.HasMore()))
+	if records := timeline.Records(); timeline.HasMore() && len(records) > 0 {
+		last := records[len(records)-1]
+		c.Response().Header().Set(consts.HeaderNextCursor, cursor.Cursor{
+			Key:   messagesCursorKey,
+			Desc:  !query.Asc,
+			Value: last.GetCreatedAt(),
+			ID:    last.GetID(),
+		}.Encode())
+	}
 	return c.JSON(http.StatusOK, timeline.Records())
 }
@@ -48,10 +48,12 @@ func newRouter(hub2 *hub.Hub, db *gorm.DB, repo repository.Repository, ss *servi
 	wsStreamer := ss.BotWS
 	onlineCounter := ss.OnlineCounter
 	ogpService := ss.OGP
+	outgoingwebhookService := ss.OutgoingWebhook
 	viewerManager := ss.ViewerManager
 	webrtcv3Manager := ss.WebRTCv3
 	processor := ss.Imaging
 	engine := ss.Search
+	entityEngine := ss.EntitySearch
 	v3Config := provideV3Config(config)
 	v3Handlers := &v3.Handlers{
 		RBAC:           rbac,
@@ -62,11 +64,13 @@ func newRouter(hub2 *hub.Hub, db *gorm.DB, repo repository.Repository, ss *servi
 		Logger:         logger,
 		OC:             onlineCounter,
 		OGP:            ogpService,
+		OWH:            outgoingwebhookService,
 		VM:             viewerManager,
 		WebRTC:         webrtcv3Manager,
 		Imaging:        processor,
 		SessStore:      store,
 		SearchEngine:   engine,
+		EntitySearch:   entityEngine,
 		ChannelManager: manager,
 		MessageManager: messageManager,
 		FileManager:    fileManager,
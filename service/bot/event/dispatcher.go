@@ -3,6 +3,7 @@ package event
 
 import (
 	"sync"
+	"time"
 
 	"github.com/gofrs/uuid"
 	jsonIter "github.com/json-iterator/go"
@@ -14,6 +15,8 @@ import (
 type Dispatcher interface {
 	// Send Botにイベントを送信します
 	Send(b *model.Bot, event model.BotEventType, body []byte) (ok bool)
+	// RetryDue 次回試行日時がnow以前の配送に失敗したイベントを再送信します
+	RetryDue(now time.Time)
 }
 
 // Unicast 単一のBOTにイベントを送信
@@ -1,6 +1,12 @@
 package event
 
 import (
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
 	"github.com/gofrs/uuid"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
@@ -9,6 +15,7 @@ import (
 	"github.com/traPtitech/traQ/model"
 	"github.com/traPtitech/traQ/repository"
 	botWS "github.com/traPtitech/traQ/service/bot/ws"
+	"github.com/traPtitech/traQ/utils/optional"
 )
 
 var eventSendCounter = promauto.NewCounterVec(prometheus.CounterOpts{
@@ -21,21 +28,39 @@ const (
 	resultNG           = "ng"
 	resultNetworkError = "ne"
 	resultDropped      = "dp"
+
+	// maxDeliveryAttempts 1イベントあたりの最大配送試行回数
+	maxDeliveryAttempts = 5
+	// maxConcurrentDeliveriesPerBot 1Botあたりの最大同時配送数
+	maxConcurrentDeliveriesPerBot = 5
+	// retryBatchSize 1回の再試行処理で取得する配送の最大数
+	retryBatchSize = 100
 )
 
 type dispatcherImpl struct {
 	http *httpDispatcher
 	ws   *wsDispatcher
 	l    *zap.Logger
-	repo repository.BotRepository
+	repo repository.Repository
+	// retryInterval 1回目の再試行までの待機時間 (以降、再試行ごとに2倍)
+	retryInterval time.Duration
+
+	semsLock sync.Mutex
+	sems     map[uuid.UUID]chan struct{}
 }
 
-func NewDispatcher(logger *zap.Logger, repo repository.BotRepository, s *botWS.Streamer) Dispatcher {
+func NewDispatcher(logger *zap.Logger, repo repository.Repository, s *botWS.Streamer) Dispatcher {
+	return newDispatcher(logger, repo, s, 30*time.Second)
+}
+
+func newDispatcher(logger *zap.Logger, repo repository.Repository, s *botWS.Streamer, retryInterval time.Duration) *dispatcherImpl {
 	return &dispatcherImpl{
-		http: newHTTPDispatcher(logger),
-		ws:   newWSDispatcher(s, logger),
-		l:    logger.Named("bot.dispatcher"),
-		repo: repo,
+		http:          newHTTPDispatcher(logger),
+		ws:            newWSDispatcher(s, logger),
+		l:             logger.Named("bot.dispatcher"),
+		repo:          repo,
+		retryInterval: retryInterval,
+		sems:          map[uuid.UUID]chan struct{}{},
 	}
 }
 
@@ -45,7 +70,10 @@ func (d *dispatcherImpl) Send(b *model.Bot, event model.BotEventType, body []byt
 	var log *model.BotEventLog
 	switch b.Mode {
 	case model.BotModeHTTP:
-		ok, log = d.http.send(b, event, reqID, body)
+		ok, log = d.sendHTTP(b, event, reqID, body)
+		if !ok && isRetryable(log) {
+			d.enqueue(reqID, b, event, body, log)
+		}
 	case model.BotModeWebSocket:
 		ok, log = d.ws.send(b, event, reqID, body)
 	default:
@@ -56,8 +84,173 @@ func (d *dispatcherImpl) Send(b *model.Bot, event model.BotEventType, body []byt
 	return ok
 }
 
+func (d *dispatcherImpl) RetryDue(now time.Time) {
+	for {
+		deliveries, err := d.repo.GetDueBotEventDeliveries(now, retryBatchSize)
+		if err != nil {
+			d.l.Error("failed to GetDueBotEventDeliveries", zap.Error(err))
+			return
+		}
+
+		var (
+			wg       sync.WaitGroup
+			progress atomic.Bool
+		)
+		for _, delivery := range deliveries {
+			delivery := delivery
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if d.retry(now, delivery) {
+					progress.Store(true)
+				}
+			}()
+		}
+		wg.Wait()
+
+		if len(deliveries) < retryBatchSize {
+			return
+		}
+		// 1件も処理できなかった場合、同じ配送を再取得し続けてしまうので次回に持ち越す
+		if !progress.Load() {
+			return
+		}
+	}
+}
+
+// sendHTTP Botごとの同時配送数を制限しながらHTTPでイベントを送信します
+func (d *dispatcherImpl) sendHTTP(b *model.Bot, event model.BotEventType, reqID uuid.UUID, body []byte) (ok bool, log *model.BotEventLog) {
+	release := d.acquire(b.ID)
+	defer release()
+	return d.http.send(b, event, reqID, body)
+}
+
+// acquire 指定したBotへの配送枠を確保します
+func (d *dispatcherImpl) acquire(botID uuid.UUID) (release func()) {
+	d.semsLock.Lock()
+	sem, ok := d.sems[botID]
+	if !ok {
+		sem = make(chan struct{}, maxConcurrentDeliveriesPerBot)
+		d.sems[botID] = sem
+	}
+	d.semsLock.Unlock()
+
+	sem <- struct{}{}
+	return func() { <-sem }
+}
+
+// enqueue 配送に失敗したイベントを再試行キューに追加します
+func (d *dispatcherImpl) enqueue(id uuid.UUID, b *model.Bot, event model.BotEventType, body []byte, log *model.BotEventLog) {
+	delivery := &model.BotEventDelivery{
+		ID:            id,
+		BotID:         b.ID,
+		Event:         event,
+		Body:          string(body),
+		Attempts:      1,
+		LastError:     describeFailure(log),
+		NextAttemptAt: time.Now().Add(d.retryInterval),
+	}
+	if err := d.repo.CreateBotEventDelivery(delivery); err != nil {
+		d.l.Warn("failed to enqueue bot event delivery", zap.Error(err), zap.Stringer("deliveryId", id))
+	}
+}
+
+// retry キューに入っているイベントを再送信します
+//
+// 最大試行回数に達しても配送できなかった場合、イベントをDeadにしてBotを一時停止します。
+// 配送がキューから削除されたか、次回試行日時が更新された場合にtrueを返します。
+func (d *dispatcherImpl) retry(now time.Time, delivery *model.BotEventDelivery) (progressed bool) {
+	l := d.l.With(zap.Stringer("deliveryId", delivery.ID), zap.Stringer("botId", delivery.BotID))
+
+	b, err := d.repo.GetBotByID(delivery.BotID)
+	if err != nil {
+		if err != repository.ErrNotFound {
+			l.Error("failed to GetBotByID", zap.Error(err))
+			return false
+		}
+		// Botが削除されているので配送を破棄
+		return d.delete(l, delivery.ID)
+	}
+	if b.Mode != model.BotModeHTTP || b.State != model.BotActive {
+		return d.markDead(l, delivery.ID, delivery.Attempts, "bot is not active")
+	}
+
+	ok, log := d.sendHTTP(b, delivery.Event, uuid.Must(uuid.NewV4()), []byte(delivery.Body))
+	d.writeLog(log)
+	if ok {
+		return d.delete(l, delivery.ID)
+	}
+
+	attempts := delivery.Attempts + 1
+	if !isRetryable(log) {
+		return d.markDead(l, delivery.ID, attempts, describeFailure(log))
+	}
+	if attempts >= maxDeliveryAttempts {
+		progressed = d.markDead(l, delivery.ID, attempts, describeFailure(log))
+		// 配送の失敗が続いているのでBotを一時停止
+		if err := d.repo.ChangeBotState(b.ID, model.BotPaused); err != nil {
+			l.Error("failed to ChangeBotState", zap.Error(err))
+		}
+		return progressed
+	}
+
+	err = d.repo.UpdateBotEventDelivery(delivery.ID, repository.UpdateBotEventDeliveryArgs{
+		Attempts:      optional.From(attempts),
+		LastError:     optional.From(describeFailure(log)),
+		NextAttemptAt: optional.From(now.Add(d.retryInterval << (attempts - 1))),
+	})
+	if err != nil {
+		l.Error("failed to UpdateBotEventDelivery", zap.Error(err))
+		return false
+	}
+	return true
+}
+
+// delete 配送をキューから削除します
+func (d *dispatcherImpl) delete(l *zap.Logger, id uuid.UUID) bool {
+	if err := d.repo.DeleteBotEventDelivery(id); err != nil && err != repository.ErrNotFound {
+		l.Error("failed to DeleteBotEventDelivery", zap.Error(err))
+		return false
+	}
+	return true
+}
+
+// markDead 配送をDeadにします
+func (d *dispatcherImpl) markDead(l *zap.Logger, id uuid.UUID, attempts int, reason string) bool {
+	err := d.repo.UpdateBotEventDelivery(id, repository.UpdateBotEventDeliveryArgs{
+		Attempts:  optional.From(attempts),
+		LastError: optional.From(reason),
+		Dead:      optional.From(true),
+	})
+	if err != nil {
+		l.Error("failed to UpdateBotEventDelivery", zap.Error(err))
+		return false
+	}
+	return true
+}
+
 func (d *dispatcherImpl) writeLog(log *model.BotEventLog) {
 	if err := d.repo.WriteBotEventLog(log); err != nil {
 		d.l.Warn("failed to write log", zap.Error(err), zap.Any("eventLog", log))
 	}
 }
+
+// isRetryable 再試行すべき配送失敗かどうか
+func isRetryable(log *model.BotEventLog) bool {
+	switch log.Result {
+	case resultNetworkError:
+		return true
+	case resultNG:
+		return log.Code >= 500 || log.Code == http.StatusTooManyRequests
+	default:
+		return false
+	}
+}
+
+// describeFailure 配送失敗の理由を返します
+func describeFailure(log *model.BotEventLog) string {
+	if len(log.Error) > 0 {
+		return log.Error
+	}
+	return fmt.Sprintf("unexpected status code: %d", log.Code)
+}
@@ -0,0 +1,207 @@
+package event
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/repository/mock_repository"
+	"github.com/traPtitech/traQ/testUtils"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+type Repo struct {
+	*mock_repository.MockBotRepository
+	*mock_repository.MockBotEventDeliveryRepository
+	testUtils.EmptyTestRepository
+}
+
+func setupDispatcher(t *testing.T, status int) (*dispatcherImpl, *Repo, *model.Bot) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	repo := &Repo{
+		MockBotRepository:              mock_repository.NewMockBotRepository(ctrl),
+		MockBotEventDeliveryRepository: mock_repository.NewMockBotEventDeliveryRepository(ctrl),
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(srv.Close)
+
+	b := &model.Bot{
+		ID:        uuid.Must(uuid.NewV4()),
+		BotUserID: uuid.Must(uuid.NewV4()),
+		PostURL:   srv.URL,
+		Mode:      model.BotModeHTTP,
+		State:     model.BotActive,
+	}
+	return newDispatcher(zap.NewNop(), repo, nil, time.Second), repo, b
+}
+
+func TestDispatcherImpl_Send(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		d, repo, b := setupDispatcher(t, http.StatusNoContent)
+
+		repo.MockBotRepository.EXPECT().WriteBotEventLog(gomock.Any()).Return(nil).Times(1)
+
+		assert.True(t, d.Send(b, Ping, []byte("{}")))
+	})
+
+	t.Run("retryable failure", func(t *testing.T) {
+		t.Parallel()
+		d, repo, b := setupDispatcher(t, http.StatusServiceUnavailable)
+
+		repo.MockBotRepository.EXPECT().WriteBotEventLog(gomock.Any()).Return(nil).Times(1)
+		repo.MockBotEventDeliveryRepository.EXPECT().
+			CreateBotEventDelivery(gomock.Any()).
+			DoAndReturn(func(delivery *model.BotEventDelivery) error {
+				assert.Equal(t, b.ID, delivery.BotID)
+				assert.Equal(t, Ping, delivery.Event)
+				assert.Equal(t, "{}", delivery.Body)
+				assert.Equal(t, 1, delivery.Attempts)
+				assert.False(t, delivery.Dead)
+				assert.True(t, delivery.NextAttemptAt.After(time.Now()))
+				return nil
+			}).
+			Times(1)
+
+		assert.False(t, d.Send(b, Ping, []byte("{}")))
+	})
+
+	t.Run("non-retryable failure", func(t *testing.T) {
+		t.Parallel()
+		d, repo, b := setupDispatcher(t, http.StatusBadRequest)
+
+		repo.MockBotRepository.EXPECT().WriteBotEventLog(gomock.Any()).Return(nil).Times(1)
+
+		assert.False(t, d.Send(b, Ping, []byte("{}")))
+	})
+}
+
+func TestDispatcherImpl_RetryDue(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	makeDelivery := func(b *model.Bot, attempts int) *model.BotEventDelivery {
+		return &model.BotEventDelivery{
+			ID:            uuid.Must(uuid.NewV4()),
+			BotID:         b.ID,
+			Event:         Ping,
+			Body:          "{}",
+			Attempts:      attempts,
+			NextAttemptAt: now,
+		}
+	}
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		d, repo, b := setupDispatcher(t, http.StatusNoContent)
+		delivery := makeDelivery(b, 1)
+
+		repo.MockBotEventDeliveryRepository.EXPECT().GetDueBotEventDeliveries(now, retryBatchSize).Return([]*model.BotEventDelivery{delivery}, nil).Times(1)
+		repo.MockBotRepository.EXPECT().GetBotByID(b.ID).Return(b, nil).Times(1)
+		repo.MockBotRepository.EXPECT().WriteBotEventLog(gomock.Any()).Return(nil).Times(1)
+		repo.MockBotEventDeliveryRepository.EXPECT().DeleteBotEventDelivery(delivery.ID).Return(nil).Times(1)
+
+		d.RetryDue(now)
+	})
+
+	t.Run("failure (will be retried)", func(t *testing.T) {
+		t.Parallel()
+		d, repo, b := setupDispatcher(t, http.StatusInternalServerError)
+		delivery := makeDelivery(b, 2)
+
+		repo.MockBotEventDeliveryRepository.EXPECT().GetDueBotEventDeliveries(now, retryBatchSize).Return([]*model.BotEventDelivery{delivery}, nil).Times(1)
+		repo.MockBotRepository.EXPECT().GetBotByID(b.ID).Return(b, nil).Times(1)
+		repo.MockBotRepository.EXPECT().WriteBotEventLog(gomock.Any()).Return(nil).Times(1)
+		repo.MockBotEventDeliveryRepository.EXPECT().
+			UpdateBotEventDelivery(delivery.ID, repository.UpdateBotEventDeliveryArgs{
+				Attempts:      optional.From(3),
+				LastError:     optional.From("unexpected status code: 500"),
+				NextAttemptAt: optional.From(now.Add(4 * time.Second)),
+			}).
+			Return(nil).
+			Times(1)
+
+		d.RetryDue(now)
+	})
+
+	t.Run("failure (dead, bot paused)", func(t *testing.T) {
+		t.Parallel()
+		d, repo, b := setupDispatcher(t, http.StatusInternalServerError)
+		delivery := makeDelivery(b, maxDeliveryAttempts-1)
+
+		repo.MockBotEventDeliveryRepository.EXPECT().GetDueBotEventDeliveries(now, retryBatchSize).Return([]*model.BotEventDelivery{delivery}, nil).Times(1)
+		repo.MockBotRepository.EXPECT().GetBotByID(b.ID).Return(b, nil).Times(1)
+		repo.MockBotRepository.EXPECT().WriteBotEventLog(gomock.Any()).Return(nil).Times(1)
+		repo.MockBotEventDeliveryRepository.EXPECT().
+			UpdateBotEventDelivery(delivery.ID, repository.UpdateBotEventDeliveryArgs{
+				Attempts:  optional.From(maxDeliveryAttempts),
+				LastError: optional.From("unexpected status code: 500"),
+				Dead:      optional.From(true),
+			}).
+			Return(nil).
+			Times(1)
+		repo.MockBotRepository.EXPECT().ChangeBotState(b.ID, model.BotPaused).Return(nil).Times(1)
+
+		d.RetryDue(now)
+	})
+
+	t.Run("bot is not active", func(t *testing.T) {
+		t.Parallel()
+		d, repo, b := setupDispatcher(t, http.StatusNoContent)
+		b.State = model.BotPaused
+		delivery := makeDelivery(b, 1)
+
+		repo.MockBotEventDeliveryRepository.EXPECT().GetDueBotEventDeliveries(now, retryBatchSize).Return([]*model.BotEventDelivery{delivery}, nil).Times(1)
+		repo.MockBotRepository.EXPECT().GetBotByID(b.ID).Return(b, nil).Times(1)
+		repo.MockBotEventDeliveryRepository.EXPECT().
+			UpdateBotEventDelivery(delivery.ID, repository.UpdateBotEventDeliveryArgs{
+				Attempts:  optional.From(1),
+				LastError: optional.From("bot is not active"),
+				Dead:      optional.From(true),
+			}).
+			Return(nil).
+			Times(1)
+
+		d.RetryDue(now)
+	})
+
+	t.Run("no progress", func(t *testing.T) {
+		t.Parallel()
+		d, repo, b := setupDispatcher(t, http.StatusNoContent)
+		deliveries := make([]*model.BotEventDelivery, retryBatchSize)
+		for i := range deliveries {
+			deliveries[i] = makeDelivery(b, 1)
+		}
+
+		repo.MockBotEventDeliveryRepository.EXPECT().GetDueBotEventDeliveries(now, retryBatchSize).Return(deliveries, nil).Times(1)
+		repo.MockBotRepository.EXPECT().GetBotByID(b.ID).Return(nil, errors.New("db error")).Times(retryBatchSize)
+
+		d.RetryDue(now)
+	})
+
+	t.Run("bot not found", func(t *testing.T) {
+		t.Parallel()
+		d, repo, b := setupDispatcher(t, http.StatusNoContent)
+		delivery := makeDelivery(b, 1)
+
+		repo.MockBotEventDeliveryRepository.EXPECT().GetDueBotEventDeliveries(now, retryBatchSize).Return([]*model.BotEventDelivery{delivery}, nil).Times(1)
+		repo.MockBotRepository.EXPECT().GetBotByID(b.ID).Return(nil, repository.ErrNotFound).Times(1)
+		repo.MockBotEventDeliveryRepository.EXPECT().DeleteBotEventDelivery(delivery.ID).Return(nil).Times(1)
+
+		d.RetryDue(now)
+	})
+}
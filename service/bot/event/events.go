@@ -17,6 +17,12 @@ const (
 	MessageUpdated model.BotEventType = "MESSAGE_UPDATED"
 	// BotMessageStampsUpdated BOTメッセージスタンプ更新イベント
 	BotMessageStampsUpdated model.BotEventType = "BOT_MESSAGE_STAMPS_UPDATED"
+	// MessageStampsUpdated メッセージスタンプ更新イベント
+	MessageStampsUpdated model.BotEventType = "MESSAGE_STAMPS_UPDATED"
+	// MessagePinned メッセージピン留めイベント
+	MessagePinned model.BotEventType = "MESSAGE_PINNED"
+	// MessageUnpinned メッセージピン留め解除イベント
+	MessageUnpinned model.BotEventType = "MESSAGE_UNPINNED"
 	// MentionMessageCreated メンションメッセージ作成イベント
 	MentionMessageCreated model.BotEventType = "MENTION_MESSAGE_CREATED"
 	// DirectMessageCreated ダイレクトメッセージ作成イベント
@@ -29,14 +35,28 @@ const (
 	ChannelCreated model.BotEventType = "CHANNEL_CREATED"
 	// ChannelTopicChanged チャンネルトピック変更イベント
 	ChannelTopicChanged model.BotEventType = "CHANNEL_TOPIC_CHANGED"
+	// ChannelUpdated チャンネル更新イベント
+	ChannelUpdated model.BotEventType = "CHANNEL_UPDATED"
 	// UserCreated ユーザー作成イベント
 	UserCreated model.BotEventType = "USER_CREATED"
+	// UserActivated ユーザー凍結解除イベント
+	UserActivated model.BotEventType = "USER_ACTIVATED"
+	// UserDeactivated ユーザー凍結イベント
+	UserDeactivated model.BotEventType = "USER_DEACTIVATED"
+	// UserGroupMemberAdded ユーザーグループメンバー追加イベント
+	UserGroupMemberAdded model.BotEventType = "USER_GROUP_MEMBER_ADDED"
+	// UserGroupMemberRemoved ユーザーグループメンバー削除イベント
+	UserGroupMemberRemoved model.BotEventType = "USER_GROUP_MEMBER_REMOVED"
 	// StampCreated スタンプ作成イベント
 	StampCreated model.BotEventType = "STAMP_CREATED"
 	// TagAdded タグ追加イベント
 	TagAdded model.BotEventType = "TAG_ADDED"
 	// TagRemoved タグ削除イベント
 	TagRemoved model.BotEventType = "TAG_REMOVED"
+	// SlashCommand スラッシュコマンド実行イベント
+	SlashCommand model.BotEventType = "SLASH_COMMAND"
+	// Interaction メッセージコンポーネント操作イベント
+	Interaction model.BotEventType = "INTERACTION"
 )
 
 var Types model.BotEventTypes
@@ -52,16 +72,26 @@ func init() {
 		MessageDeleted,
 		MessageUpdated,
 		BotMessageStampsUpdated,
+		MessageStampsUpdated,
+		MessagePinned,
+		MessageUnpinned,
 		MentionMessageCreated,
 		DirectMessageCreated,
 		DirectMessageUpdated,
 		DirectMessageDeleted,
 		ChannelCreated,
 		ChannelTopicChanged,
+		ChannelUpdated,
 		UserCreated,
+		UserActivated,
+		UserDeactivated,
+		UserGroupMemberAdded,
+		UserGroupMemberRemoved,
 		StampCreated,
 		TagAdded,
 		TagRemoved,
+		SlashCommand,
+		Interaction,
 	} {
 		Types[t] = struct{}{}
 	}
@@ -6,6 +6,7 @@ package mock_event
 
 import (
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	model "github.com/traPtitech/traQ/model"
@@ -34,6 +35,18 @@ func (m *MockDispatcher) EXPECT() *MockDispatcherMockRecorder {
 	return m.recorder
 }
 
+// RetryDue mocks base method.
+func (m *MockDispatcher) RetryDue(now time.Time) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RetryDue", now)
+}
+
+// RetryDue indicates an expected call of RetryDue.
+func (mr *MockDispatcherMockRecorder) RetryDue(now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetryDue", reflect.TypeOf((*MockDispatcher)(nil).RetryDue), now)
+}
+
 // Send mocks base method.
 func (m *MockDispatcher) Send(b *model.Bot, event model.BotEventType, body []byte) bool {
 	m.ctrl.T.Helper()
@@ -88,3 +88,8 @@ func MakeUser(user model.UserInfo) User {
 	}
 	return payload
 }
+
+type GroupMember struct {
+	GroupID uuid.UUID `json:"groupId"`
+	UserID  uuid.UUID `json:"userId"`
+}
@@ -0,0 +1,22 @@
+package payload
+
+import (
+	"time"
+
+	"github.com/traPtitech/traQ/model"
+)
+
+// ChannelUpdated CHANNEL_UPDATEDイベントペイロード
+type ChannelUpdated struct {
+	Base
+	Channel  Channel `json:"channel"`
+	Archived bool    `json:"archived"`
+}
+
+func MakeChannelUpdated(et time.Time, ch *model.Channel, chPath string, chCreator model.UserInfo) *ChannelUpdated {
+	return &ChannelUpdated{
+		Base:     MakeBase(et),
+		Channel:  MakeChannel(ch, chPath, chCreator),
+		Archived: ch.IsArchived(),
+	}
+}
@@ -0,0 +1,36 @@
+package payload
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/utils/message"
+)
+
+// Interaction INTERACTIONイベントペイロード
+type Interaction struct {
+	Base
+	InteractionID uuid.UUID `json:"interactionId"`
+	CustomID      string    `json:"customId"`
+	Values        []string  `json:"values"`
+	Message       Message   `json:"message"`
+	User          User      `json:"user"`
+}
+
+func MakeInteraction(et time.Time, i *model.MessageInteraction, m *model.Message, author model.UserInfo, user model.UserInfo) *Interaction {
+	embedded, _ := message.ExtractEmbedding(m.Text)
+	values := []string(i.Values)
+	if values == nil {
+		values = []string{}
+	}
+	return &Interaction{
+		Base:          MakeBase(et),
+		InteractionID: i.ID,
+		CustomID:      i.CustomID,
+		Values:        values,
+		Message:       MakeMessage(m, author, embedded, message.Parse(m.Text).PlainText),
+		User:          MakeUser(user),
+	}
+}
@@ -0,0 +1,22 @@
+package payload
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// MessagePinned MESSAGE_PINNEDイベントペイロード
+type MessagePinned struct {
+	Base
+	MessageID uuid.UUID `json:"messageId"`
+	ChannelID uuid.UUID `json:"channelId"`
+}
+
+func MakeMessagePinned(et time.Time, mid uuid.UUID, cid uuid.UUID) *MessagePinned {
+	return &MessagePinned{
+		Base:      MakeBase(et),
+		MessageID: mid,
+		ChannelID: cid,
+	}
+}
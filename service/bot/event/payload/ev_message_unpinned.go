@@ -0,0 +1,22 @@
+package payload
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// MessageUnpinned MESSAGE_UNPINNEDイベントペイロード
+type MessageUnpinned struct {
+	Base
+	MessageID uuid.UUID `json:"messageId"`
+	ChannelID uuid.UUID `json:"channelId"`
+}
+
+func MakeMessageUnpinned(et time.Time, mid uuid.UUID, cid uuid.UUID) *MessageUnpinned {
+	return &MessageUnpinned{
+		Base:      MakeBase(et),
+		MessageID: mid,
+		ChannelID: cid,
+	}
+}
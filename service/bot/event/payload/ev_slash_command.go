@@ -0,0 +1,32 @@
+package payload
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/model"
+)
+
+// SlashCommand SLASH_COMMANDイベントペイロード
+type SlashCommand struct {
+	Base
+	CommandID uuid.UUID              `json:"commandId"`
+	Command   string                 `json:"command"`
+	Arguments string                 `json:"arguments"`
+	Options   map[string]interface{} `json:"options"`
+	ChannelID uuid.UUID              `json:"channelId"`
+	User      User                   `json:"user"`
+}
+
+func MakeSlashCommand(et time.Time, cmd *model.BotSlashCommand, args string, options map[string]interface{}, channelID uuid.UUID, user model.UserInfo) *SlashCommand {
+	return &SlashCommand{
+		Base:      MakeBase(et),
+		CommandID: cmd.ID,
+		Command:   cmd.Name,
+		Arguments: args,
+		Options:   options,
+		ChannelID: channelID,
+		User:      MakeUser(user),
+	}
+}
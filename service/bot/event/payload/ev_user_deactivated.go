@@ -0,0 +1,20 @@
+package payload
+
+import (
+	"time"
+
+	"github.com/traPtitech/traQ/model"
+)
+
+// UserDeactivated USER_DEACTIVATEDイベントペイロード
+type UserDeactivated struct {
+	Base
+	User User `json:"user"`
+}
+
+func MakeUserDeactivated(et time.Time, user model.UserInfo) *UserDeactivated {
+	return &UserDeactivated{
+		Base: MakeBase(et),
+		User: MakeUser(user),
+	}
+}
@@ -0,0 +1,23 @@
+package payload
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// UserGroupMemberAdded USER_GROUP_MEMBER_ADDEDイベントペイロード
+type UserGroupMemberAdded struct {
+	Base
+	GroupMember GroupMember `json:"groupMember"`
+}
+
+func MakeUserGroupMemberAdded(et time.Time, groupID uuid.UUID, userID uuid.UUID) *UserGroupMemberAdded {
+	return &UserGroupMemberAdded{
+		Base: MakeBase(et),
+		GroupMember: GroupMember{
+			GroupID: groupID,
+			UserID:  userID,
+		},
+	}
+}
@@ -0,0 +1,23 @@
+package payload
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// UserGroupMemberRemoved USER_GROUP_MEMBER_REMOVEDイベントペイロード
+type UserGroupMemberRemoved struct {
+	Base
+	GroupMember GroupMember `json:"groupMember"`
+}
+
+func MakeUserGroupMemberRemoved(et time.Time, groupID uuid.UUID, userID uuid.UUID) *UserGroupMemberRemoved {
+	return &UserGroupMemberRemoved{
+		Base: MakeBase(et),
+		GroupMember: GroupMember{
+			GroupID: groupID,
+			UserID:  userID,
+		},
+	}
+}
@@ -19,6 +19,7 @@ type Context interface {
 
 	Unicast(ev model.BotEventType, payload interface{}, target *model.Bot) error
 	Multicast(ev model.BotEventType, payload interface{}, targets []*model.Bot) error
+	WriteToUser(userID uuid.UUID, wsEventType string, wsPayload interface{})
 
 	GetBot(id uuid.UUID) (*model.Bot, error)
 	GetBotByBotUserID(uid uuid.UUID) (*model.Bot, error)
@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang/mock/gomock"
+	"github.com/leandro-lugaresi/hub"
+	"github.com/stretchr/testify/assert"
+
+	intevent "github.com/traPtitech/traQ/event"
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/service/bot/event"
+	"github.com/traPtitech/traQ/service/bot/event/payload"
+	"github.com/traPtitech/traQ/service/bot/handler/mock_handler"
+	"github.com/traPtitech/traQ/service/channel/mock_channel"
+)
+
+func TestChannelUpdated(t *testing.T) {
+	t.Parallel()
+
+	b := &model.Bot{
+		ID:              uuid.NewV3(uuid.Nil, "b"),
+		BotUserID:       uuid.NewV3(uuid.Nil, "bu"),
+		SubscribeEvents: model.BotEventTypesFromArray([]string{event.ChannelUpdated.String()}),
+		State:           model.BotActive,
+	}
+	u := &model.User{
+		ID:   uuid.NewV3(uuid.Nil, "u"),
+		Name: "testman",
+	}
+	ch := &model.Channel{
+		ID:        uuid.NewV3(uuid.Nil, "c"),
+		Name:      "renamed",
+		IsPublic:  true,
+		IsVisible: false,
+		CreatorID: u.ID,
+	}
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		handlerCtx, cm, repo := setup(t, ctrl)
+
+		tree := mock_channel.NewMockTree(ctrl)
+		cm.EXPECT().PublicChannelTree().Return(tree).AnyTimes()
+		tree.EXPECT().GetChannelPath(ch.ID).Return(ch.Name).AnyTimes()
+
+		registerBot(t, handlerCtx, b)
+		registerChannel(cm, ch)
+		registerUser(repo, u)
+		et := time.Now()
+
+		p := payload.MakeChannelUpdated(et, ch, ch.Name, u)
+		assert.True(t, p.Archived)
+		expectMulticast(handlerCtx, event.ChannelUpdated, p, []*model.Bot{b})
+		assert.NoError(t, ChannelUpdated(handlerCtx, et, intevent.ChannelUpdated, hub.Fields{
+			"channel_id": ch.ID,
+			"private":    false,
+		}))
+	})
+
+	t.Run("private channel", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		handlerCtx := mock_handler.NewMockContext(ctrl)
+		registerBot(t, handlerCtx, b)
+
+		assert.NoError(t, ChannelUpdated(handlerCtx, time.Now(), intevent.ChannelUpdated, hub.Fields{
+			"channel_id": ch.ID,
+			"private":    true,
+		}))
+	})
+}
@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/leandro-lugaresi/hub"
+	"go.uber.org/zap"
+
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+func EphemeralMessage(ctx Context, datetime time.Time, _ string, fields hub.Fields) error {
+	botID := fields["bot_id"].(uuid.UUID)
+	messageID := fields["message_id"].(uuid.UUID)
+	channelID := fields["channel_id"].(uuid.UUID)
+	userID := fields["user_id"].(uuid.UUID)
+	content := fields["content"].(string)
+	ttl := fields["ttl"].(time.Duration)
+
+	bot, err := ctx.GetBot(botID)
+	if err != nil {
+		return fmt.Errorf("failed to GetBot: %w", err)
+	}
+	if bot == nil {
+		return nil
+	}
+
+	// BOTがチャンネルに参加しているか
+	joined, err := isBotJoined(ctx, bot.ID, bot.BotUserID, channelID)
+	if err != nil {
+		return err
+	}
+	if !joined {
+		ctx.L().Info("ephemeral message rejected: bot has not joined the channel", zap.Stringer("botId", bot.ID), zap.Stringer("channelId", channelID))
+		return nil
+	}
+
+	// 対象ユーザーがチャンネルを閲覧できるか
+	accessible, err := ctx.CM().IsChannelAccessibleToUser(userID, channelID)
+	if err != nil {
+		return fmt.Errorf("failed to IsChannelAccessibleToUser: %w", err)
+	}
+	if !accessible {
+		ctx.L().Info("ephemeral message rejected: user is not a member of the channel", zap.Stringer("botId", bot.ID), zap.Stringer("userId", userID))
+		return nil
+	}
+
+	var expiresAt optional.Of[time.Time]
+	if ttl > 0 {
+		expiresAt = optional.From(datetime.Add(ttl))
+	}
+	ctx.WriteToUser(userID, "EPHEMERAL_MESSAGE", map[string]interface{}{
+		"id":         messageID,
+		"channel_id": channelID,
+		"user_id":    bot.BotUserID,
+		"content":    content,
+		"created_at": datetime,
+		"expires_at": expiresAt,
+	})
+	return nil
+}
+
+func isBotJoined(ctx Context, botID, botUserID, channelID uuid.UUID) (bool, error) {
+	if ctx.CM().IsPublicChannel(channelID) {
+		ids, err := ctx.R().GetParticipatingChannelIDsByBot(botID)
+		if err != nil {
+			return false, fmt.Errorf("failed to GetParticipatingChannelIDsByBot: %w", err)
+		}
+		for _, id := range ids {
+			if id == channelID {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+
+	// DM
+	members, err := ctx.CM().GetDMChannelMembers(channelID)
+	if err != nil {
+		return false, fmt.Errorf("failed to GetDMChannelMembers: %w", err)
+	}
+	for _, id := range members {
+		if id == botUserID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang/mock/gomock"
+	"github.com/leandro-lugaresi/hub"
+	"github.com/stretchr/testify/assert"
+
+	intevent "github.com/traPtitech/traQ/event"
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+func TestEphemeralMessage(t *testing.T) {
+	t.Parallel()
+
+	b := &model.Bot{
+		ID:              uuid.NewV3(uuid.Nil, "b"),
+		BotUserID:       uuid.NewV3(uuid.Nil, "bu"),
+		SubscribeEvents: model.BotEventTypes{},
+		State:           model.BotActive,
+	}
+	chID := uuid.NewV3(uuid.Nil, "c")
+	userID := uuid.NewV3(uuid.Nil, "u")
+	messageID := uuid.NewV3(uuid.Nil, "m")
+	fields := func(channelID, userID uuid.UUID, ttl time.Duration) hub.Fields {
+		return hub.Fields{
+			"bot_id":     b.ID,
+			"message_id": messageID,
+			"channel_id": channelID,
+			"user_id":    userID,
+			"content":    "only for you",
+			"ttl":        ttl,
+		}
+	}
+
+	t.Run("success (public channel)", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		handlerCtx, cm, repo := setup(t, ctrl)
+		registerBot(t, handlerCtx, b)
+
+		et := time.Now()
+		cm.EXPECT().IsPublicChannel(chID).Return(true).Times(1)
+		repo.MockBotRepository.EXPECT().GetParticipatingChannelIDsByBot(b.ID).Return([]uuid.UUID{chID}, nil).Times(1)
+		cm.EXPECT().IsChannelAccessibleToUser(userID, chID).Return(true, nil).Times(1)
+		handlerCtx.EXPECT().
+			WriteToUser(userID, "EPHEMERAL_MESSAGE", map[string]interface{}{
+				"id":         messageID,
+				"channel_id": chID,
+				"user_id":    b.BotUserID,
+				"content":    "only for you",
+				"created_at": et,
+				"expires_at": optional.From(et.Add(time.Minute)),
+			}).
+			Times(1)
+
+		assert.NoError(t, EphemeralMessage(handlerCtx, et, intevent.BotEphemeralMessageRequested, fields(chID, userID, time.Minute)))
+	})
+
+	t.Run("success (dm channel, no ttl)", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		handlerCtx, cm, repo := setup(t, ctrl)
+		registerBot(t, handlerCtx, b)
+		dm, u := createDMChannel(handlerCtx, cm, repo, b)
+
+		et := time.Now()
+		cm.EXPECT().IsPublicChannel(dm.ID).Return(false).Times(1)
+		cm.EXPECT().IsChannelAccessibleToUser(u.ID, dm.ID).Return(true, nil).Times(1)
+		handlerCtx.EXPECT().
+			WriteToUser(u.ID, "EPHEMERAL_MESSAGE", map[string]interface{}{
+				"id":         messageID,
+				"channel_id": dm.ID,
+				"user_id":    b.BotUserID,
+				"content":    "only for you",
+				"created_at": et,
+				"expires_at": optional.Of[time.Time]{},
+			}).
+			Times(1)
+
+		assert.NoError(t, EphemeralMessage(handlerCtx, et, intevent.BotEphemeralMessageRequested, fields(dm.ID, u.ID, 0)))
+	})
+
+	t.Run("bot has not joined the channel", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		handlerCtx, cm, repo := setup(t, ctrl)
+		registerBot(t, handlerCtx, b)
+
+		cm.EXPECT().IsPublicChannel(chID).Return(true).Times(1)
+		repo.MockBotRepository.EXPECT().GetParticipatingChannelIDsByBot(b.ID).Return([]uuid.UUID{uuid.NewV3(uuid.Nil, "other")}, nil).Times(1)
+		handlerCtx.EXPECT().WriteToUser(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		assert.NoError(t, EphemeralMessage(handlerCtx, time.Now(), intevent.BotEphemeralMessageRequested, fields(chID, userID, 0)))
+	})
+
+	t.Run("bot is not a member of the dm", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		handlerCtx, cm, _ := setup(t, ctrl)
+		registerBot(t, handlerCtx, b)
+
+		dmID := uuid.NewV3(uuid.Nil, "dm2")
+		cm.EXPECT().IsPublicChannel(dmID).Return(false).Times(1)
+		cm.EXPECT().GetDMChannelMembers(dmID).Return([]uuid.UUID{userID, uuid.NewV3(uuid.Nil, "u2")}, nil).Times(1)
+		handlerCtx.EXPECT().WriteToUser(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		assert.NoError(t, EphemeralMessage(handlerCtx, time.Now(), intevent.BotEphemeralMessageRequested, fields(dmID, userID, 0)))
+	})
+
+	t.Run("target user is not a member of the channel", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		handlerCtx, cm, repo := setup(t, ctrl)
+		registerBot(t, handlerCtx, b)
+
+		cm.EXPECT().IsPublicChannel(chID).Return(true).Times(1)
+		repo.MockBotRepository.EXPECT().GetParticipatingChannelIDsByBot(b.ID).Return([]uuid.UUID{chID}, nil).Times(1)
+		cm.EXPECT().IsChannelAccessibleToUser(userID, chID).Return(false, nil).Times(1)
+		handlerCtx.EXPECT().WriteToUser(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		assert.NoError(t, EphemeralMessage(handlerCtx, time.Now(), intevent.BotEphemeralMessageRequested, fields(chID, userID, 0)))
+	})
+
+	t.Run("inactive bot", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		handlerCtx, _, _ := setup(t, ctrl)
+		handlerCtx.EXPECT().GetBot(b.ID).Return(nil, nil).Times(1)
+		handlerCtx.EXPECT().WriteToUser(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		assert.NoError(t, EphemeralMessage(handlerCtx, time.Now(), intevent.BotEphemeralMessageRequested, fields(chID, userID, 0)))
+	})
+}
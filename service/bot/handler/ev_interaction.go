@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/leandro-lugaresi/hub"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/service/bot/event"
+	"github.com/traPtitech/traQ/service/bot/event/payload"
+)
+
+func Interaction(ctx Context, datetime time.Time, _ string, fields hub.Fields) error {
+	m := fields["message"].(*model.Message)
+	i := fields["interaction"].(*model.MessageInteraction)
+
+	bot, err := ctx.GetBotByBotUserID(m.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to GetBotByBotUserID: %w", err)
+	}
+	if bot == nil {
+		return nil
+	}
+
+	author, err := ctx.R().GetUser(m.UserID, false)
+	if err != nil {
+		return fmt.Errorf("failed to GetUser: %w", err)
+	}
+	user, err := ctx.R().GetUser(i.UserID, false)
+	if err != nil {
+		return fmt.Errorf("failed to GetUser: %w", err)
+	}
+
+	if err := ctx.Unicast(
+		event.Interaction,
+		payload.MakeInteraction(datetime, i, m, author, user),
+		bot,
+	); err != nil {
+		return fmt.Errorf("failed to unicast: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang/mock/gomock"
+	"github.com/leandro-lugaresi/hub"
+	"github.com/stretchr/testify/assert"
+
+	intevent "github.com/traPtitech/traQ/event"
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/service/bot/event"
+	"github.com/traPtitech/traQ/service/bot/event/payload"
+)
+
+func TestInteraction(t *testing.T) {
+	t.Parallel()
+
+	b := &model.Bot{
+		ID:              uuid.NewV3(uuid.Nil, "b"),
+		BotUserID:       uuid.NewV3(uuid.Nil, "bu"),
+		SubscribeEvents: model.BotEventTypes{},
+		State:           model.BotActive,
+	}
+	bu := &model.User{
+		ID:     b.BotUserID,
+		Name:   "bot",
+		Status: model.UserAccountStatusActive,
+		Bot:    true,
+	}
+	u := &model.User{
+		ID:   uuid.NewV3(uuid.Nil, "u"),
+		Name: "testman",
+	}
+	m := &model.Message{
+		ID:        uuid.NewV3(uuid.Nil, "m"),
+		UserID:    bu.ID,
+		ChannelID: uuid.NewV3(uuid.Nil, "c"),
+		Text:      "choose one",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	i := &model.MessageInteraction{
+		ID:        uuid.NewV3(uuid.Nil, "i"),
+		MessageID: m.ID,
+		UserID:    u.ID,
+		CustomID:  "choice",
+		Values:    model.MessageInteractionValues{"a"},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		handlerCtx, _, repo := setup(t, ctrl)
+		registerBot(t, handlerCtx, b)
+		registerUser(repo, bu)
+		registerUser(repo, u)
+
+		et := time.Now()
+		expectUnicast(handlerCtx, event.Interaction, payload.MakeInteraction(et, i, m, bu, u), b)
+		assert.NoError(t, Interaction(handlerCtx, et, intevent.MessageInteractionCreated, hub.Fields{
+			"message_id":  m.ID,
+			"message":     m,
+			"interaction": i,
+		}))
+	})
+
+	t.Run("not a bot message", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		handlerCtx, _, _ := setup(t, ctrl)
+		m := &model.Message{
+			ID:        uuid.NewV3(uuid.Nil, "m2"),
+			UserID:    u.ID,
+			ChannelID: uuid.NewV3(uuid.Nil, "c"),
+			Text:      "not from bot",
+		}
+		handlerCtx.EXPECT().GetBotByBotUserID(u.ID).Return(nil, nil).Times(1)
+
+		assert.NoError(t, Interaction(handlerCtx, time.Now(), intevent.MessageInteractionCreated, hub.Fields{
+			"message_id":  m.ID,
+			"message":     m,
+			"interaction": i,
+		}))
+	})
+}
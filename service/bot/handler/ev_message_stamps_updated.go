@@ -18,16 +18,30 @@ func MessageStampsUpdated(ctx Context, datetime time.Time, _ string, fields hub.
 	if err != nil {
 		return fmt.Errorf("failed to GetBotByBotUserID: %w", err)
 	}
-	if bot == nil || !bot.SubscribeEvents.Contains(event.BotMessageStampsUpdated) {
+	if bot != nil && bot.SubscribeEvents.Contains(event.BotMessageStampsUpdated) {
+		if err := ctx.Unicast(
+			event.BotMessageStampsUpdated,
+			payload.MakeBotMessageStampsUpdated(datetime, m.GetID(), m.GetStamps()),
+			bot,
+		); err != nil {
+			return fmt.Errorf("failed to unicast: %w", err)
+		}
+	}
+
+	bots, err := ctx.GetChannelBots(m.GetChannelID(), event.MessageStampsUpdated)
+	if err != nil {
+		return fmt.Errorf("failed to GetChannelBots: %w", err)
+	}
+	if len(bots) == 0 {
 		return nil
 	}
 
-	if err := ctx.Unicast(
-		event.BotMessageStampsUpdated,
-		payload.MakeBotMessageStampsUpdated(datetime, m.GetID(), m.GetStamps()),
-		bot,
+	if err := ctx.Multicast(
+		event.MessageStampsUpdated,
+		payload.MakeMessageStampsUpdated(datetime, m.GetID(), m.GetChannelID(), m.GetStamps()),
+		bots,
 	); err != nil {
-		return fmt.Errorf("failed to unicast: %w", err)
+		return fmt.Errorf("failed to multicast: %w", err)
 	}
 	return nil
 }
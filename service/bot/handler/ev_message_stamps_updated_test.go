@@ -37,9 +37,14 @@ func TestMessageStampsUpdated(t *testing.T) {
 		m := &messageImpl{
 			ID:     uuid.NewV3(uuid.Nil, "m"),
 			UID:    uuid.NewV3(uuid.Nil, "bu"),
+			CID:    uuid.NewV3(uuid.Nil, "c"),
 			Stamps: []model.MessageStamp{},
 		}
 		et := time.Now()
+		handlerCtx.EXPECT().
+			GetChannelBots(m.CID, event.MessageStampsUpdated).
+			Return(nil, nil).
+			AnyTimes()
 
 		expectUnicast(handlerCtx, event.BotMessageStampsUpdated, payload.MakeBotMessageStampsUpdated(et, m.ID, m.Stamps), b)
 		assert.NoError(t, MessageStampsUpdated(handlerCtx, et, intevent.MessageStampsUpdated, hub.Fields{
@@ -64,21 +69,61 @@ func TestMessageStampsUpdated(t *testing.T) {
 		m := &messageImpl{
 			ID:     uuid.NewV3(uuid.Nil, "m"),
 			UID:    b.BotUserID,
+			CID:    uuid.NewV3(uuid.Nil, "c"),
 			Stamps: []model.MessageStamp{},
 		}
 		et := time.Now()
+		handlerCtx.EXPECT().
+			GetChannelBots(m.CID, event.MessageStampsUpdated).
+			Return(nil, nil).
+			AnyTimes()
 
 		assert.NoError(t, MessageStampsUpdated(handlerCtx, et, intevent.MessageStampsUpdated, hub.Fields{
 			"message":    m,
 			"message_id": m.ID,
 		}))
 	})
+
+	t.Run("channel bots", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		handlerCtx := mock_handler.NewMockContext(ctrl)
+
+		cb := &model.Bot{
+			ID:              uuid.NewV3(uuid.Nil, "cb"),
+			BotUserID:       uuid.NewV3(uuid.Nil, "cbu"),
+			SubscribeEvents: model.BotEventTypesFromArray([]string{event.MessageStampsUpdated.String()}),
+			State:           model.BotActive,
+		}
+		m := &messageImpl{
+			ID:     uuid.NewV3(uuid.Nil, "m"),
+			UID:    uuid.NewV3(uuid.Nil, "u"),
+			CID:    uuid.NewV3(uuid.Nil, "c"),
+			Stamps: []model.MessageStamp{},
+		}
+		et := time.Now()
+		handlerCtx.EXPECT().
+			GetBotByBotUserID(m.UID).
+			Return(nil, nil).
+			AnyTimes()
+		handlerCtx.EXPECT().
+			GetChannelBots(m.CID, event.MessageStampsUpdated).
+			Return([]*model.Bot{cb}, nil).
+			AnyTimes()
+
+		expectMulticast(handlerCtx, event.MessageStampsUpdated, payload.MakeMessageStampsUpdated(et, m.ID, m.CID, m.Stamps), []*model.Bot{cb})
+		assert.NoError(t, MessageStampsUpdated(handlerCtx, et, intevent.MessageStampsUpdated, hub.Fields{
+			"message":    m,
+			"message_id": m.ID,
+		}))
+	})
 }
 
 type messageImpl struct {
 	message.Message
 	ID     uuid.UUID
 	UID    uuid.UUID
+	CID    uuid.UUID
 	Stamps []model.MessageStamp
 }
 
@@ -90,6 +135,10 @@ func (m *messageImpl) GetUserID() uuid.UUID {
 	return m.UID
 }
 
+func (m *messageImpl) GetChannelID() uuid.UUID {
+	return m.CID
+}
+
 func (m *messageImpl) GetStamps() []model.MessageStamp {
 	return m.Stamps
 }
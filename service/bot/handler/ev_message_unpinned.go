@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/leandro-lugaresi/hub"
+
+	"github.com/traPtitech/traQ/service/bot/event"
+	"github.com/traPtitech/traQ/service/bot/event/payload"
+)
+
+func MessageUnpinned(ctx Context, datetime time.Time, _ string, fields hub.Fields) error {
+	messageID := fields["message_id"].(uuid.UUID)
+	chID := fields["channel_id"].(uuid.UUID)
+
+	bots, err := ctx.GetChannelBots(chID, event.MessageUnpinned)
+	if err != nil {
+		return fmt.Errorf("failed to GetChannelBots: %w", err)
+	}
+	if len(bots) == 0 {
+		return nil
+	}
+
+	if err := ctx.Multicast(
+		event.MessageUnpinned,
+		payload.MakeMessageUnpinned(datetime, messageID, chID),
+		bots,
+	); err != nil {
+		return fmt.Errorf("failed to multicast: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang/mock/gomock"
+	"github.com/leandro-lugaresi/hub"
+	"github.com/stretchr/testify/assert"
+
+	intevent "github.com/traPtitech/traQ/event"
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/service/bot/event"
+	"github.com/traPtitech/traQ/service/bot/event/payload"
+	"github.com/traPtitech/traQ/service/bot/handler/mock_handler"
+)
+
+func TestMessageUnpinned(t *testing.T) {
+	t.Parallel()
+
+	b := &model.Bot{
+		ID:              uuid.NewV3(uuid.Nil, "b"),
+		BotUserID:       uuid.NewV3(uuid.Nil, "bu"),
+		SubscribeEvents: model.BotEventTypesFromArray([]string{event.MessageUnpinned.String()}),
+		State:           model.BotActive,
+	}
+	chID := uuid.NewV3(uuid.Nil, "c")
+	messageID := uuid.NewV3(uuid.Nil, "m")
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		handlerCtx := mock_handler.NewMockContext(ctrl)
+
+		handlerCtx.EXPECT().
+			GetChannelBots(chID, event.MessageUnpinned).
+			Return([]*model.Bot{b}, nil).
+			AnyTimes()
+		et := time.Now()
+
+		expectMulticast(handlerCtx, event.MessageUnpinned, payload.MakeMessageUnpinned(et, messageID, chID), []*model.Bot{b})
+		assert.NoError(t, MessageUnpinned(handlerCtx, et, intevent.MessageUnpinned, hub.Fields{
+			"message_id": messageID,
+			"channel_id": chID,
+		}))
+	})
+
+	t.Run("no bots", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		handlerCtx := mock_handler.NewMockContext(ctrl)
+
+		handlerCtx.EXPECT().
+			GetChannelBots(chID, event.MessageUnpinned).
+			Return(nil, nil).
+			AnyTimes()
+
+		assert.NoError(t, MessageUnpinned(handlerCtx, time.Now(), intevent.MessageUnpinned, hub.Fields{
+			"message_id": messageID,
+			"channel_id": chID,
+		}))
+	})
+}
@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/leandro-lugaresi/hub"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/service/bot/event"
+	"github.com/traPtitech/traQ/service/bot/event/payload"
+)
+
+func SlashCommand(ctx Context, datetime time.Time, _ string, fields hub.Fields) error {
+	botID := fields["bot_id"].(uuid.UUID)
+	cmd := fields["command"].(*model.BotSlashCommand)
+	args := fields["arguments"].(string)
+	options := fields["options"].(map[string]interface{})
+	userID := fields["user_id"].(uuid.UUID)
+	channelID := fields["channel_id"].(uuid.UUID)
+
+	bot, err := ctx.GetBot(botID)
+	if err != nil {
+		return fmt.Errorf("failed to GetBot: %w", err)
+	}
+	if bot == nil {
+		return nil
+	}
+
+	user, err := ctx.R().GetUser(userID, false)
+	if err != nil {
+		return fmt.Errorf("failed to GetUser: %w", err)
+	}
+
+	if err := ctx.Unicast(
+		event.SlashCommand,
+		payload.MakeSlashCommand(datetime, cmd, args, options, channelID, user),
+		bot,
+	); err != nil {
+		return fmt.Errorf("failed to unicast: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang/mock/gomock"
+	"github.com/leandro-lugaresi/hub"
+	"github.com/stretchr/testify/assert"
+
+	intevent "github.com/traPtitech/traQ/event"
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/service/bot/event"
+	"github.com/traPtitech/traQ/service/bot/event/payload"
+)
+
+func TestSlashCommand(t *testing.T) {
+	t.Parallel()
+
+	b := &model.Bot{
+		ID:              uuid.NewV3(uuid.Nil, "b"),
+		BotUserID:       uuid.NewV3(uuid.Nil, "bu"),
+		SubscribeEvents: model.BotEventTypes{},
+		State:           model.BotActive,
+	}
+	u := &model.User{
+		ID:   uuid.NewV3(uuid.Nil, "u"),
+		Name: "testman",
+	}
+	chID := uuid.NewV3(uuid.Nil, "c")
+	cmd := &model.BotSlashCommand{
+		ID:    uuid.NewV3(uuid.Nil, "cmd"),
+		BotID: b.ID,
+		Name:  "roll",
+		Options: model.BotSlashCommandOptions{
+			{Name: "count", Type: model.BotSlashCommandOptionTypeInteger},
+		},
+	}
+	options := map[string]interface{}{"count": int64(3)}
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		handlerCtx, _, repo := setup(t, ctrl)
+		registerBot(t, handlerCtx, b)
+		registerUser(repo, u)
+
+		et := time.Now()
+		expectUnicast(handlerCtx, event.SlashCommand, payload.MakeSlashCommand(et, cmd, "3", options, chID, u), b)
+		assert.NoError(t, SlashCommand(handlerCtx, et, intevent.BotSlashCommandInvoked, hub.Fields{
+			"bot_id":     b.ID,
+			"command":    cmd,
+			"arguments":  "3",
+			"options":    options,
+			"user_id":    u.ID,
+			"channel_id": chID,
+		}))
+	})
+
+	t.Run("inactive bot", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		handlerCtx, _, _ := setup(t, ctrl)
+		handlerCtx.EXPECT().GetBot(b.ID).Return(nil, nil).Times(1)
+
+		assert.NoError(t, SlashCommand(handlerCtx, time.Now(), intevent.BotSlashCommandInvoked, hub.Fields{
+			"bot_id":     b.ID,
+			"command":    cmd,
+			"arguments":  "3",
+			"options":    options,
+			"user_id":    u.ID,
+			"channel_id": chID,
+		}))
+	})
+}
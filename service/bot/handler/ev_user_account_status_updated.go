@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/leandro-lugaresi/hub"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/service/bot/event"
+	"github.com/traPtitech/traQ/service/bot/event/payload"
+)
+
+func UserAccountStatusUpdated(ctx Context, datetime time.Time, _ string, fields hub.Fields) error {
+	userID := fields["user_id"].(uuid.UUID)
+	status := fields["status"].(model.UserAccountStatus)
+	oldStatus := fields["old_status"].(model.UserAccountStatus)
+
+	active := status == model.UserAccountStatusActive
+	if active == (oldStatus == model.UserAccountStatusActive) {
+		return nil // 凍結・凍結解除を伴わない変更は通知しない
+	}
+
+	ev := event.UserDeactivated
+	if active {
+		ev = event.UserActivated
+	}
+
+	bots, err := ctx.GetBots(ev)
+	if err != nil {
+		return fmt.Errorf("failed to GetBots: %w", err)
+	}
+	if len(bots) == 0 {
+		return nil
+	}
+
+	user, err := ctx.R().GetUser(userID, false)
+	if err != nil {
+		return fmt.Errorf("failed to GetUser: %w", err)
+	}
+
+	var p interface{}
+	if active {
+		p = payload.MakeUserActivated(datetime, user)
+	} else {
+		p = payload.MakeUserDeactivated(datetime, user)
+	}
+	if err := ctx.Multicast(ev, p, bots); err != nil {
+		return fmt.Errorf("failed to multicast: %w", err)
+	}
+	return nil
+}
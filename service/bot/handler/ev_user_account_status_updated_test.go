@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang/mock/gomock"
+	"github.com/leandro-lugaresi/hub"
+	"github.com/stretchr/testify/assert"
+
+	intevent "github.com/traPtitech/traQ/event"
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/service/bot/event"
+	"github.com/traPtitech/traQ/service/bot/event/payload"
+	"github.com/traPtitech/traQ/service/bot/handler/mock_handler"
+)
+
+func TestUserAccountStatusUpdated(t *testing.T) {
+	t.Parallel()
+
+	b := &model.Bot{
+		ID:              uuid.NewV3(uuid.Nil, "b"),
+		BotUserID:       uuid.NewV3(uuid.Nil, "bu"),
+		SubscribeEvents: model.BotEventTypesFromArray([]string{event.UserActivated.String(), event.UserDeactivated.String()}),
+		State:           model.BotActive,
+	}
+	u := &model.User{
+		ID:   uuid.NewV3(uuid.Nil, "u"),
+		Name: "testman",
+	}
+
+	t.Run("activated", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		handlerCtx, _, repo := setup(t, ctrl)
+		registerBot(t, handlerCtx, b)
+		registerUser(repo, u)
+		et := time.Now()
+
+		expectMulticast(handlerCtx, event.UserActivated, payload.MakeUserActivated(et, u), []*model.Bot{b})
+		assert.NoError(t, UserAccountStatusUpdated(handlerCtx, et, intevent.UserAccountStatusUpdated, hub.Fields{
+			"user_id":    u.ID,
+			"status":     model.UserAccountStatusActive,
+			"old_status": model.UserAccountStatusDeactivated,
+		}))
+	})
+
+	t.Run("deactivated", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		handlerCtx, _, repo := setup(t, ctrl)
+		registerBot(t, handlerCtx, b)
+		registerUser(repo, u)
+		et := time.Now()
+
+		expectMulticast(handlerCtx, event.UserDeactivated, payload.MakeUserDeactivated(et, u), []*model.Bot{b})
+		assert.NoError(t, UserAccountStatusUpdated(handlerCtx, et, intevent.UserAccountStatusUpdated, hub.Fields{
+			"user_id":    u.ID,
+			"status":     model.UserAccountStatusSuspended,
+			"old_status": model.UserAccountStatusActive,
+		}))
+	})
+
+	t.Run("deactivated to suspended", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		handlerCtx := mock_handler.NewMockContext(ctrl)
+
+		assert.NoError(t, UserAccountStatusUpdated(handlerCtx, time.Now(), intevent.UserAccountStatusUpdated, hub.Fields{
+			"user_id":    u.ID,
+			"status":     model.UserAccountStatusSuspended,
+			"old_status": model.UserAccountStatusDeactivated,
+		}))
+	})
+}
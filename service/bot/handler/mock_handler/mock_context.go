@@ -182,3 +182,15 @@ func (mr *MockContextMockRecorder) Unicast(ev, payload, target interface{}) *gom
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unicast", reflect.TypeOf((*MockContext)(nil).Unicast), ev, payload, target)
 }
+
+// WriteToUser mocks base method.
+func (m *MockContext) WriteToUser(userID uuid.UUID, wsEventType string, wsPayload interface{}) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "WriteToUser", userID, wsEventType, wsPayload)
+}
+
+// WriteToUser indicates an expected call of WriteToUser.
+func (mr *MockContextMockRecorder) WriteToUser(userID, wsEventType, wsPayload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteToUser", reflect.TypeOf((*MockContext)(nil).WriteToUser), userID, wsEventType, wsPayload)
+}
@@ -12,17 +12,26 @@ import (
 type eventHandler func(ctx handler.Context, datetime time.Time, event string, fields hub.Fields) error
 
 var eventHandlerSet = map[string]eventHandler{
-	intevent.BotJoined:            handler.BotJoined,
-	intevent.BotLeft:              handler.BotLeft,
-	intevent.BotPingRequest:       handler.BotPingRequest,
-	intevent.MessageCreated:       handler.MessageCreated,
-	intevent.MessageDeleted:       handler.MessageDeleted,
-	intevent.MessageUpdated:       handler.MessageUpdated,
-	intevent.UserCreated:          handler.UserCreated,
-	intevent.ChannelCreated:       handler.ChannelCreated,
-	intevent.ChannelTopicUpdated:  handler.ChannelTopicUpdated,
-	intevent.StampCreated:         handler.StampCreated,
-	intevent.UserTagAdded:         handler.UserTagAdded,
-	intevent.UserTagRemoved:       handler.UserTagRemoved,
-	intevent.MessageStampsUpdated: handler.MessageStampsUpdated,
+	intevent.BotJoined:                    handler.BotJoined,
+	intevent.BotLeft:                      handler.BotLeft,
+	intevent.BotPingRequest:               handler.BotPingRequest,
+	intevent.MessageCreated:               handler.MessageCreated,
+	intevent.MessageDeleted:               handler.MessageDeleted,
+	intevent.MessageUpdated:               handler.MessageUpdated,
+	intevent.UserCreated:                  handler.UserCreated,
+	intevent.ChannelCreated:               handler.ChannelCreated,
+	intevent.ChannelTopicUpdated:          handler.ChannelTopicUpdated,
+	intevent.StampCreated:                 handler.StampCreated,
+	intevent.UserTagAdded:                 handler.UserTagAdded,
+	intevent.UserTagRemoved:               handler.UserTagRemoved,
+	intevent.MessageStampsUpdated:         handler.MessageStampsUpdated,
+	intevent.MessagePinned:                handler.MessagePinned,
+	intevent.MessageUnpinned:              handler.MessageUnpinned,
+	intevent.ChannelUpdated:               handler.ChannelUpdated,
+	intevent.UserAccountStatusUpdated:     handler.UserAccountStatusUpdated,
+	intevent.UserGroupMemberAdded:         handler.UserGroupMemberAdded,
+	intevent.UserGroupMemberRemoved:       handler.UserGroupMemberRemoved,
+	intevent.BotSlashCommandInvoked:       handler.SlashCommand,
+	intevent.MessageInteractionCreated:    handler.Interaction,
+	intevent.BotEphemeralMessageRequested: handler.EphemeralMessage,
 }
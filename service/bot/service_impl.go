@@ -15,10 +15,13 @@ import (
 	"github.com/traPtitech/traQ/service/bot/event"
 	botWS "github.com/traPtitech/traQ/service/bot/ws"
 	"github.com/traPtitech/traQ/service/channel"
+	"github.com/traPtitech/traQ/service/ws"
 )
 
 const (
-	botEventLogPurgeBefore = time.Hour * 24 * 365 // BOTイベントログを1年間保持
+	botEventLogPurgeBefore  = time.Hour * 24 * 365 // BOTイベントログを1年間保持
+	deadDeliveryPurgeBefore = time.Hour * 24 * 30  // DeadなBOTイベント配送を30日間保持
+	retryPollInterval       = 10 * time.Second     // 配送に失敗したBOTイベントの再試行間隔
 )
 
 type serviceImpl struct {
@@ -27,26 +30,30 @@ type serviceImpl struct {
 	logger     *zap.Logger
 	dispatcher event.Dispatcher
 	hub        *hub.Hub
+	ws         *ws.Streamer
 
 	sub         hub.Subscription
 	logPurger   *jitterbug.Ticker
 	serviceDone chan struct{}
 	hubDone     chan struct{}
 	purgerDone  chan struct{}
+	retryDone   chan struct{}
 }
 
 // NewService ボットサービスを生成します
-func NewService(repo repository.Repository, cm channel.Manager, hub *hub.Hub, s *botWS.Streamer, logger *zap.Logger) Service {
+func NewService(repo repository.Repository, cm channel.Manager, hub *hub.Hub, s *botWS.Streamer, ws *ws.Streamer, logger *zap.Logger) Service {
 	p := &serviceImpl{
 		repo:       repo,
 		cm:         cm,
 		logger:     logger.Named("bot"),
 		hub:        hub,
+		ws:         ws,
 		dispatcher: event.NewDispatcher(logger, repo, s),
 
 		serviceDone: make(chan struct{}),
 		hubDone:     make(chan struct{}),
 		purgerDone:  make(chan struct{}),
+		retryDone:   make(chan struct{}),
 	}
 	p.start()
 	return p
@@ -79,7 +86,7 @@ func (p *serviceImpl) start() {
 		wg.Wait()
 	}()
 
-	// BOTイベントログの定期的消去
+	// BOTイベントログ・DeadなBOTイベント配送の定期的消去
 	p.logPurger = jitterbug.New(time.Hour*24, &jitterbug.Uniform{
 		Min: time.Hour * 23,
 	})
@@ -94,6 +101,24 @@ func (p *serviceImpl) start() {
 				if err := p.repo.PurgeBotEventLogs(time.Now().Add(-botEventLogPurgeBefore)); err != nil {
 					p.logger.Error("an error occurred while puring old bot event logs", zap.Error(err))
 				}
+				if err := p.repo.PurgeDeadBotEventDeliveries(time.Now().Add(-deadDeliveryPurgeBefore)); err != nil {
+					p.logger.Error("an error occurred while purging old dead bot event deliveries", zap.Error(err))
+				}
+			case <-p.serviceDone:
+				return
+			}
+		}
+	}()
+
+	// 配送に失敗したBOTイベントの再試行
+	go func() {
+		defer close(p.retryDone)
+		ticker := time.NewTicker(retryPollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case now := <-ticker.C:
+				p.dispatcher.RetryDue(now)
 			case <-p.serviceDone:
 				return
 			}
@@ -109,6 +134,7 @@ func (p *serviceImpl) Shutdown(ctx context.Context) error {
 	close(p.serviceDone)
 	<-p.hubDone
 	<-p.purgerDone
+	<-p.retryDone
 	return nil
 }
 
@@ -136,6 +162,10 @@ func (p *serviceImpl) Multicast(ev model.BotEventType, payload interface{}, targ
 	return event.Multicast(p.dispatcher, ev, payload, targets)
 }
 
+func (p *serviceImpl) WriteToUser(userID uuid.UUID, wsEventType string, wsPayload interface{}) {
+	p.ws.WriteMessage(wsEventType, wsPayload, ws.TargetUsers(userID))
+}
+
 func (p *serviceImpl) GetBot(id uuid.UUID) (*model.Bot, error) {
 	bots, err := p.repo.GetBots(repository.BotsQuery{}.Active().BotID(id))
 	if err != nil {
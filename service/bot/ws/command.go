@@ -0,0 +1,282 @@
+package ws
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gofrs/uuid"
+	"github.com/gorilla/websocket"
+	jsonIter "github.com/json-iterator/go"
+)
+
+const (
+	// maxConcurrentCommands 1セッションあたりの同時実行可能なコマンド数
+	maxConcurrentCommands = 4
+	apiPrefix             = "/api/v3"
+)
+
+// commandRequest Botから送られるJSONコマンド
+type commandRequest struct {
+	// ID 応答との対応付けに使われるID (Bot側で自由に決められる)
+	ID      string              `json:"id"`
+	Command string              `json:"command"`
+	Args    jsonIter.RawMessage `json:"args"`
+}
+
+// commandResponse コマンドの実行結果
+type commandResponse struct {
+	Type string              `json:"type"`
+	ID   string              `json:"id"`
+	Body commandResponseBody `json:"body"`
+}
+
+type commandResponseBody struct {
+	Status int           `json:"status"`
+	Data   marshalledRaw `json:"data,omitempty"`
+	Error  string        `json:"error,omitempty"`
+}
+
+func (m *commandResponse) toJSON() (b []byte) {
+	b, _ = json.Marshal(m)
+	return
+}
+
+// apiRequest コマンドを変換したv3 APIへのリクエスト
+type apiRequest struct {
+	method string
+	path   string
+	body   interface{}
+}
+
+type commandBuilder func(s *session, args jsonIter.RawMessage) (*apiRequest, error)
+
+var errBotIDUnavailable = errors.New("bot id is unavailable in this session")
+
+// commands コマンド名とv3 APIへの変換の対応
+var commands = map[string]commandBuilder{
+	"postMessage": func(_ *session, raw jsonIter.RawMessage) (*apiRequest, error) {
+		var args struct {
+			ChannelID uuid.UUID `json:"channelId"`
+			Content   string    `json:"content"`
+			Embed     bool      `json:"embed"`
+		}
+		if err := json.Unmarshal(raw, &args); err != nil {
+			return nil, err
+		}
+		return &apiRequest{
+			method: http.MethodPost,
+			path:   fmt.Sprintf("/channels/%s/messages", args.ChannelID),
+			body:   map[string]interface{}{"content": args.Content, "embed": args.Embed},
+		}, nil
+	},
+	"editMessage": func(_ *session, raw jsonIter.RawMessage) (*apiRequest, error) {
+		var args struct {
+			MessageID uuid.UUID `json:"messageId"`
+			Content   string    `json:"content"`
+		}
+		if err := json.Unmarshal(raw, &args); err != nil {
+			return nil, err
+		}
+		return &apiRequest{
+			method: http.MethodPut,
+			path:   fmt.Sprintf("/messages/%s", args.MessageID),
+			body:   map[string]interface{}{"content": args.Content},
+		}, nil
+	},
+	"deleteMessage": func(_ *session, raw jsonIter.RawMessage) (*apiRequest, error) {
+		var args struct {
+			MessageID uuid.UUID `json:"messageId"`
+		}
+		if err := json.Unmarshal(raw, &args); err != nil {
+			return nil, err
+		}
+		return &apiRequest{
+			method: http.MethodDelete,
+			path:   fmt.Sprintf("/messages/%s", args.MessageID),
+		}, nil
+	},
+	"addStamp": func(_ *session, raw jsonIter.RawMessage) (*apiRequest, error) {
+		var args struct {
+			MessageID uuid.UUID `json:"messageId"`
+			StampID   uuid.UUID `json:"stampId"`
+			Count     int       `json:"count"`
+		}
+		if err := json.Unmarshal(raw, &args); err != nil {
+			return nil, err
+		}
+		if args.Count == 0 {
+			args.Count = 1
+		}
+		return &apiRequest{
+			method: http.MethodPost,
+			path:   fmt.Sprintf("/messages/%s/stamps/%s", args.MessageID, args.StampID),
+			body:   map[string]interface{}{"count": args.Count},
+		}, nil
+	},
+	"removeStamp": func(_ *session, raw jsonIter.RawMessage) (*apiRequest, error) {
+		var args struct {
+			MessageID uuid.UUID `json:"messageId"`
+			StampID   uuid.UUID `json:"stampId"`
+		}
+		if err := json.Unmarshal(raw, &args); err != nil {
+			return nil, err
+		}
+		return &apiRequest{
+			method: http.MethodDelete,
+			path:   fmt.Sprintf("/messages/%s/stamps/%s", args.MessageID, args.StampID),
+		}, nil
+	},
+	"joinChannel": func(s *session, raw jsonIter.RawMessage) (*apiRequest, error) {
+		return makeBotActionRequest(s, raw, "join")
+	},
+	"leaveChannel": func(s *session, raw jsonIter.RawMessage) (*apiRequest, error) {
+		return makeBotActionRequest(s, raw, "leave")
+	},
+}
+
+func makeBotActionRequest(s *session, raw jsonIter.RawMessage, action string) (*apiRequest, error) {
+	if s.botID == uuid.Nil {
+		return nil, errBotIDUnavailable
+	}
+	var args struct {
+		ChannelID uuid.UUID `json:"channelId"`
+	}
+	if err := json.Unmarshal(raw, &args); err != nil {
+		return nil, err
+	}
+	return &apiRequest{
+		method: http.MethodPost,
+		path:   fmt.Sprintf("/bots/%s/actions/%s", s.botID, action),
+		body:   map[string]interface{}{"channelId": args.ChannelID},
+	}, nil
+}
+
+// isJSONCommand メッセージがJSONコマンドかどうか
+func isJSONCommand(m []byte) bool {
+	m = bytes.TrimSpace(m)
+	return len(m) > 0 && m[0] == '{'
+}
+
+// jsonCommandHandler JSONコマンドを実行し、結果をRESPONSEメッセージとして返します
+//
+// コマンドはv3 APIへのリクエストに変換され、接続時の認証情報で実行されるため、
+// バリデーションや権限チェックはHTTP APIと同一です。
+func (s *session) jsonCommandHandler(m []byte) {
+	var req commandRequest
+	if err := json.Unmarshal(m, &req); err != nil {
+		s.sendErrorMessage(fmt.Sprintf("invalid command: %s", err))
+		return
+	}
+	if len(req.ID) == 0 {
+		s.sendErrorMessage("invalid command: id is required")
+		return
+	}
+
+	build, ok := commands[req.Command]
+	if !ok {
+		s.sendCommandResponse(req.ID, commandResponseBody{Status: http.StatusBadRequest, Error: fmt.Sprintf("unknown command: %s", req.Command)})
+		return
+	}
+	if len(req.Args) == 0 {
+		req.Args = jsonIter.RawMessage("{}")
+	}
+	apiReq, err := build(s, req.Args)
+	if err != nil {
+		s.sendCommandResponse(req.ID, commandResponseBody{Status: http.StatusBadRequest, Error: fmt.Sprintf("invalid args: %s", err)})
+		return
+	}
+
+	s.commandSem <- struct{}{}
+	go func() {
+		defer func() { <-s.commandSem }()
+		s.sendCommandResponse(req.ID, s.execute(apiReq))
+	}()
+}
+
+// execute v3 APIへのリクエストを実行します
+func (s *session) execute(req *apiRequest) commandResponseBody {
+	api := s.streamer.api()
+	if api == nil {
+		return commandResponseBody{Status: http.StatusServiceUnavailable, Error: "commands are not available"}
+	}
+
+	var body []byte
+	if req.body != nil {
+		body, _ = json.Marshal(req.body)
+	}
+	r, err := http.NewRequestWithContext(context.Background(), req.method, apiPrefix+req.path, bytes.NewReader(body))
+	if err != nil {
+		return commandResponseBody{Status: http.StatusInternalServerError, Error: http.StatusText(http.StatusInternalServerError)}
+	}
+	r.Header = s.authHeader.Clone()
+	if body != nil {
+		r.Header.Set("Content-Type", "application/json")
+	}
+
+	rw := newResponseBuffer()
+	api.ServeHTTP(rw, r)
+
+	if rw.status == 0 {
+		rw.status = http.StatusOK
+	}
+	res := commandResponseBody{Status: rw.status}
+	data := bytes.TrimSpace(rw.buf.Bytes())
+	if rw.status >= http.StatusBadRequest {
+		var e struct {
+			Message string `json:"message"`
+		}
+		if err := json.Unmarshal(data, &e); err == nil && len(e.Message) > 0 {
+			res.Error = e.Message
+		} else {
+			res.Error = strings.ToLower(http.StatusText(rw.status))
+		}
+		return res
+	}
+	if len(data) > 0 {
+		res.Data = data
+	}
+	return res
+}
+
+func (s *session) sendCommandResponse(id string, body commandResponseBody) {
+	_ = s.WriteMessage(&rawMessage{
+		t: websocket.TextMessage,
+		data: (&commandResponse{
+			Type: "RESPONSE",
+			ID:   id,
+			Body: body,
+		}).toJSON(),
+	})
+}
+
+// responseBuffer コマンド実行時のレスポンスを保持するhttp.ResponseWriter
+type responseBuffer struct {
+	header http.Header
+	status int
+	buf    bytes.Buffer
+}
+
+func newResponseBuffer() *responseBuffer {
+	return &responseBuffer{header: http.Header{}}
+}
+
+func (w *responseBuffer) Header() http.Header {
+	return w.header
+}
+
+func (w *responseBuffer) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return w.buf.Write(b)
+}
+
+func (w *responseBuffer) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+}
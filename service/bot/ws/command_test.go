@@ -0,0 +1,160 @@
+package ws
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type recordedRequest struct {
+	method string
+	path   string
+	auth   string
+	body   string
+}
+
+func setupCommandSession(t *testing.T, handler http.HandlerFunc) (*session, chan recordedRequest) {
+	t.Helper()
+	reqs := make(chan recordedRequest, 1)
+	s := NewStreamer(nil, nil, zap.NewNop())
+	s.SetAPIHandler(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		reqs <- recordedRequest{method: r.Method, path: r.URL.Path, auth: r.Header.Get("Authorization"), body: string(b)}
+		handler(rw, r)
+	}))
+	sess := newSession(uuid.NewV3(uuid.Nil, "bu"), uuid.NewV3(uuid.Nil, "b"), http.Header{"Authorization": {"Bearer token"}}, s, nil)
+	return sess, reqs
+}
+
+func readResponse(t *testing.T, s *session) *commandResponse {
+	t.Helper()
+	m := <-s.send
+	var res struct {
+		Type string `json:"type"`
+		ID   string `json:"id"`
+		Body struct {
+			Status int    `json:"status"`
+			Error  string `json:"error"`
+		} `json:"body"`
+	}
+	require.NoError(t, json.Unmarshal(m.data, &res))
+	assert.Equal(t, "RESPONSE", res.Type)
+	return &commandResponse{Type: res.Type, ID: res.ID, Body: commandResponseBody{Status: res.Body.Status, Error: res.Body.Error}}
+}
+
+func TestSession_jsonCommandHandler(t *testing.T) {
+	t.Parallel()
+
+	cid := uuid.NewV3(uuid.Nil, "c")
+	mid := uuid.NewV3(uuid.Nil, "m")
+	sid := uuid.NewV3(uuid.Nil, "s")
+
+	t.Run("postMessage", func(t *testing.T) {
+		t.Parallel()
+		s, reqs := setupCommandSession(t, func(rw http.ResponseWriter, _ *http.Request) {
+			rw.Header().Set("Content-Type", "application/json")
+			rw.WriteHeader(http.StatusCreated)
+			_, _ = rw.Write([]byte(`{"id":"x"}`))
+		})
+
+		s.jsonCommandHandler([]byte(`{"id":"1","command":"postMessage","args":{"channelId":"` + cid.String() + `","content":"hello","embed":true}}`))
+		req := <-reqs
+		assert.Equal(t, http.MethodPost, req.method)
+		assert.Equal(t, "/api/v3/channels/"+cid.String()+"/messages", req.path)
+		assert.Equal(t, "Bearer token", req.auth)
+		assert.JSONEq(t, `{"content":"hello","embed":true}`, req.body)
+
+		res := readResponse(t, s)
+		assert.Equal(t, "1", res.ID)
+		assert.Equal(t, http.StatusCreated, res.Body.Status)
+	})
+
+	t.Run("addStamp", func(t *testing.T) {
+		t.Parallel()
+		s, reqs := setupCommandSession(t, func(rw http.ResponseWriter, _ *http.Request) {
+			rw.WriteHeader(http.StatusNoContent)
+		})
+
+		s.jsonCommandHandler([]byte(`{"id":"2","command":"addStamp","args":{"messageId":"` + mid.String() + `","stampId":"` + sid.String() + `"}}`))
+		req := <-reqs
+		assert.Equal(t, http.MethodPost, req.method)
+		assert.Equal(t, "/api/v3/messages/"+mid.String()+"/stamps/"+sid.String(), req.path)
+		assert.JSONEq(t, `{"count":1}`, req.body)
+
+		res := readResponse(t, s)
+		assert.Equal(t, "2", res.ID)
+		assert.Equal(t, http.StatusNoContent, res.Body.Status)
+	})
+
+	t.Run("joinChannel", func(t *testing.T) {
+		t.Parallel()
+		s, reqs := setupCommandSession(t, func(rw http.ResponseWriter, _ *http.Request) {
+			rw.WriteHeader(http.StatusNoContent)
+		})
+
+		s.jsonCommandHandler([]byte(`{"id":"3","command":"joinChannel","args":{"channelId":"` + cid.String() + `"}}`))
+		req := <-reqs
+		assert.Equal(t, "/api/v3/bots/"+s.botID.String()+"/actions/join", req.path)
+		assert.JSONEq(t, `{"channelId":"`+cid.String()+`"}`, req.body)
+		assert.Equal(t, http.StatusNoContent, readResponse(t, s).Body.Status)
+	})
+
+	t.Run("api error", func(t *testing.T) {
+		t.Parallel()
+		s, reqs := setupCommandSession(t, func(rw http.ResponseWriter, _ *http.Request) {
+			rw.Header().Set("Content-Type", "application/json")
+			rw.WriteHeader(http.StatusForbidden)
+			_, _ = rw.Write([]byte(`{"message":"you are not permitted to request to '/api/v3/messages/:messageID'"}`))
+		})
+
+		s.jsonCommandHandler([]byte(`{"id":"4","command":"deleteMessage","args":{"messageId":"` + mid.String() + `"}}`))
+		req := <-reqs
+		assert.Equal(t, http.MethodDelete, req.method)
+
+		res := readResponse(t, s)
+		assert.Equal(t, http.StatusForbidden, res.Body.Status)
+		assert.Equal(t, "you are not permitted to request to '/api/v3/messages/:messageID'", res.Body.Error)
+	})
+
+	t.Run("unknown command", func(t *testing.T) {
+		t.Parallel()
+		s, _ := setupCommandSession(t, func(rw http.ResponseWriter, _ *http.Request) {})
+
+		s.jsonCommandHandler([]byte(`{"id":"5","command":"foo"}`))
+		res := readResponse(t, s)
+		assert.Equal(t, "5", res.ID)
+		assert.Equal(t, http.StatusBadRequest, res.Body.Status)
+		assert.Equal(t, "unknown command: foo", res.Body.Error)
+	})
+
+	t.Run("invalid args", func(t *testing.T) {
+		t.Parallel()
+		s, _ := setupCommandSession(t, func(rw http.ResponseWriter, _ *http.Request) {})
+
+		s.jsonCommandHandler([]byte(`{"id":"6","command":"editMessage","args":{"messageId":"invalid"}}`))
+		res := readResponse(t, s)
+		assert.Equal(t, http.StatusBadRequest, res.Body.Status)
+	})
+
+	t.Run("missing id", func(t *testing.T) {
+		t.Parallel()
+		s, _ := setupCommandSession(t, func(rw http.ResponseWriter, _ *http.Request) {})
+
+		s.jsonCommandHandler([]byte(`{"command":"postMessage"}`))
+		m := <-s.send
+		assert.Contains(t, string(m.data), `"type":"ERROR"`)
+	})
+}
+
+func TestIsJSONCommand(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, isJSONCommand([]byte(` {"id":"1"}`)))
+	assert.False(t, isJSONCommand([]byte("rtcstate:null")))
+	assert.False(t, isJSONCommand([]byte("  ")))
+}
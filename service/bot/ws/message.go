@@ -14,16 +14,20 @@ func (m marshalledRaw) MarshalJSON() ([]byte, error) {
 }
 
 type eventMessage struct {
-	Type  string        `json:"type"`
-	ReqID uuid.UUID     `json:"reqId"`
-	Body  marshalledRaw `json:"body"`
+	Type   string        `json:"type"`
+	ReqID  uuid.UUID     `json:"reqId"`
+	Seq    uint64        `json:"seq"`
+	Cursor string        `json:"cursor"`
+	Body   marshalledRaw `json:"body"`
 }
 
-func makeEventMessage(t string, reqID uuid.UUID, b []byte) (m *eventMessage) {
+func makeEventMessage(t string, reqID uuid.UUID, c cursor, b []byte) (m *eventMessage) {
 	return &eventMessage{
-		Type:  t,
-		ReqID: reqID,
-		Body:  b,
+		Type:   t,
+		ReqID:  reqID,
+		Seq:    c.seq,
+		Cursor: c.String(),
+		Body:   b,
 	}
 }
 
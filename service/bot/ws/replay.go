@@ -0,0 +1,107 @@
+package ws
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// replayBufferSize Botごとに保持するイベントの最大数
+	//
+	// 再送時に送信バッファが溢れないよう、messageBufferSize未満にすること
+	replayBufferSize = 200
+	// replayRetention イベントを保持する期間
+	replayRetention = 5 * time.Minute
+)
+
+// ErrGapTooLarge 取りこぼしたイベントが既に破棄されているため再送できません
+var ErrGapTooLarge = errors.New("gap too large")
+
+// cursor 再送を開始する位置
+//
+// サーバーの再起動でシーケンス番号はリセットされるため、サーバーインスタンスごとのエポックと組にして`epoch:seq`の形式で表します。
+type cursor struct {
+	epoch string
+	seq   uint64
+}
+
+func (c cursor) String() string {
+	return c.epoch + ":" + strconv.FormatUint(c.seq, 10)
+}
+
+// parseCursor `epoch:seq`形式のカーソルをパースします
+func parseCursor(s string) (cursor, error) {
+	epoch, seq, ok := strings.Cut(s, ":")
+	if !ok || len(epoch) == 0 {
+		return cursor{}, errors.New("invalid cursor")
+	}
+	n, err := strconv.ParseUint(seq, 10, 64)
+	if err != nil {
+		return cursor{}, errors.New("invalid cursor")
+	}
+	return cursor{epoch: epoch, seq: n}, nil
+}
+
+type bufferedEvent struct {
+	seq  uint64
+	data []byte
+	at   time.Time
+}
+
+// replayBuffer 再接続時の再送のために最近送信したイベントを保持するバッファ
+type replayBuffer struct {
+	sync.Mutex
+	epoch   string
+	lastSeq uint64
+	events  []*bufferedEvent
+}
+
+// nextCursor 次に追加するイベントのカーソルを返します
+func (b *replayBuffer) nextCursor() cursor {
+	return cursor{epoch: b.epoch, seq: b.lastSeq + 1}
+}
+
+// push イベントを次のシーケンス番号でバッファに追加します
+func (b *replayBuffer) push(data []byte, now time.Time) {
+	b.evict(now)
+	b.events = append(b.events, &bufferedEvent{
+		seq:  b.lastSeq + 1,
+		data: data,
+		at:   now,
+	})
+	b.lastSeq++
+	if len(b.events) > replayBufferSize {
+		b.events = b.events[len(b.events)-replayBufferSize:]
+	}
+}
+
+// evict 保持期間を過ぎたイベントを破棄します
+func (b *replayBuffer) evict(now time.Time) {
+	i := 0
+	for i < len(b.events) && now.Sub(b.events[i].at) > replayRetention {
+		i++
+	}
+	b.events = b.events[i:]
+}
+
+// since カーソルより後のイベントを全て返します
+//
+// 該当するイベントの一部が既に破棄されている場合や、カーソルが別のサーバーインスタンスのものである場合、ErrGapTooLargeを返します。
+func (b *replayBuffer) since(c cursor, now time.Time) ([]*bufferedEvent, error) {
+	b.evict(now)
+	lastSeq := c.seq
+	if c.epoch != b.epoch || lastSeq > b.lastSeq {
+		// サーバーが再起動した場合など
+		return nil, ErrGapTooLarge
+	}
+	if lastSeq == b.lastSeq {
+		return nil, nil
+	}
+	if len(b.events) == 0 || b.events[0].seq > lastSeq+1 {
+		return nil, ErrGapTooLarge
+	}
+	return b.events[lastSeq+1-b.events[0].seq:], nil
+}
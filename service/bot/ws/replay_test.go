@@ -0,0 +1,126 @@
+package ws
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func seqs(events []*bufferedEvent) []uint64 {
+	res := make([]uint64, len(events))
+	for i, ev := range events {
+		res[i] = ev.seq
+	}
+	return res
+}
+
+func TestReplayBuffer_since(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	t.Run("empty", func(t *testing.T) {
+		t.Parallel()
+		var b replayBuffer
+
+		events, err := b.since(cursor{seq: 0}, now)
+		assert.NoError(t, err)
+		assert.Empty(t, events)
+
+		_, err = b.since(cursor{seq: 1}, now)
+		assert.ErrorIs(t, err, ErrGapTooLarge)
+	})
+
+	t.Run("missed events", func(t *testing.T) {
+		t.Parallel()
+		var b replayBuffer
+		for i := 0; i < 5; i++ {
+			b.push([]byte(strconv.Itoa(i)), now)
+		}
+
+		events, err := b.since(cursor{seq: 2}, now)
+		if assert.NoError(t, err) {
+			assert.Equal(t, []uint64{3, 4, 5}, seqs(events))
+			assert.Equal(t, []byte("2"), events[0].data)
+		}
+
+		events, err = b.since(cursor{seq: 5}, now)
+		assert.NoError(t, err)
+		assert.Empty(t, events)
+	})
+
+	t.Run("different epoch", func(t *testing.T) {
+		t.Parallel()
+		b := replayBuffer{epoch: "new"}
+		for i := 0; i < 5; i++ {
+			b.push([]byte(strconv.Itoa(i)), now)
+		}
+
+		// 再起動前のサーバーのカーソルでは、シーケンス番号が有効でも再送しない
+		_, err := b.since(cursor{epoch: "old", seq: 2}, now)
+		assert.ErrorIs(t, err, ErrGapTooLarge)
+
+		events, err := b.since(cursor{epoch: "new", seq: 2}, now)
+		if assert.NoError(t, err) {
+			assert.Equal(t, []uint64{3, 4, 5}, seqs(events))
+		}
+	})
+
+	t.Run("future seq", func(t *testing.T) {
+		t.Parallel()
+		var b replayBuffer
+		b.push([]byte("a"), now)
+
+		_, err := b.since(cursor{seq: 2}, now)
+		assert.ErrorIs(t, err, ErrGapTooLarge)
+	})
+
+	t.Run("overflowed", func(t *testing.T) {
+		t.Parallel()
+		var b replayBuffer
+		for i := 0; i < replayBufferSize+10; i++ {
+			b.push([]byte(strconv.Itoa(i)), now)
+		}
+
+		_, err := b.since(cursor{seq: 5}, now)
+		assert.ErrorIs(t, err, ErrGapTooLarge)
+
+		events, err := b.since(cursor{seq: 10}, now)
+		if assert.NoError(t, err) {
+			assert.Len(t, events, replayBufferSize)
+			assert.EqualValues(t, 11, events[0].seq)
+		}
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		t.Parallel()
+		var b replayBuffer
+		b.push([]byte("a"), now.Add(-2*replayRetention))
+		b.push([]byte("b"), now)
+
+		_, err := b.since(cursor{seq: 0}, now)
+		assert.ErrorIs(t, err, ErrGapTooLarge)
+
+		events, err := b.since(cursor{seq: 1}, now)
+		if assert.NoError(t, err) {
+			assert.Equal(t, []uint64{2}, seqs(events))
+		}
+	})
+}
+
+func TestParseCursor(t *testing.T) {
+	t.Parallel()
+
+	c, err := parseCursor("abc:12")
+	if assert.NoError(t, err) {
+		assert.Equal(t, cursor{epoch: "abc", seq: 12}, c)
+		assert.Equal(t, "abc:12", c.String())
+	}
+
+	for _, s := range []string{"", "12", ":12", "abc:", "abc:-1", "abc:x"} {
+		_, err := parseCursor(s)
+		assert.Error(t, err, s)
+	}
+}
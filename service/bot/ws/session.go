@@ -1,6 +1,7 @@
 package ws
 
 import (
+	"net/http"
 	"sync"
 	"time"
 
@@ -13,23 +14,32 @@ import (
 type session struct {
 	key      string
 	userID   uuid.UUID
+	botID    uuid.UUID
 	conn     *websocket.Conn
 	streamer *Streamer
 
+	// authHeader コマンド実行時に使用する接続時の認証情報
+	authHeader http.Header
+	commandSem chan struct{}
+
 	*sync.RWMutex
 	send      chan *rawMessage
 	closed    bool
 	closeWait *sync.Cond
 }
 
-func newSession(userID uuid.UUID, streamer *Streamer, conn *websocket.Conn) *session {
+func newSession(userID uuid.UUID, botID uuid.UUID, authHeader http.Header, streamer *Streamer, conn *websocket.Conn) *session {
 	mu := sync.RWMutex{}
 	return &session{
 		key:      random.AlphaNumeric(20),
 		userID:   userID,
+		botID:    botID,
 		conn:     conn,
 		streamer: streamer,
 
+		authHeader: authHeader,
+		commandSem: make(chan struct{}, maxConcurrentCommands),
+
 		RWMutex:   &mu,
 		send:      make(chan *rawMessage, messageBufferSize),
 		closed:    false,
@@ -54,7 +64,11 @@ func (s *session) ReadLoop() {
 		}
 
 		if t == websocket.TextMessage {
-			s.commandHandler(string(m))
+			if isJSONCommand(m) {
+				s.jsonCommandHandler(m)
+			} else {
+				s.commandHandler(string(m))
+			}
 		}
 
 		if t == websocket.BinaryMessage {
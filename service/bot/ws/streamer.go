@@ -2,8 +2,10 @@ package ws
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"sync"
+	"time"
 
 	"github.com/gofrs/uuid"
 	"github.com/gorilla/websocket"
@@ -13,6 +15,7 @@ import (
 	"github.com/traPtitech/traQ/event"
 	"github.com/traPtitech/traQ/router/extension/ctxKey"
 	"github.com/traPtitech/traQ/service/webrtcv3"
+	"github.com/traPtitech/traQ/utils/random"
 )
 
 var (
@@ -30,6 +33,14 @@ type Streamer struct {
 	sessions map[uuid.UUID][]*session
 	closed   bool
 	mu       sync.RWMutex
+
+	// epoch 再送用カーソルのエポック (サーバーインスタンスごとに異なる)
+	epoch     string
+	buffers   map[uuid.UUID]*replayBuffer
+	buffersMu sync.Mutex
+
+	apiHandler http.Handler
+	apiMu      sync.RWMutex
 }
 
 // NewStreamer WebSocketストリーマーを生成し起動します
@@ -40,10 +51,37 @@ func NewStreamer(hub *hub.Hub, webrtc *webrtcv3.Manager, logger *zap.Logger) *St
 		logger:   logger.Named("bot.ws"),
 		sessions: make(map[uuid.UUID][]*session),
 		closed:   false,
+		epoch:    random.AlphaNumeric(8),
+		buffers:  make(map[uuid.UUID]*replayBuffer),
 	}
 	return h
 }
 
+// SetAPIHandler JSONコマンドの実行に使用するAPIハンドラを設定します
+func (s *Streamer) SetAPIHandler(h http.Handler) {
+	s.apiMu.Lock()
+	defer s.apiMu.Unlock()
+	s.apiHandler = h
+}
+
+func (s *Streamer) api() http.Handler {
+	s.apiMu.RLock()
+	defer s.apiMu.RUnlock()
+	return s.apiHandler
+}
+
+// buffer 指定したBotユーザーの再送用バッファを返します
+func (s *Streamer) buffer(botUserID uuid.UUID) *replayBuffer {
+	s.buffersMu.Lock()
+	defer s.buffersMu.Unlock()
+	b, ok := s.buffers[botUserID]
+	if !ok {
+		b = &replayBuffer{epoch: s.epoch}
+		s.buffers[botUserID] = b
+	}
+	return b
+}
+
 func (s *Streamer) register(session *session) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -72,11 +110,19 @@ func filterSession(sessions []*session, target *session) []*session {
 }
 
 // WriteMessage 指定したセッションにメッセージを書き込みます
+//
+// 送信したメッセージは再接続時の再送のためにバッファに保持されます。
 func (s *Streamer) WriteMessage(t string, reqID uuid.UUID, body []byte, botUserID uuid.UUID) (errs []error, attempted bool) {
+	buf := s.buffer(botUserID)
+	buf.Lock()
+	defer buf.Unlock()
+
 	m := &rawMessage{
 		t:    websocket.TextMessage,
-		data: makeEventMessage(t, reqID, body).toJSON(),
+		data: makeEventMessage(t, reqID, buf.nextCursor(), body).toJSON(),
 	}
+	buf.push(m.data, time.Now())
+
 	s.mu.RLock()
 	for _, session := range s.sessions[botUserID] {
 		if err := session.WriteMessage(m); err != nil {
@@ -105,14 +151,40 @@ func (s *Streamer) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 	}
 	s.mu.RUnlock()
 
+	userID := r.Context().Value(ctxKey.UserID).(uuid.UUID)
+	botID, _ := r.Context().Value(ctxKey.BotID).(uuid.UUID)
+
+	// 再接続時の再送要求
+	c, replay, err := parseCursorQuery(r)
+	if err != nil {
+		http.Error(rw, "invalid cursor", http.StatusBadRequest)
+		return
+	}
+	if replay {
+		buf := s.buffer(userID)
+		buf.Lock()
+		_, err := buf.since(c, time.Now())
+		buf.Unlock()
+		if err != nil {
+			http.Error(rw, gapTooLargeMessage(c), http.StatusGone)
+			return
+		}
+	}
+
 	conn, err := upgrader.Upgrade(rw, r, rw.Header())
 	if err != nil {
 		return
 	}
 
-	session := newSession(r.Context().Value(ctxKey.UserID).(uuid.UUID), s, conn)
+	session := newSession(userID, botID, extractAuthHeader(r), s, conn)
 
-	s.register(session)
+	if err := s.registerAndReplay(session, c, replay); err != nil {
+		// 接続中に取りこぼしたイベントが破棄された
+		_ = conn.WriteMessage(websocket.TextMessage, makeErrorMessage(gapTooLargeMessage(c)).toJSON())
+		_ = conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.ClosePolicyViolation, ErrGapTooLarge.Error()))
+		_ = conn.Close()
+		return
+	}
 	s.hub.Publish(hub.Message{
 		Name: event.BotWSConnected,
 		Fields: hub.Fields{
@@ -135,6 +207,58 @@ func (s *Streamer) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 	s.unregister(session)
 }
 
+// registerAndReplay セッションを登録し、カーソルより後のイベントを再送します
+//
+// 再送中に新しいイベントが割り込まないよう、バッファをロックした状態で登録します。
+func (s *Streamer) registerAndReplay(session *session, c cursor, replay bool) error {
+	if !replay {
+		s.register(session)
+		return nil
+	}
+
+	buf := s.buffer(session.userID)
+	buf.Lock()
+	defer buf.Unlock()
+
+	events, err := buf.since(c, time.Now())
+	if err != nil {
+		return err
+	}
+	s.register(session)
+	for _, ev := range events {
+		_ = session.WriteMessage(&rawMessage{t: websocket.TextMessage, data: ev.data})
+	}
+	return nil
+}
+
+// parseCursorQuery リクエストのcursorクエリをパースします
+func parseCursorQuery(r *http.Request) (c cursor, ok bool, err error) {
+	v := r.URL.Query().Get("cursor")
+	if len(v) == 0 {
+		return cursor{}, false, nil
+	}
+	c, err = parseCursor(v)
+	if err != nil {
+		return cursor{}, false, err
+	}
+	return c, true, nil
+}
+
+// extractAuthHeader JSONコマンドの実行に引き継ぐ認証情報を取り出します
+func extractAuthHeader(r *http.Request) http.Header {
+	h := http.Header{}
+	for _, k := range []string{"Authorization", "Cookie"} {
+		if v := r.Header.Values(k); len(v) > 0 {
+			h[k] = v
+		}
+	}
+	return h
+}
+
+func gapTooLargeMessage(c cursor) string {
+	return fmt.Sprintf("gap too large: events after cursor %s are no longer available. reconnect without cursor", c)
+}
+
 // Close ストリーマーを停止します
 func (s *Streamer) Close() error {
 	s.mu.Lock()
@@ -0,0 +1,22 @@
+package email
+
+// Mail 送信するメール
+type Mail struct {
+	// To 宛先アドレス
+	To string
+	// Subject 件名
+	Subject string
+	// Body 本文(プレーンテキスト)
+	Body string
+	// UnsubscribeURL 配信停止URL (List-Unsubscribeヘッダに使用)
+	UnsubscribeURL string
+}
+
+// Client メール通知クライアント
+type Client interface {
+	// Send メールを送信します
+	Send(m *Mail) error
+	// Available メールを送信可能かどうかを返します
+	Available() bool
+	Close()
+}
@@ -0,0 +1,21 @@
+package email
+
+var nullC = &nullClient{}
+
+type nullClient struct{}
+
+// NewNullClient 何もしないメール通知クライアントを返します
+func NewNullClient() Client {
+	return nullC
+}
+
+func (n *nullClient) Send(*Mail) error {
+	return nil
+}
+
+func (n *nullClient) Available() bool {
+	return false
+}
+
+func (n *nullClient) Close() {
+}
@@ -0,0 +1,117 @@
+package email
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"go.uber.org/zap"
+)
+
+// SMTPConfig SMTPサーバー設定
+type SMTPConfig struct {
+	// Host SMTPサーバーのホスト名
+	Host string
+	// Port SMTPサーバーのポート番号
+	Port int
+	// Username 認証ユーザー名 (空の場合は認証しない)
+	Username string
+	// Password 認証パスワード
+	Password string
+	// From 送信元アドレス
+	From string
+}
+
+// Valid 有効な設定かどうか
+func (c SMTPConfig) Valid() bool {
+	return len(c.Host) > 0 && c.Port > 0 && len(c.From) > 0
+}
+
+type smtpClient struct {
+	config SMTPConfig
+	logger *zap.Logger
+	auth   smtp.Auth
+	closed bool
+	mu     sync.RWMutex
+	// sendMail テスト用に差し替え可能なsmtp.SendMail
+	sendMail func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+// NewSMTPClient SMTPサーバーを使用するメール通知クライアントを生成します
+func NewSMTPClient(config SMTPConfig, logger *zap.Logger) (Client, error) {
+	if !config.Valid() {
+		return nil, errors.New("invalid smtp config")
+	}
+	c := &smtpClient{
+		config:   config,
+		logger:   logger.Named("email"),
+		sendMail: smtp.SendMail,
+	}
+	if len(config.Username) > 0 {
+		c.auth = smtp.PlainAuth("", config.Username, config.Password, config.Host)
+	}
+	return c, nil
+}
+
+func (c *smtpClient) Send(m *Mail) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.closed {
+		return errors.New("email client has already been closed")
+	}
+
+	addr := net.JoinHostPort(c.config.Host, strconv.Itoa(c.config.Port))
+	if err := c.sendMail(addr, c.auth, c.config.From, []string{m.To}, c.build(m)); err != nil {
+		c.logger.Error("failed to send email", zap.Error(err), zap.String("to", m.To))
+		return err
+	}
+	return nil
+}
+
+func (c *smtpClient) Available() bool {
+	return true
+}
+
+func (c *smtpClient) Close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closed = true
+}
+
+// build RFC 5322形式のメッセージを組み立てます
+func (c *smtpClient) build(m *Mail) []byte {
+	var b bytes.Buffer
+	header := func(k, v string) {
+		fmt.Fprintf(&b, "%s: %s\r\n", k, v)
+	}
+	header("From", c.config.From)
+	header("To", m.To)
+	header("Subject", mime.BEncoding.Encode("UTF-8", m.Subject))
+	header("Date", time.Now().Format(time.RFC1123Z))
+	header("Message-ID", fmt.Sprintf("<%s@%s>", uuid.Must(uuid.NewV4()), c.config.Host))
+	header("MIME-Version", "1.0")
+	header("Content-Type", `text/plain; charset="UTF-8"`)
+	header("Content-Transfer-Encoding", "base64")
+	if len(m.UnsubscribeURL) > 0 {
+		header("List-Unsubscribe", "<"+m.UnsubscribeURL+">")
+		header("List-Unsubscribe-Post", "List-Unsubscribe=One-Click")
+	}
+	b.WriteString("\r\n")
+
+	// 76文字ごとに改行 (RFC 2045)
+	encoded := base64.StdEncoding.EncodeToString([]byte(m.Body))
+	for len(encoded) > 76 {
+		b.WriteString(encoded[:76] + "\r\n")
+		encoded = encoded[76:]
+	}
+	b.WriteString(encoded + "\r\n")
+	return b.Bytes()
+}
@@ -0,0 +1,66 @@
+package email
+
+import (
+	"encoding/base64"
+	"net/smtp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestNewSMTPClient(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewSMTPClient(SMTPConfig{}, zap.NewNop())
+	assert.Error(t, err)
+
+	c, err := NewSMTPClient(SMTPConfig{Host: "localhost", Port: 25, From: "traq@example.com"}, zap.NewNop())
+	require.NoError(t, err)
+	assert.True(t, c.Available())
+}
+
+func TestSMTPClient_Send(t *testing.T) {
+	t.Parallel()
+
+	var (
+		gotAddr string
+		gotTo   []string
+		gotMsg  string
+	)
+	c := &smtpClient{
+		config: SMTPConfig{Host: "smtp.example.com", Port: 587, From: "traq@example.com"},
+		logger: zap.NewNop(),
+		sendMail: func(addr string, _ smtp.Auth, _ string, to []string, msg []byte) error {
+			gotAddr, gotTo, gotMsg = addr, to, string(msg)
+			return nil
+		},
+	}
+
+	body := strings.Repeat("本文", 50)
+	require.NoError(t, c.Send(&Mail{
+		To:             "user@example.com",
+		Subject:        "件名",
+		Body:           body,
+		UnsubscribeURL: "https://example.com/unsubscribe",
+	}))
+	assert.Equal(t, "smtp.example.com:587", gotAddr)
+	assert.Equal(t, []string{"user@example.com"}, gotTo)
+
+	header, encoded, ok := strings.Cut(gotMsg, "\r\n\r\n")
+	require.True(t, ok)
+	assert.Contains(t, header, "Subject: =?UTF-8?b?")
+	assert.Contains(t, header, "List-Unsubscribe: <https://example.com/unsubscribe>")
+	assert.Contains(t, header, "List-Unsubscribe-Post: List-Unsubscribe=One-Click")
+	for _, line := range strings.Split(strings.TrimSpace(encoded), "\r\n") {
+		assert.LessOrEqual(t, len(line), 76)
+	}
+	decoded, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(strings.TrimSpace(encoded), "\r\n", ""))
+	require.NoError(t, err)
+	assert.Equal(t, body, string(decoded))
+
+	c.Close()
+	assert.Error(t, c.Send(&Mail{To: "user@example.com"}))
+}
@@ -0,0 +1,486 @@
+package export
+
+import (
+	"archive/zip"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/gofrs/uuid"
+	jsonIter "github.com/json-iterator/go"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/service/channel"
+	"github.com/traPtitech/traQ/service/file"
+	"github.com/traPtitech/traQ/utils/message"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+const pageSize = 500
+
+var (
+	// ErrNotPublicChannel 公開チャンネルではありません
+	ErrNotPublicChannel = errors.New("not a public channel")
+)
+
+// Query エクスポート対象の指定
+type Query struct {
+	// ChannelID エクスポートするチャンネルのID。子孫チャンネルも含まれます
+	ChannelID uuid.UUID
+	// Since この日時以降に投稿されたメッセージのみを対象にします
+	Since optional.Of[time.Time]
+	// Until この日時以前に投稿されたメッセージのみを対象にします
+	Until optional.Of[time.Time]
+	// ExcludeFiles 参照されているファイルの実体をアーカイブに含めないかどうか
+	ExcludeFiles bool
+}
+
+// Exporter チャンネルのデータをzipアーカイブに書き出します
+type Exporter struct {
+	repo repository.Repository
+	cm   channel.Manager
+	fm   file.Manager
+}
+
+// NewExporter Exporterを生成します
+func NewExporter(repo repository.Repository, cm channel.Manager, fm file.Manager) *Exporter {
+	return &Exporter{
+		repo: repo,
+		cm:   cm,
+		fm:   fm,
+	}
+}
+
+// Export qで指定したチャンネルのサブツリーのデータをzipアーカイブとしてwに書き出します
+//
+// アーカイブには以下のファイルが含まれます
+//
+//	manifest.json         エクスポート条件
+//	channels.jsonl        チャンネル
+//	messages.jsonl        メッセージ(スタンプを含む)
+//	pins.jsonl            ピン留め
+//	channel_events.jsonl  チャンネルイベント
+//	files.jsonl           メッセージから参照されているファイルのメタ情報
+//	files/{fileId}        メッセージから参照されているファイルの実体
+func (e *Exporter) Export(w io.Writer, q Query) error {
+	if !e.cm.IsPublicChannel(q.ChannelID) {
+		return ErrNotPublicChannel
+	}
+
+	ex := &exportContext{
+		Exporter:   e,
+		q:          q,
+		zw:         zip.NewWriter(w),
+		userNames:  map[uuid.UUID]string{},
+		groupNames: map[uuid.UUID]string{},
+		stampNames: map[uuid.UUID]string{},
+		fileIDs:    []uuid.UUID{},
+		fileSet:    map[uuid.UUID]struct{}{},
+	}
+	tree := e.cm.PublicChannelTree()
+	ex.channelIDs = append([]uuid.UUID{q.ChannelID}, tree.GetDescendantIDs(q.ChannelID)...)
+
+	steps := []func() error{
+		ex.writeManifest,
+		ex.writeChannels,
+		ex.writeMessages,
+		ex.writePins,
+		ex.writeChannelEvents,
+		ex.writeFiles,
+	}
+	for _, step := range steps {
+		if err := step(); err != nil {
+			return err
+		}
+	}
+	return ex.zw.Close()
+}
+
+type exportContext struct {
+	*Exporter
+	q          Query
+	zw         *zip.Writer
+	channelIDs []uuid.UUID
+
+	userNames  map[uuid.UUID]string
+	groupNames map[uuid.UUID]string
+	stampNames map[uuid.UUID]string
+	fileIDs    []uuid.UUID
+	fileSet    map[uuid.UUID]struct{}
+}
+
+// jsonlWriter 1行1オブジェクトのJSONを書き出すWriter
+type jsonlWriter struct {
+	enc *jsonIter.Encoder
+}
+
+func (ex *exportContext) createJSONL(name string) (*jsonlWriter, error) {
+	w, err := ex.zw.Create(name)
+	if err != nil {
+		return nil, err
+	}
+	return &jsonlWriter{enc: jsonIter.ConfigCompatibleWithStandardLibrary.NewEncoder(w)}, nil
+}
+
+func (w *jsonlWriter) write(v interface{}) error {
+	return w.enc.Encode(v)
+}
+
+func (ex *exportContext) writeManifest() error {
+	w, err := ex.createJSONL("manifest.json")
+	if err != nil {
+		return err
+	}
+	return w.write(struct {
+		ChannelID  uuid.UUID              `json:"channelId"`
+		Since      optional.Of[time.Time] `json:"since"`
+		Until      optional.Of[time.Time] `json:"until"`
+		ExportedAt time.Time              `json:"exportedAt"`
+	}{
+		ChannelID:  ex.q.ChannelID,
+		Since:      ex.q.Since,
+		Until:      ex.q.Until,
+		ExportedAt: time.Now(),
+	})
+}
+
+func (ex *exportContext) writeChannels() error {
+	w, err := ex.createJSONL("channels.jsonl")
+	if err != nil {
+		return err
+	}
+	tree := ex.cm.PublicChannelTree()
+	for _, id := range ex.channelIDs {
+		ch, err := ex.cm.GetChannel(id)
+		if err != nil {
+			return fmt.Errorf("failed to GetChannel: %w", err)
+		}
+		if err := w.write(struct {
+			ID        uuid.UUID  `json:"id"`
+			ParentID  *uuid.UUID `json:"parentId"`
+			Name      string     `json:"name"`
+			Path      string     `json:"path"`
+			Topic     string     `json:"topic"`
+			Archived  bool       `json:"archived"`
+			CreatedAt time.Time  `json:"createdAt"`
+		}{
+			ID:        ch.ID,
+			ParentID:  nilIfNil(ch.ParentID),
+			Name:      ch.Name,
+			Path:      tree.GetChannelPath(ch.ID),
+			Topic:     ch.Topic,
+			Archived:  ch.IsArchived(),
+			CreatedAt: ch.CreatedAt,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (ex *exportContext) writeMessages() error {
+	w, err := ex.createJSONL("messages.jsonl")
+	if err != nil {
+		return err
+	}
+
+	for _, channelID := range ex.channelIDs {
+		query := repository.MessagesQuery{
+			Channel:   channelID,
+			Since:     ex.q.Since,
+			Until:     ex.q.Until,
+			Inclusive: true,
+			Limit:     pageSize,
+			Asc:       true,
+		}
+		for {
+			messages, more, err := ex.repo.GetMessages(query)
+			if err != nil {
+				return fmt.Errorf("failed to GetMessages: %w", err)
+			}
+			for _, m := range messages {
+				if err := ex.writeMessage(w, m); err != nil {
+					return err
+				}
+			}
+			if !more || len(messages) == 0 {
+				break
+			}
+			query.Offset += len(messages)
+		}
+	}
+	return nil
+}
+
+type stampRecord struct {
+	StampID   uuid.UUID `json:"stampId"`
+	StampName string    `json:"stampName"`
+	UserID    uuid.UUID `json:"userId"`
+	UserName  string    `json:"userName"`
+	Count     int       `json:"count"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+func (ex *exportContext) writeMessage(w *jsonlWriter, m *model.Message) error {
+	for _, id := range message.Parse(m.Text).Attachments {
+		if _, ok := ex.fileSet[id]; !ok && id != uuid.Nil {
+			ex.fileSet[id] = struct{}{}
+			ex.fileIDs = append(ex.fileIDs, id)
+		}
+	}
+
+	stamps := make([]stampRecord, len(m.Stamps))
+	for i, s := range m.Stamps {
+		stamps[i] = stampRecord{
+			StampID:   s.StampID,
+			StampName: ex.stampName(s.StampID),
+			UserID:    s.UserID,
+			UserName:  ex.userName(s.UserID),
+			Count:     s.Count,
+			CreatedAt: s.CreatedAt,
+			UpdatedAt: s.UpdatedAt,
+		}
+	}
+
+	return w.write(struct {
+		ID        uuid.UUID              `json:"id"`
+		ChannelID uuid.UUID              `json:"channelId"`
+		UserID    uuid.UUID              `json:"userId"`
+		UserName  string                 `json:"userName"`
+		Content   string                 `json:"content"`
+		Text      string                 `json:"text"`
+		ThreadID  optional.Of[uuid.UUID] `json:"threadId"`
+		Stamps    []stampRecord          `json:"stamps"`
+		CreatedAt time.Time              `json:"createdAt"`
+		UpdatedAt time.Time              `json:"updatedAt"`
+	}{
+		ID:        m.ID,
+		ChannelID: m.ChannelID,
+		UserID:    m.UserID,
+		UserName:  ex.userName(m.UserID),
+		Content:   m.Text,
+		Text:      ex.resolveEmbedding(m.Text),
+		ThreadID:  m.ParentID,
+		Stamps:    stamps,
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+	})
+}
+
+func (ex *exportContext) writePins() error {
+	w, err := ex.createJSONL("pins.jsonl")
+	if err != nil {
+		return err
+	}
+	for _, channelID := range ex.channelIDs {
+		pins, err := ex.repo.GetPinnedMessageByChannelID(channelID)
+		if err != nil {
+			return fmt.Errorf("failed to GetPinnedMessageByChannelID: %w", err)
+		}
+		for _, p := range pins {
+			if !ex.inRange(p.Message.CreatedAt) {
+				continue
+			}
+			if err := w.write(struct {
+				MessageID uuid.UUID `json:"messageId"`
+				ChannelID uuid.UUID `json:"channelId"`
+				UserID    uuid.UUID `json:"userId"`
+				UserName  string    `json:"userName"`
+				PinnedAt  time.Time `json:"pinnedAt"`
+			}{
+				MessageID: p.MessageID,
+				ChannelID: channelID,
+				UserID:    p.UserID,
+				UserName:  ex.userName(p.UserID),
+				PinnedAt:  p.CreatedAt,
+			}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (ex *exportContext) writeChannelEvents() error {
+	w, err := ex.createJSONL("channel_events.jsonl")
+	if err != nil {
+		return err
+	}
+	for _, channelID := range ex.channelIDs {
+		query := repository.ChannelEventsQuery{
+			Channel:   channelID,
+			Since:     ex.q.Since,
+			Until:     ex.q.Until,
+			Inclusive: true,
+			Limit:     pageSize,
+			Asc:       true,
+		}
+		for {
+			events, more, err := ex.repo.GetChannelEvents(query)
+			if err != nil {
+				return fmt.Errorf("failed to GetChannelEvents: %w", err)
+			}
+			for _, ev := range events {
+				if err := w.write(struct {
+					ChannelID uuid.UUID                `json:"channelId"`
+					Type      model.ChannelEventType   `json:"type"`
+					Detail    model.ChannelEventDetail `json:"detail"`
+					DateTime  time.Time                `json:"dateTime"`
+				}{
+					ChannelID: channelID,
+					Type:      ev.EventType,
+					Detail:    ev.Detail,
+					DateTime:  ev.DateTime,
+				}); err != nil {
+					return err
+				}
+			}
+			if !more || len(events) == 0 {
+				break
+			}
+			query.Offset += len(events)
+		}
+	}
+	return nil
+}
+
+func (ex *exportContext) writeFiles() error {
+	w, err := ex.createJSONL("files.jsonl")
+	if err != nil {
+		return err
+	}
+	files := make([]model.File, 0, len(ex.fileIDs))
+	for _, id := range ex.fileIDs {
+		f, err := ex.fm.Get(id)
+		if err != nil {
+			if err == file.ErrNotFound {
+				continue
+			}
+			return fmt.Errorf("failed to get file %s: %w", id, err)
+		}
+		files = append(files, f)
+		if err := w.write(struct {
+			ID        uuid.UUID `json:"id"`
+			Name      string    `json:"name"`
+			Mime      string    `json:"mime"`
+			Size      int64     `json:"size"`
+			CreatedAt time.Time `json:"createdAt"`
+		}{
+			ID:        f.GetID(),
+			Name:      f.GetFileName(),
+			Mime:      f.GetMIMEType(),
+			Size:      f.GetFileSize(),
+			CreatedAt: f.GetCreatedAt(),
+		}); err != nil {
+			return err
+		}
+	}
+
+	if ex.q.ExcludeFiles {
+		return nil
+	}
+	for _, f := range files {
+		if err := ex.copyFile(f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (ex *exportContext) copyFile(f model.File) error {
+	r, err := f.Open()
+	if err != nil {
+		return fmt.Errorf("failed to open file %s: %w", f.GetID(), err)
+	}
+	defer r.Close()
+
+	w, err := ex.zw.CreateHeader(&zip.FileHeader{
+		Name:     "files/" + f.GetID().String(),
+		Method:   zip.Store,
+		Modified: f.GetCreatedAt(),
+	})
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(w, r)
+	return err
+}
+
+// resolveEmbedding メッセージ中の埋め込みを現在の名前に置き換えます
+func (ex *exportContext) resolveEmbedding(text string) string {
+	tree := ex.cm.PublicChannelTree()
+	return message.ReplaceEmbedding(text, func(info *message.EmbeddedInfo) string {
+		id := uuid.FromStringOrNil(info.ID)
+		switch info.Type {
+		case "user":
+			if name := ex.userName(id); len(name) > 0 {
+				return "@" + name
+			}
+		case "group":
+			if name := ex.groupName(id); len(name) > 0 {
+				return "@" + name
+			}
+		case "channel":
+			if path := tree.GetChannelPath(id); len(path) > 0 {
+				return "#" + path
+			}
+		}
+		return info.Raw
+	})
+}
+
+func (ex *exportContext) inRange(t time.Time) bool {
+	if ex.q.Since.Valid && t.Before(ex.q.Since.V) {
+		return false
+	}
+	if ex.q.Until.Valid && t.After(ex.q.Until.V) {
+		return false
+	}
+	return true
+}
+
+func (ex *exportContext) userName(id uuid.UUID) string {
+	if name, ok := ex.userNames[id]; ok {
+		return name
+	}
+	var name string
+	if u, err := ex.repo.GetUser(id, false); err == nil {
+		name = u.GetName()
+	}
+	ex.userNames[id] = name
+	return name
+}
+
+func (ex *exportContext) groupName(id uuid.UUID) string {
+	if name, ok := ex.groupNames[id]; ok {
+		return name
+	}
+	var name string
+	if g, err := ex.repo.GetUserGroup(id); err == nil {
+		name = g.Name
+	}
+	ex.groupNames[id] = name
+	return name
+}
+
+func (ex *exportContext) stampName(id uuid.UUID) string {
+	if name, ok := ex.stampNames[id]; ok {
+		return name
+	}
+	var name string
+	if s, err := ex.repo.GetStamp(id); err == nil {
+		name = s.Name
+	}
+	ex.stampNames[id] = name
+	return name
+}
+
+func nilIfNil(id uuid.UUID) *uuid.UUID {
+	if id == uuid.Nil {
+		return nil
+	}
+	return &id
+}
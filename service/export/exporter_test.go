@@ -0,0 +1,194 @@
+package export
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/repository/mock_repository"
+	"github.com/traPtitech/traQ/service/channel/mock_channel"
+	"github.com/traPtitech/traQ/testUtils"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+type Repo struct {
+	*mock_repository.MockChannelRepository
+	*mock_repository.MockMessageRepository
+	*mock_repository.MockPinRepository
+	*mock_repository.MockUserRepository
+	testUtils.EmptyTestRepository
+}
+
+type stampRepository struct {
+	repository.StampRepository
+	stamps map[uuid.UUID]*model.Stamp
+}
+
+func (r *stampRepository) GetStamp(id uuid.UUID) (*model.Stamp, error) {
+	s, ok := r.stamps[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return s, nil
+}
+
+func NewMockRepo(ctrl *gomock.Controller) *Repo {
+	r := &Repo{
+		MockChannelRepository: mock_repository.NewMockChannelRepository(ctrl),
+		MockMessageRepository: mock_repository.NewMockMessageRepository(ctrl),
+		MockPinRepository:     mock_repository.NewMockPinRepository(ctrl),
+		MockUserRepository:    mock_repository.NewMockUserRepository(ctrl),
+	}
+	r.StampRepository = &stampRepository{stamps: map[uuid.UUID]*model.Stamp{}}
+	return r
+}
+
+func readJSONL(t *testing.T, files map[string]*zip.File, name string) []map[string]interface{} {
+	t.Helper()
+	f, ok := files[name]
+	require.True(t, ok, name)
+	r, err := f.Open()
+	require.NoError(t, err)
+	defer r.Close()
+
+	res := make([]map[string]interface{}, 0)
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		var v map[string]interface{}
+		require.NoError(t, json.Unmarshal(sc.Bytes(), &v))
+		res = append(res, v)
+	}
+	require.NoError(t, sc.Err())
+	return res
+}
+
+func TestExporter_Export(t *testing.T) {
+	t.Parallel()
+
+	var (
+		rootID  = uuid.Must(uuid.NewV4())
+		childID = uuid.Must(uuid.NewV4())
+		userID  = uuid.Must(uuid.NewV4())
+		stampID = uuid.Must(uuid.NewV4())
+		m1ID    = uuid.Must(uuid.NewV4())
+		m2ID    = uuid.Must(uuid.NewV4())
+		now     = time.Now()
+	)
+
+	t.Run("not public channel", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		repo := NewMockRepo(ctrl)
+		cm := mock_channel.NewMockManager(ctrl)
+
+		cm.EXPECT().IsPublicChannel(rootID).Return(false)
+
+		err := NewExporter(repo, cm, nil).Export(io.Discard, Query{ChannelID: rootID})
+		assert.ErrorIs(t, err, ErrNotPublicChannel)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		repo := NewMockRepo(ctrl)
+		cm := mock_channel.NewMockManager(ctrl)
+		tree := mock_channel.NewMockTree(ctrl)
+
+		cm.EXPECT().IsPublicChannel(rootID).Return(true)
+		cm.EXPECT().PublicChannelTree().Return(tree).AnyTimes()
+		tree.EXPECT().GetDescendantIDs(rootID).Return([]uuid.UUID{childID})
+		tree.EXPECT().GetChannelPath(rootID).Return("a").AnyTimes()
+		tree.EXPECT().GetChannelPath(childID).Return("a/b").AnyTimes()
+		cm.EXPECT().GetChannel(rootID).Return(&model.Channel{ID: rootID, Name: "a", CreatedAt: now}, nil)
+		cm.EXPECT().GetChannel(childID).Return(&model.Channel{ID: childID, Name: "b", ParentID: rootID, CreatedAt: now}, nil)
+
+		user := &model.User{ID: userID, Name: "new_name"}
+		repo.MockUserRepository.EXPECT().GetUser(userID, false).Return(user, nil).AnyTimes()
+		repo.StampRepository.(*stampRepository).stamps[stampID] = &model.Stamp{ID: stampID, Name: "good"}
+
+		since := now.Add(-time.Hour)
+		repo.MockMessageRepository.EXPECT().
+			GetMessages(repository.MessagesQuery{Channel: rootID, Since: optional.From(since), Inclusive: true, Limit: pageSize, Asc: true}).
+			Return([]*model.Message{
+				{
+					ID:        m1ID,
+					UserID:    userID,
+					ChannelID: rootID,
+					Text:      `hello !{"type":"user","raw":"@old_name","id":"` + userID.String() + `"} !{"type":"channel","raw":"#x","id":"` + childID.String() + `"}`,
+					CreatedAt: now,
+					UpdatedAt: now,
+					Stamps:    []model.MessageStamp{{MessageID: m1ID, StampID: stampID, UserID: userID, Count: 2}},
+				},
+			}, false, nil)
+		repo.MockMessageRepository.EXPECT().
+			GetMessages(repository.MessagesQuery{Channel: childID, Since: optional.From(since), Inclusive: true, Limit: pageSize, Asc: true}).
+			Return([]*model.Message{
+				{ID: m2ID, UserID: userID, ChannelID: childID, Text: "child", CreatedAt: now, UpdatedAt: now},
+			}, false, nil)
+
+		repo.MockPinRepository.EXPECT().GetPinnedMessageByChannelID(rootID).Return([]*model.Pin{
+			{MessageID: m1ID, UserID: userID, CreatedAt: now, Message: model.Message{ID: m1ID, CreatedAt: now}},
+			{MessageID: uuid.Must(uuid.NewV4()), UserID: userID, CreatedAt: now, Message: model.Message{CreatedAt: now.Add(-2 * time.Hour)}},
+		}, nil)
+		repo.MockPinRepository.EXPECT().GetPinnedMessageByChannelID(childID).Return([]*model.Pin{}, nil)
+
+		repo.MockChannelRepository.EXPECT().
+			GetChannelEvents(repository.ChannelEventsQuery{Channel: rootID, Since: optional.From(since), Inclusive: true, Limit: pageSize, Asc: true}).
+			Return([]*model.ChannelEvent{{ChannelID: rootID, EventType: model.ChannelEventTopicChanged, Detail: model.ChannelEventDetail{"topic": "t"}, DateTime: now}}, false, nil)
+		repo.MockChannelRepository.EXPECT().
+			GetChannelEvents(repository.ChannelEventsQuery{Channel: childID, Since: optional.From(since), Inclusive: true, Limit: pageSize, Asc: true}).
+			Return([]*model.ChannelEvent{}, false, nil)
+
+		var buf bytes.Buffer
+		require.NoError(t, NewExporter(repo, cm, nil).Export(&buf, Query{ChannelID: rootID, Since: optional.From(since)}))
+
+		zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		require.NoError(t, err)
+		files := map[string]*zip.File{}
+		for _, f := range zr.File {
+			files[f.Name] = f
+		}
+
+		channels := readJSONL(t, files, "channels.jsonl")
+		if assert.Len(t, channels, 2) {
+			assert.Equal(t, "a/b", channels[1]["path"])
+			assert.Equal(t, rootID.String(), channels[1]["parentId"])
+			assert.Nil(t, channels[0]["parentId"])
+		}
+
+		messages := readJSONL(t, files, "messages.jsonl")
+		if assert.Len(t, messages, 2) {
+			assert.Equal(t, "hello @new_name #a/b", messages[0]["text"])
+			assert.Equal(t, "new_name", messages[0]["userName"])
+			stamps := messages[0]["stamps"].([]interface{})
+			if assert.Len(t, stamps, 1) {
+				assert.Equal(t, "good", stamps[0].(map[string]interface{})["stampName"])
+			}
+			assert.Equal(t, childID.String(), messages[1]["channelId"])
+		}
+
+		pins := readJSONL(t, files, "pins.jsonl")
+		if assert.Len(t, pins, 1) {
+			assert.Equal(t, m1ID.String(), pins[0]["messageId"])
+		}
+
+		events := readJSONL(t, files, "channel_events.jsonl")
+		if assert.Len(t, events, 1) {
+			assert.Equal(t, string(model.ChannelEventTopicChanged), events[0]["type"])
+		}
+
+		assert.Len(t, readJSONL(t, files, "files.jsonl"), 0)
+		assert.Len(t, readJSONL(t, files, "manifest.json"), 1)
+	})
+}
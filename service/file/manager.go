@@ -6,6 +6,7 @@ import (
 	"io"
 	"mime"
 	"path/filepath"
+	"time"
 
 	vd "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/go-ozzo/ozzo-validation/v4/is"
@@ -31,6 +32,8 @@ type SaveArgs struct {
 	ACL       ACL
 	Src       io.Reader
 	Thumbnail image.Image
+	// CreatedAt 作成日時 (未指定の場合は現在時刻)
+	CreatedAt optional.Of[time.Time]
 }
 
 // ACL アクセスコントロールリスト
@@ -22,6 +22,8 @@ type managerImpl struct {
 	fs   storage.FileStorage
 	ip   imaging.Processor
 	l    *zap.Logger
+	// variants サムネイル画像をPNGに加えて保存するフォーマット
+	variants []imaging.Format
 }
 
 func makeSureSeekable(r io.Reader) (io.ReadSeeker, error) {
@@ -39,16 +41,17 @@ func makeSureSeekable(r io.Reader) (io.ReadSeeker, error) {
 
 func InitFileManager(repo repository.FileRepository, fs storage.FileStorage, ip imaging.Processor, l *zap.Logger) (Manager, error) {
 	return &managerImpl{
-		repo: repo,
-		fs:   fs,
-		ip:   ip,
-		l:    l.Named("file_manager"),
+		repo:     repo,
+		fs:       fs,
+		ip:       ip,
+		l:        l.Named("file_manager"),
+		variants: ip.VariantFormats(),
 	}, nil
 }
 
 func (m *managerImpl) canGenerateThumbnail(mimeType string) bool {
 	switch mimeType {
-	case "image/jpeg", "image/png", "image/gif", "image/webp":
+	case "image/jpeg", "image/png", "image/gif", "image/webp", "image/avif":
 		return true
 	default:
 		return false
@@ -79,6 +82,9 @@ func (m *managerImpl) Save(args SaveArgs) (model.File, error) {
 		ChannelID:       args.ChannelID,
 		IsAnimatedImage: false,
 	}
+	if args.CreatedAt.Valid {
+		f.CreatedAt = args.CreatedAt.V
+	}
 
 	// アニメーション画像判定
 	switch args.MimeType {
@@ -187,6 +193,16 @@ func (m *managerImpl) Save(args SaveArgs) (model.File, error) {
 		if err := m.fs.SaveByKey(r, key, key+".png", "image/png", model.FileTypeThumbnail); err != nil {
 			return nil, fmt.Errorf("failed to save thumbnail to storage: %w", err)
 		}
+
+		// 追加フォーマットのサムネイル画像は失敗しても無視する
+		for _, format := range m.variants {
+			thumbnail, err := SaveThumbnailVariant(m.fs, m.ip, f.ID, args.Thumbnail, format)
+			if err != nil {
+				m.l.Warn("failed to generate thumbnail variant", zap.Error(err), zap.Stringer("format", format), zap.Stringer("fid", f.ID))
+				continue
+			}
+			f.Thumbnails = append(f.Thumbnails, thumbnail)
+		}
 	}
 
 	hash := md5.New()
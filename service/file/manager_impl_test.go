@@ -150,6 +150,79 @@ func TestManagerImpl_Save(t *testing.T) {
 		}
 	})
 
+	t.Run("file with thumbnail variants", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		repo := mock_repository.NewMockFileRepository(ctrl)
+		fs := mock_storage.NewMockFileStorage(ctrl)
+		ip := mock_imaging.NewMockProcessor(ctrl)
+		fm := initFM(t, repo, fs, ip)
+		fm.variants = []imaging.Format{imaging.FormatWebP, imaging.FormatAVIF}
+
+		data := []byte("test text file")
+		thumb := imaging2.GenerateIcon("test")
+		args := SaveArgs{
+			FileName:  "dummy.png",
+			FileSize:  int64(len(data)),
+			MimeType:  "image/png",
+			FileType:  model.FileTypeUserFile,
+			ChannelID: optional.From(uuid.NewV3(uuid.Nil, "c")),
+			Src:       bytes.NewReader(data),
+			Thumbnail: thumb,
+		}
+
+		fs.EXPECT().
+			SaveByKey(gomock.Any(), gomock.Any(), args.FileName, args.MimeType, args.FileType).
+			DoAndReturn(func(src io.Reader, key, name, contentType string, fileType model.FileType) error {
+				_, _ = io.Copy(io.Discard, src)
+				return nil
+			}).
+			Times(1)
+		fs.EXPECT().
+			SaveByKey(gomock.Any(), gomock.Any(), gomock.Any(), "image/png", model.FileTypeThumbnail).
+			DoAndReturn(func(src io.Reader, key, name, contentType string, fileType model.FileType) error {
+				_, err := png.Decode(src)
+				return err
+			}).
+			Times(1)
+		fs.EXPECT().
+			SaveByKey(gomock.Any(), gomock.Any(), gomock.Any(), "image/webp", model.FileTypeThumbnail).
+			DoAndReturn(func(src io.Reader, key, name, contentType string, fileType model.FileType) error {
+				assert.Equal(t, key+".webp", name)
+				assert.Contains(t, key, "-"+model.ThumbnailTypeImageWebP.Suffix())
+				return nil
+			}).
+			Times(1)
+		ip.EXPECT().
+			Encode(thumb, imaging.FormatWebP).
+			Return(bytes.NewReader([]byte("webp")), nil).
+			Times(1)
+		ip.EXPECT().
+			Encode(thumb, imaging.FormatAVIF).
+			Return(nil, errMock).
+			Times(1)
+		repo.EXPECT().
+			SaveFileMeta(gomock.Any(), []*model.FileACLEntry{{UserID: uuid.Nil, Allow: true}}).
+			DoAndReturn(func(meta *model.FileMeta, acl []*model.FileACLEntry) error {
+				meta.CreatedAt = time.Now()
+				return nil
+			}).
+			Times(1)
+
+		result, err := fm.Save(args)
+		if assert.NoError(t, err) {
+			// AVIFのエンコードに失敗しても保存は成功する
+			thumbs := result.GetThumbnails()
+			if assert.Len(t, thumbs, 2) {
+				assert.EqualValues(t, model.ThumbnailTypeImage, thumbs[0].Type)
+				assert.EqualValues(t, model.ThumbnailTypeImageWebP, thumbs[1].Type)
+				assert.EqualValues(t, "image/webp", thumbs[1].Mime)
+				assert.EqualValues(t, thumb.Bounds().Size().X, thumbs[1].Width)
+				assert.EqualValues(t, thumb.Bounds().Size().Y, thumbs[1].Height)
+			}
+		}
+	})
+
 	t.Run("image with generating thumbnail (io.ReadSeeker)", func(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
@@ -0,0 +1,52 @@
+package file
+
+import (
+	"fmt"
+	"image"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/service/imaging"
+	"github.com/traPtitech/traQ/utils/storage"
+)
+
+// ThumbnailVariantType サムネイル画像の追加フォーマットに対応するサムネイルタイプを返します
+func ThumbnailVariantType(format imaging.Format) (model.ThumbnailType, bool) {
+	switch format {
+	case imaging.FormatWebP:
+		return model.ThumbnailTypeImageWebP, true
+	case imaging.FormatAVIF:
+		return model.ThumbnailTypeImageAVIF, true
+	default:
+		return 0, false
+	}
+}
+
+// SaveThumbnailVariant サムネイル画像thumbをformatでエンコードし、fileIDのファイルのサムネイルとしてストレージに保存します
+//
+// 成功した場合、保存したサムネイルの情報を返します。DBへの保存は行いません。
+func SaveThumbnailVariant(fs storage.FileStorage, ip imaging.Processor, fileID uuid.UUID, thumb image.Image, format imaging.Format) (model.FileThumbnail, error) {
+	thumbnailType, ok := ThumbnailVariantType(format)
+	if !ok {
+		return model.FileThumbnail{}, fmt.Errorf("unsupported thumbnail variant format: %s", format)
+	}
+
+	b, err := ip.Encode(thumb, format)
+	if err != nil {
+		return model.FileThumbnail{}, fmt.Errorf("failed to encode thumbnail: %w", err)
+	}
+
+	key := fileID.String() + "-" + thumbnailType.Suffix()
+	if err := fs.SaveByKey(b, key, key+"."+format.String(), format.Mime(), model.FileTypeThumbnail); err != nil {
+		return model.FileThumbnail{}, fmt.Errorf("failed to save thumbnail to storage: %w", err)
+	}
+
+	return model.FileThumbnail{
+		FileID: fileID,
+		Type:   thumbnailType,
+		Mime:   format.Mime(),
+		Width:  thumb.Bounds().Size().X,
+		Height: thumb.Bounds().Size().Y,
+	}, nil
+}
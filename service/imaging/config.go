@@ -21,4 +21,7 @@ type Config struct {
 	ThumbnailMaxSize image.Point
 	// ImageMagickPath imagemagickの実行パス
 	ImageMagickPath string
+	// VariantFormats サムネイル画像をPNGに加えて保存するフォーマット (WebP, AVIF)
+	// エンコードにはimagemagickを使用するため、ImageMagickPathが空の場合は無視されます
+	VariantFormats []Format
 }
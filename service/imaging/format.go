@@ -0,0 +1,67 @@
+package imaging
+
+import (
+	"errors"
+	"strings"
+)
+
+// Format 画像フォーマット
+type Format int
+
+const (
+	// FormatPNG PNG
+	FormatPNG Format = iota + 1
+	// FormatGIF GIF
+	FormatGIF
+	// FormatWebP WebP
+	FormatWebP
+	// FormatAVIF AVIF
+	FormatAVIF
+)
+
+func (f Format) String() string {
+	switch f {
+	case FormatPNG:
+		return "png"
+	case FormatGIF:
+		return "gif"
+	case FormatWebP:
+		return "webp"
+	case FormatAVIF:
+		return "avif"
+	default:
+		return "null"
+	}
+}
+
+// Mime フォーマットのMIMEタイプ
+func (f Format) Mime() string {
+	switch f {
+	case FormatPNG:
+		return "image/png"
+	case FormatGIF:
+		return "image/gif"
+	case FormatWebP:
+		return "image/webp"
+	case FormatAVIF:
+		return "image/avif"
+	default:
+		return ""
+	}
+}
+
+// FormatFromString 文字列からフォーマットを返します
+func FormatFromString(s string) (Format, error) {
+	switch strings.ToLower(s) {
+	case "png":
+		return FormatPNG, nil
+	case "gif":
+		return FormatGIF, nil
+	case "webp":
+		return FormatWebP, nil
+	case "avif":
+		return FormatAVIF, nil
+	default:
+		return 0, errors.New("unknown Format")
+	}
+}
@@ -11,6 +11,7 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	imaging "github.com/traPtitech/traQ/service/imaging"
 )
 
 // MockProcessor is a mock of Processor interface.
@@ -36,6 +37,21 @@ func (m *MockProcessor) EXPECT() *MockProcessorMockRecorder {
 	return m.recorder
 }
 
+// Encode mocks base method.
+func (m *MockProcessor) Encode(img image.Image, format imaging.Format) (*bytes.Reader, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Encode", img, format)
+	ret0, _ := ret[0].(*bytes.Reader)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Encode indicates an expected call of Encode.
+func (mr *MockProcessorMockRecorder) Encode(img, format interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Encode", reflect.TypeOf((*MockProcessor)(nil).Encode), img, format)
+}
+
 // Fit mocks base method.
 func (m *MockProcessor) Fit(src io.ReadSeeker, width, height int) (image.Image, error) {
 	m.ctrl.T.Helper()
@@ -51,19 +67,19 @@ func (mr *MockProcessorMockRecorder) Fit(src, width, height interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fit", reflect.TypeOf((*MockProcessor)(nil).Fit), src, width, height)
 }
 
-// FitAnimationGIF mocks base method.
-func (m *MockProcessor) FitAnimationGIF(src io.Reader, width, height int) (*bytes.Reader, error) {
+// FitAnimation mocks base method.
+func (m *MockProcessor) FitAnimation(src io.Reader, width, height int, format imaging.Format) (*bytes.Reader, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FitAnimationGIF", src, width, height)
+	ret := m.ctrl.Call(m, "FitAnimation", src, width, height, format)
 	ret0, _ := ret[0].(*bytes.Reader)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// FitAnimationGIF indicates an expected call of FitAnimationGIF.
-func (mr *MockProcessorMockRecorder) FitAnimationGIF(src, width, height interface{}) *gomock.Call {
+// FitAnimation indicates an expected call of FitAnimation.
+func (mr *MockProcessorMockRecorder) FitAnimation(src, width, height, format interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FitAnimationGIF", reflect.TypeOf((*MockProcessor)(nil).FitAnimationGIF), src, width, height)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FitAnimation", reflect.TypeOf((*MockProcessor)(nil).FitAnimation), src, width, height, format)
 }
 
 // Thumbnail mocks base method.
@@ -81,6 +97,20 @@ func (mr *MockProcessorMockRecorder) Thumbnail(src interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Thumbnail", reflect.TypeOf((*MockProcessor)(nil).Thumbnail), src)
 }
 
+// VariantFormats mocks base method.
+func (m *MockProcessor) VariantFormats() []imaging.Format {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VariantFormats")
+	ret0, _ := ret[0].([]imaging.Format)
+	return ret0
+}
+
+// VariantFormats indicates an expected call of VariantFormats.
+func (mr *MockProcessorMockRecorder) VariantFormats() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VariantFormats", reflect.TypeOf((*MockProcessor)(nil).VariantFormats))
+}
+
 // WaveformMp3 mocks base method.
 func (m *MockProcessor) WaveformMp3(src io.ReadSeeker, width, height int) (io.Reader, error) {
 	m.ctrl.T.Helper()
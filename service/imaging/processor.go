@@ -10,7 +10,9 @@ import (
 type Processor interface {
 	Thumbnail(src io.ReadSeeker) (image.Image, error)
 	Fit(src io.ReadSeeker, width, height int) (image.Image, error)
-	FitAnimationGIF(src io.Reader, width, height int) (*bytes.Reader, error)
+	FitAnimation(src io.Reader, width, height int, format Format) (*bytes.Reader, error)
+	Encode(img image.Image, format Format) (*bytes.Reader, error)
+	VariantFormats() []Format
 	WaveformMp3(src io.ReadSeeker, width, height int) (io.Reader, error)
 	WaveformWav(src io.ReadSeeker, width, height int) (io.Reader, error)
 }
@@ -5,8 +5,9 @@ import (
 	"context"
 	"fmt"
 	"image"
+	"image/gif"
 	_ "image/jpeg" // image.Decode用
-	_ "image/png"  // image.Decode用
+	"image/png"
 	"io"
 	"time"
 
@@ -41,6 +42,44 @@ func (p *defaultProcessor) Fit(src io.ReadSeeker, width, height int) (image.Imag
 	_ = p.sp.Acquire(context.Background(), 1)
 	defer p.sp.Release(1)
 
+	orig, err := p.decode(src)
+	if err != nil {
+		return nil, err
+	}
+
+	if size := orig.Bounds().Size(); size.X > width || size.Y > height {
+		// mks2013: フロントで使用している https://github.com/nodeca/pica のデフォルト
+		return imaging.Fit(orig, width, height, mks2013Filter), nil
+	}
+	return orig, nil
+}
+
+// decode srcの画像を読み込みます
+//
+// Goで読み込めない画像(AVIFやアニメーションWebPなど)は、imagemagickが使用できる場合はPNGに変換してから読み込みます
+func (p *defaultProcessor) decode(src io.ReadSeeker) (image.Image, error) {
+	img, err := p.decodeStd(src)
+	if err != ErrInvalidImageSrc || len(p.c.ImageMagickPath) == 0 {
+		return img, err
+	}
+
+	// 先頭に戻す
+	if _, err := src.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second) // 10秒以内に終わらないファイルは無効
+	defer cancel()
+
+	b, err := imaging2.Convert(ctx, p.c.ImageMagickPath, src, imaging2.FormatPNG)
+	if err != nil {
+		return nil, convertError(err)
+	}
+	return p.decodeStd(b)
+}
+
+// decodeStd srcの画像をGoのデコーダーで読み込みます
+func (p *defaultProcessor) decodeStd(src io.ReadSeeker) (image.Image, error) {
 	imgCfg, _, err := image.DecodeConfig(src)
 	if err != nil {
 		if err == image.ErrFormat {
@@ -55,39 +94,105 @@ func (p *defaultProcessor) Fit(src io.ReadSeeker, width, height int) (image.Imag
 	}
 
 	// 先頭に戻す
-	if _, err := src.Seek(0, 0); err != nil {
+	if _, err := src.Seek(0, io.SeekStart); err != nil {
 		return nil, err
 	}
 
 	// 変換
-	orig, err := imaging.Decode(src, imaging.AutoOrientation(true))
+	img, err := imaging.Decode(src, imaging.AutoOrientation(true))
 	if err != nil {
 		return nil, ErrInvalidImageSrc
 	}
-
-	if imgCfg.Width > width || imgCfg.Height > height {
-		// mks2013: フロントで使用している https://github.com/nodeca/pica のデフォルト
-		return imaging.Fit(orig, width, height, mks2013Filter), nil
-	}
-	return orig, nil
+	return img, nil
 }
 
-func (p *defaultProcessor) FitAnimationGIF(src io.Reader, width, height int) (*bytes.Reader, error) {
+func (p *defaultProcessor) FitAnimation(src io.Reader, width, height int, format Format) (*bytes.Reader, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second) // 10秒以内に終わらないファイルは無効
 	defer cancel()
 
-	b, err := imaging2.ResizeAnimationGIF(ctx, p.c.ImageMagickPath, src, width, height, false)
+	var f imaging2.Format
+	switch format {
+	case FormatGIF:
+		f = imaging2.FormatGIF
+	case FormatWebP:
+		f = imaging2.FormatWebP
+	default:
+		return nil, fmt.Errorf("unsupported animation format: %s", format)
+	}
+
+	b, err := imaging2.ResizeAnimation(ctx, p.c.ImageMagickPath, src, f, width, height, false)
 	if err != nil {
-		switch err {
-		case context.DeadlineExceeded:
-			return nil, ErrTimeout
-		case imaging2.ErrInvalidImageSrc:
-			return nil, ErrInvalidImageSrc
-		default:
+		return nil, convertError(err)
+	}
+	return b, nil
+}
+
+func (p *defaultProcessor) Encode(img image.Image, format Format) (*bytes.Reader, error) {
+	var b bytes.Buffer
+	switch format {
+	case FormatPNG:
+		if err := png.Encode(&b, img); err != nil {
+			return nil, err
+		}
+		return bytes.NewReader(b.Bytes()), nil
+	case FormatGIF:
+		if err := gif.Encode(&b, img, nil); err != nil {
 			return nil, err
 		}
+		return bytes.NewReader(b.Bytes()), nil
+	case FormatWebP, FormatAVIF:
+		// Goのエンコーダーが無いため、PNGを経由してimagemagickで変換
+		if len(p.c.ImageMagickPath) == 0 {
+			return nil, imaging2.ErrImageMagickUnavailable
+		}
+		if err := png.Encode(&b, img); err != nil {
+			return nil, err
+		}
+
+		_ = p.sp.Acquire(context.Background(), 1)
+		defer p.sp.Release(1)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second) // 10秒以内に終わらないファイルは無効
+		defer cancel()
+
+		f := imaging2.FormatWebP
+		if format == FormatAVIF {
+			f = imaging2.FormatAVIF
+		}
+		r, err := imaging2.Convert(ctx, p.c.ImageMagickPath, &b, f)
+		if err != nil {
+			return nil, convertError(err)
+		}
+		return r, nil
+	default:
+		return nil, fmt.Errorf("unsupported format: %s", format)
+	}
+}
+
+func (p *defaultProcessor) VariantFormats() []Format {
+	if len(p.c.ImageMagickPath) == 0 {
+		return nil
+	}
+	formats := make([]Format, 0, len(p.c.VariantFormats))
+	for _, f := range p.c.VariantFormats {
+		switch f {
+		case FormatWebP, FormatAVIF:
+			formats = append(formats, f)
+		}
+	}
+	return formats
+}
+
+// convertError imagemagickでの変換時のエラーを変換します
+func convertError(err error) error {
+	switch err {
+	case context.DeadlineExceeded:
+		return ErrTimeout
+	case imaging2.ErrInvalidImageSrc:
+		return ErrInvalidImageSrc
+	default:
+		return err
 	}
-	return b, nil
 }
 
 func (p *defaultProcessor) WaveformMp3(src io.ReadSeeker, width, height int) (r io.Reader, err error) {
@@ -9,6 +9,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	imaging2 "github.com/traPtitech/traQ/utils/imaging"
 )
 
 const testDataFolder = "../../testData/images/"
@@ -67,3 +69,46 @@ func TestProcessorDefault_Fit(t *testing.T) {
 	assert.Nil(t, err)
 	assertImg(t, actualImg, "test_fit.png")
 }
+
+func TestProcessorDefault_Encode(t *testing.T) {
+	t.Parallel()
+
+	processor, fp := setup()
+	defer fp.Close()
+	img, err := png.Decode(fp)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	t.Run("png", func(t *testing.T) {
+		t.Parallel()
+		b, err := processor.Encode(img, FormatPNG)
+		if assert.NoError(t, err) {
+			_, err := png.Decode(b)
+			assert.NoError(t, err)
+		}
+	})
+
+	t.Run("webp without imagemagick", func(t *testing.T) {
+		t.Parallel()
+		_, err := processor.Encode(img, FormatWebP)
+		assert.ErrorIs(t, err, imaging2.ErrImageMagickUnavailable)
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		t.Parallel()
+		_, err := processor.Encode(img, Format(0))
+		assert.Error(t, err)
+	})
+}
+
+func TestProcessorDefault_VariantFormats(t *testing.T) {
+	t.Parallel()
+
+	formats := []Format{FormatWebP, FormatPNG, FormatAVIF}
+	assert.Empty(t, NewProcessor(Config{Concurrency: 1, VariantFormats: formats}).VariantFormats())
+	assert.Equal(t,
+		[]Format{FormatWebP, FormatAVIF},
+		NewProcessor(Config{Concurrency: 1, ImageMagickPath: "convert", VariantFormats: formats}).VariantFormats(),
+	)
+}
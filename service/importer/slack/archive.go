@@ -0,0 +1,132 @@
+package slack
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Slackエクスポートアーカイブの構造
+//
+//	users.json
+//	channels.json
+//	{channel name}/{yyyy-mm-dd}.json
+
+type slackUser struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	IsBot   bool   `json:"is_bot"`
+	Deleted bool   `json:"deleted"`
+}
+
+type slackChannel struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	Creator    string `json:"creator"`
+	IsArchived bool   `json:"is_archived"`
+}
+
+type slackMessage struct {
+	Type      string          `json:"type"`
+	Subtype   string          `json:"subtype"`
+	User      string          `json:"user"`
+	Text      string          `json:"text"`
+	TS        string          `json:"ts"`
+	ThreadTS  string          `json:"thread_ts"`
+	Reactions []slackReaction `json:"reactions"`
+	Files     []slackFile     `json:"files"`
+}
+
+type slackReaction struct {
+	Name  string   `json:"name"`
+	Users []string `json:"users"`
+}
+
+type slackFile struct {
+	ID                 string `json:"id"`
+	Name               string `json:"name"`
+	Mimetype           string `json:"mimetype"`
+	Mode               string `json:"mode"`
+	URLPrivateDownload string `json:"url_private_download"`
+}
+
+// importableSubtypes 取り込み対象のメッセージのsubtype
+var importableSubtypes = map[string]bool{
+	"":                 true,
+	"bot_message":      true,
+	"file_share":       true,
+	"me_message":       true,
+	"thread_broadcast": true,
+}
+
+type archive struct {
+	users    []slackUser
+	channels []slackChannel
+	// messageFiles チャンネル名をキーとした日毎のメッセージファイル(日付順)
+	messageFiles map[string][]*zip.File
+}
+
+func readArchive(zr *zip.Reader) (*archive, error) {
+	a := &archive{messageFiles: map[string][]*zip.File{}}
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		dir, name := path.Split(f.Name)
+		switch {
+		case dir == "" && name == "users.json":
+			if err := readJSON(f, &a.users); err != nil {
+				return nil, err
+			}
+		case dir == "" && name == "channels.json":
+			if err := readJSON(f, &a.channels); err != nil {
+				return nil, err
+			}
+		case dir != "" && path.Ext(name) == ".json":
+			ch := strings.TrimSuffix(dir, "/")
+			a.messageFiles[ch] = append(a.messageFiles[ch], f)
+		}
+	}
+	if a.channels == nil {
+		return nil, fmt.Errorf("channels.json was not found in the archive")
+	}
+	for _, files := range a.messageFiles {
+		sort.Slice(files, func(i, j int) bool { return files[i].Name < files[j].Name })
+	}
+	return a, nil
+}
+
+func readJSON(f *zip.File, v interface{}) error {
+	r, err := f.Open()
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", f.Name, err)
+	}
+	defer r.Close()
+	if err := json.NewDecoder(r).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", f.Name, err)
+	}
+	return nil
+}
+
+// parseTS Slackのタイムスタンプ("1428592523.000002")を時刻に変換します
+func parseTS(ts string) (time.Time, error) {
+	sec, frac, _ := strings.Cut(ts, ".")
+	s, err := strconv.ParseInt(sec, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid ts %q: %w", ts, err)
+	}
+	var us int64
+	if len(frac) > 0 {
+		frac = (frac + "000000")[:6]
+		us, err = strconv.ParseInt(frac, 10, 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid ts %q: %w", ts, err)
+		}
+	}
+	return time.Unix(s, us*int64(time.Microsecond)), nil
+}
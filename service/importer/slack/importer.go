@@ -0,0 +1,406 @@
+package slack
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"go.uber.org/zap"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/service/channel"
+	"github.com/traPtitech/traQ/service/file"
+	"github.com/traPtitech/traQ/utils/optional"
+	"github.com/traPtitech/traQ/utils/validator"
+)
+
+// idNamespace 取り込んだメッセージのIDを生成するためのUUIDv5名前空間
+//
+// 同じSlackメッセージからは常に同じIDが生成されるため、再実行時に重複して取り込まれることはありません。
+var idNamespace = uuid.Must(uuid.FromString("297fc0f3-1f86-4a4a-99f5-33cd72984da4"))
+
+// Config インポート設定
+type Config struct {
+	// ProviderName Slackアカウントを紐づけている外部認証プロバイダ名
+	ProviderName string
+	// ParentChannelID チャンネルを作成する親チャンネルのID (uuid.Nilの場合はルート)
+	ParentChannelID uuid.UUID
+	// FallbackUserID 紐づくtraQユーザーが存在しないSlackユーザー・ボットのメッセージの投稿者 (未指定の場合は取り込まない)
+	FallbackUserID optional.Of[uuid.UUID]
+	// Token 添付ファイルのダウンロードに使用するSlackのトークン (空の場合は添付ファイルを取り込まない)
+	Token string
+	// Origin traQサーバーのオリジン (添付ファイルURLの生成に使用)
+	Origin string
+	// DryRun trueの場合、書き込みを行わずにレポートのみを作成する
+	DryRun bool
+}
+
+// Importer Slackエクスポートアーカイブのインポーター
+type Importer struct {
+	repo   repository.Repository
+	cm     channel.Manager
+	fm     file.Manager
+	l      *zap.Logger
+	client *http.Client
+}
+
+// NewImporter Importerを生成します
+func NewImporter(repo repository.Repository, cm channel.Manager, fm file.Manager, logger *zap.Logger) *Importer {
+	return &Importer{
+		repo:   repo,
+		cm:     cm,
+		fm:     fm,
+		l:      logger.Named("slack_importer"),
+		client: &http.Client{Timeout: 5 * time.Minute},
+	}
+}
+
+type mappedUser struct {
+	id   uuid.UUID
+	name string
+}
+
+type mappedChannel struct {
+	id   uuid.UUID
+	path string
+}
+
+type importContext struct {
+	*Importer
+	c      Config
+	report *Report
+
+	slackUsers map[string]slackUser
+	users      map[string]mappedUser
+	channels   map[string]mappedChannel
+	stamps     map[string]optional.Of[uuid.UUID]
+	// known 取り込み済み(または取り込み予定)のメッセージID
+	known map[uuid.UUID]bool
+}
+
+// Import Slackエクスポートアーカイブを取り込みます
+func (im *Importer) Import(zr *zip.Reader, c Config) (*Report, error) {
+	a, err := readArchive(zr)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := &importContext{
+		Importer:   im,
+		c:          c,
+		report:     &Report{DryRun: c.DryRun},
+		slackUsers: map[string]slackUser{},
+		users:      map[string]mappedUser{},
+		channels:   map[string]mappedChannel{},
+		stamps:     map[string]optional.Of[uuid.UUID]{},
+		known:      map[uuid.UUID]bool{},
+	}
+	if err := ctx.mapUsers(a.users); err != nil {
+		return nil, err
+	}
+	// メッセージ中のチャンネルリンクを解決するため、先に全てのチャンネルを作成する
+	for _, ch := range a.channels {
+		if err := ctx.mapChannel(ch); err != nil {
+			return nil, err
+		}
+	}
+	for _, ch := range a.channels {
+		mc, ok := ctx.channels[ch.ID]
+		if !ok {
+			continue
+		}
+		for _, f := range a.messageFiles[ch.Name] {
+			if err := ctx.importMessages(ch, mc.id, f); err != nil {
+				return nil, err
+			}
+		}
+		if ch.IsArchived && !c.DryRun && !im.cm.PublicChannelTree().IsArchivedChannel(mc.id) {
+			if err := im.cm.ArchiveChannel(mc.id, ctx.channelCreator(ch)); err != nil {
+				return nil, fmt.Errorf("failed to archive channel %s: %w", mc.path, err)
+			}
+		}
+	}
+	return ctx.report, nil
+}
+
+func (ctx *importContext) mapUsers(users []slackUser) error {
+	for _, su := range users {
+		ctx.slackUsers[su.ID] = su
+		u, err := ctx.repo.GetUserByExternalID(ctx.c.ProviderName, su.ID, false)
+		if err != nil {
+			if err == repository.ErrNotFound {
+				ctx.report.UnmappedUsers = append(ctx.report.UnmappedUsers, su.Name)
+				continue
+			}
+			return fmt.Errorf("failed to GetUserByExternalID: %w", err)
+		}
+		ctx.users[su.ID] = mappedUser{id: u.GetID(), name: u.GetName()}
+		ctx.report.MappedUsers++
+	}
+	return nil
+}
+
+func (ctx *importContext) mapChannel(ch slackChannel) error {
+	tree := ctx.cm.PublicChannelTree()
+	parent := ctx.c.ParentChannelID
+	if !validator.ChannelRegex.MatchString(ch.Name) {
+		ctx.report.InvalidChannels = append(ctx.report.InvalidChannels, ch.Name)
+		return nil
+	}
+
+	// 同名のチャンネルが既に存在する場合はそのチャンネルに取り込む
+	for _, id := range tree.GetChildrenIDs(parent) {
+		m, err := tree.GetModel(id)
+		if err != nil {
+			return err
+		}
+		if strings.EqualFold(m.Name, ch.Name) {
+			ctx.channels[ch.ID] = mappedChannel{id: id, path: tree.GetChannelPath(id)}
+			ctx.report.ExistingChannels = append(ctx.report.ExistingChannels, ch.Name)
+			return nil
+		}
+	}
+
+	path := ch.Name
+	if parent != uuid.Nil {
+		path = tree.GetChannelPath(parent) + "/" + ch.Name
+	}
+	ctx.report.CreatedChannels = append(ctx.report.CreatedChannels, ch.Name)
+	if ctx.c.DryRun {
+		ctx.channels[ch.ID] = mappedChannel{id: uuid.NewV5(idNamespace, ch.ID), path: path}
+		return nil
+	}
+	created, err := ctx.cm.CreatePublicChannel(ch.Name, parent, ctx.channelCreator(ch))
+	if err != nil {
+		return fmt.Errorf("failed to create channel %s: %w", path, err)
+	}
+	ctx.channels[ch.ID] = mappedChannel{id: created.ID, path: path}
+	return nil
+}
+
+func (ctx *importContext) channelCreator(ch slackChannel) uuid.UUID {
+	if u, ok := ctx.users[ch.Creator]; ok {
+		return u.id
+	}
+	if ctx.c.FallbackUserID.Valid {
+		return ctx.c.FallbackUserID.V
+	}
+	return uuid.Nil
+}
+
+func (ctx *importContext) importMessages(ch slackChannel, channelID uuid.UUID, f *zip.File) error {
+	var messages []slackMessage
+	if err := readJSON(f, &messages); err != nil {
+		return err
+	}
+	for _, sm := range messages {
+		if sm.Type != "message" || !importableSubtypes[sm.Subtype] {
+			continue
+		}
+		if err := ctx.importMessage(ch, channelID, sm); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (ctx *importContext) importMessage(ch slackChannel, channelID uuid.UUID, sm slackMessage) error {
+	createdAt, err := parseTS(sm.TS)
+	if err != nil {
+		return err
+	}
+	id := uuid.NewV5(idNamespace, ch.ID+"/"+sm.TS)
+
+	userID, ok := ctx.messageUser(sm)
+	if !ok {
+		ctx.report.SkippedMessages++
+		return nil
+	}
+
+	if _, err := ctx.repo.GetMessageByID(id); err == nil {
+		ctx.known[id] = true
+		ctx.report.ExistingMessages++
+		return nil
+	} else if err != repository.ErrNotFound {
+		return fmt.Errorf("failed to GetMessageByID: %w", err)
+	}
+
+	m := &model.Message{
+		ID:        id,
+		UserID:    userID,
+		ChannelID: channelID,
+		Text:      convertText(sm.Text, ctx),
+		CreatedAt: createdAt,
+		UpdatedAt: createdAt,
+		Stamps:    ctx.convertReactions(id, sm.Reactions, createdAt),
+	}
+	if len(sm.ThreadTS) > 0 && sm.ThreadTS != sm.TS {
+		if parentID := uuid.NewV5(idNamespace, ch.ID+"/"+sm.ThreadTS); ctx.known[parentID] {
+			m.ParentID = optional.From(parentID)
+		}
+	}
+	for _, sf := range sm.Files {
+		fileID, err := ctx.importFile(sf, userID, channelID, createdAt)
+		if err != nil {
+			return err
+		}
+		if fileID == uuid.Nil {
+			ctx.report.SkippedFiles++
+			continue
+		}
+		m.Text += "\n" + ctx.c.Origin + "/files/" + fileID.String()
+		ctx.report.ImportedFiles++
+	}
+
+	ctx.known[id] = true
+	ctx.report.ImportedMessages++
+	ctx.report.ImportedStamps += len(m.Stamps)
+	if ctx.c.DryRun {
+		return nil
+	}
+	if err := ctx.repo.ImportMessage(m); err != nil {
+		if err == repository.ErrAlreadyExists {
+			// 削除済みのメッセージ
+			ctx.report.ImportedMessages--
+			ctx.report.ImportedStamps -= len(m.Stamps)
+			ctx.report.ExistingMessages++
+			return nil
+		}
+		return fmt.Errorf("failed to ImportMessage: %w", err)
+	}
+	return nil
+}
+
+func (ctx *importContext) messageUser(sm slackMessage) (uuid.UUID, bool) {
+	if sm.Subtype != "bot_message" {
+		if u, ok := ctx.users[sm.User]; ok {
+			return u.id, true
+		}
+	}
+	if ctx.c.FallbackUserID.Valid {
+		return ctx.c.FallbackUserID.V, true
+	}
+	return uuid.Nil, false
+}
+
+func (ctx *importContext) convertReactions(messageID uuid.UUID, reactions []slackReaction, at time.Time) []model.MessageStamp {
+	stamps := make([]model.MessageStamp, 0)
+	added := map[[2]uuid.UUID]bool{}
+	for _, r := range reactions {
+		// スキントーン修飾子は無視する
+		name, _, _ := strings.Cut(r.Name, "::")
+		stampID, ok := ctx.stampID(name)
+		if !ok {
+			continue
+		}
+		for _, su := range r.Users {
+			u, ok := ctx.users[su]
+			if !ok || added[[2]uuid.UUID{stampID, u.id}] {
+				continue
+			}
+			added[[2]uuid.UUID{stampID, u.id}] = true
+			stamps = append(stamps, model.MessageStamp{
+				MessageID: messageID,
+				StampID:   stampID,
+				UserID:    u.id,
+				Count:     1,
+				CreatedAt: at,
+				UpdatedAt: at,
+			})
+		}
+	}
+	return stamps
+}
+
+func (ctx *importContext) stampID(name string) (uuid.UUID, bool) {
+	if s, ok := ctx.stamps[name]; ok {
+		return s.V, s.Valid
+	}
+	s, err := ctx.repo.GetStampByName(name)
+	if err != nil {
+		if err != repository.ErrNotFound {
+			ctx.l.Warn("failed to GetStampByName", zap.Error(err), zap.String("name", name))
+		}
+		ctx.stamps[name] = optional.Of[uuid.UUID]{}
+		ctx.report.UnknownStamps = append(ctx.report.UnknownStamps, name)
+		return uuid.Nil, false
+	}
+	ctx.stamps[name] = optional.From(s.ID)
+	return s.ID, true
+}
+
+// importFile 添付ファイルを取り込みます。取り込まなかった場合はuuid.Nilを返します。
+func (ctx *importContext) importFile(sf slackFile, userID, channelID uuid.UUID, at time.Time) (uuid.UUID, error) {
+	if len(ctx.c.Token) == 0 || len(sf.URLPrivateDownload) == 0 || sf.Mode == "tombstone" || sf.Mode == "hidden_by_limit" {
+		return uuid.Nil, nil
+	}
+	if ctx.c.DryRun {
+		// ダウンロードせずに取り込み予定として扱う
+		return uuid.NewV5(idNamespace, sf.ID), nil
+	}
+
+	b, err := ctx.download(sf.URLPrivateDownload)
+	if err != nil {
+		ctx.l.Warn("failed to download slack file", zap.Error(err), zap.String("fileId", sf.ID))
+		return uuid.Nil, nil
+	}
+	f, err := ctx.fm.Save(file.SaveArgs{
+		FileName:  sf.Name,
+		FileSize:  int64(len(b)),
+		MimeType:  sf.Mimetype,
+		FileType:  model.FileTypeUserFile,
+		CreatorID: optional.From(userID),
+		ChannelID: optional.From(channelID),
+		Src:       bytes.NewReader(b),
+		CreatedAt: optional.From(at),
+	})
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to save file %s: %w", sf.ID, err)
+	}
+	return f.GetID(), nil
+}
+
+func (ctx *importContext) download(url string) ([]byte, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+ctx.c.Token)
+	res, err := ctx.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("invalid status code: %d", res.StatusCode)
+	}
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) == 0 {
+		return nil, errors.New("empty file")
+	}
+	return b, nil
+}
+
+func (ctx *importContext) resolveUser(slackID string) (uuid.UUID, string, bool) {
+	u, ok := ctx.users[slackID]
+	return u.id, u.name, ok
+}
+
+func (ctx *importContext) slackUserName(slackID string) string {
+	return ctx.slackUsers[slackID].Name
+}
+
+func (ctx *importContext) resolveChannel(slackID string) (uuid.UUID, string, bool) {
+	c, ok := ctx.channels[slackID]
+	return c.id, c.path, ok
+}
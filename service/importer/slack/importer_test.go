@@ -0,0 +1,171 @@
+package slack
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/repository/mock_repository"
+	"github.com/traPtitech/traQ/service/channel/mock_channel"
+	"github.com/traPtitech/traQ/testUtils"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+type Repo struct {
+	*mock_repository.MockMessageRepository
+	*mock_repository.MockUserRepository
+	testUtils.EmptyTestRepository
+}
+
+type stampRepository struct {
+	repository.StampRepository
+	stamps map[string]*model.Stamp
+}
+
+func (r *stampRepository) GetStampByName(name string) (*model.Stamp, error) {
+	s, ok := r.stamps[name]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return s, nil
+}
+
+func NewMockRepo(ctrl *gomock.Controller, stamps map[string]*model.Stamp) *Repo {
+	r := &Repo{
+		MockMessageRepository: mock_repository.NewMockMessageRepository(ctrl),
+		MockUserRepository:    mock_repository.NewMockUserRepository(ctrl),
+	}
+	r.StampRepository = &stampRepository{stamps: stamps}
+	return r
+}
+
+type mockUser struct {
+	model.UserInfo
+	id   uuid.UUID
+	name string
+}
+
+func (u *mockUser) GetID() uuid.UUID { return u.id }
+func (u *mockUser) GetName() string  { return u.name }
+
+func makeArchive(t *testing.T, files map[string]string) *zip.Reader {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	return zr
+}
+
+func TestImporter_Import(t *testing.T) {
+	t.Parallel()
+
+	var (
+		userID  = uuid.Must(uuid.NewV4())
+		stampID = uuid.Must(uuid.NewV4())
+		chID    = uuid.Must(uuid.NewV4())
+	)
+	archive := map[string]string{
+		"users.json":    `[{"id":"U1","name":"alice"},{"id":"U2","name":"bob"}]`,
+		"channels.json": `[{"id":"C1","name":"general","creator":"U1"},{"id":"C2","name":"this-name-is-too-long-for-traq"}]`,
+		"general/2015-04-09.json": `[
+			{"type":"message","user":"U1","text":"hello <@U2>","ts":"1428592523.000002","thread_ts":"1428592523.000002",
+			 "reactions":[{"name":"good","users":["U1","U2"]},{"name":"+1::skin-tone-2","users":["U1"]}]},
+			{"type":"message","user":"U1","text":"reply","ts":"1428592524.000000","thread_ts":"1428592523.000002"},
+			{"type":"message","subtype":"channel_join","user":"U1","text":"joined","ts":"1428592525.000000"},
+			{"type":"message","user":"U2","text":"unmapped","ts":"1428592526.000000"}
+		]`,
+	}
+	parentMessageID := uuid.NewV5(idNamespace, "C1/1428592523.000002")
+	replyMessageID := uuid.NewV5(idNamespace, "C1/1428592524.000000")
+
+	setup := func(t *testing.T) (*Repo, *mock_channel.MockManager) {
+		ctrl := gomock.NewController(t)
+		repo := NewMockRepo(ctrl, map[string]*model.Stamp{"good": {ID: stampID, Name: "good"}})
+		cm := mock_channel.NewMockManager(ctrl)
+		tree := mock_channel.NewMockTree(ctrl)
+		cm.EXPECT().PublicChannelTree().Return(tree).AnyTimes()
+		tree.EXPECT().GetChildrenIDs(uuid.Nil).Return([]uuid.UUID{}).AnyTimes()
+		tree.EXPECT().IsArchivedChannel(gomock.Any()).Return(false).AnyTimes()
+		repo.MockUserRepository.EXPECT().GetUserByExternalID("slack", "U1", false).Return(&mockUser{id: userID, name: "alice_traq"}, nil)
+		repo.MockUserRepository.EXPECT().GetUserByExternalID("slack", "U2", false).Return(nil, repository.ErrNotFound)
+		return repo, cm
+	}
+
+	t.Run("dry run", func(t *testing.T) {
+		t.Parallel()
+		repo, cm := setup(t)
+		repo.MockMessageRepository.EXPECT().GetMessageByID(gomock.Any()).Return(nil, repository.ErrNotFound).Times(2)
+
+		report, err := NewImporter(repo, cm, nil, zap.NewNop()).Import(makeArchive(t, archive), Config{ProviderName: "slack", DryRun: true})
+		require.NoError(t, err)
+		assert.Equal(t, 1, report.MappedUsers)
+		assert.Equal(t, []string{"bob"}, report.UnmappedUsers)
+		assert.Equal(t, []string{"general"}, report.CreatedChannels)
+		assert.Equal(t, []string{"this-name-is-too-long-for-traq"}, report.InvalidChannels)
+		assert.Equal(t, 2, report.ImportedMessages)
+		assert.Equal(t, 1, report.SkippedMessages)
+		assert.Equal(t, 1, report.ImportedStamps)
+		assert.Equal(t, []string{"+1"}, report.UnknownStamps)
+	})
+
+	t.Run("import", func(t *testing.T) {
+		t.Parallel()
+		repo, cm := setup(t)
+		cm.EXPECT().CreatePublicChannel("general", uuid.Nil, userID).Return(&model.Channel{ID: chID, Name: "general"}, nil)
+		repo.MockMessageRepository.EXPECT().GetMessageByID(gomock.Any()).Return(nil, repository.ErrNotFound).Times(2)
+
+		var imported []*model.Message
+		repo.MockMessageRepository.EXPECT().ImportMessage(gomock.Any()).DoAndReturn(func(m *model.Message) error {
+			imported = append(imported, m)
+			return nil
+		}).Times(2)
+
+		_, err := NewImporter(repo, cm, nil, zap.NewNop()).Import(makeArchive(t, archive), Config{ProviderName: "slack"})
+		require.NoError(t, err)
+		if assert.Len(t, imported, 2) {
+			parent := imported[0]
+			assert.Equal(t, parentMessageID, parent.ID)
+			assert.Equal(t, chID, parent.ChannelID)
+			assert.Equal(t, userID, parent.UserID)
+			assert.Equal(t, "hello @bob", parent.Text)
+			assert.EqualValues(t, 1428592523, parent.CreatedAt.Unix())
+			assert.False(t, parent.ParentID.Valid)
+			if assert.Len(t, parent.Stamps, 1) {
+				assert.Equal(t, stampID, parent.Stamps[0].StampID)
+				assert.Equal(t, userID, parent.Stamps[0].UserID)
+			}
+
+			reply := imported[1]
+			assert.Equal(t, replyMessageID, reply.ID)
+			assert.Equal(t, optional.From(parentMessageID), reply.ParentID)
+		}
+	})
+
+	t.Run("re-run", func(t *testing.T) {
+		t.Parallel()
+		repo, cm := setup(t)
+		cm.EXPECT().CreatePublicChannel("general", uuid.Nil, userID).Return(&model.Channel{ID: chID, Name: "general"}, nil)
+		repo.MockMessageRepository.EXPECT().GetMessageByID(gomock.Any()).Return(&model.Message{}, nil).Times(2)
+
+		report, err := NewImporter(repo, cm, nil, zap.NewNop()).Import(makeArchive(t, archive), Config{ProviderName: "slack"})
+		require.NoError(t, err)
+		assert.Equal(t, 0, report.ImportedMessages)
+		assert.Equal(t, 2, report.ExistingMessages)
+	})
+}
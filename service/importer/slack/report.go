@@ -0,0 +1,63 @@
+package slack
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Report インポート結果
+type Report struct {
+	// DryRun ドライランの結果かどうか
+	DryRun bool
+	// MappedUsers traQユーザーと紐づいたSlackユーザーの数
+	MappedUsers int
+	// UnmappedUsers traQユーザーと紐づかなかったSlackユーザーの名前
+	UnmappedUsers []string
+	// CreatedChannels 作成したチャンネルの名前
+	CreatedChannels []string
+	// ExistingChannels 既に存在していたチャンネルの名前
+	ExistingChannels []string
+	// InvalidChannels チャンネル名がtraQで使用できないため取り込まなかったチャンネルの名前
+	InvalidChannels []string
+	// ImportedMessages 取り込んだメッセージの数
+	ImportedMessages int
+	// ExistingMessages 取り込み済みのためスキップしたメッセージの数
+	ExistingMessages int
+	// SkippedMessages 投稿者が紐づかないためスキップしたメッセージの数
+	SkippedMessages int
+	// ImportedStamps 取り込んだスタンプの数
+	ImportedStamps int
+	// UnknownStamps traQに存在しないため取り込まなかったスタンプの名前
+	UnknownStamps []string
+	// ImportedFiles 取り込んだ添付ファイルの数
+	ImportedFiles int
+	// SkippedFiles 取り込まなかった添付ファイルの数
+	SkippedFiles int
+}
+
+// Write レポートを人が読める形式でwに書き込みます
+func (r *Report) Write(w io.Writer) error {
+	var sb strings.Builder
+	if r.DryRun {
+		sb.WriteString("[dry-run] no changes were made\n")
+	}
+	fmt.Fprintf(&sb, "users: %d mapped, %d unmapped\n", r.MappedUsers, len(r.UnmappedUsers))
+	writeList(&sb, "unmapped users", r.UnmappedUsers)
+	fmt.Fprintf(&sb, "channels: %d created, %d existing, %d invalid\n", len(r.CreatedChannels), len(r.ExistingChannels), len(r.InvalidChannels))
+	writeList(&sb, "created channels", r.CreatedChannels)
+	writeList(&sb, "invalid channels", r.InvalidChannels)
+	fmt.Fprintf(&sb, "messages: %d imported, %d already imported, %d skipped (no user)\n", r.ImportedMessages, r.ExistingMessages, r.SkippedMessages)
+	fmt.Fprintf(&sb, "stamps: %d imported, %d unknown\n", r.ImportedStamps, len(r.UnknownStamps))
+	writeList(&sb, "unknown stamps", r.UnknownStamps)
+	fmt.Fprintf(&sb, "files: %d imported, %d skipped\n", r.ImportedFiles, r.SkippedFiles)
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+func writeList(sb *strings.Builder, title string, list []string) {
+	if len(list) == 0 {
+		return
+	}
+	fmt.Fprintf(sb, "  %s: %s\n", title, strings.Join(list, ", "))
+}
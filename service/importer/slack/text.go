@@ -0,0 +1,80 @@
+package slack
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/utils/message"
+)
+
+var (
+	slackTagRegex    = regexp.MustCompile(`<([^<>]+)>`)
+	slackUnescaper   = strings.NewReplacer("&lt;", "<", "&gt;", ">", "&amp;", "&")
+	slackSpecialWord = map[string]string{
+		"!here":     "@here",
+		"!channel":  "@channel",
+		"!everyone": "@everyone",
+	}
+)
+
+// nameResolver Slack上のIDをtraQ上の情報に解決します
+type nameResolver interface {
+	// resolveUser SlackユーザーIDに対応するtraQユーザーのIDと名前を返します
+	resolveUser(slackID string) (id uuid.UUID, name string, ok bool)
+	// slackUserName SlackユーザーIDに対応するSlack上のユーザー名を返します
+	slackUserName(slackID string) string
+	// resolveChannel SlackチャンネルIDに対応するtraQチャンネルのIDとパスを返します
+	resolveChannel(slackID string) (id uuid.UUID, path string, ok bool)
+}
+
+// convertText Slackのmrkdwn形式のテキストをtraQのメッセージ形式に変換します
+func convertText(text string, r nameResolver) string {
+	text = slackTagRegex.ReplaceAllStringFunc(text, func(s string) string {
+		body, label, hasLabel := strings.Cut(s[1:len(s)-1], "|")
+		switch {
+		case strings.HasPrefix(body, "@"):
+			slackID := body[1:]
+			if id, name, ok := r.resolveUser(slackID); ok {
+				return embed("user", "@"+name, id)
+			}
+			if hasLabel {
+				return "@" + label
+			}
+			if name := r.slackUserName(slackID); len(name) > 0 {
+				return "@" + name
+			}
+			return "@" + slackID
+		case strings.HasPrefix(body, "#"):
+			if id, path, ok := r.resolveChannel(body[1:]); ok {
+				return embed("channel", "#"+path, id)
+			}
+			if hasLabel {
+				return "#" + label
+			}
+			return s
+		case strings.HasPrefix(body, "!"):
+			if w, ok := slackSpecialWord[body]; ok {
+				return w
+			}
+			if hasLabel {
+				return label
+			}
+			return s
+		default:
+			if !hasLabel || label == body {
+				return body
+			}
+			return fmt.Sprintf("[%s](%s)", label, body)
+		}
+	})
+	return slackUnescaper.Replace(text)
+}
+
+func embed(typ, raw string, id uuid.UUID) string {
+	b, _ := json.Marshal(message.EmbeddedInfo{Raw: raw, Type: typ, ID: id.String()})
+	return "!" + string(b)
+}
@@ -0,0 +1,81 @@
+package slack
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeResolver struct {
+	users    map[string]mappedUser
+	channels map[string]mappedChannel
+}
+
+func (r *fakeResolver) resolveUser(slackID string) (uuid.UUID, string, bool) {
+	u, ok := r.users[slackID]
+	return u.id, u.name, ok
+}
+
+func (r *fakeResolver) slackUserName(slackID string) string {
+	if slackID == "U2" {
+		return "slack_user"
+	}
+	return ""
+}
+
+func (r *fakeResolver) resolveChannel(slackID string) (uuid.UUID, string, bool) {
+	c, ok := r.channels[slackID]
+	return c.id, c.path, ok
+}
+
+func TestConvertText(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.Must(uuid.FromString("e1a8b5b4-1e1b-4f42-8d63-9e7c3c6b2b4f"))
+	channelID := uuid.Must(uuid.FromString("b3c9c2e8-3d52-4f8f-9d3e-2c5e0a0d6f1a"))
+	r := &fakeResolver{
+		users:    map[string]mappedUser{"U1": {id: userID, name: "traq_user"}},
+		channels: map[string]mappedChannel{"C1": {id: channelID, path: "slack/general"}},
+	}
+
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"plain", "hello &lt;world&gt; &amp;", "hello <world> &"},
+		{"mapped user", "hi <@U1>", `hi !{"raw":"@traq_user","type":"user","id":"` + userID.String() + `"}`},
+		{"unmapped user", "hi <@U2>", "hi @slack_user"},
+		{"unknown user", "hi <@U3>", "hi @U3"},
+		{"mapped channel", "see <#C1|general>", `see !{"raw":"#slack/general","type":"channel","id":"` + channelID.String() + `"}`},
+		{"unmapped channel", "see <#C2|random>", "see #random"},
+		{"special", "<!here> <!channel>", "@here @channel"},
+		{"subteam", "<!subteam^S1|@team>", "@team"},
+		{"link", "<https://example.com>", "https://example.com"},
+		{"link with label", "<https://example.com|example>", "[example](https://example.com)"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, convertText(tt.text, r))
+		})
+	}
+}
+
+func TestParseTS(t *testing.T) {
+	t.Parallel()
+
+	ts, err := parseTS("1428592523.000002")
+	if assert.NoError(t, err) {
+		assert.Equal(t, time.Unix(1428592523, 2000), ts)
+	}
+	ts, err = parseTS("1428592523")
+	if assert.NoError(t, err) {
+		assert.Equal(t, time.Unix(1428592523, 0), ts)
+	}
+	_, err = parseTS("invalid")
+	assert.Error(t, err)
+}
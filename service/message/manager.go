@@ -1,3 +1,4 @@
+//go:generate mockgen -source=$GOFILE -destination=mock_$GOPACKAGE/mock_$GOFILE
 package message
 
 import (
@@ -8,6 +9,7 @@ import (
 	"github.com/gofrs/uuid"
 
 	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
 	"github.com/traPtitech/traQ/utils/optional"
 )
 
@@ -23,14 +25,17 @@ type TimelineQuery struct {
 	Channel uuid.UUID
 	// ChannelsSubscribedByUser 指定したユーザーが購読しているチャンネルのメッセージを指定
 	ChannelsSubscribedByUser uuid.UUID
-	Since                    optional.Of[time.Time]
-	Until                    optional.Of[time.Time]
-	Inclusive                bool
-	Limit                    int
-	Offset                   int
-	Asc                      bool
-	ExcludeDMs               bool
-	DisablePreload           bool
+	// Thread 指定したメッセージを親とするスレッドのメッセージを指定
+	Thread         uuid.UUID
+	Since          optional.Of[time.Time]
+	Until          optional.Of[time.Time]
+	Inclusive      bool
+	Limit          int
+	Offset         int
+	Asc            bool
+	Cursor         optional.Of[repository.MessageCursor]
+	ExcludeDMs     bool
+	DisablePreload bool
 }
 
 type Manager interface {
@@ -51,6 +56,15 @@ type Manager interface {
 	// アーカイブされているチャンネルを指定すると、ErrChannelArchivedを返します。
 	// DBによるエラーを返すことがあります。
 	Create(channelID, userID uuid.UUID, content string) (Message, error)
+	// CreateReply 指定したメッセージのスレッドに返信を作成します
+	//
+	// 成功した場合、メッセージとnilを返します。
+	// 指定したメッセージがスレッドの返信だった場合、そのスレッドの親メッセージへの返信を作成します。
+	// 返信したユーザーはスレッドを自動でフォローします。
+	// アーカイブされているチャンネルを指定すると、ErrChannelArchivedを返します。
+	// 存在しないメッセージを指定した場合、ErrNotFoundを返します。
+	// DBによるエラーを返すことがあります。
+	CreateReply(parentID, userID uuid.UUID, content string) (Message, error)
 	// CreateDM ダイレクトメッセージを作成します
 	//
 	// 成功した場合、メッセージとnilを返します。
@@ -70,6 +84,14 @@ type Manager interface {
 	// 存在しないメッセージを指定した場合、ErrNotFoundを返します。
 	// DBによるエラーを返すことがあります。
 	Delete(id uuid.UUID) error
+	// SetComponents 指定したメッセージのコンポーネントを設定します
+	//
+	// 成功した場合、nilを返します。
+	// 空の配列を指定した場合、コンポーネントを削除します。
+	// アーカイブされているチャンネルを指定すると、ErrChannelArchivedを返します。
+	// 存在しないメッセージを指定した場合、ErrNotFoundを返します。
+	// DBによるエラーを返すことがあります。
+	SetComponents(id uuid.UUID, components model.MessageComponentList) error
 	// Pin 指定したユーザーによって指定したメッセージをピン留めします
 	//
 	// 成功した場合は、ピンとnilを返します。
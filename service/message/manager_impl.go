@@ -67,12 +67,14 @@ func (m *manager) GetTimeline(query TimelineQuery) (Timeline, error) {
 		User:                     query.User,
 		Channel:                  query.Channel,
 		ChannelsSubscribedByUser: query.ChannelsSubscribedByUser,
+		Thread:                   query.Thread,
 		Since:                    query.Since,
 		Until:                    query.Until,
 		Inclusive:                query.Inclusive,
 		Limit:                    query.Limit,
 		Offset:                   query.Offset,
 		Asc:                      query.Asc,
+		Cursor:                   query.Cursor,
 		ExcludeDMs:               query.ExcludeDMs,
 		DisablePreload:           query.DisablePreload,
 	}
@@ -110,6 +112,45 @@ func (m *manager) Create(channelID, userID uuid.UUID, content string) (Message,
 	return m.create(channelID, userID, content)
 }
 
+func (m *manager) CreateReply(parentID, userID uuid.UUID, content string) (Message, error) {
+	// 親メッセージ取得
+	parent, err := m.Get(parentID)
+	if err != nil {
+		return nil, err
+	}
+	// 返信への返信はスレッドの親メッセージへの返信として扱う
+	if root := parent.GetParentID(); root.Valid {
+		parent, err = m.Get(root.V)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// チャンネルがアーカイブされているかどうか確認
+	if m.CM.IsPublicChannel(parent.GetChannelID()) && m.CM.PublicChannelTree().IsArchivedChannel(parent.GetChannelID()) {
+		return nil, ErrChannelArchived
+	}
+
+	// 作成前の返信数でスレッドの最初の返信かどうかを判定
+	counts, err := m.R.GetThreadReplyCounts([]uuid.UUID{parent.GetID()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to GetThreadReplyCounts: %w", err)
+	}
+
+	// 返信者をフォロー。スレッドの最初の返信の場合は親メッセージの投稿者もフォロー
+	followers := []uuid.UUID{userID}
+	if counts[parent.GetID()] == 0 && parent.GetUserID() != userID {
+		followers = append(followers, parent.GetUserID())
+	}
+
+	// 作成
+	msg, err := m.R.CreateThreadMessage(userID, parent.GetChannelID(), parent.GetID(), content, followers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to CreateThreadMessage: %w", err)
+	}
+	return &message{Model: msg}, nil
+}
+
 func (m *manager) create(channelID, userID uuid.UUID, content string) (Message, error) {
 	// 作成
 	msg, err := m.R.CreateMessage(userID, channelID, content)
@@ -171,6 +212,32 @@ func (m *manager) Delete(id uuid.UUID) error {
 	return nil
 }
 
+func (m *manager) SetComponents(id uuid.UUID, components model.MessageComponentList) error {
+	// メッセージ取得
+	msg, err := m.Get(id)
+	if err != nil {
+		return err
+	}
+
+	// チャンネルがアーカイブされているかどうか確認
+	if m.CM.IsPublicChannel(msg.GetChannelID()) && m.CM.PublicChannelTree().IsArchivedChannel(msg.GetChannelID()) {
+		return ErrChannelArchived
+	}
+
+	// 更新
+	if err := m.R.SetMessageComponents(id, components); err != nil {
+		switch err {
+		case repository.ErrNotFound:
+			return ErrNotFound
+		default:
+			return fmt.Errorf("failed to SetMessageComponents: %w", err)
+		}
+	}
+	m.cache.Forget(id)
+
+	return nil
+}
+
 func (m *manager) Pin(id uuid.UUID, userID uuid.UUID) (*model.Pin, error) {
 	// メッセージ取得
 	msg, err := m.Get(id)
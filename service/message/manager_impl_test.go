@@ -1,6 +1,7 @@
 package message
 
 import (
+	"errors"
 	"testing"
 	"time"
 
@@ -12,6 +13,7 @@ import (
 	"github.com/traPtitech/traQ/model"
 	"github.com/traPtitech/traQ/repository"
 	"github.com/traPtitech/traQ/service/channel/mock_channel"
+	"github.com/traPtitech/traQ/utils/optional"
 )
 
 func setupM(ctrl *gomock.Controller) (Manager, *mock_channel.MockManager, *Repo, *mock_channel.MockTree) {
@@ -163,6 +165,151 @@ func TestManager_Create(t *testing.T) {
 	})
 }
 
+func TestManager_CreateReply(t *testing.T) {
+	t.Parallel()
+	const content = "content"
+
+	t.Run("parent not found", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		m, _, repo, _ := setupM(ctrl)
+
+		pid := uuid.NewV3(uuid.Nil, "m1")
+		repo.MockMessageRepository.
+			EXPECT().
+			GetMessageByID(pid).
+			Return(nil, repository.ErrNotFound).
+			Times(1)
+
+		_, err := m.CreateReply(pid, uuid.NewV3(uuid.Nil, "u1"), content)
+		assert.EqualError(t, err, ErrNotFound.Error())
+	})
+
+	t.Run("channel archived", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		m, cm, repo, tree := setupM(ctrl)
+
+		cid := uuid.NewV3(uuid.Nil, "c1")
+		pid := uuid.NewV3(uuid.Nil, "m1")
+		repo.MockMessageRepository.
+			EXPECT().
+			GetMessageByID(pid).
+			Return(&model.Message{ID: pid, UserID: uuid.NewV3(uuid.Nil, "u2"), ChannelID: cid}, nil).
+			Times(1)
+		cm.EXPECT().IsPublicChannel(cid).Return(true).Times(1)
+		tree.EXPECT().IsArchivedChannel(cid).Return(true).Times(1)
+
+		_, err := m.CreateReply(pid, uuid.NewV3(uuid.Nil, "u1"), content)
+		assert.EqualError(t, err, ErrChannelArchived.Error())
+	})
+
+	t.Run("create failed", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		m, cm, repo, tree := setupM(ctrl)
+
+		cid := uuid.NewV3(uuid.Nil, "c1")
+		pid := uuid.NewV3(uuid.Nil, "m1")
+		uid := uuid.NewV3(uuid.Nil, "u1")
+		repo.MockMessageRepository.
+			EXPECT().
+			GetMessageByID(pid).
+			Return(&model.Message{ID: pid, UserID: uuid.NewV3(uuid.Nil, "u2"), ChannelID: cid}, nil).
+			Times(1)
+		cm.EXPECT().IsPublicChannel(cid).Return(true).Times(1)
+		tree.EXPECT().IsArchivedChannel(cid).Return(false).Times(1)
+		repo.MockMessageRepository.
+			EXPECT().
+			GetThreadReplyCounts([]uuid.UUID{pid}).
+			Return(map[uuid.UUID]int{}, nil).
+			Times(1)
+		repo.MockMessageRepository.
+			EXPECT().
+			CreateThreadMessage(uid, cid, pid, content, []uuid.UUID{uid, uuid.NewV3(uuid.Nil, "u2")}).
+			Return(nil, errors.New("mock error")).
+			Times(1)
+
+		_, err := m.CreateReply(pid, uid, content)
+		assert.Error(t, err)
+	})
+
+	t.Run("success (first reply)", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		m, cm, repo, tree := setupM(ctrl)
+
+		cid := uuid.NewV3(uuid.Nil, "c1")
+		pid := uuid.NewV3(uuid.Nil, "m1")
+		uid := uuid.NewV3(uuid.Nil, "u1")
+		author := uuid.NewV3(uuid.Nil, "u2")
+		repo.MockMessageRepository.
+			EXPECT().
+			GetMessageByID(pid).
+			Return(&model.Message{ID: pid, UserID: author, ChannelID: cid}, nil).
+			Times(1)
+		cm.EXPECT().IsPublicChannel(cid).Return(true).Times(1)
+		tree.EXPECT().IsArchivedChannel(cid).Return(false).Times(1)
+		repo.MockMessageRepository.
+			EXPECT().
+			GetThreadReplyCounts([]uuid.UUID{pid}).
+			Return(map[uuid.UUID]int{}, nil).
+			Times(1)
+		// 返信者と親メッセージの投稿者がメッセージの作成と同時にフォローする
+		repo.MockMessageRepository.
+			EXPECT().
+			CreateThreadMessage(uid, cid, pid, content, []uuid.UUID{uid, author}).
+			Return(&model.Message{ID: uuid.NewV3(uuid.Nil, "m2"), UserID: uid, ChannelID: cid, Text: content, ParentID: optional.From(pid)}, nil).
+			Times(1)
+
+		msg, err := m.CreateReply(pid, uid, content)
+		if assert.NoError(t, err) {
+			assert.EqualValues(t, cid, msg.GetChannelID())
+			assert.EqualValues(t, uid, msg.GetUserID())
+			assert.EqualValues(t, content, msg.GetText())
+			assert.EqualValues(t, optional.From(pid), msg.GetParentID())
+		}
+	})
+
+	t.Run("success (reply to reply)", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		m, cm, repo, tree := setupM(ctrl)
+
+		cid := uuid.NewV3(uuid.Nil, "c1")
+		rootID := uuid.NewV3(uuid.Nil, "m1")
+		replyID := uuid.NewV3(uuid.Nil, "m2")
+		uid := uuid.NewV3(uuid.Nil, "u1")
+		repo.MockMessageRepository.
+			EXPECT().
+			GetMessageByID(replyID).
+			Return(&model.Message{ID: replyID, UserID: uuid.NewV3(uuid.Nil, "u3"), ChannelID: cid, ParentID: optional.From(rootID)}, nil).
+			Times(1)
+		repo.MockMessageRepository.
+			EXPECT().
+			GetMessageByID(rootID).
+			Return(&model.Message{ID: rootID, UserID: uuid.NewV3(uuid.Nil, "u2"), ChannelID: cid}, nil).
+			Times(1)
+		cm.EXPECT().IsPublicChannel(cid).Return(true).Times(1)
+		tree.EXPECT().IsArchivedChannel(cid).Return(false).Times(1)
+		repo.MockMessageRepository.
+			EXPECT().
+			GetThreadReplyCounts([]uuid.UUID{rootID}).
+			Return(map[uuid.UUID]int{rootID: 1}, nil).
+			Times(1)
+		repo.MockMessageRepository.
+			EXPECT().
+			CreateThreadMessage(uid, cid, rootID, content, []uuid.UUID{uid}).
+			Return(&model.Message{ID: uuid.NewV3(uuid.Nil, "m3"), UserID: uid, ChannelID: cid, Text: content, ParentID: optional.From(rootID)}, nil).
+			Times(1)
+
+		msg, err := m.CreateReply(replyID, uid, content)
+		if assert.NoError(t, err) {
+			assert.EqualValues(t, optional.From(rootID), msg.GetParentID())
+		}
+	})
+}
+
 func TestManager_CreateDM(t *testing.T) {
 	t.Parallel()
 	const content = "content"
@@ -278,6 +425,72 @@ func TestManager_Edit(t *testing.T) {
 	})
 }
 
+func TestManager_SetComponents(t *testing.T) {
+	t.Parallel()
+	components := model.MessageComponentList{
+		{Type: model.MessageComponentTypeButton, CustomID: "ok", Label: "OK"},
+	}
+
+	t.Run("message not found", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		m, _, repo, _ := setupM(ctrl)
+
+		id := uuid.NewV3(uuid.Nil, "m1")
+		repo.MockMessageRepository.
+			EXPECT().
+			GetMessageByID(id).
+			Return(nil, repository.ErrNotFound).
+			Times(1)
+
+		err := m.SetComponents(id, components)
+		assert.EqualError(t, err, ErrNotFound.Error())
+	})
+
+	t.Run("channel archived", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		m, cm, repo, tree := setupM(ctrl)
+
+		id := uuid.NewV3(uuid.Nil, "m1")
+		cid := uuid.NewV3(uuid.Nil, "c1")
+		repo.MockMessageRepository.
+			EXPECT().
+			GetMessageByID(id).
+			Return(&model.Message{ID: id, ChannelID: cid}, nil).
+			Times(1)
+		cm.EXPECT().IsPublicChannel(cid).Return(true).Times(1)
+		tree.EXPECT().IsArchivedChannel(cid).Return(true).Times(1)
+
+		err := m.SetComponents(id, components)
+		assert.EqualError(t, err, ErrChannelArchived.Error())
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		m, cm, repo, tree := setupM(ctrl)
+
+		id := uuid.NewV3(uuid.Nil, "m1")
+		cid := uuid.NewV3(uuid.Nil, "c1")
+		repo.MockMessageRepository.
+			EXPECT().
+			GetMessageByID(id).
+			Return(&model.Message{ID: id, ChannelID: cid}, nil).
+			Times(1)
+		cm.EXPECT().IsPublicChannel(cid).Return(true).Times(1)
+		tree.EXPECT().IsArchivedChannel(cid).Return(false).Times(1)
+		repo.MockMessageComponentRepository.
+			EXPECT().
+			SetMessageComponents(id, components).
+			Return(nil).
+			Times(1)
+
+		err := m.SetComponents(id, components)
+		assert.NoError(t, err)
+	})
+}
+
 func TestManager_Delete(t *testing.T) {
 	t.Parallel()
 
@@ -10,14 +10,18 @@ import (
 type Repo struct {
 	*mock_repository.MockChannelRepository
 	*mock_repository.MockMessageRepository
+	*mock_repository.MockMessageComponentRepository
 	*mock_repository.MockPinRepository
+	*mock_repository.MockThreadRepository
 	testUtils.EmptyTestRepository
 }
 
 func NewMockRepo(ctrl *gomock.Controller) *Repo {
 	return &Repo{
-		MockChannelRepository: mock_repository.NewMockChannelRepository(ctrl),
-		MockMessageRepository: mock_repository.NewMockMessageRepository(ctrl),
-		MockPinRepository:     mock_repository.NewMockPinRepository(ctrl),
+		MockChannelRepository:          mock_repository.NewMockChannelRepository(ctrl),
+		MockMessageRepository:          mock_repository.NewMockMessageRepository(ctrl),
+		MockMessageComponentRepository: mock_repository.NewMockMessageComponentRepository(ctrl),
+		MockPinRepository:              mock_repository.NewMockPinRepository(ctrl),
+		MockThreadRepository:           mock_repository.NewMockThreadRepository(ctrl),
 	}
 }
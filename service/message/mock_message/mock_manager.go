@@ -0,0 +1,227 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: manager.go
+
+// Package mock_message is a generated GoMock package.
+package mock_message
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/gofrs/uuid"
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/traPtitech/traQ/model"
+	message "github.com/traPtitech/traQ/service/message"
+)
+
+// MockManager is a mock of Manager interface.
+type MockManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockManagerMockRecorder
+}
+
+// MockManagerMockRecorder is the mock recorder for MockManager.
+type MockManagerMockRecorder struct {
+	mock *MockManager
+}
+
+// NewMockManager creates a new mock instance.
+func NewMockManager(ctrl *gomock.Controller) *MockManager {
+	mock := &MockManager{ctrl: ctrl}
+	mock.recorder = &MockManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockManager) EXPECT() *MockManagerMockRecorder {
+	return m.recorder
+}
+
+// AddStamps mocks base method.
+func (m *MockManager) AddStamps(id, stampID, userID uuid.UUID, n int) (*model.MessageStamp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddStamps", id, stampID, userID, n)
+	ret0, _ := ret[0].(*model.MessageStamp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddStamps indicates an expected call of AddStamps.
+func (mr *MockManagerMockRecorder) AddStamps(id, stampID, userID, n interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddStamps", reflect.TypeOf((*MockManager)(nil).AddStamps), id, stampID, userID, n)
+}
+
+// Create mocks base method.
+func (m *MockManager) Create(channelID, userID uuid.UUID, content string) (message.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", channelID, userID, content)
+	ret0, _ := ret[0].(message.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockManagerMockRecorder) Create(channelID, userID, content interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockManager)(nil).Create), channelID, userID, content)
+}
+
+// CreateDM mocks base method.
+func (m *MockManager) CreateDM(from, to uuid.UUID, content string) (message.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDM", from, to, content)
+	ret0, _ := ret[0].(message.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDM indicates an expected call of CreateDM.
+func (mr *MockManagerMockRecorder) CreateDM(from, to, content interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDM", reflect.TypeOf((*MockManager)(nil).CreateDM), from, to, content)
+}
+
+// CreateReply mocks base method.
+func (m *MockManager) CreateReply(parentID, userID uuid.UUID, content string) (message.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReply", parentID, userID, content)
+	ret0, _ := ret[0].(message.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateReply indicates an expected call of CreateReply.
+func (mr *MockManagerMockRecorder) CreateReply(parentID, userID, content interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReply", reflect.TypeOf((*MockManager)(nil).CreateReply), parentID, userID, content)
+}
+
+// Delete mocks base method.
+func (m *MockManager) Delete(id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockManagerMockRecorder) Delete(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockManager)(nil).Delete), id)
+}
+
+// Edit mocks base method.
+func (m *MockManager) Edit(id uuid.UUID, content string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Edit", id, content)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Edit indicates an expected call of Edit.
+func (mr *MockManagerMockRecorder) Edit(id, content interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Edit", reflect.TypeOf((*MockManager)(nil).Edit), id, content)
+}
+
+// Get mocks base method.
+func (m *MockManager) Get(id uuid.UUID) (message.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", id)
+	ret0, _ := ret[0].(message.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockManagerMockRecorder) Get(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockManager)(nil).Get), id)
+}
+
+// GetTimeline mocks base method.
+func (m *MockManager) GetTimeline(query message.TimelineQuery) (message.Timeline, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTimeline", query)
+	ret0, _ := ret[0].(message.Timeline)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTimeline indicates an expected call of GetTimeline.
+func (mr *MockManagerMockRecorder) GetTimeline(query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTimeline", reflect.TypeOf((*MockManager)(nil).GetTimeline), query)
+}
+
+// Pin mocks base method.
+func (m *MockManager) Pin(id, userID uuid.UUID) (*model.Pin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Pin", id, userID)
+	ret0, _ := ret[0].(*model.Pin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Pin indicates an expected call of Pin.
+func (mr *MockManagerMockRecorder) Pin(id, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pin", reflect.TypeOf((*MockManager)(nil).Pin), id, userID)
+}
+
+// RemoveStamps mocks base method.
+func (m *MockManager) RemoveStamps(id, stampID, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveStamps", id, stampID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveStamps indicates an expected call of RemoveStamps.
+func (mr *MockManagerMockRecorder) RemoveStamps(id, stampID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveStamps", reflect.TypeOf((*MockManager)(nil).RemoveStamps), id, stampID, userID)
+}
+
+// SetComponents mocks base method.
+func (m *MockManager) SetComponents(id uuid.UUID, components model.MessageComponentList) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetComponents", id, components)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetComponents indicates an expected call of SetComponents.
+func (mr *MockManagerMockRecorder) SetComponents(id, components interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetComponents", reflect.TypeOf((*MockManager)(nil).SetComponents), id, components)
+}
+
+// Unpin mocks base method.
+func (m *MockManager) Unpin(id, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unpin", id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unpin indicates an expected call of Unpin.
+func (mr *MockManagerMockRecorder) Unpin(id, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unpin", reflect.TypeOf((*MockManager)(nil).Unpin), id, userID)
+}
+
+// Wait mocks base method.
+func (m *MockManager) Wait(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Wait", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Wait indicates an expected call of Wait.
+func (mr *MockManagerMockRecorder) Wait(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Wait", reflect.TypeOf((*MockManager)(nil).Wait), ctx)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/gofrs/uuid"
 
 	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/utils/optional"
 )
 
 type Message interface {
@@ -16,8 +17,11 @@ type Message interface {
 	GetText() string
 	GetCreatedAt() time.Time
 	GetUpdatedAt() time.Time
+	GetParentID() optional.Of[uuid.UUID]
+	GetRevisionCount() int
 	GetStamps() []model.MessageStamp
 	GetPin() *model.Pin
+	GetComponents() model.MessageComponentList
 
 	json.Marshaler
 }
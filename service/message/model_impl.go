@@ -57,6 +57,18 @@ func (m *message) GetUpdatedAt() time.Time {
 	return m.Model.UpdatedAt
 }
 
+func (m *message) GetParentID() optional.Of[uuid.UUID] {
+	m.RLock()
+	defer m.RUnlock()
+	return m.Model.ParentID
+}
+
+func (m *message) GetRevisionCount() int {
+	m.RLock()
+	defer m.RUnlock()
+	return m.Model.RevisionCount
+}
+
 func (m *message) GetStamps() []model.MessageStamp {
 	m.Lock()
 	defer m.Unlock()
@@ -124,29 +136,40 @@ func (m *message) GetPin() *model.Pin {
 	return m.Model.Pin
 }
 
+func (m *message) GetComponents() model.MessageComponentList {
+	m.RLock()
+	defer m.RUnlock()
+	return m.Model.GetComponents()
+}
+
 func (m *message) MarshalJSON() ([]byte, error) {
 	type obj struct {
-		ID        uuid.UUID              `json:"id"`
-		UserID    uuid.UUID              `json:"userId"`
-		ChannelID uuid.UUID              `json:"channelId"`
-		Content   string                 `json:"content"`
-		CreatedAt time.Time              `json:"createdAt"`
-		UpdatedAt time.Time              `json:"updatedAt"`
-		Pinned    bool                   `json:"pinned"`
-		Stamps    []model.MessageStamp   `json:"stamps"`
-		ThreadID  optional.Of[uuid.UUID] `json:"threadId"` // TODO
+		ID            uuid.UUID                 `json:"id"`
+		UserID        uuid.UUID                 `json:"userId"`
+		ChannelID     uuid.UUID                 `json:"channelId"`
+		Content       string                    `json:"content"`
+		CreatedAt     time.Time                 `json:"createdAt"`
+		UpdatedAt     time.Time                 `json:"updatedAt"`
+		Pinned        bool                      `json:"pinned"`
+		Stamps        []model.MessageStamp      `json:"stamps"`
+		ThreadID      optional.Of[uuid.UUID]    `json:"threadId"`
+		RevisionCount int                       `json:"revisionCount"`
+		Components    []*model.MessageComponent `json:"components"`
 	}
 	stamps := m.GetStamps()
 	m.RLock()
 	v := &obj{
-		ID:        m.Model.ID,
-		UserID:    m.Model.UserID,
-		ChannelID: m.Model.ChannelID,
-		Content:   m.Model.Text,
-		CreatedAt: m.Model.CreatedAt,
-		UpdatedAt: m.Model.UpdatedAt,
-		Pinned:    m.Model.Pin != nil,
-		Stamps:    stamps,
+		ID:            m.Model.ID,
+		UserID:        m.Model.UserID,
+		ChannelID:     m.Model.ChannelID,
+		Content:       m.Model.Text,
+		CreatedAt:     m.Model.CreatedAt,
+		UpdatedAt:     m.Model.UpdatedAt,
+		Pinned:        m.Model.Pin != nil,
+		Stamps:        stamps,
+		ThreadID:      m.Model.ParentID,
+		RevisionCount: m.Model.RevisionCount,
+		Components:    m.Model.GetComponents(),
 	}
 	m.RUnlock()
 	return jsonIter.ConfigFastest.Marshal(v)
@@ -68,6 +68,14 @@ func (m *timelineMessage) GetUpdatedAt() time.Time {
 	return m.Model.UpdatedAt
 }
 
+func (m *timelineMessage) GetParentID() optional.Of[uuid.UUID] {
+	return m.Model.ParentID
+}
+
+func (m *timelineMessage) GetRevisionCount() int {
+	return m.Model.RevisionCount
+}
+
 func (m *timelineMessage) GetStamps() []model.MessageStamp {
 	return m.Model.Stamps
 }
@@ -76,43 +84,54 @@ func (m *timelineMessage) GetPin() *model.Pin {
 	return m.Model.Pin
 }
 
+func (m *timelineMessage) GetComponents() model.MessageComponentList {
+	return m.Model.GetComponents()
+}
+
 func (m *timelineMessage) MarshalJSON() ([]byte, error) {
 	type object struct {
-		ID        uuid.UUID `json:"id"`
-		UserID    uuid.UUID `json:"userId"`
-		ChannelID uuid.UUID `json:"channelId"`
-		Content   string    `json:"content"`
-		CreatedAt time.Time `json:"createdAt"`
-		UpdatedAt time.Time `json:"updatedAt"`
+		ID            uuid.UUID              `json:"id"`
+		UserID        uuid.UUID              `json:"userId"`
+		ChannelID     uuid.UUID              `json:"channelId"`
+		Content       string                 `json:"content"`
+		CreatedAt     time.Time              `json:"createdAt"`
+		UpdatedAt     time.Time              `json:"updatedAt"`
+		ThreadID      optional.Of[uuid.UUID] `json:"threadId"`
+		RevisionCount int                    `json:"revisionCount"`
 	}
 	type objectWithPreload struct {
 		object
-		Pinned   bool                   `json:"pinned"`
-		Stamps   []model.MessageStamp   `json:"stamps"`
-		ThreadID optional.Of[uuid.UUID] `json:"threadId"` // TODO
+		Pinned     bool                      `json:"pinned"`
+		Stamps     []model.MessageStamp      `json:"stamps"`
+		Components []*model.MessageComponent `json:"components"`
 	}
 	var v interface{}
 	if m.preloaded {
 		v = &objectWithPreload{
 			object: object{
-				ID:        m.Model.ID,
-				UserID:    m.Model.UserID,
-				ChannelID: m.Model.ChannelID,
-				Content:   m.Model.Text,
-				CreatedAt: m.Model.CreatedAt,
-				UpdatedAt: m.Model.UpdatedAt,
+				ID:            m.Model.ID,
+				UserID:        m.Model.UserID,
+				ChannelID:     m.Model.ChannelID,
+				Content:       m.Model.Text,
+				CreatedAt:     m.Model.CreatedAt,
+				UpdatedAt:     m.Model.UpdatedAt,
+				ThreadID:      m.Model.ParentID,
+				RevisionCount: m.Model.RevisionCount,
 			},
-			Pinned: m.Model.Pin != nil,
-			Stamps: m.Model.Stamps,
+			Pinned:     m.Model.Pin != nil,
+			Stamps:     m.Model.Stamps,
+			Components: m.Model.GetComponents(),
 		}
 	} else {
 		v = &object{
-			ID:        m.Model.ID,
-			UserID:    m.Model.UserID,
-			ChannelID: m.Model.ChannelID,
-			Content:   m.Model.Text,
-			CreatedAt: m.Model.CreatedAt,
-			UpdatedAt: m.Model.UpdatedAt,
+			ID:            m.Model.ID,
+			UserID:        m.Model.UserID,
+			ChannelID:     m.Model.ChannelID,
+			Content:       m.Model.Text,
+			CreatedAt:     m.Model.CreatedAt,
+			UpdatedAt:     m.Model.UpdatedAt,
+			ThreadID:      m.Model.ParentID,
+			RevisionCount: m.Model.RevisionCount,
 		}
 	}
 	return jsonIter.ConfigFastest.Marshal(v)
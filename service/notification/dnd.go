@@ -0,0 +1,139 @@
+package notification
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"go.uber.org/zap"
+
+	"github.com/traPtitech/traQ/service/fcm"
+	"github.com/traPtitech/traQ/utils/set"
+)
+
+// dndCheckInterval 保留中の通知のおやすみモード終了を確認する間隔
+const dndCheckInterval = time.Minute
+
+// heldNotifications おやすみモード中に保留したユーザーのFCM通知
+type heldNotifications struct {
+	count int
+	last  *fcm.Payload
+}
+
+// dndHolder おやすみモード中のユーザーへのFCM通知の保留場所
+//
+// 保留中の通知はメモリ上にのみ保持されるため、サーバーの再起動時におやすみモード中のユーザーの保留中の通知は失われます。
+type dndHolder struct {
+	mu   sync.Mutex
+	held map[uuid.UUID]*heldNotifications
+}
+
+func (h *dndHolder) hold(userID uuid.UUID, p *fcm.Payload) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.held == nil {
+		h.held = map[uuid.UUID]*heldNotifications{}
+	}
+	n, ok := h.held[userID]
+	if !ok {
+		n = &heldNotifications{}
+		h.held[userID] = n
+	}
+	n.count++
+	n.last = p
+}
+
+func (h *dndHolder) userIDs() []uuid.UUID {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	ids := make([]uuid.UUID, 0, len(h.held))
+	for id := range h.held {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func (h *dndHolder) pop(userID uuid.UUID) *heldNotifications {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	n := h.held[userID]
+	delete(h.held, userID)
+	return n
+}
+
+// sendFCM おやすみモード中のユーザーを除いてFCM通知を送信します
+//
+// おやすみモード中のユーザーへの通知は保留され、おやすみモード終了時にまとめて通知されます。
+func (ns *Service) sendFCM(targets set.UUID, p *fcm.Payload, withUnreadCount bool) {
+	if len(targets) == 0 {
+		return
+	}
+	settings, err := ns.repo.GetUserSettingsByUserIDs(targets.Array())
+	if err != nil {
+		ns.logger.Error("failed to GetUserSettingsByUserIDs", zap.Error(err))
+		ns.fcm.Send(targets, p, withUnreadCount)
+		return
+	}
+
+	now := time.Now()
+	for _, us := range settings {
+		if us.IsDND(now) {
+			targets.Remove(us.UserID)
+			ns.dnd.hold(us.UserID, p)
+		}
+	}
+	if len(targets) > 0 {
+		ns.fcm.Send(targets, p, withUnreadCount)
+	}
+}
+
+func (ns *Service) dndWorker() {
+	ticker := time.NewTicker(dndCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			ns.flushHeldNotifications(time.Now())
+		case <-ns.done:
+			return
+		}
+	}
+}
+
+// flushHeldNotifications おやすみモードが終了したユーザーの保留中の通知を処理します
+func (ns *Service) flushHeldNotifications(now time.Time) {
+	ids := ns.dnd.userIDs()
+	if len(ids) == 0 {
+		return
+	}
+	settings, err := ns.repo.GetUserSettingsByUserIDs(ids)
+	if err != nil {
+		ns.logger.Error("failed to GetUserSettingsByUserIDs", zap.Error(err))
+		return
+	}
+
+	remaining := set.UUIDSetFromArray(ids)
+	for _, us := range settings {
+		remaining.Remove(us.UserID)
+		if us.IsDND(now) {
+			continue
+		}
+		n := ns.dnd.pop(us.UserID)
+		if n == nil || !us.DNDDigest {
+			continue
+		}
+		ns.fcm.Send(set.UUIDSetFromArray([]uuid.UUID{us.UserID}), &fcm.Payload{
+			Type:  "dnd_digest",
+			Title: "おやすみモード中の通知",
+			Body:  fmt.Sprintf("%d件の通知があります", n.count),
+			Path:  n.last.Path,
+			Tag:   "dnd_digest",
+			Icon:  n.last.Icon,
+		}, true)
+	}
+	// 設定が削除されたユーザーの保留中の通知は破棄
+	for id := range remaining {
+		ns.dnd.pop(id)
+	}
+}
@@ -0,0 +1,247 @@
+package notification
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/leandro-lugaresi/hub"
+	"go.uber.org/zap"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/service/email"
+	"github.com/traPtitech/traQ/utils/message"
+	"github.com/traPtitech/traQ/utils/set"
+)
+
+const (
+	// emailDigestCheckInterval まとめメールの送信対象を確認する間隔
+	emailDigestCheckInterval = 5 * time.Minute
+	// emailDigestMaxMessages まとめメールに含める最大メッセージ数
+	emailDigestMaxMessages = 50
+	// emailQueueSize 送信待ちの即時通知メールの最大数
+	emailQueueSize = 1000
+)
+
+// sendEmail 即時メール通知が有効なユーザーへのメールを送信キューに入れます
+//
+// おやすみモード中のユーザーには送信しません。
+// 送信は emailSendWorker が行います。
+func (ns *Service) sendEmail(targets set.UUID, subject, body string) {
+	if len(targets) == 0 || !ns.email.Available() {
+		return
+	}
+	settings, err := ns.repo.GetUserSettingsByUserIDs(targets.Array())
+	if err != nil {
+		ns.logger.Error("failed to GetUserSettingsByUserIDs", zap.Error(err))
+		return
+	}
+
+	now := time.Now()
+	for _, us := range settings {
+		if !us.IsEmailEnabled() || !us.EmailImmediate || us.IsDND(now) {
+			continue
+		}
+		mail := &email.Mail{
+			To:             us.EmailAddress,
+			Subject:        subject,
+			Body:           body + ns.emailFooter(us),
+			UnsubscribeURL: ns.unsubscribeURL(us),
+		}
+		ns.enqueueEmail(us.UserID, mail)
+	}
+}
+
+// emailVerificationRequestedHandler 宛先アドレスの確認メールを送信キューに入れます
+func emailVerificationRequestedHandler(ns *Service, ev hub.Message) {
+	if !ns.email.Available() {
+		return
+	}
+	u := fmt.Sprintf("%s/api/v3/public/email/verify?token=%s", ns.origin, url.QueryEscape(ev.Fields["token"].(string)))
+	ns.enqueueEmail(ev.Fields["user_id"].(uuid.UUID), &email.Mail{
+		To:      ev.Fields["address"].(string),
+		Subject: "[traQ] メール通知の宛先の確認",
+		Body:    "traQのメール通知の宛先としてこのアドレスが設定されました。\n以下のURLにアクセスして、宛先を確認してください。\n" + u + "\n\n心当たりがない場合は、このメールを無視してください。\n",
+	})
+}
+
+func (ns *Service) enqueueEmail(userID uuid.UUID, mail *email.Mail) {
+	select {
+	case ns.mailQ <- mail:
+	default:
+		ns.logger.Warn("email queue is full, dropping email", zap.Stringer("userId", userID))
+	}
+}
+
+func newMailQueue() chan *email.Mail {
+	return make(chan *email.Mail, emailQueueSize)
+}
+
+// emailSendWorker 送信キューに入れられた即時通知メールを順に送信します
+//
+// 停止時は送信キューに残っているメールを送信してから終了します。
+func (ns *Service) emailSendWorker() {
+	if !ns.email.Available() {
+		return
+	}
+	for {
+		select {
+		case mail := <-ns.mailQ:
+			ns.sendQueuedEmail(mail)
+		case <-ns.done:
+			for {
+				select {
+				case mail := <-ns.mailQ:
+					ns.sendQueuedEmail(mail)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+func (ns *Service) sendQueuedEmail(mail *email.Mail) {
+	if err := ns.email.Send(mail); err != nil {
+		ns.logger.Error("failed to send email notification", zap.Error(err))
+	}
+}
+
+func (ns *Service) emailDigestWorker() {
+	if !ns.email.Available() {
+		return
+	}
+	ticker := time.NewTicker(emailDigestCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			ns.sendEmailDigests(time.Now())
+		case <-ns.done:
+			return
+		}
+	}
+}
+
+// sendEmailDigests 送信時刻になったユーザーに未読メンションのまとめメールを送信します
+func (ns *Service) sendEmailDigests(now time.Time) {
+	settings, err := ns.repo.GetEmailDigestTargets()
+	if err != nil {
+		ns.logger.Error("failed to GetEmailDigestTargets", zap.Error(err))
+		return
+	}
+	for _, us := range settings {
+		if !us.IsEmailDigestDue(now) {
+			continue
+		}
+		if err := ns.sendEmailDigest(us, now); err != nil {
+			ns.logger.Error("failed to send email digest", zap.Error(err), zap.Stringer("userId", us.UserID))
+			continue
+		}
+		if err := ns.repo.UpdateEmailDigestSentAt(us.UserID, now); err != nil {
+			ns.logger.Error("failed to UpdateEmailDigestSentAt", zap.Error(err), zap.Stringer("userId", us.UserID))
+		}
+	}
+}
+
+// sendEmailDigest 前回送信時以降の未読メンション・DMをまとめたメールを送信します
+func (ns *Service) sendEmailDigest(us *model.UserSettings, now time.Time) error {
+	since := now.Add(-us.EmailDigest.Duration())
+	if us.EmailDigestSentAt.Valid {
+		since = us.EmailDigestSentAt.V
+	}
+
+	unreads, err := ns.repo.GetUnreadMessagesByUserID(us.UserID)
+	if err != nil {
+		return err
+	}
+	groups, err := ns.repo.GetUserBelongingGroupIDs(us.UserID)
+	if err != nil {
+		return err
+	}
+	groupSet := set.UUIDSetFromArray(groups)
+
+	chTree := ns.cm.PublicChannelTree()
+	var (
+		lines []string
+		count int
+	)
+	names := map[uuid.UUID]string{}
+	for _, m := range unreads {
+		if !m.CreatedAt.After(since) {
+			continue
+		}
+		parsed := message.Parse(m.Text)
+		isDM := !chTree.IsChannelPresent(m.ChannelID)
+		if !isDM && !mentions(parsed, us.UserID, groupSet) {
+			continue
+		}
+		count++
+		if len(lines) >= emailDigestMaxMessages {
+			continue
+		}
+
+		name, ok := names[m.UserID]
+		if !ok {
+			if u, err := ns.repo.GetUser(m.UserID, false); err == nil {
+				name = u.GetResponseDisplayName()
+			}
+			names[m.UserID] = name
+		}
+		place := "DM"
+		if !isDM {
+			place = "#" + chTree.GetChannelPath(m.ChannelID)
+		}
+		lines = append(lines, fmt.Sprintf("[%s] %s: %s\n%s/messages/%s", place, name, parsed.NotificationText(), ns.origin, m.ID))
+	}
+	if count == 0 {
+		return nil
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%d件の未読メンション・DMがあります\n\n", count)
+	sb.WriteString(strings.Join(lines, "\n\n"))
+	if count > len(lines) {
+		fmt.Fprintf(&sb, "\n\nほか%d件", count-len(lines))
+	}
+	sb.WriteString("\n")
+	sb.WriteString(ns.emailFooter(us))
+	return ns.email.Send(&email.Mail{
+		To:             us.EmailAddress,
+		Subject:        fmt.Sprintf("[traQ] %d件の未読メンション", count),
+		Body:           sb.String(),
+		UnsubscribeURL: ns.unsubscribeURL(us),
+	})
+}
+
+// mentions メッセージがユーザー、またはユーザーが所属するグループへのメンションを含むかどうか
+func mentions(parsed *message.ParseResult, userID uuid.UUID, groups set.UUID) bool {
+	for _, uid := range parsed.Mentions {
+		if uid == userID {
+			return true
+		}
+	}
+	for _, gid := range parsed.GroupMentions {
+		if groups.Contains(gid) {
+			return true
+		}
+	}
+	return false
+}
+
+func (ns *Service) unsubscribeURL(us *model.UserSettings) string {
+	if len(us.EmailUnsubscribeToken) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s/api/v3/public/email/unsubscribe?token=%s", ns.origin, url.QueryEscape(us.EmailUnsubscribeToken))
+}
+
+func (ns *Service) emailFooter(us *model.UserSettings) string {
+	u := ns.unsubscribeURL(us)
+	if len(u) == 0 {
+		return ""
+	}
+	return "\n\n--\nメール通知の配信を停止するには以下のURLにアクセスしてください\n" + u + "\n"
+}
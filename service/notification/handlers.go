@@ -15,6 +15,7 @@ import (
 	"github.com/traPtitech/traQ/model"
 	"github.com/traPtitech/traQ/repository"
 	"github.com/traPtitech/traQ/service/fcm"
+	"github.com/traPtitech/traQ/service/rbac/role"
 	"github.com/traPtitech/traQ/service/viewer"
 	"github.com/traPtitech/traQ/service/ws"
 	"github.com/traPtitech/traQ/utils/message"
@@ -24,50 +25,59 @@ import (
 type eventHandler func(ns *Service, ev hub.Message)
 
 var handlerMap = map[string]eventHandler{
-	event.MessageCreated:            messageCreatedHandler,
-	event.MessageUpdated:            messageUpdatedHandler,
-	event.MessageDeleted:            messageDeletedHandler,
-	event.MessagePinned:             messagePinnedHandler,
-	event.MessageUnpinned:           messageUnpinnedHandler,
-	event.MessageStamped:            messageStampedHandler,
-	event.MessageUnstamped:          messageUnstampedHandler,
-	event.ChannelCreated:            channelCreatedHandler,
-	event.ChannelUpdated:            channelUpdatedHandler,
-	event.ChannelDeleted:            channelDeletedHandler,
-	event.ChannelStared:             channelStaredHandler,
-	event.ChannelUnstared:           channelUnstaredHandler,
-	event.ChannelRead:               channelReadHandler,
-	event.ChannelViewersChanged:     channelViewersChangedHandler,
-	event.ChannelSubscribersChanged: channelSubscribersChangedHandler,
-	event.UserCreated:               userCreatedHandler,
-	event.UserUpdated:               userUpdatedHandler,
-	event.UserIconUpdated:           userIconUpdatedHandler,
-	event.UserOnline:                userOnlineHandler,
-	event.UserOffline:               userOfflineHandler,
-	event.UserViewStateChanged:      userViewStateChangedHandler,
-	event.UserTagAdded:              userTagUpdatedHandler,
-	event.UserTagRemoved:            userTagUpdatedHandler,
-	event.UserTagUpdated:            userTagUpdatedHandler,
-	event.UserGroupCreated:          userGroupCreatedHandler,
-	event.UserGroupUpdated:          userGroupUpdatedHandler,
-	event.UserGroupDeleted:          userGroupDeletedHandler,
-	event.UserGroupMemberAdded:      userGroupUpdatedHandler,
-	event.UserGroupMemberUpdated:    userGroupUpdatedHandler,
-	event.UserGroupMemberRemoved:    userGroupUpdatedHandler,
-	event.UserGroupAdminAdded:       userGroupUpdatedHandler,
-	event.UserGroupAdminRemoved:     userGroupUpdatedHandler,
-	event.StampCreated:              stampCreatedHandler,
-	event.StampUpdated:              stampUpdatedHandler,
-	event.StampDeleted:              stampDeletedHandler,
-	event.StampPaletteCreated:       stampPaletteCreatedHandler,
-	event.StampPaletteUpdated:       stampPaletteUpdatedHandler,
-	event.StampPaletteDeleted:       stampPaletteDeletedHandler,
-	event.UserWebRTCv3StateChanged:  userWebRTCv3StateChangedHandler,
-	event.ClipFolderCreated:         clipFolderCreatedHandler,
-	event.ClipFolderUpdated:         clipFolderUpdatedHandler,
-	event.ClipFolderDeleted:         clipFolderDeletedHandler,
-	event.ClipFolderMessageDeleted:  clipFolderMessageDeletedHandler,
-	event.ClipFolderMessageAdded:    clipFolderMessageAddedHandler,
+	event.MessageCreated:                 messageCreatedHandler,
+	event.MessageUpdated:                 messageUpdatedHandler,
+	event.MessageComponentsUpdated:       messageUpdatedHandler,
+	event.MessageInteractionReplied:      messageInteractionRepliedHandler,
+	event.MessageDeleted:                 messageDeletedHandler,
+	event.MessagePinned:                  messagePinnedHandler,
+	event.MessageUnpinned:                messageUnpinnedHandler,
+	event.MessageStamped:                 messageStampedHandler,
+	event.MessageUnstamped:               messageUnstampedHandler,
+	event.ThreadFollowed:                 threadFollowedHandler,
+	event.ThreadUnfollowed:               threadUnfollowedHandler,
+	event.MessageReportCreated:           messageReportCreatedHandler,
+	event.MessageReportResolved:          messageReportResolvedHandler,
+	event.SavedSearchMatched:             savedSearchMatchedHandler,
+	event.ChannelCreated:                 channelCreatedHandler,
+	event.ChannelUpdated:                 channelUpdatedHandler,
+	event.ChannelDeleted:                 channelDeletedHandler,
+	event.ChannelStared:                  channelStaredHandler,
+	event.ChannelUnstared:                channelUnstaredHandler,
+	event.ChannelRead:                    channelReadHandler,
+	event.ChannelViewersChanged:          channelViewersChangedHandler,
+	event.ChannelSubscribersChanged:      channelSubscribersChangedHandler,
+	event.UserCreated:                    userCreatedHandler,
+	event.UserUpdated:                    userUpdatedHandler,
+	event.UserIconUpdated:                userIconUpdatedHandler,
+	event.UserOnline:                     userOnlineHandler,
+	event.UserOffline:                    userOfflineHandler,
+	event.UserViewStateChanged:           userViewStateChangedHandler,
+	event.UserTagAdded:                   userTagUpdatedHandler,
+	event.UserTagRemoved:                 userTagUpdatedHandler,
+	event.UserTagUpdated:                 userTagUpdatedHandler,
+	event.UserGroupCreated:               userGroupCreatedHandler,
+	event.UserGroupUpdated:               userGroupUpdatedHandler,
+	event.UserGroupDeleted:               userGroupDeletedHandler,
+	event.UserGroupMemberAdded:           userGroupUpdatedHandler,
+	event.UserGroupMemberUpdated:         userGroupUpdatedHandler,
+	event.UserGroupMemberRemoved:         userGroupUpdatedHandler,
+	event.UserGroupAdminAdded:            userGroupUpdatedHandler,
+	event.UserGroupAdminRemoved:          userGroupUpdatedHandler,
+	event.StampCreated:                   stampCreatedHandler,
+	event.StampUpdated:                   stampUpdatedHandler,
+	event.StampDeleted:                   stampDeletedHandler,
+	event.StampPaletteCreated:            stampPaletteCreatedHandler,
+	event.StampPaletteUpdated:            stampPaletteUpdatedHandler,
+	event.StampPaletteDeleted:            stampPaletteDeletedHandler,
+	event.UserWebRTCv3StateChanged:       userWebRTCv3StateChangedHandler,
+	event.ClipFolderCreated:              clipFolderCreatedHandler,
+	event.ClipFolderUpdated:              clipFolderUpdatedHandler,
+	event.ClipFolderDeleted:              clipFolderDeletedHandler,
+	event.ClipFolderMessageDeleted:       clipFolderMessageDeletedHandler,
+	event.ClipFolderMessageAdded:         clipFolderMessageAddedHandler,
+	event.UserKeywordsUpdated:            keywordsUpdatedHandler,
+	event.UserEmailVerificationRequested: emailVerificationRequestedHandler,
 }
 
 func messageCreatedHandler(ns *Service, ev hub.Message) {
@@ -102,12 +112,17 @@ func messageCreatedHandler(ns *Service, ev hub.Message) {
 		"is_citing": true,
 	}
 
-	viewers := set.UUID{}       // バックグラウンドを含む対象チャンネル閲覧中のユーザー
-	notifiedUsers := set.UUID{} // チャンネル通知購読ユーザー
-	markedUsers := set.UUID{}   // チャンネル未読管理ユーザー
-	noticeable := set.UUID{}    // noticeableな未読追加対象のユーザー
-	citedUsers := set.UUID{}    // メッセージで引用されたメッセージを投稿したユーザー
-	dmMembers := set.UUID{}     // isDMの場合 DMのメンバー
+	viewers := set.UUID{}         // バックグラウンドを含む対象チャンネル閲覧中のユーザー
+	notifiedUsers := set.UUID{}   // チャンネル通知購読ユーザー
+	markedUsers := set.UUID{}     // チャンネル未読管理ユーザー
+	noticeable := set.UUID{}      // noticeableな未読追加対象のユーザー
+	citedUsers := set.UUID{}      // メッセージで引用されたメッセージを投稿したユーザー
+	dmMembers := set.UUID{}       // isDMの場合 DMのメンバー
+	threadFollowers := set.UUID{} // スレッドの返信の場合 スレッドのフォロワー
+	// 通知キーワードに一致したユーザーと一致したキーワード
+	keywordUsers := map[uuid.UUID]string{}
+	// ユーザー・グループメンションで言及されたユーザー
+	mentionedUsers := set.UUID{}
 
 	// メッセージボディ作成
 	if !isDM {
@@ -133,6 +148,15 @@ func messageCreatedHandler(ns *Service, ev hub.Message) {
 
 	// 対象者計算
 	q := repository.UsersQuery{}.Active().NotBot()
+	if m.ParentID.Valid {
+		// スレッドのフォロワー取得
+		users, err := ns.repo.GetUserIDs(q.ThreadFollowerOf(m.ParentID.V))
+		if err != nil {
+			logger.Error("failed to GetUserIDs", zap.Error(err), zap.Stringer("threadId", m.ParentID.V)) // 失敗
+			return
+		}
+		threadFollowers.Add(users...)
+	}
 	switch {
 	case forceNotify: // 強制通知チャンネル
 		users, err := ns.repo.GetUserIDs(q)
@@ -155,21 +179,27 @@ func messageCreatedHandler(ns *Service, ev hub.Message) {
 		dmMembers.Add(users...)
 
 	default: // 通常チャンネルメッセージ
-		// チャンネル通知購読者取得
-		notify, err := ns.repo.GetUserIDs(q.SubscriberAtNotifyLevelOf(chID))
-		if err != nil {
-			logger.Error("failed to GetUserIDs", zap.Error(err), zap.Stringer("channelId", m.ChannelID)) // 失敗
-			return
-		}
-		notifiedUsers.Add(notify...)
+		if m.ParentID.Valid {
+			// スレッドの返信はチャンネル購読者ではなくスレッドのフォロワーに通知
+			notifiedUsers.Plus(threadFollowers)
+			markedUsers.Plus(threadFollowers)
+		} else {
+			// チャンネル通知購読者取得
+			notify, err := ns.repo.GetUserIDs(q.SubscriberAtNotifyLevelOf(chID))
+			if err != nil {
+				logger.Error("failed to GetUserIDs", zap.Error(err), zap.Stringer("channelId", m.ChannelID)) // 失敗
+				return
+			}
+			notifiedUsers.Add(notify...)
 
-		// チャンネル未読管理購読者取得
-		mark, err := ns.repo.GetUserIDs(q.SubscriberAtMarkLevelOf(chID))
-		if err != nil {
-			logger.Error("failed to GetUserIDs", zap.Error(err), zap.Stringer("channelId", m.ChannelID)) // 失敗
-			return
+			// チャンネル未読管理購読者取得
+			mark, err := ns.repo.GetUserIDs(q.SubscriberAtMarkLevelOf(chID))
+			if err != nil {
+				logger.Error("failed to GetUserIDs", zap.Error(err), zap.Stringer("channelId", m.ChannelID)) // 失敗
+				return
+			}
+			markedUsers.Add(mark...)
 		}
-		markedUsers.Add(mark...)
 
 		// ユーザーグループ・メンションユーザー取得
 		for _, uid := range parsed.Mentions {
@@ -186,6 +216,7 @@ func messageCreatedHandler(ns *Service, ev hub.Message) {
 			notifiedUsers.Add(uid)
 			markedUsers.Add(uid)
 			noticeable.Add(uid)
+			mentionedUsers.Add(uid)
 		}
 		for _, gid := range parsed.GroupMentions {
 			gs, err := ns.repo.GetUserIDs(q.GMemberOf(gid))
@@ -196,6 +227,7 @@ func messageCreatedHandler(ns *Service, ev hub.Message) {
 			notifiedUsers.Add(gs...)
 			markedUsers.Add(gs...)
 			noticeable.Add(gs...)
+			mentionedUsers.Add(gs...)
 		}
 		// メッセージを引用されたユーザーへの通知
 		for _, mid := range parsed.Citation {
@@ -230,6 +262,29 @@ func messageCreatedHandler(ns *Service, ev hub.Message) {
 				notifiedUsers.Add(uid)
 			}
 		}
+		// 通知キーワードに一致したユーザーへの通知
+		matched, err := ns.matchKeywords(parsed.PlainText)
+		if err != nil {
+			logger.Error("failed to match notification keywords", zap.Error(err)) // 失敗
+		}
+		for uid, keyword := range matched {
+			if notifiedUsers.Contains(uid) {
+				continue
+			}
+			user, err := ns.repo.GetUser(uid, false)
+			if err != nil {
+				logger.Error("failed to GetUser", zap.Error(err), zap.Stringer("userId", uid)) // 失敗
+				continue
+			}
+			// 凍結ユーザー / Botの除外
+			if !user.IsActive() || user.IsBot() {
+				continue
+			}
+
+			markedUsers.Add(uid)
+			noticeable.Add(uid)
+			keywordUsers[uid] = keyword
+		}
 	}
 
 	// チャンネル閲覧者取得
@@ -238,6 +293,7 @@ func messageCreatedHandler(ns *Service, ev hub.Message) {
 		if swt.State > viewer.StateNone {
 			markedUsers.Remove(uid)   // 閲覧中ユーザーは未読管理から外す
 			notifiedUsers.Remove(uid) // 閲覧中ユーザーは通知から外す
+			delete(keywordUsers, uid) // 閲覧中ユーザーはキーワード通知から外す
 		}
 	}
 
@@ -268,11 +324,42 @@ func messageCreatedHandler(ns *Service, ev hub.Message) {
 	}
 	go ns.ws.WriteMessage(wsEventType, wsPayloadNotCited, targetFuncNotCited)
 	go ns.ws.WriteMessage(wsEventType, wsPayloadCited, targetFuncCited)
+	if m.ParentID.Valid {
+		go ns.ws.WriteMessage("THREAD_MESSAGE_CREATED", map[string]interface{}{
+			"id":        m.ID,
+			"thread_id": m.ParentID.V,
+		}, ws.TargetUserSets(threadFollowers))
+	}
 
 	// FCM送信
 	targets := notifiedUsers.Clone()
 	targets.Remove(m.UserID)
-	ns.fcm.Send(targets, fcmPayload, true)
+	ns.sendFCM(targets, fcmPayload, true)
+
+	// メール送信 (DM・メンションのみ、閲覧中ユーザーを除く)
+	emailTargets := set.UUID{}
+	for uid := range targets {
+		if dmMembers.Contains(uid) || mentionedUsers.Contains(uid) {
+			emailTargets.Add(uid)
+		}
+	}
+	ns.sendEmail(emailTargets, "[traQ] "+fcmPayload.Title, fmt.Sprintf("%s: %s\n\n%s%s\n", mUser.GetResponseDisplayName(), parsed.NotificationText(), ns.origin, fcmPayload.Path))
+
+	// キーワード通知のFCM送信
+	delete(keywordUsers, m.UserID)
+	keywordTargets := map[string]set.UUID{}
+	for uid, keyword := range keywordUsers {
+		if _, ok := keywordTargets[keyword]; !ok {
+			keywordTargets[keyword] = set.UUID{}
+		}
+		keywordTargets[keyword].Add(uid)
+	}
+	for keyword, targets := range keywordTargets {
+		p := *fcmPayload
+		p.Type = "keyword"
+		p.Title = fmt.Sprintf("「%s」 %s", keyword, fcmPayload.Title)
+		ns.sendFCM(targets, &p, true)
+	}
 }
 
 func messageUpdatedHandler(ns *Service, ev hub.Message) {
@@ -363,6 +450,98 @@ func messageUnstampedHandler(ns *Service, ev hub.Message) {
 	)
 }
 
+func threadFollowedHandler(ns *Service, ev hub.Message) {
+	userMulticast(ns, ev.Fields["user_id"].(uuid.UUID),
+		"THREAD_FOLLOWED",
+		map[string]interface{}{
+			"id": ev.Fields["message_id"].(uuid.UUID),
+		},
+	)
+}
+
+func threadUnfollowedHandler(ns *Service, ev hub.Message) {
+	userMulticast(ns, ev.Fields["user_id"].(uuid.UUID),
+		"THREAD_UNFOLLOWED",
+		map[string]interface{}{
+			"id": ev.Fields["message_id"].(uuid.UUID),
+		},
+	)
+}
+
+func messageInteractionRepliedHandler(ns *Service, ev hub.Message) {
+	i := ev.Fields["interaction"].(*model.MessageInteraction)
+	userMulticast(ns, i.UserID,
+		"MESSAGE_INTERACTION_REPLIED",
+		map[string]interface{}{
+			"message_id":     ev.Fields["message_id"].(uuid.UUID),
+			"channel_id":     ev.Fields["channel_id"].(uuid.UUID),
+			"interaction_id": i.ID,
+			"user_id":        ev.Fields["bot_user_id"].(uuid.UUID),
+			"content":        ev.Fields["content"].(string),
+		},
+	)
+}
+
+func messageReportCreatedHandler(ns *Service, ev hub.Message) {
+	r := ev.Fields["report"].(*model.MessageReport)
+	adminMulticast(ns,
+		"MESSAGE_REPORT_CREATED",
+		map[string]interface{}{
+			"id":         r.ID,
+			"message_id": r.MessageID,
+		},
+	)
+}
+
+func messageReportResolvedHandler(ns *Service, ev hub.Message) {
+	r := ev.Fields["report"].(*model.MessageReport)
+	adminMulticast(ns,
+		"MESSAGE_REPORT_RESOLVED",
+		map[string]interface{}{
+			"id":         r.ID,
+			"message_id": r.MessageID,
+			"state":      r.State,
+		},
+	)
+}
+
+func savedSearchMatchedHandler(ns *Service, ev hub.Message) {
+	userID := ev.Fields["user_id"].(uuid.UUID)
+	ss := ev.Fields["saved_search"].(*model.SavedSearch)
+	messageIDs := ev.Fields["message_ids"].([]uuid.UUID)
+	logger := ns.logger.With(zap.Stringer("savedSearchId", ss.ID))
+
+	userMulticast(ns, userID, "SAVED_SEARCH_MATCHED", map[string]interface{}{
+		"id":          ss.ID,
+		"message_ids": messageIDs,
+	})
+
+	// 最新のメッセージの内容を通知する
+	m, err := ns.mm.Get(messageIDs[0])
+	if err != nil {
+		logger.Error("failed to get message", zap.Error(err), zap.Stringer("messageId", messageIDs[0])) // 失敗
+		return
+	}
+	mUser, err := ns.repo.GetUser(m.GetUserID(), false)
+	if err != nil {
+		logger.Error("failed to GetUser", zap.Error(err), zap.Stringer("userId", m.GetUserID())) // 失敗
+		return
+	}
+
+	p := &fcm.Payload{
+		Type:  "saved_search",
+		Title: fmt.Sprintf("「%s」 新着%d件", ss.Name, len(messageIDs)),
+		Icon:  fmt.Sprintf("%s/api/v3/public/icon/%s", ns.origin, strings.ReplaceAll(mUser.GetName(), "#", "%23")),
+		Path:  "/messages/" + m.GetID().String(),
+		Tag:   "s:" + ss.ID.String(),
+	}
+	p.SetBodyWithEllipsis(mUser.GetResponseDisplayName() + ": " + message.Parse(m.GetText()).NotificationText())
+
+	targets := set.UUID{}
+	targets.Add(userID)
+	ns.sendFCM(targets, p, false)
+}
+
 func channelCreatedHandler(ns *Service, ev hub.Message) {
 	channelHandler(ns, ev, "CHANNEL_CREATED")
 }
@@ -707,3 +886,12 @@ func broadcast(ns *Service, wsEventType string, wsPayload interface{}) {
 func userMulticast(ns *Service, userID uuid.UUID, wsEventType string, wsPayload interface{}) {
 	go ns.ws.WriteMessage(wsEventType, wsPayload, ws.TargetUsers(userID))
 }
+
+func adminMulticast(ns *Service, wsEventType string, wsPayload interface{}) {
+	admins, err := ns.repo.GetUserIDs(repository.UsersQuery{}.Active().NotBot().RoleOf(role.Admin))
+	if err != nil {
+		ns.logger.Error("failed to GetUserIDs", zap.Error(err))
+		return
+	}
+	go ns.ws.WriteMessage(wsEventType, wsPayload, ws.TargetUsers(admins...))
+}
@@ -0,0 +1,82 @@
+package notification
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/gofrs/uuid"
+	"github.com/leandro-lugaresi/hub"
+
+	"github.com/traPtitech/traQ/utils/ahocorasick"
+)
+
+// keywordIndex 通知キーワードの検索インデックス
+type keywordIndex struct {
+	matcher  *ahocorasick.Matcher
+	keywords []string
+	// users キーワード(keywordsと同じ順)を登録しているユーザー
+	users [][]uuid.UUID
+}
+
+// keywordIndexCache 通知キーワードの検索インデックスのキャッシュ
+type keywordIndexCache struct {
+	mu    sync.Mutex
+	index *keywordIndex
+}
+
+func (c *keywordIndexCache) invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.index = nil
+}
+
+// getKeywordIndex 通知キーワードの検索インデックスを取得します
+func (ns *Service) getKeywordIndex() (*keywordIndex, error) {
+	ns.kwIdx.mu.Lock()
+	defer ns.kwIdx.mu.Unlock()
+	if ns.kwIdx.index != nil {
+		return ns.kwIdx.index, nil
+	}
+
+	all, err := ns.repo.GetAllNotificationKeywords()
+	if err != nil {
+		return nil, err
+	}
+	index := &keywordIndex{}
+	positions := map[string]int{}
+	for _, k := range all {
+		kw := strings.ToLower(k.Keyword)
+		i, ok := positions[kw]
+		if !ok {
+			i = len(index.keywords)
+			positions[kw] = i
+			index.keywords = append(index.keywords, kw)
+			index.users = append(index.users, nil)
+		}
+		index.users[i] = append(index.users[i], k.UserID)
+	}
+	index.matcher = ahocorasick.New(index.keywords)
+	ns.kwIdx.index = index
+	return index, nil
+}
+
+// matchKeywords textに含まれる通知キーワードを登録しているユーザーと、一致したキーワードを返します
+func (ns *Service) matchKeywords(text string) (map[uuid.UUID]string, error) {
+	index, err := ns.getKeywordIndex()
+	if err != nil {
+		return nil, err
+	}
+	res := map[uuid.UUID]string{}
+	for _, i := range index.matcher.Match(strings.ToLower(text)) {
+		for _, uid := range index.users[i] {
+			if _, ok := res[uid]; !ok {
+				res[uid] = index.keywords[i]
+			}
+		}
+	}
+	return res, nil
+}
+
+func keywordsUpdatedHandler(ns *Service, _ hub.Message) {
+	ns.kwIdx.invalidate()
+}
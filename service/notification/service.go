@@ -1,11 +1,16 @@
 package notification
 
 import (
+	"context"
+	"sync"
+	"time"
+
 	"github.com/leandro-lugaresi/hub"
 	"go.uber.org/zap"
 
 	"github.com/traPtitech/traQ/repository"
 	"github.com/traPtitech/traQ/service/channel"
+	"github.com/traPtitech/traQ/service/email"
 	"github.com/traPtitech/traQ/service/fcm"
 	"github.com/traPtitech/traQ/service/file"
 	"github.com/traPtitech/traQ/service/message"
@@ -23,13 +28,19 @@ type Service struct {
 	hub    *hub.Hub
 	logger *zap.Logger
 	fcm    fcm.Client
+	email  email.Client
 	ws     *ws.Streamer
 	vm     *viewer.Manager
 	origin string
+	dnd    dndHolder
+	kwIdx  keywordIndexCache
+	mailQ  chan *email.Mail // 送信待ちの即時通知メール
+	wg     sync.WaitGroup
+	done   chan struct{}
 }
 
 // NewService 通知サービスを作成して起動します
-func NewService(repo repository.Repository, cm channel.Manager, mm message.Manager, fm file.Manager, hub *hub.Hub, logger *zap.Logger, fcm fcm.Client, ws *ws.Streamer, vm *viewer.Manager, origin variable.ServerOriginString) *Service {
+func NewService(repo repository.Repository, cm channel.Manager, mm message.Manager, fm file.Manager, hub *hub.Hub, logger *zap.Logger, fcm fcm.Client, email email.Client, ws *ws.Streamer, vm *viewer.Manager, origin variable.ServerOriginString) *Service {
 	service := &Service{
 		repo:   repo,
 		cm:     cm,
@@ -38,9 +49,12 @@ func NewService(repo repository.Repository, cm channel.Manager, mm message.Manag
 		hub:    hub,
 		logger: logger.Named("notification"),
 		fcm:    fcm,
+		email:  email,
 		ws:     ws,
 		vm:     vm,
 		origin: string(origin),
+		mailQ:  newMailQueue(),
+		done:   make(chan struct{}),
 	}
 	go func() {
 		topics := make([]string, 0, len(handlerMap))
@@ -54,5 +68,30 @@ func NewService(repo repository.Repository, cm channel.Manager, mm message.Manag
 			}
 		}
 	}()
+	service.wg.Add(1)
+	go func() {
+		defer service.wg.Done()
+		service.dndWorker()
+	}()
+	service.wg.Add(1)
+	go func() {
+		defer service.wg.Done()
+		service.emailDigestWorker()
+	}()
+	service.wg.Add(1)
+	go func() {
+		defer service.wg.Done()
+		service.emailSendWorker()
+	}()
 	return service
 }
+
+// Shutdown 定期処理を停止します
+//
+// おやすみモードが終了しているユーザーの保留中の通知は停止前に送信されます。
+func (ns *Service) Shutdown(_ context.Context) error {
+	close(ns.done)
+	ns.wg.Wait()
+	ns.flushHeldNotifications(time.Now())
+	return nil
+}
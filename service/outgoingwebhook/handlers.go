@@ -0,0 +1,63 @@
+package outgoingwebhook
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/leandro-lugaresi/hub"
+
+	"github.com/traPtitech/traQ/event"
+	"github.com/traPtitech/traQ/model"
+)
+
+// eventHandler hubのイベントから配送対象のチャンネル・イベントタイプ・ペイロードを生成します
+type eventHandler func(s *serviceImpl, datetime time.Time, fields hub.Fields) (channelID uuid.UUID, ev model.OutgoingWebhookEventType, payload interface{}, err error)
+
+var handlerMap = map[string]eventHandler{
+	event.MessageCreated: messageCreatedHandler,
+	event.MessageUpdated: messageUpdatedHandler,
+	event.MessageDeleted: messageDeletedHandler,
+	event.MessageStamped: messageStampedHandler,
+	event.MessagePinned:  messagePinnedHandler,
+}
+
+func messageCreatedHandler(_ *serviceImpl, datetime time.Time, fields hub.Fields) (uuid.UUID, model.OutgoingWebhookEventType, interface{}, error) {
+	m := fields["message"].(*model.Message)
+	return m.ChannelID, model.OutgoingWebhookEventMessageCreated, makeMessagePayload(datetime, m), nil
+}
+
+func messageUpdatedHandler(_ *serviceImpl, datetime time.Time, fields hub.Fields) (uuid.UUID, model.OutgoingWebhookEventType, interface{}, error) {
+	m := fields["message"].(*model.Message)
+	return m.ChannelID, model.OutgoingWebhookEventMessageUpdated, makeMessagePayload(datetime, m), nil
+}
+
+func messageDeletedHandler(_ *serviceImpl, datetime time.Time, fields hub.Fields) (uuid.UUID, model.OutgoingWebhookEventType, interface{}, error) {
+	m := fields["message"].(*model.Message)
+	return m.ChannelID, model.OutgoingWebhookEventMessageDeleted, &messageIDPayload{
+		payloadBase: payloadBase{EventTime: datetime, ChannelID: m.ChannelID},
+		MessageID:   m.ID,
+	}, nil
+}
+
+func messageStampedHandler(s *serviceImpl, datetime time.Time, fields hub.Fields) (uuid.UUID, model.OutgoingWebhookEventType, interface{}, error) {
+	messageID := fields["message_id"].(uuid.UUID)
+	m, err := s.repo.GetMessageByID(messageID)
+	if err != nil {
+		return uuid.Nil, "", nil, err
+	}
+	return m.ChannelID, model.OutgoingWebhookEventMessageStamped, &messageStampedPayload{
+		payloadBase: payloadBase{EventTime: datetime, ChannelID: m.ChannelID},
+		MessageID:   messageID,
+		UserID:      fields["user_id"].(uuid.UUID),
+		StampID:     fields["stamp_id"].(uuid.UUID),
+		Count:       fields["count"].(int),
+	}, nil
+}
+
+func messagePinnedHandler(_ *serviceImpl, datetime time.Time, fields hub.Fields) (uuid.UUID, model.OutgoingWebhookEventType, interface{}, error) {
+	channelID := fields["channel_id"].(uuid.UUID)
+	return channelID, model.OutgoingWebhookEventMessagePinned, &messageIDPayload{
+		payloadBase: payloadBase{EventTime: datetime, ChannelID: channelID},
+		MessageID:   fields["message_id"].(uuid.UUID),
+	}, nil
+}
@@ -0,0 +1,55 @@
+package outgoingwebhook
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/model"
+)
+
+type payloadBase struct {
+	EventTime time.Time `json:"eventTime"`
+	ChannelID uuid.UUID `json:"channelId"`
+}
+
+type messagePayload struct {
+	payloadBase
+	Message payloadMessage `json:"message"`
+}
+
+type payloadMessage struct {
+	ID        uuid.UUID `json:"id"`
+	UserID    uuid.UUID `json:"userId"`
+	ChannelID uuid.UUID `json:"channelId"`
+	Text      string    `json:"text"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+func makeMessagePayload(et time.Time, m *model.Message) *messagePayload {
+	return &messagePayload{
+		payloadBase: payloadBase{EventTime: et, ChannelID: m.ChannelID},
+		Message: payloadMessage{
+			ID:        m.ID,
+			UserID:    m.UserID,
+			ChannelID: m.ChannelID,
+			Text:      m.Text,
+			CreatedAt: m.CreatedAt,
+			UpdatedAt: m.UpdatedAt,
+		},
+	}
+}
+
+type messageIDPayload struct {
+	payloadBase
+	MessageID uuid.UUID `json:"messageId"`
+}
+
+type messageStampedPayload struct {
+	payloadBase
+	MessageID uuid.UUID `json:"messageId"`
+	UserID    uuid.UUID `json:"userId"`
+	StampID   uuid.UUID `json:"stampId"`
+	Count     int       `json:"count"`
+}
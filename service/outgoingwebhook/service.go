@@ -0,0 +1,22 @@
+package outgoingwebhook
+
+import (
+	"context"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/model"
+)
+
+// Service 送信Webhookサービス
+//
+// チャンネルで発生したイベントを、そのチャンネルに設定された送信WebhookのURLに配送します。
+type Service interface {
+	// Redeliver 指定した配送ログと同じ内容を再配送します
+	//
+	// 再配送は1回のみ試行し、新しい配送ログを返します。
+	// 存在しない配送ログを指定した場合、repository.ErrNotFoundを返します。
+	Redeliver(deliveryID uuid.UUID) (*model.OutgoingWebhookDelivery, error)
+	// Shutdown 送信Webhookサービスをシャットダウンします
+	Shutdown(ctx context.Context) error
+}
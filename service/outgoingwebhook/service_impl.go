@@ -0,0 +1,256 @@
+package outgoingwebhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+	jsonIter "github.com/json-iterator/go"
+	"github.com/labstack/echo/v4"
+	"github.com/leandro-lugaresi/hub"
+	"github.com/lthibault/jitterbug/v2"
+	"go.uber.org/zap"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/service/channel"
+	"github.com/traPtitech/traQ/utils/hmac"
+)
+
+const (
+	headerEvent      = "X-TRAQ-Webhook-Event"
+	headerDeliveryID = "X-TRAQ-Webhook-Delivery-ID"
+	headerSignature  = "X-TRAQ-Signature"
+	headerUserAgent  = "User-Agent"
+	ua               = "traQ_Webhook_Processor/1.0"
+
+	resultOK           = "ok"
+	resultNG           = "ng"
+	resultNetworkError = "ne"
+
+	// maxAttempts 1イベントあたりの最大配送試行回数
+	maxAttempts = 5
+	// deliveryPurgeBefore 配送ログの保持期間
+	deliveryPurgeBefore = time.Hour * 24 * 30
+)
+
+type serviceImpl struct {
+	repo   repository.Repository
+	cm     channel.Manager
+	hub    *hub.Hub
+	logger *zap.Logger
+	client http.Client
+	// retryInterval 1回目の再試行までの待機時間 (以降、再試行ごとに2倍)
+	retryInterval time.Duration
+
+	sub       hub.Subscription
+	logPurger *jitterbug.Ticker
+	wg        sync.WaitGroup
+	done      chan struct{}
+}
+
+// NewService 送信Webhookサービスを生成して起動します
+func NewService(repo repository.Repository, cm channel.Manager, hub *hub.Hub, logger *zap.Logger) Service {
+	s := newService(repo, cm, hub, logger, time.Second)
+	s.start()
+	return s
+}
+
+func newService(repo repository.Repository, cm channel.Manager, hub *hub.Hub, logger *zap.Logger, retryInterval time.Duration) *serviceImpl {
+	return &serviceImpl{
+		repo:   repo,
+		cm:     cm,
+		hub:    hub,
+		logger: logger.Named("outgoing_webhook"),
+		client: http.Client{
+			Jar:     nil,
+			Timeout: 5 * time.Second,
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		retryInterval: retryInterval,
+		done:          make(chan struct{}),
+	}
+}
+
+func (s *serviceImpl) start() {
+	topics := make([]string, 0, len(handlerMap))
+	for k := range handlerMap {
+		topics = append(topics, k)
+	}
+	s.sub = s.hub.Subscribe(100, topics...)
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		for ev := range s.sub.Receiver {
+			s.handle(time.Now(), ev)
+		}
+	}()
+
+	// 配送ログの定期的消去
+	s.logPurger = jitterbug.New(time.Hour*24, &jitterbug.Uniform{
+		Min: time.Hour * 23,
+	})
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		for {
+			select {
+			case _, ok := <-s.logPurger.C:
+				if !ok {
+					return
+				}
+				if err := s.repo.PurgeOutgoingWebhookDeliveries(time.Now().Add(-deliveryPurgeBefore)); err != nil {
+					s.logger.Error("an error occurred while purging old outgoing webhook deliveries", zap.Error(err))
+				}
+			case <-s.done:
+				return
+			}
+		}
+	}()
+}
+
+// handle イベントを購読している送信Webhookに配送します
+func (s *serviceImpl) handle(datetime time.Time, ev hub.Message) {
+	h, ok := handlerMap[ev.Topic()]
+	if !ok {
+		return
+	}
+	channelID, evType, payload, err := h(s, datetime, ev.Fields)
+	if err != nil {
+		s.logger.Error("an error occurred while processing event", zap.Error(err), zap.String("event", ev.Topic()))
+		return
+	}
+	// 公開チャンネルのイベントのみ配送
+	if !s.cm.IsPublicChannel(channelID) {
+		return
+	}
+
+	webhooks, err := s.repo.GetOutgoingWebhooksByChannelID(channelID)
+	if err != nil {
+		s.logger.Error("failed to GetOutgoingWebhooksByChannelID", zap.Error(err), zap.Stringer("channelId", channelID))
+		return
+	}
+	var targets []*model.OutgoingWebhook
+	for _, w := range webhooks {
+		if w.Events.Contains(evType) {
+			targets = append(targets, w)
+		}
+	}
+	if len(targets) == 0 {
+		return
+	}
+
+	body, err := jsonIter.ConfigFastest.Marshal(payload)
+	if err != nil {
+		s.logger.Error("failed to encode payload", zap.Error(err))
+		return
+	}
+	for _, w := range targets {
+		w := w
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.deliver(w, evType, body, maxAttempts)
+		}()
+	}
+}
+
+// deliver 送信Webhookにイベントを配送し、配送ログを書き込みます
+//
+// 配送に失敗した場合、最大maxAttempts回まで指数的に間隔を空けて再試行します。
+func (s *serviceImpl) deliver(w *model.OutgoingWebhook, ev model.OutgoingWebhookEventType, body []byte, maxAttempts int) *model.OutgoingWebhookDelivery {
+	d := &model.OutgoingWebhookDelivery{
+		ID:        uuid.Must(uuid.NewV4()),
+		WebhookID: w.ID,
+		Event:     ev,
+		Body:      string(body),
+		DateTime:  time.Now(),
+	}
+
+	interval := s.retryInterval
+retry:
+	for {
+		d.Attempts++
+		retryable := s.send(w, d)
+		if d.Result == resultOK || !retryable || d.Attempts >= maxAttempts {
+			break
+		}
+		select {
+		case <-time.After(interval):
+			interval *= 2
+		case <-s.done:
+			break retry
+		}
+	}
+
+	if err := s.repo.WriteOutgoingWebhookDelivery(d); err != nil {
+		s.logger.Warn("failed to write delivery log", zap.Error(err), zap.Any("delivery", d))
+	}
+	return d
+}
+
+// send 送信Webhookにリクエストを1回送信し、結果をdに記録します
+//
+// 再試行すべき失敗の場合はtrueを返します。
+func (s *serviceImpl) send(w *model.OutgoingWebhook, d *model.OutgoingWebhookDelivery) (retryable bool) {
+	req, err := http.NewRequest(http.MethodPost, w.URL, bytes.NewReader([]byte(d.Body)))
+	if err != nil {
+		d.Result = resultNG
+		d.Error = err.Error()
+		d.Code = -1
+		return false
+	}
+	req.Header.Set(headerUserAgent, ua)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
+	req.Header.Set(headerEvent, string(d.Event))
+	req.Header.Set(headerDeliveryID, d.ID.String())
+	if len(w.Secret) > 0 {
+		req.Header.Set(headerSignature, hex.EncodeToString(hmac.SHA256([]byte(d.Body), w.Secret)))
+	}
+
+	start := time.Now()
+	res, err := s.client.Do(req)
+	d.Latency = time.Since(start).Nanoseconds()
+	if err != nil {
+		d.Result = resultNetworkError
+		d.Error = err.Error()
+		d.Code = -1
+		return true
+	}
+	_ = res.Body.Close()
+
+	d.Code = res.StatusCode
+	d.Error = ""
+	if 200 <= res.StatusCode && res.StatusCode < 300 {
+		d.Result = resultOK
+		return false
+	}
+	d.Result = resultNG
+	return res.StatusCode >= 500 || res.StatusCode == http.StatusTooManyRequests
+}
+
+func (s *serviceImpl) Redeliver(deliveryID uuid.UUID) (*model.OutgoingWebhookDelivery, error) {
+	d, err := s.repo.GetOutgoingWebhookDelivery(deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	w, err := s.repo.GetOutgoingWebhook(d.WebhookID)
+	if err != nil {
+		return nil, err
+	}
+	return s.deliver(w, d.Event, []byte(d.Body), 1), nil
+}
+
+func (s *serviceImpl) Shutdown(_ context.Context) error {
+	s.hub.Unsubscribe(s.sub)
+	s.logPurger.Stop()
+	close(s.done)
+	s.wg.Wait()
+	return nil
+}
@@ -0,0 +1,237 @@
+package outgoingwebhook
+
+import (
+	"context"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang/mock/gomock"
+	"github.com/leandro-lugaresi/hub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/traPtitech/traQ/event"
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/service/channel/mock_channel"
+	"github.com/traPtitech/traQ/testUtils"
+	"github.com/traPtitech/traQ/utils/hmac"
+)
+
+// webhookRepository テスト用のインメモリ送信Webhookリポジトリ
+type webhookRepository struct {
+	repository.OutgoingWebhookRepository
+	mu         sync.Mutex
+	webhooks   map[uuid.UUID]*model.OutgoingWebhook
+	deliveries map[uuid.UUID]*model.OutgoingWebhookDelivery
+	written    chan *model.OutgoingWebhookDelivery
+}
+
+func newWebhookRepository(ws ...*model.OutgoingWebhook) *webhookRepository {
+	r := &webhookRepository{
+		webhooks:   map[uuid.UUID]*model.OutgoingWebhook{},
+		deliveries: map[uuid.UUID]*model.OutgoingWebhookDelivery{},
+		written:    make(chan *model.OutgoingWebhookDelivery, 10),
+	}
+	for _, w := range ws {
+		r.webhooks[w.ID] = w
+	}
+	return r
+}
+
+func (r *webhookRepository) GetOutgoingWebhook(id uuid.UUID) (*model.OutgoingWebhook, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	w, ok := r.webhooks[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return w, nil
+}
+
+func (r *webhookRepository) GetOutgoingWebhooksByChannelID(channelID uuid.UUID) ([]*model.OutgoingWebhook, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	res := make([]*model.OutgoingWebhook, 0)
+	for _, w := range r.webhooks {
+		if w.ChannelID == channelID {
+			res = append(res, w)
+		}
+	}
+	return res, nil
+}
+
+func (r *webhookRepository) WriteOutgoingWebhookDelivery(d *model.OutgoingWebhookDelivery) error {
+	r.mu.Lock()
+	r.deliveries[d.ID] = d
+	r.mu.Unlock()
+	r.written <- d
+	return nil
+}
+
+func (r *webhookRepository) GetOutgoingWebhookDelivery(id uuid.UUID) (*model.OutgoingWebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	d, ok := r.deliveries[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return d, nil
+}
+
+func (r *webhookRepository) waitDelivery(t *testing.T) *model.OutgoingWebhookDelivery {
+	t.Helper()
+	select {
+	case d := <-r.written:
+		return d
+	case <-time.After(3 * time.Second):
+		t.Fatal("delivery timeout")
+		return nil
+	}
+}
+
+func setup(t *testing.T, handler http.HandlerFunc, events model.OutgoingWebhookEventTypes) (*serviceImpl, *webhookRepository, *model.OutgoingWebhook, *hub.Hub) {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	w := &model.OutgoingWebhook{
+		ID:        uuid.Must(uuid.NewV4()),
+		ChannelID: uuid.Must(uuid.NewV4()),
+		URL:       srv.URL,
+		Secret:    "secret",
+		Events:    events,
+	}
+	wr := newWebhookRepository(w)
+	repo := &testUtils.EmptyTestRepository{OutgoingWebhookRepository: wr}
+
+	ctrl := gomock.NewController(t)
+	cm := mock_channel.NewMockManager(ctrl)
+	cm.EXPECT().IsPublicChannel(w.ChannelID).Return(true).AnyTimes()
+
+	h := hub.New()
+	s := newService(repo, cm, h, zap.NewNop(), time.Millisecond)
+	s.start()
+	t.Cleanup(func() { _ = s.Shutdown(context.Background()) })
+	return s, wr, w, h
+}
+
+func TestService_Deliver(t *testing.T) {
+	t.Parallel()
+
+	type request struct {
+		header http.Header
+		body   []byte
+	}
+	received := make(chan request, 1)
+	_, wr, w, h := setup(t, func(rw http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received <- request{header: r.Header, body: body}
+		rw.WriteHeader(http.StatusNoContent)
+	}, model.OutgoingWebhookEventTypes{model.OutgoingWebhookEventMessageCreated})
+
+	m := &model.Message{ID: uuid.Must(uuid.NewV4()), ChannelID: w.ChannelID, UserID: uuid.Must(uuid.NewV4()), Text: "hello"}
+	// 購読していないイベントは配送されない
+	h.Publish(hub.Message{Name: event.MessageUpdated, Fields: hub.Fields{"message_id": m.ID, "message": m}})
+	h.Publish(hub.Message{Name: event.MessageCreated, Fields: hub.Fields{"message_id": m.ID, "message": m}})
+
+	d := wr.waitDelivery(t)
+	assert.Equal(t, w.ID, d.WebhookID)
+	assert.Equal(t, model.OutgoingWebhookEventMessageCreated, d.Event)
+	assert.Equal(t, resultOK, d.Result)
+	assert.Equal(t, http.StatusNoContent, d.Code)
+	assert.Equal(t, 1, d.Attempts)
+
+	req := <-received
+	assert.Equal(t, "MESSAGE_CREATED", req.header.Get(headerEvent))
+	assert.Equal(t, d.ID.String(), req.header.Get(headerDeliveryID))
+	assert.Equal(t, hex.EncodeToString(hmac.SHA256(req.body, "secret")), req.header.Get(headerSignature))
+	assert.Contains(t, string(req.body), `"text":"hello"`)
+}
+
+func TestService_DeliverRetry(t *testing.T) {
+	t.Parallel()
+
+	t.Run("retry until success", func(t *testing.T) {
+		t.Parallel()
+		var (
+			mu    sync.Mutex
+			count int
+		)
+		_, wr, w, h := setup(t, func(rw http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+			count++
+			if count < 3 {
+				rw.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			rw.WriteHeader(http.StatusOK)
+		}, model.OutgoingWebhookEventTypes{model.OutgoingWebhookEventMessagePinned})
+
+		h.Publish(hub.Message{Name: event.MessagePinned, Fields: hub.Fields{"message_id": uuid.Must(uuid.NewV4()), "channel_id": w.ChannelID}})
+		d := wr.waitDelivery(t)
+		assert.Equal(t, resultOK, d.Result)
+		assert.Equal(t, 3, d.Attempts)
+	})
+
+	t.Run("no retry on client error", func(t *testing.T) {
+		t.Parallel()
+		_, wr, w, h := setup(t, func(rw http.ResponseWriter, r *http.Request) {
+			rw.WriteHeader(http.StatusBadRequest)
+		}, model.OutgoingWebhookEventTypes{model.OutgoingWebhookEventMessagePinned})
+
+		h.Publish(hub.Message{Name: event.MessagePinned, Fields: hub.Fields{"message_id": uuid.Must(uuid.NewV4()), "channel_id": w.ChannelID}})
+		d := wr.waitDelivery(t)
+		assert.Equal(t, resultNG, d.Result)
+		assert.Equal(t, http.StatusBadRequest, d.Code)
+		assert.Equal(t, 1, d.Attempts)
+	})
+
+	t.Run("give up", func(t *testing.T) {
+		t.Parallel()
+		_, wr, w, h := setup(t, func(rw http.ResponseWriter, r *http.Request) {
+			rw.WriteHeader(http.StatusInternalServerError)
+		}, model.OutgoingWebhookEventTypes{model.OutgoingWebhookEventMessagePinned})
+
+		h.Publish(hub.Message{Name: event.MessagePinned, Fields: hub.Fields{"message_id": uuid.Must(uuid.NewV4()), "channel_id": w.ChannelID}})
+		d := wr.waitDelivery(t)
+		assert.Equal(t, resultNG, d.Result)
+		assert.Equal(t, maxAttempts, d.Attempts)
+	})
+}
+
+func TestService_Redeliver(t *testing.T) {
+	t.Parallel()
+
+	s, wr, w, _ := setup(t, func(rw http.ResponseWriter, r *http.Request) {
+		rw.WriteHeader(http.StatusNoContent)
+	}, model.OutgoingWebhookEventTypes{})
+
+	_, err := s.Redeliver(uuid.Must(uuid.NewV4()))
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+
+	orig := &model.OutgoingWebhookDelivery{
+		ID:        uuid.Must(uuid.NewV4()),
+		WebhookID: w.ID,
+		Event:     model.OutgoingWebhookEventMessageCreated,
+		Body:      `{"test":true}`,
+		Result:    resultNG,
+	}
+	require.NoError(t, wr.WriteOutgoingWebhookDelivery(orig))
+	wr.waitDelivery(t)
+
+	d, err := s.Redeliver(orig.ID)
+	require.NoError(t, err)
+	assert.NotEqual(t, orig.ID, d.ID)
+	assert.Equal(t, orig.Body, d.Body)
+	assert.Equal(t, resultOK, d.Result)
+	assert.Equal(t, 1, d.Attempts)
+}
@@ -22,6 +22,8 @@ const (
 	DeleteBot = Permission("delete_bot")
 	// AccessOthersBot 他人のBotのアクセス権限
 	AccessOthersBot = Permission("access_others_bot")
+	// EditBotSlashCommand Botスラッシュコマンド編集権限
+	EditBotSlashCommand = Permission("edit_bot_slash_command")
 
 	// BotActionJoinChannel BOTアクション実行権限：チャンネル参加
 	BotActionJoinChannel = Permission("bot_action_join_channel")
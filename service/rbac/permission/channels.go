@@ -17,4 +17,6 @@ const (
 	GetChannelStar = Permission("get_channel_star")
 	// EditChannelStar チャンネルスター編集権限
 	EditChannelStar = Permission("edit_channel_star")
+	// ExportChannel チャンネルエクスポート権限
+	ExportChannel = Permission("export_channel")
 )
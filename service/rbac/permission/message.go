@@ -7,12 +7,18 @@ const (
 	PostMessage = Permission("post_message")
 	// EditMessage メッセージ編集権限
 	EditMessage = Permission("edit_message")
+	// GetMessageRevisions メッセージ編集履歴取得権限
+	GetMessageRevisions = Permission("get_message_revisions")
 	// DeleteMessage メッセージ削除権限
 	DeleteMessage = Permission("delete_message")
 	// ReportMessage メッセージ通報権限
 	ReportMessage = Permission("report_message")
+	// InteractMessage メッセージコンポーネント操作権限
+	InteractMessage = Permission("interact_message")
 	// GetMessageReports メッセージ通報取得権限
 	GetMessageReports = Permission("get_message_reports")
+	// ResolveMessageReports メッセージ通報対応権限
+	ResolveMessageReports = Permission("resolve_message_reports")
 	// CreateMessagePin ピン留め作成権限
 	CreateMessagePin = Permission("create_message_pin")
 	// DeleteMessagePin ピン留め削除権限
@@ -54,6 +54,7 @@ var List = []Permission{
 	GetBot,
 	CreateBot,
 	EditBot,
+	EditBotSlashCommand,
 	DeleteBot,
 	AccessOthersBot,
 
@@ -66,6 +67,7 @@ var List = []Permission{
 	DeleteChannel,
 	ChangeParentChannel,
 	EditChannelTopic,
+	ExportChannel,
 
 	GetMyTokens,
 	RevokeMyToken,
@@ -82,9 +84,12 @@ var List = []Permission{
 	GetMessage,
 	PostMessage,
 	EditMessage,
+	GetMessageRevisions,
 	DeleteMessage,
 	ReportMessage,
+	InteractMessage,
 	GetMessageReports,
+	ResolveMessageReports,
 
 	GetChannelSubscription,
 	EditChannelSubscription,
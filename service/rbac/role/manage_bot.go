@@ -18,6 +18,7 @@ var manageBotPerms = []permission.Permission{
 	permission.GetBot,
 	permission.CreateBot,
 	permission.EditBot,
+	permission.EditBotSlashCommand,
 	permission.DeleteBot,
 	permission.BotActionJoinChannel,
 	permission.BotActionLeaveChannel,
@@ -26,6 +26,7 @@ var userPerms = []permission.Permission{
 	permission.DeleteWebhook,
 	permission.CreateBot,
 	permission.EditBot,
+	permission.EditBotSlashCommand,
 	permission.DeleteBot,
 	permission.BotActionJoinChannel,
 	permission.BotActionLeaveChannel,
@@ -14,6 +14,7 @@ var writePerms = []permission.Permission{
 	permission.EditMessage,
 	permission.DeleteMessage,
 	permission.ReportMessage,
+	permission.InteractMessage,
 	permission.CreateMessagePin,
 	permission.DeleteMessagePin,
 	permission.EditChannelSubscription,
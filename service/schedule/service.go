@@ -0,0 +1,7 @@
+package schedule
+
+// Service 予約投稿サービス
+type Service interface {
+	// Shutdown 予約投稿サービスを停止します
+	Shutdown() error
+}
@@ -0,0 +1,161 @@
+package schedule
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/service/channel"
+	"github.com/traPtitech/traQ/service/message"
+)
+
+const (
+	pollInterval = 10 * time.Second
+	batchSize    = 100
+	// claimTimeout 投稿処理中として確保してから、処理が中断されたとみなして再度投稿を試みるまでの時間
+	claimTimeout = 5 * time.Minute
+)
+
+type ServiceImpl struct {
+	repo   repository.Repository
+	cm     channel.Manager
+	mm     message.Manager
+	logger *zap.Logger
+
+	serviceDone chan struct{}
+	workerDone  chan struct{}
+}
+
+func NewService(repo repository.Repository, cm channel.Manager, mm message.Manager, logger *zap.Logger) (Service, error) {
+	s := &ServiceImpl{
+		repo:   repo,
+		cm:     cm,
+		mm:     mm,
+		logger: logger.Named("schedule"),
+
+		serviceDone: make(chan struct{}),
+		workerDone:  make(chan struct{}),
+	}
+	s.start()
+	return s, nil
+}
+
+func (s *ServiceImpl) start() {
+	go func() {
+		defer close(s.workerDone)
+		ticker := time.NewTicker(pollInterval)
+		defer ticker.Stop()
+
+		// 停止中に投稿予定日時を過ぎたメッセージを起動時に投稿
+		s.postDueMessages(time.Now())
+		for {
+			select {
+			case now := <-ticker.C:
+				s.postDueMessages(now)
+			case <-s.serviceDone:
+				return
+			}
+		}
+	}()
+
+	s.logger.Info("Schedule service started")
+}
+
+func (s *ServiceImpl) Shutdown() error {
+	close(s.serviceDone)
+	<-s.workerDone
+	return nil
+}
+
+// postDueMessages 投稿予定日時がnow以前の予約投稿メッセージを全て投稿します
+func (s *ServiceImpl) postDueMessages(now time.Time) {
+	for {
+		sms, err := s.repo.GetDueScheduledMessages(now, batchSize)
+		if err != nil {
+			s.logger.Error("failed to GetDueScheduledMessages", zap.Error(err))
+			return
+		}
+		progressed := false
+		for _, sm := range sms {
+			if s.post(now, sm) {
+				progressed = true
+			}
+		}
+		// 1件も確保できなかった場合は同じメッセージが返り続けるので、次回に回す
+		if len(sms) < batchSize || !progressed {
+			return
+		}
+	}
+}
+
+// post 予約投稿メッセージを投稿します
+//
+// 投稿処理中として確保できた(または他で確保済みだった)場合、trueを返します。
+// 投稿に失敗した場合は削除せず、確保期限が過ぎた後に再度投稿を試みます。
+func (s *ServiceImpl) post(now time.Time, sm *model.ScheduledMessage) bool {
+	logger := s.logger.With(zap.Stringer("scheduledMessageId", sm.ID))
+
+	// 先に確保することで、複数回投稿されることを防ぐ
+	if err := s.repo.ClaimScheduledMessage(sm.ID, now, now.Add(claimTimeout)); err != nil {
+		if err == repository.ErrNotFound {
+			return true
+		}
+		logger.Error("failed to ClaimScheduledMessage", zap.Error(err))
+		return false
+	}
+
+	// 投稿時点でチャンネルにアクセスできるかどうか確認
+	ok, err := s.cm.IsChannelAccessibleToUser(sm.UserID, sm.ChannelID)
+	if err != nil {
+		logger.Error("failed to IsChannelAccessibleToUser", zap.Error(err))
+		return true
+	}
+	if !ok {
+		logger.Info("scheduled message was discarded because the channel is not accessible", zap.Stringer("userId", sm.UserID), zap.Stringer("channelId", sm.ChannelID))
+		s.delete(logger, sm)
+		return true
+	}
+
+	if s.cm.IsPublicChannel(sm.ChannelID) {
+		_, err = s.mm.Create(sm.ChannelID, sm.UserID, sm.Text)
+	} else {
+		err = s.postDM(sm)
+	}
+	if err != nil {
+		switch err {
+		case message.ErrChannelArchived:
+			logger.Info("scheduled message was discarded because the channel has been archived", zap.Stringer("channelId", sm.ChannelID))
+		default:
+			logger.Error("failed to post scheduled message", zap.Error(err))
+			return true
+		}
+	}
+	s.delete(logger, sm)
+	return true
+}
+
+// delete 投稿済み・破棄した予約投稿メッセージを削除します
+func (s *ServiceImpl) delete(logger *zap.Logger, sm *model.ScheduledMessage) {
+	if err := s.repo.DeleteScheduledMessage(sm.ID); err != nil && err != repository.ErrNotFound {
+		// 確保期限が過ぎると再度投稿される
+		logger.Error("failed to DeleteScheduledMessage", zap.Error(err))
+	}
+}
+
+func (s *ServiceImpl) postDM(sm *model.ScheduledMessage) error {
+	members, err := s.cm.GetDMChannelMembers(sm.ChannelID)
+	if err != nil {
+		return err
+	}
+	// 自分自身とのDMの場合はメンバーが1人
+	to := sm.UserID
+	for _, member := range members {
+		if member != sm.UserID {
+			to = member
+		}
+	}
+	_, err = s.mm.CreateDM(sm.UserID, to, sm.Text)
+	return err
+}
@@ -0,0 +1,136 @@
+package schedule
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang/mock/gomock"
+	"go.uber.org/zap"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/repository/mock_repository"
+	"github.com/traPtitech/traQ/service/channel/mock_channel"
+	"github.com/traPtitech/traQ/service/message/mock_message"
+	"github.com/traPtitech/traQ/testUtils"
+)
+
+type Repo struct {
+	*mock_repository.MockScheduledMessageRepository
+	testUtils.EmptyTestRepository
+}
+
+func setup(ctrl *gomock.Controller) (*ServiceImpl, *Repo, *mock_channel.MockManager, *mock_message.MockManager) {
+	repo := &Repo{MockScheduledMessageRepository: mock_repository.NewMockScheduledMessageRepository(ctrl)}
+	cm := mock_channel.NewMockManager(ctrl)
+	mm := mock_message.NewMockManager(ctrl)
+	s := &ServiceImpl{
+		repo:   repo,
+		cm:     cm,
+		mm:     mm,
+		logger: zap.NewNop(),
+	}
+	return s, repo, cm, mm
+}
+
+func TestServiceImpl_postDueMessages(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	uid := uuid.NewV3(uuid.Nil, "u1")
+	cid := uuid.NewV3(uuid.Nil, "c1")
+
+	t.Run("public channel", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		s, repo, cm, mm := setup(ctrl)
+
+		sm := &model.ScheduledMessage{ID: uuid.NewV3(uuid.Nil, "s1"), UserID: uid, ChannelID: cid, Text: "a"}
+		repo.MockScheduledMessageRepository.EXPECT().GetDueScheduledMessages(now, batchSize).Return([]*model.ScheduledMessage{sm}, nil).Times(1)
+		repo.MockScheduledMessageRepository.EXPECT().ClaimScheduledMessage(sm.ID, now, now.Add(claimTimeout)).Return(nil).Times(1)
+		cm.EXPECT().IsChannelAccessibleToUser(uid, cid).Return(true, nil).Times(1)
+		cm.EXPECT().IsPublicChannel(cid).Return(true).Times(1)
+		created := mm.EXPECT().Create(cid, uid, "a").Return(nil, nil).Times(1)
+		repo.MockScheduledMessageRepository.EXPECT().DeleteScheduledMessage(sm.ID).Return(nil).After(created).Times(1)
+
+		s.postDueMessages(now)
+	})
+
+	t.Run("dm channel", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		s, repo, cm, mm := setup(ctrl)
+
+		to := uuid.NewV3(uuid.Nil, "u2")
+		sm := &model.ScheduledMessage{ID: uuid.NewV3(uuid.Nil, "s1"), UserID: uid, ChannelID: cid, Text: "a"}
+		repo.MockScheduledMessageRepository.EXPECT().GetDueScheduledMessages(now, batchSize).Return([]*model.ScheduledMessage{sm}, nil).Times(1)
+		repo.MockScheduledMessageRepository.EXPECT().ClaimScheduledMessage(sm.ID, now, now.Add(claimTimeout)).Return(nil).Times(1)
+		cm.EXPECT().IsChannelAccessibleToUser(uid, cid).Return(true, nil).Times(1)
+		cm.EXPECT().IsPublicChannel(cid).Return(false).Times(1)
+		cm.EXPECT().GetDMChannelMembers(cid).Return([]uuid.UUID{uid, to}, nil).Times(1)
+		created := mm.EXPECT().CreateDM(uid, to, "a").Return(nil, nil).Times(1)
+		repo.MockScheduledMessageRepository.EXPECT().DeleteScheduledMessage(sm.ID).Return(nil).After(created).Times(1)
+
+		s.postDueMessages(now)
+	})
+
+	t.Run("not accessible", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		s, repo, cm, _ := setup(ctrl)
+
+		sm := &model.ScheduledMessage{ID: uuid.NewV3(uuid.Nil, "s1"), UserID: uid, ChannelID: cid, Text: "a"}
+		repo.MockScheduledMessageRepository.EXPECT().GetDueScheduledMessages(now, batchSize).Return([]*model.ScheduledMessage{sm}, nil).Times(1)
+		repo.MockScheduledMessageRepository.EXPECT().ClaimScheduledMessage(sm.ID, now, now.Add(claimTimeout)).Return(nil).Times(1)
+		cm.EXPECT().IsChannelAccessibleToUser(uid, cid).Return(false, nil).Times(1)
+		repo.MockScheduledMessageRepository.EXPECT().DeleteScheduledMessage(sm.ID).Return(nil).Times(1)
+
+		s.postDueMessages(now)
+	})
+
+	t.Run("post failed", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		s, repo, cm, mm := setup(ctrl)
+
+		// 削除されず、確保期限が過ぎた後に再度投稿される
+		sm := &model.ScheduledMessage{ID: uuid.NewV3(uuid.Nil, "s1"), UserID: uid, ChannelID: cid, Text: "a"}
+		repo.MockScheduledMessageRepository.EXPECT().GetDueScheduledMessages(now, batchSize).Return([]*model.ScheduledMessage{sm}, nil).Times(1)
+		repo.MockScheduledMessageRepository.EXPECT().ClaimScheduledMessage(sm.ID, now, now.Add(claimTimeout)).Return(nil).Times(1)
+		cm.EXPECT().IsChannelAccessibleToUser(uid, cid).Return(true, nil).Times(1)
+		cm.EXPECT().IsPublicChannel(cid).Return(true).Times(1)
+		mm.EXPECT().Create(cid, uid, "a").Return(nil, errors.New("mock error")).Times(1)
+
+		s.postDueMessages(now)
+	})
+
+	t.Run("already deleted", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		s, repo, _, _ := setup(ctrl)
+
+		sm := &model.ScheduledMessage{ID: uuid.NewV3(uuid.Nil, "s1"), UserID: uid, ChannelID: cid, Text: "a"}
+		repo.MockScheduledMessageRepository.EXPECT().GetDueScheduledMessages(now, batchSize).Return([]*model.ScheduledMessage{sm}, nil).Times(1)
+		repo.MockScheduledMessageRepository.EXPECT().ClaimScheduledMessage(sm.ID, now, now.Add(claimTimeout)).Return(repository.ErrNotFound).Times(1)
+
+		s.postDueMessages(now)
+	})
+
+	t.Run("no progress", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		s, repo, _, _ := setup(ctrl)
+
+		// 1件も確保できない場合は同じバッチを取得し続けない
+		sms := make([]*model.ScheduledMessage, batchSize)
+		for i := range sms {
+			sms[i] = &model.ScheduledMessage{ID: uuid.Must(uuid.NewV4()), UserID: uid, ChannelID: cid, Text: "a"}
+		}
+		repo.MockScheduledMessageRepository.EXPECT().GetDueScheduledMessages(now, batchSize).Return(sms, nil).Times(1)
+		repo.MockScheduledMessageRepository.EXPECT().ClaimScheduledMessage(gomock.Any(), now, now.Add(claimTimeout)).Return(errors.New("mock error")).Times(batchSize)
+
+		s.postDueMessages(now)
+	})
+}
@@ -0,0 +1,184 @@
+package search
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gofrs/uuid"
+	"github.com/leandro-lugaresi/hub"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/service/channel"
+	"github.com/traPtitech/traQ/service/message"
+)
+
+// dbSortColumns ソートキーとカラムの対応
+var dbSortColumns = map[string]string{
+	createdAtSortKey: "created_at",
+	updatedAtSortKey: "updated_at",
+}
+
+// dbEngine search.Engine 実装
+//
+// MariaDBのFULLTEXTインデックスを用いるため、Elasticsearchが無い環境でも利用できます。
+type dbEngine struct {
+	db    *gorm.DB
+	mm    message.Manager
+	cm    channel.Manager
+	repo  repository.Repository
+	l     *zap.Logger
+	saved *savedSearchEvaluator
+	done  chan<- struct{}
+}
+
+// NewDBEngine MariaDBを用いた検索エンジンを生成します
+func NewDBEngine(db *gorm.DB, mm message.Manager, cm channel.Manager, repo repository.Repository, hub *hub.Hub, logger *zap.Logger) (Engine, error) {
+	if !db.Migrator().HasTable(&model.MessageSearchIndex{}) {
+		return nil, fmt.Errorf("failed to init search engine: table %s does not exist", (&model.MessageSearchIndex{}).TableName())
+	}
+
+	done := make(chan struct{})
+	engine := &dbEngine{
+		db:   db,
+		mm:   mm,
+		cm:   cm,
+		repo: repo,
+		l:    logger.Named("search"),
+		done: done,
+	}
+	engine.saved = &savedSearchEvaluator{
+		engine: engine,
+		cm:     cm,
+		repo:   repo,
+		hub:    hub,
+		l:      engine.l,
+	}
+
+	go engine.syncLoop(done)
+
+	return engine, nil
+}
+
+func (e *dbEngine) Do(q *Query) (Result, error) {
+	e.l.Debug("do search", zap.Reflect("q", q))
+
+	musts, mustNots := []string(nil), []string(nil)
+	if q.Word.Valid {
+		musts, mustNots = parseWord(q.Word.V)
+	}
+	if q.To.Valid {
+		musts = append(musts, uuidToken(toTokenPrefix, q.To.V))
+	}
+	if q.Citation.Valid {
+		musts = append(musts, uuidToken(citationTokenPrefix, q.Citation.V))
+	}
+
+	filter := func(tx *gorm.DB) *gorm.DB {
+		if len(musts) > 0 {
+			tx = tx.Where("MATCH(tokens) AGAINST(? IN BOOLEAN MODE)", "+"+strings.Join(musts, " +"))
+		}
+		if len(mustNots) > 0 {
+			tx = tx.Where("NOT MATCH(tokens) AGAINST(? IN BOOLEAN MODE)", strings.Join(mustNots, " "))
+		}
+
+		if q.After.Valid {
+			tx = tx.Where("created_at > ?", q.After.V)
+		}
+		if q.Before.Valid {
+			tx = tx.Where("created_at < ?", q.Before.V)
+		}
+
+		// チャンネル指定があるときはそのチャンネルを検索
+		// そうでないときはPublicチャンネルを検索
+		if q.In.Valid {
+			tx = tx.Where("channel_id = ?", q.In.V)
+		} else {
+			tx = tx.Where("is_public = ?", true)
+		}
+
+		if q.From.Valid {
+			tx = tx.Where("user_id = ?", q.From.V)
+		}
+		if q.Bot.Valid {
+			tx = tx.Where("bot = ?", q.Bot.V)
+		}
+		if q.HasURL.Valid {
+			tx = tx.Where("has_url = ?", q.HasURL.V)
+		}
+		if q.HasAttachments.Valid {
+			tx = tx.Where("has_attachments = ?", q.HasAttachments.V)
+		}
+		if q.HasImage.Valid {
+			tx = tx.Where("has_image = ?", q.HasImage.V)
+		}
+		if q.HasVideo.Valid {
+			tx = tx.Where("has_video = ?", q.HasVideo.V)
+		}
+		if q.HasAudio.Valid {
+			tx = tx.Where("has_audio = ?", q.HasAudio.V)
+		}
+		if len(q.messageIDs) > 0 {
+			tx = tx.Where("message_id IN ?", q.messageIDs)
+		}
+		return tx
+	}
+
+	sort := q.GetSortKey()
+	column := dbSortColumns[sort.Key]
+	order := column + ", message_id"
+	if sort.Desc {
+		order = column + " DESC, message_id DESC"
+	}
+
+	// カーソルより後のもののみ 総ヒット件数には影響させない
+	after := func(tx *gorm.DB) *gorm.DB {
+		c, ok := q.GetCursor()
+		if !ok {
+			return tx
+		}
+		op := ">"
+		if sort.Desc {
+			op = "<"
+		}
+		return tx.Where(fmt.Sprintf("(%[1]s %[2]s ? OR (%[1]s = ? AND message_id %[2]s ?))", column, op), c.Value, c.Value, c.ID)
+	}
+
+	limit, offset := 20, 0
+	if q.Limit.Valid {
+		limit = q.Limit.V
+	}
+	if q.Offset.Valid {
+		offset = q.Offset.V
+	}
+
+	var total int64
+	if err := e.db.Model(&model.MessageSearchIndex{}).Scopes(filter).Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	var ids []uuid.UUID
+	if err := e.db.Model(&model.MessageSearchIndex{}).
+		Scopes(filter, after).
+		Order(order).
+		Limit(limit).
+		Offset(offset).
+		Pluck("message_id", &ids).
+		Error; err != nil {
+		return nil, err
+	}
+
+	e.l.Debug("search result", zap.Int64("total", total), zap.Int("hits", len(ids)))
+	return e.bindDBResult(total, ids, q, limit)
+}
+
+func (e *dbEngine) Available() bool {
+	return true
+}
+
+func (e *dbEngine) Close() error {
+	e.done <- struct{}{}
+	return nil
+}
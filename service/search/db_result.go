@@ -0,0 +1,54 @@
+package search
+
+import (
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/service/message"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+// dbResult search.Result 実装
+type dbResult struct {
+	totalHits  int64
+	messages   []message.Message
+	highlights []Highlight
+	nextCursor optional.Of[string]
+}
+
+func (e *dbEngine) bindDBResult(totalHits int64, ids []uuid.UUID, q *Query, limit int) (Result, error) {
+	terms := highlightTerms(q.Word.ValueOrZero())
+	r := &dbResult{
+		totalHits:  totalHits,
+		messages:   make([]message.Message, 0, len(ids)),
+		highlights: make([]Highlight, 0, len(ids)),
+	}
+
+	for _, id := range ids {
+		// NOTE: N+1 の可能性
+		m, err := e.mm.Get(id)
+		if err != nil {
+			return nil, err
+		}
+		r.messages = append(r.messages, m)
+		r.highlights = append(r.highlights, makeHighlight(m, terms))
+	}
+	r.nextCursor = makeNextCursor(q, r.messages, limit)
+
+	return r, nil
+}
+
+func (r *dbResult) TotalHits() int64 {
+	return r.totalHits
+}
+
+func (r *dbResult) Hits() []message.Message {
+	return r.messages
+}
+
+func (r *dbResult) Highlights() []Highlight {
+	return r.highlights
+}
+
+func (r *dbResult) NextCursor() optional.Of[string] {
+	return r.nextCursor
+}
@@ -0,0 +1,165 @@
+package search
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm/clause"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/utils/message"
+)
+
+// convertMessage メッセージをインデックスに入れる型に変換する
+func (e *dbEngine) convertMessage(m *model.Message, parseResult *message.ParseResult, userCache userCache) (*model.MessageSearchIndex, error) {
+	var isBot, ok bool
+	if isBot, ok = userCache[m.UserID]; !ok {
+		// 新規ユーザー or キャッシュが存在しない
+		user, err := e.repo.GetUser(m.UserID, false)
+		if err != nil {
+			return nil, err
+		}
+		isBot = user.IsBot()
+	}
+
+	attr := getAttributes(e.repo, e.l, m, parseResult)
+
+	return &model.MessageSearchIndex{
+		MessageID:      m.ID,
+		UserID:         m.UserID,
+		ChannelID:      m.ChannelID,
+		IsPublic:       e.cm.IsPublicChannel(m.ChannelID),
+		Bot:            isBot,
+		Tokens:         makeIndexTokens(m.Text, attr.To, attr.Citation),
+		HasURL:         attr.HasURL,
+		HasAttachments: attr.HasAttachments,
+		HasImage:       attr.HasImage,
+		HasVideo:       attr.HasVideo,
+		HasAudio:       attr.HasAudio,
+		CreatedAt:      m.CreatedAt,
+		UpdatedAt:      m.UpdatedAt,
+	}, nil
+}
+
+func (e *dbEngine) syncLoop(done <-chan struct{}) {
+	t := time.NewTicker(syncInterval)
+	defer t.Stop()
+loop:
+	for {
+		err := e.sync()
+		if err != nil {
+			e.l.Error(err.Error(), zap.Error(err))
+		}
+
+		select {
+		case <-t.C:
+		case <-done:
+			break loop
+		}
+	}
+}
+
+// sync メッセージを repository.MessageRepository から読み取り、インデックスに反映します
+func (e *dbEngine) sync() error {
+	e.l.Debug("syncing messages with search index")
+
+	lastSynced, err := e.lastInsertedUpdated()
+	if err != nil {
+		return err
+	}
+
+	var userCache userCache
+	lastInsert := lastSynced
+	for {
+		messages, more, err := e.repo.GetUpdatedMessagesAfter(lastInsert, syncMessageBulk)
+		if err != nil {
+			return err
+		}
+		if len(messages) == 0 {
+			break
+		}
+		lastInsert = messages[len(messages)-1].UpdatedAt
+
+		// NOTE: index時にBotかどうかを確認するN+1問題へのworkaround
+		if userCache == nil && more {
+			userCache, err = newUserCache(e.repo, e.l)
+			if err != nil {
+				return err
+			}
+		}
+
+		docs := make([]*model.MessageSearchIndex, 0, len(messages))
+		created := make([]*model.Message, 0, len(messages))
+		for _, v := range messages {
+			if v.CreatedAt.After(lastSynced) {
+				created = append(created, v)
+			}
+			doc, err := e.convertMessage(v, message.Parse(v.Text), userCache)
+			if err != nil {
+				return err
+			}
+			docs = append(docs, doc)
+		}
+		if err := e.db.Clauses(clause.OnConflict{UpdateAll: true}).Create(&docs).Error; err != nil {
+			return err
+		}
+
+		e.l.Info(fmt.Sprintf("indexed %v message(s), last insert %v", len(docs), lastInsert))
+
+		// 初回のインデックス作成時は保存された検索を実行しない
+		if !lastSynced.IsZero() {
+			if err := e.saved.evaluate(created); err != nil {
+				e.l.Error("failed to evaluate saved searches", zap.Error(err))
+			}
+		}
+
+		if !more {
+			break
+		}
+	}
+
+	lastDelete := lastSynced
+	for {
+		messages, more, err := e.repo.GetDeletedMessagesAfter(lastDelete, syncMessageBulk)
+		if err != nil {
+			return err
+		}
+		if len(messages) == 0 {
+			break
+		}
+		if !messages[len(messages)-1].DeletedAt.Valid {
+			return errors.New("expected DeletedAt to exist, but found nil")
+		}
+		lastDelete = messages[len(messages)-1].DeletedAt.Time
+
+		ids := make([]uuid.UUID, len(messages))
+		for i, v := range messages {
+			ids[i] = v.ID
+		}
+		res := e.db.Where("message_id IN ?", ids).Delete(&model.MessageSearchIndex{})
+		if res.Error != nil {
+			return res.Error
+		}
+
+		e.l.Info(fmt.Sprintf("deleted %v message(s) from index, last delete %v", res.RowsAffected, lastDelete))
+
+		if !more {
+			break
+		}
+	}
+
+	return nil
+}
+
+// lastInsertedUpdated インデックスに存在している、updatedAtが一番新しいメッセージの値を取得します
+func (e *dbEngine) lastInsertedUpdated() (time.Time, error) {
+	var t sql.NullTime
+	if err := e.db.Model(&model.MessageSearchIndex{}).Select("MAX(updated_at)").Row().Scan(&t); err != nil {
+		return time.Time{}, err
+	}
+	return t.Time, nil
+}
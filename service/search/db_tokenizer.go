@@ -0,0 +1,148 @@
+package search
+
+import (
+	"encoding/hex"
+	"strings"
+	"unicode"
+
+	"github.com/gofrs/uuid"
+	"golang.org/x/text/unicode/norm"
+)
+
+// MariaDBにはngramパーサーが無いため、アプリケーション側でbi-gramに分割したトークンを
+// FULLTEXTインデックスに入れる。トークンはInnoDBのデフォルトパーサーで単語として扱われ、
+// かつ最小トークン長(innodb_ft_min_token_size=3)やストップワードに掛からないよう、
+// 種類を表す1文字のprefixと16進数表記で表す。
+const (
+	unigramTokenPrefix  = "u" // 1文字
+	bigramTokenPrefix   = "b" // 2文字
+	toTokenPrefix       = "t" // メンション先
+	citationTokenPrefix = "c" // 引用しているメッセージ
+	segmentSeparator    = "s00"
+)
+
+// normalizeText 検索用に文字列を正規化します
+func normalizeText(s string) string {
+	return strings.ToLower(norm.NFKC.String(s))
+}
+
+// splitSegments 文字・数字の連続した部分に文字列を分割します
+func splitSegments(s string) [][]rune {
+	var (
+		segments [][]rune
+		current  []rune
+	)
+	for _, r := range normalizeText(s) {
+		if unicode.IsLetter(r) || unicode.IsNumber(r) || unicode.IsMark(r) {
+			current = append(current, r)
+			continue
+		}
+		if len(current) > 0 {
+			segments = append(segments, current)
+			current = nil
+		}
+	}
+	if len(current) > 0 {
+		segments = append(segments, current)
+	}
+	return segments
+}
+
+func unigramToken(r rune) string {
+	return unigramTokenPrefix + hex.EncodeToString([]byte(string(r)))
+}
+
+func bigramToken(r1, r2 rune) string {
+	return bigramTokenPrefix + hex.EncodeToString([]byte(string([]rune{r1, r2})))
+}
+
+func uuidToken(prefix string, id uuid.UUID) string {
+	return prefix + hex.EncodeToString(id.Bytes())
+}
+
+// makeIndexTokens インデックスに入れるトークン列を生成します
+func makeIndexTokens(text string, to []uuid.UUID, citation []uuid.UUID) string {
+	var tokens []string
+	for _, seg := range splitSegments(text) {
+		// フレーズ検索のため、bi-gramは連続して並べる
+		for i := 0; i+1 < len(seg); i++ {
+			tokens = append(tokens, bigramToken(seg[i], seg[i+1]))
+		}
+		for _, r := range seg {
+			tokens = append(tokens, unigramToken(r))
+		}
+		tokens = append(tokens, segmentSeparator)
+	}
+	for _, id := range to {
+		tokens = append(tokens, uuidToken(toTokenPrefix, id))
+	}
+	for _, id := range citation {
+		tokens = append(tokens, uuidToken(citationTokenPrefix, id))
+	}
+	return strings.Join(tokens, " ")
+}
+
+// segmentExpr 1つのセグメントにマッチするBOOLEAN MODEの式を返します
+func segmentExpr(seg []rune) string {
+	if len(seg) == 1 {
+		return unigramToken(seg[0])
+	}
+	grams := make([]string, 0, len(seg)-1)
+	for i := 0; i+1 < len(seg); i++ {
+		grams = append(grams, bigramToken(seg[i], seg[i+1]))
+	}
+	return `"` + strings.Join(grams, " ") + `"`
+}
+
+// splitWords 検索ワードを空白で区切ります。ダブルクォートで囲まれた部分は1つの語として扱います
+func splitWords(word string) []string {
+	var (
+		words   []string
+		current strings.Builder
+		quoted  bool
+	)
+	flush := func() {
+		if current.Len() > 0 {
+			words = append(words, current.String())
+			current.Reset()
+		}
+	}
+	for _, r := range word {
+		switch {
+		case r == '"':
+			quoted = !quoted
+		case unicode.IsSpace(r) && !quoted:
+			flush()
+		default:
+			current.WriteRune(r)
+		}
+	}
+	flush()
+	return words
+}
+
+// parseWord 検索ワードを必須の式と除外する式に変換します
+//
+// Simple-Query-String-Syntaxのうち、AND検索(空白区切り)、`-`による除外、`"`によるフレーズ指定に対応します。
+func parseWord(word string) (musts []string, mustNots []string) {
+	for _, w := range splitWords(word) {
+		exclude := false
+		switch {
+		case w == "|":
+			continue
+		case strings.HasPrefix(w, "-"):
+			exclude = true
+			w = w[1:]
+		case strings.HasPrefix(w, "+"):
+			w = w[1:]
+		}
+		for _, seg := range splitSegments(w) {
+			if exclude {
+				mustNots = append(mustNots, segmentExpr(seg))
+			} else {
+				musts = append(musts, segmentExpr(seg))
+			}
+		}
+	}
+	return
+}
@@ -0,0 +1,80 @@
+package search
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitSegments(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{name: "empty", text: "", want: nil},
+		{name: "ascii", text: "Hello, traQ!", want: []string{"hello", "traq"}},
+		{name: "japanese", text: "今日は　いい天気", want: []string{"今日は", "いい天気"}},
+		{name: "full width", text: "ＴＲＡＱ１２３", want: []string{"traq123"}},
+		{name: "half width kana", text: "ｶﾞｷﾞ", want: []string{"ガギ"}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var got []string
+			for _, seg := range splitSegments(tt.text) {
+				got = append(got, string(seg))
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMakeIndexTokens(t *testing.T) {
+	t.Parallel()
+
+	to := uuid.Must(uuid.FromString("a5e6c0d8-8b5e-4a3f-9c1e-0f3b2d1c4e5a"))
+	tokens := strings.Split(makeIndexTokens("ab c", []uuid.UUID{to}, nil), " ")
+
+	assert.Equal(t, []string{
+		"b6162", "u61", "u62", segmentSeparator,
+		"u63", segmentSeparator,
+		"ta5e6c0d88b5e4a3f9c1e0f3b2d1c4e5a",
+	}, tokens)
+	for _, token := range tokens {
+		// InnoDBのデフォルトの最小トークン長
+		assert.GreaterOrEqual(t, len(token), 3)
+	}
+}
+
+func TestParseWord(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		word     string
+		musts    []string
+		mustNots []string
+	}{
+		{name: "single char", word: "a", musts: []string{"u61"}},
+		{name: "phrase", word: "abc", musts: []string{`"b6162 b6263"`}},
+		{name: "and", word: "a  b", musts: []string{"u61", "u62"}},
+		{name: "exclude", word: "a -bc", musts: []string{"u61"}, mustNots: []string{`"b6263"`}},
+		{name: "quoted", word: `"a b" | c`, musts: []string{"u61", "u62", "u63"}},
+		{name: "symbols only", word: "!?", musts: nil},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			musts, mustNots := parseWord(tt.word)
+			assert.Equal(t, tt.musts, musts)
+			assert.Equal(t, tt.mustNots, mustNots)
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/gofrs/uuid"
 
 	"github.com/traPtitech/traQ/service/message"
+	"github.com/traPtitech/traQ/utils/cursor"
 	"github.com/traPtitech/traQ/utils/optional"
 )
 
@@ -28,22 +29,26 @@ type Engine interface {
 
 // Query 検索クエリ
 type Query struct {
-	Word           optional.Of[string]    `query:"word"`           // 検索ワード Simple-Query-String-Syntax
-	After          optional.Of[time.Time] `query:"after"`          // 以降(投稿日時) 2020-06-20T00:00:00Z
-	Before         optional.Of[time.Time] `query:"before"`         // 以前(投稿日時)
-	In             optional.Of[uuid.UUID] `query:"in"`             // 投稿チャンネル
-	To             optional.Of[uuid.UUID] `query:"to"`             // メンション先
-	From           optional.Of[uuid.UUID] `query:"from"`           // 投稿者
-	Citation       optional.Of[uuid.UUID] `query:"citation"`       // 引用しているメッセージ
-	Bot            optional.Of[bool]      `query:"bot"`            // 投稿者がBotか
-	HasURL         optional.Of[bool]      `query:"hasURL"`         // URLの存在
-	HasAttachments optional.Of[bool]      `query:"hasAttachments"` // 添付ファイル
-	HasImage       optional.Of[bool]      `query:"hasImage"`       // 添付ファイル（画像）
-	HasVideo       optional.Of[bool]      `query:"hasVideo"`       // 添付ファイル（動画）
-	HasAudio       optional.Of[bool]      `query:"hasAudio"`       // 添付ファイル（音声ファイル）
-	Limit          optional.Of[int]       `query:"limit"`          // 取得件数
-	Offset         optional.Of[int]       `query:"offset"`         // 取得Offset
-	Sort           optional.Of[string]    `query:"sort"`           // 並び順 /[-\+]?key/
+	Word           optional.Of[string]    `query:"word" json:"word"`                     // 検索ワード Simple-Query-String-Syntax
+	After          optional.Of[time.Time] `query:"after" json:"after"`                   // 以降(投稿日時) 2020-06-20T00:00:00Z
+	Before         optional.Of[time.Time] `query:"before" json:"before"`                 // 以前(投稿日時)
+	In             optional.Of[uuid.UUID] `query:"in" json:"in"`                         // 投稿チャンネル
+	To             optional.Of[uuid.UUID] `query:"to" json:"to"`                         // メンション先
+	From           optional.Of[uuid.UUID] `query:"from" json:"from"`                     // 投稿者
+	Citation       optional.Of[uuid.UUID] `query:"citation" json:"citation"`             // 引用しているメッセージ
+	Bot            optional.Of[bool]      `query:"bot" json:"bot"`                       // 投稿者がBotか
+	HasURL         optional.Of[bool]      `query:"hasURL" json:"hasURL"`                 // URLの存在
+	HasAttachments optional.Of[bool]      `query:"hasAttachments" json:"hasAttachments"` // 添付ファイル
+	HasImage       optional.Of[bool]      `query:"hasImage" json:"hasImage"`             // 添付ファイル（画像）
+	HasVideo       optional.Of[bool]      `query:"hasVideo" json:"hasVideo"`             // 添付ファイル（動画）
+	HasAudio       optional.Of[bool]      `query:"hasAudio" json:"hasAudio"`             // 添付ファイル（音声ファイル）
+	Limit          optional.Of[int]       `query:"limit" json:"-"`                       // 取得件数
+	Offset         optional.Of[int]       `query:"offset" json:"-"`                      // 取得Offset
+	Sort           optional.Of[string]    `query:"sort" json:"-"`                        // 並び順 /[-\+]?key/
+	Cursor         optional.Of[string]    `query:"cursor" json:"-"`                      // 前回の検索結果の続きを取得するカーソル
+
+	// messageIDs 指定した場合はこれらのメッセージのみを検索します (保存された検索の評価用)
+	messageIDs []uuid.UUID
 }
 
 func (q Query) Validate() error {
@@ -51,11 +56,56 @@ func (q Query) Validate() error {
 		vd.Field(&q.Limit, vd.Min(1), vd.Max(100)),
 		// Cannot page through more than 10k hits with From and Size
 		// https://www.elastic.co/guide/en/elasticsearch/reference/current/paginate-search-results.html
-		vd.Field(&q.Offset, vd.Min(0), vd.Max(9900)),
+		// それ以上はCursorを用いる
+		vd.Field(&q.Offset, vd.Min(0), vd.Max(9900), vd.When(q.Cursor.Valid, vd.Empty.Error("cannot be used with cursor"))),
 		vd.Field(&q.Sort, vd.Match(allowedSortKeysRegExp)),
+		vd.Field(&q.Cursor, vd.By(func(interface{}) error {
+			if !q.Cursor.Valid {
+				return nil
+			}
+			c, err := cursor.Decode(q.Cursor.V)
+			if err != nil {
+				return err
+			}
+			if sort := q.GetSortKey(); c.Key != sort.Key || c.Desc != sort.Desc {
+				return errors.New("sort order does not match")
+			}
+			return nil
+		})),
 	)
 }
 
+// GetCursor 指定されたカーソルを取得します
+func (q Query) GetCursor() (cursor.Cursor, bool) {
+	if !q.Cursor.Valid {
+		return cursor.Cursor{}, false
+	}
+	c, err := cursor.Decode(q.Cursor.V)
+	if err != nil {
+		return cursor.Cursor{}, false
+	}
+	return c, true
+}
+
+// makeNextCursor ヒットしたメッセージから次のページのカーソルを生成します
+//
+// 取得件数に満たない場合は続きが無いため、無効な値を返します。
+func makeNextCursor(q *Query, hits []message.Message, limit int) optional.Of[string] {
+	if len(hits) == 0 || len(hits) < limit {
+		return optional.Of[string]{}
+	}
+	last := hits[len(hits)-1]
+	sort := q.GetSortKey()
+	c := cursor.Cursor{Key: sort.Key, Desc: sort.Desc, ID: last.GetID()}
+	switch sort.Key {
+	case updatedAtSortKey:
+		c.Value = last.GetUpdatedAt()
+	default:
+		c.Value = last.GetCreatedAt()
+	}
+	return optional.From(c.Encode())
+}
+
 // Sort ソート情報
 type Sort struct {
 	Key  string // 何によってソートするか
@@ -83,6 +133,10 @@ type Result interface {
 	TotalHits() int64
 	// Hits createdAtで降順にソートされた、ヒットしたメッセージ
 	Hits() []message.Message
+	// Highlights Hitsと同じ順番の、各メッセージのハイライト情報
+	Highlights() []Highlight
+	// NextCursor 続きを取得するためのカーソル 続きが無い場合は無効な値
+	NextCursor() optional.Of[string]
 }
 
 const createdAtSortKey = "createdAt" // 作成日時の新しい順
@@ -0,0 +1,103 @@
+package search
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/traPtitech/traQ/service/message"
+	"github.com/traPtitech/traQ/utils/cursor"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+func TestQuery_Validate(t *testing.T) {
+	t.Parallel()
+
+	c := cursor.Cursor{Key: createdAtSortKey, Desc: true, Value: time.Now(), ID: uuid.NewV3(uuid.Nil, "m")}.Encode()
+
+	tests := []struct {
+		name    string
+		q       Query
+		wantErr bool
+	}{
+		{name: "empty", q: Query{}},
+		{name: "offset", q: Query{Offset: optional.From(9900)}},
+		{name: "too large offset", q: Query{Offset: optional.From(9901)}, wantErr: true},
+		{name: "cursor", q: Query{Cursor: optional.From(c)}},
+		{name: "cursor with zero offset", q: Query{Cursor: optional.From(c), Offset: optional.From(0)}},
+		{name: "cursor with offset", q: Query{Cursor: optional.From(c), Offset: optional.From(20)}, wantErr: true},
+		{name: "cursor with different sort", q: Query{Cursor: optional.From(c), Sort: optional.From("-createdAt")}, wantErr: true},
+		{name: "invalid cursor", q: Query{Cursor: optional.From("invalid")}, wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if tt.wantErr {
+				assert.Error(t, tt.q.Validate())
+			} else {
+				assert.NoError(t, tt.q.Validate())
+			}
+		})
+	}
+}
+
+type cursorTestMessage struct {
+	message.Message
+	id        uuid.UUID
+	createdAt time.Time
+	updatedAt time.Time
+}
+
+func (m *cursorTestMessage) GetID() uuid.UUID        { return m.id }
+func (m *cursorTestMessage) GetCreatedAt() time.Time { return m.createdAt }
+func (m *cursorTestMessage) GetUpdatedAt() time.Time { return m.updatedAt }
+
+func TestMakeNextCursor(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	hits := []message.Message{
+		&cursorTestMessage{id: uuid.NewV3(uuid.Nil, "1"), createdAt: now, updatedAt: now},
+		&cursorTestMessage{id: uuid.NewV3(uuid.Nil, "2"), createdAt: now.Add(-time.Hour), updatedAt: now.Add(time.Hour)},
+	}
+
+	t.Run("no more", func(t *testing.T) {
+		t.Parallel()
+		assert.False(t, makeNextCursor(&Query{}, hits, 3).Valid)
+		assert.False(t, makeNextCursor(&Query{}, nil, 0).Valid)
+	})
+
+	t.Run("created at", func(t *testing.T) {
+		t.Parallel()
+		next := makeNextCursor(&Query{}, hits, 2)
+		if assert.True(t, next.Valid) {
+			c, err := cursor.Decode(next.V)
+			if assert.NoError(t, err) {
+				assert.Equal(t, createdAtSortKey, c.Key)
+				assert.True(t, c.Desc)
+				assert.True(t, now.Add(-time.Hour).Equal(c.Value))
+				assert.Equal(t, hits[1].GetID(), c.ID)
+			}
+			// 同じ条件のクエリで使える
+			assert.NoError(t, Query{Cursor: next}.Validate())
+		}
+	})
+
+	t.Run("updated at", func(t *testing.T) {
+		t.Parallel()
+		q := &Query{Sort: optional.From("-updatedAt")}
+		next := makeNextCursor(q, hits, 2)
+		if assert.True(t, next.Valid) {
+			c, err := cursor.Decode(next.V)
+			if assert.NoError(t, err) {
+				assert.Equal(t, updatedAtSortKey, c.Key)
+				assert.False(t, c.Desc)
+				assert.True(t, now.Add(time.Hour).Equal(c.Value))
+			}
+			assert.NoError(t, Query{Cursor: next, Sort: q.Sort}.Validate())
+		}
+	})
+}
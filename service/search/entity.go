@@ -0,0 +1,87 @@
+package search
+
+import (
+	"strings"
+
+	vd "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+const (
+	// EntityTypeFile ファイル
+	EntityTypeFile = "file"
+	// EntityTypeChannel チャンネル
+	EntityTypeChannel = "channel"
+	// EntityTypeUser ユーザー
+	EntityTypeUser = "user"
+)
+
+// EntityEngine ファイル・チャンネル・ユーザー検索エンジンインターフェイス
+type EntityEngine interface {
+	// Do 与えられたクエリで、指定したユーザーがアクセス可能なものを検索します
+	Do(userID uuid.UUID, q *EntityQuery) (EntityResult, error)
+	// Available 検索サービスが使用可能かどうかを返します
+	Available() bool
+	// Close 検索サービスを終了します
+	Close() error
+}
+
+// EntityQuery ファイル・チャンネル・ユーザー検索クエリ
+type EntityQuery struct {
+	Word   optional.Of[string]    `query:"word"`   // 検索ワード
+	Type   optional.Of[string]    `query:"type"`   // 検索対象 file, channel, user
+	Mime   optional.Of[string]    `query:"mime"`   // ファイルのMIMEタイプ(前方一致)
+	From   optional.Of[uuid.UUID] `query:"from"`   // ファイルのアップロード者
+	In     optional.Of[uuid.UUID] `query:"in"`     // ファイルのアップロード先チャンネル
+	Limit  optional.Of[int]       `query:"limit"`  // 取得件数
+	Offset optional.Of[int]       `query:"offset"` // 取得Offset
+}
+
+func (q EntityQuery) Validate() error {
+	return vd.ValidateStruct(&q,
+		vd.Field(&q.Word, vd.Required, vd.RuneLength(1, 100)),
+		vd.Field(&q.Type, vd.In(EntityTypeFile, EntityTypeChannel, EntityTypeUser)),
+		vd.Field(&q.Mime, vd.RuneLength(1, 255)),
+		vd.Field(&q.Limit, vd.Min(1), vd.Max(100)),
+		vd.Field(&q.Offset, vd.Min(0), vd.Max(9900)),
+	)
+}
+
+// isFileOnly ファイルに関する条件が指定されているかどうか
+func (q EntityQuery) isFileOnly() bool {
+	return q.Mime.Valid || q.From.Valid || q.In.Valid
+}
+
+// EntityHit ヒットしたファイル・チャンネル・ユーザー
+type EntityHit struct {
+	Type string
+	ID   uuid.UUID
+}
+
+// EntityResult 検索結果インターフェイス
+type EntityResult interface {
+	// TotalHits 総ヒット件数
+	TotalHits() int64
+	// Hits 作成日時の新しい順にソートされたヒットしたもの
+	Hits() []EntityHit
+}
+
+type entityResult struct {
+	totalHits int64
+	hits      []EntityHit
+}
+
+func (r *entityResult) TotalHits() int64 {
+	return r.totalHits
+}
+
+func (r *entityResult) Hits() []EntityHit {
+	return r.hits
+}
+
+// makeEntityTokens 複数のテキストからインデックスに入れるトークン列を生成します
+func makeEntityTokens(texts ...string) string {
+	return makeIndexTokens(strings.Join(texts, "\n"), nil, nil)
+}
@@ -0,0 +1,138 @@
+package search
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gofrs/uuid"
+	"github.com/leandro-lugaresi/hub"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/service/channel"
+)
+
+// entityDBEngine search.EntityEngine 実装
+//
+// ファイル・チャンネル・ユーザーはメッセージに比べて少ないため、
+// メッセージ検索が有効な場合は検索エンジンの種類に関わらずMariaDBのFULLTEXTインデックスを用います。
+type entityDBEngine struct {
+	db   *gorm.DB
+	hub  *hub.Hub
+	cm   channel.Manager
+	repo repository.Repository
+	l    *zap.Logger
+	sub  hub.Subscription
+	done chan struct{}
+}
+
+// NewEntityEngine ファイル・チャンネル・ユーザー検索エンジンを生成します
+func NewEntityEngine(db *gorm.DB, hub *hub.Hub, cm channel.Manager, repo repository.Repository, logger *zap.Logger) (EntityEngine, error) {
+	if !db.Migrator().HasTable(&model.SearchEntityIndex{}) {
+		return nil, fmt.Errorf("failed to init entity search engine: table %s does not exist", (&model.SearchEntityIndex{}).TableName())
+	}
+
+	engine := &entityDBEngine{
+		db:   db,
+		hub:  hub,
+		cm:   cm,
+		repo: repo,
+		l:    logger.Named("entity_search"),
+		done: make(chan struct{}),
+	}
+	engine.sub = hub.Subscribe(100, entityIndexTopics...)
+
+	go engine.syncLoop()
+
+	return engine, nil
+}
+
+func (e *entityDBEngine) Do(userID uuid.UUID, q *EntityQuery) (EntityResult, error) {
+	e.l.Debug("do search", zap.Reflect("q", q))
+
+	musts, mustNots := parseWord(q.Word.ValueOrZero())
+
+	filter := func(tx *gorm.DB) *gorm.DB {
+		if len(musts) > 0 {
+			tx = tx.Where("MATCH(tokens) AGAINST(? IN BOOLEAN MODE)", "+"+strings.Join(musts, " +"))
+		}
+		if len(mustNots) > 0 {
+			tx = tx.Where("NOT MATCH(tokens) AGAINST(? IN BOOLEAN MODE)", strings.Join(mustNots, " "))
+		}
+
+		switch {
+		case q.isFileOnly():
+			tx = tx.Where("type = ?", EntityTypeFile)
+			if q.Mime.Valid {
+				tx = tx.Where("mime LIKE ?", escapeLike(q.Mime.V)+"%")
+			}
+			if q.From.Valid {
+				tx = tx.Where("creator_id = ?", q.From.V)
+			}
+			if q.In.Valid {
+				tx = tx.Where("channel_id = ?", q.In.V)
+			}
+		case q.Type.Valid:
+			tx = tx.Where("type = ?", q.Type.V)
+		}
+
+		// アクセス可能なもののみ
+		accessibleUsers := []uuid.UUID{userID, uuid.Nil}
+		return tx.Where(
+			e.db.Where("type = ?", EntityTypeUser).
+				Or("type = ? AND (is_public = TRUE OR EXISTS (SELECT 1 FROM users_private_channels p WHERE p.channel_id = entity_id AND p.user_id = ?))", EntityTypeChannel, userID).
+				Or("type = ? AND EXISTS (SELECT 1 FROM files_acl a WHERE a.file_id = entity_id AND a.user_id IN ? AND a.allow = TRUE) AND NOT EXISTS (SELECT 1 FROM files_acl a WHERE a.file_id = entity_id AND a.user_id IN ? AND a.allow = FALSE)", EntityTypeFile, accessibleUsers, accessibleUsers),
+		)
+	}
+
+	limit, offset := 20, 0
+	if q.Limit.Valid {
+		limit = q.Limit.V
+	}
+	if q.Offset.Valid {
+		offset = q.Offset.V
+	}
+
+	var total int64
+	if err := e.db.Model(&model.SearchEntityIndex{}).Scopes(filter).Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	var indices []*model.SearchEntityIndex
+	if err := e.db.
+		Select("type", "entity_id").
+		Scopes(filter).
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&indices).
+		Error; err != nil {
+		return nil, err
+	}
+
+	r := &entityResult{
+		totalHits: total,
+		hits:      make([]EntityHit, len(indices)),
+	}
+	for i, index := range indices {
+		r.hits[i] = EntityHit{Type: index.Type, ID: index.EntityID}
+	}
+	return r, nil
+}
+
+func (e *entityDBEngine) Available() bool {
+	return true
+}
+
+func (e *entityDBEngine) Close() error {
+	e.hub.Unsubscribe(e.sub)
+	close(e.done)
+	return nil
+}
+
+// escapeLike LIKE句の特殊文字をエスケープします
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
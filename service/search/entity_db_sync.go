@@ -0,0 +1,310 @@
+package search
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/leandro-lugaresi/hub"
+	"go.uber.org/zap"
+	"gorm.io/gorm/clause"
+
+	"github.com/traPtitech/traQ/event"
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/service/channel"
+)
+
+const syncFileBulk = 500
+
+// entityIndexTopics インデックスの更新に用いるイベント
+var entityIndexTopics = []string{
+	event.UserCreated,
+	event.UserUpdated,
+	event.UserTagAdded,
+	event.UserTagUpdated,
+	event.UserTagRemoved,
+	event.ChannelCreated,
+	event.ChannelUpdated,
+	event.ChannelTopicUpdated,
+	event.ChannelDeleted,
+}
+
+func (e *entityDBEngine) syncLoop() {
+	go func() {
+		for ev := range e.sub.Receiver {
+			if err := e.handleEvent(ev); err != nil {
+				e.l.Error("failed to update entity search index", zap.Error(err), zap.String("event", ev.Topic()))
+			}
+		}
+	}()
+
+	if err := e.syncUsers(); err != nil {
+		e.l.Error(err.Error(), zap.Error(err))
+	}
+	if err := e.syncChannels(); err != nil {
+		e.l.Error(err.Error(), zap.Error(err))
+	}
+
+	t := time.NewTicker(syncInterval)
+	defer t.Stop()
+	for {
+		if err := e.syncFiles(); err != nil {
+			e.l.Error(err.Error(), zap.Error(err))
+		}
+
+		select {
+		case <-t.C:
+		case <-e.done:
+			return
+		}
+	}
+}
+
+// handleEvent イベントに応じてインデックスを更新します
+func (e *entityDBEngine) handleEvent(ev hub.Message) error {
+	switch ev.Topic() {
+	case event.UserCreated, event.UserUpdated, event.UserTagAdded, event.UserTagUpdated, event.UserTagRemoved:
+		user, err := e.repo.GetUser(ev.Fields["user_id"].(uuid.UUID), false)
+		if err != nil {
+			return err
+		}
+		return e.indexUsers([]model.UserInfo{user})
+	case event.ChannelCreated, event.ChannelTopicUpdated:
+		return e.indexChannels([]uuid.UUID{ev.Fields["channel_id"].(uuid.UUID)})
+	case event.ChannelUpdated:
+		// 親チャンネルや名前の変更で子孫チャンネルのパスも変わる
+		id := ev.Fields["channel_id"].(uuid.UUID)
+		return e.indexChannels(append([]uuid.UUID{id}, e.cm.PublicChannelTree().GetDescendantIDs(id)...))
+	case event.ChannelDeleted:
+		return e.db.
+			Where("type = ? AND entity_id = ?", EntityTypeChannel, ev.Fields["channel_id"].(uuid.UUID)).
+			Delete(&model.SearchEntityIndex{}).
+			Error
+	}
+	return nil
+}
+
+// syncUsers 前回の同期以降に更新されたユーザーをインデックスに反映します
+//
+// タグの削除は検出できないため、イベントによる更新に任せます
+func (e *entityDBEngine) syncUsers() error {
+	lastUpdate, err := e.lastUpdatedEntity(EntityTypeUser)
+	if err != nil {
+		return err
+	}
+
+	var ids []uuid.UUID
+	if err := e.db.
+		Model(&model.User{}).
+		Where("updated_at > ? OR id IN (SELECT user_id FROM user_profiles WHERE updated_at > ?) OR id IN (SELECT user_id FROM users_tags WHERE updated_at > ?)", lastUpdate, lastUpdate, lastUpdate).
+		Pluck("id", &ids).
+		Error; err != nil {
+		return err
+	}
+
+	for i := 0; i < len(ids); i += syncFileBulk {
+		end := i + syncFileBulk
+		if end > len(ids) {
+			end = len(ids)
+		}
+		chunk := ids[i:end]
+
+		var users []*model.User
+		if err := e.db.Preload("Profile").Where("id IN ?", chunk).Find(&users).Error; err != nil {
+			return err
+		}
+		infos := make([]model.UserInfo, len(users))
+		for j, user := range users {
+			infos[j] = user
+		}
+		if err := e.indexUsers(infos); err != nil {
+			return err
+		}
+	}
+	e.l.Info(fmt.Sprintf("indexed %v user(s), last update %v", len(ids), lastUpdate))
+	return nil
+}
+
+func (e *entityDBEngine) indexUsers(users []model.UserInfo) error {
+	if len(users) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, len(users))
+	for i, user := range users {
+		ids[i] = user.GetID()
+	}
+	var userTags []*model.UsersTag
+	if err := e.db.Preload("Tag").Where("user_id IN ?", ids).Find(&userTags).Error; err != nil {
+		return err
+	}
+	tagsByUser := make(map[uuid.UUID][]*model.UsersTag, len(users))
+	for _, tag := range userTags {
+		tagsByUser[tag.UserID] = append(tagsByUser[tag.UserID], tag)
+	}
+
+	docs := make([]*model.SearchEntityIndex, 0, len(users))
+	for _, user := range users {
+		tags := tagsByUser[user.GetID()]
+		texts := make([]string, 0, len(tags)+2)
+		texts = append(texts, user.GetName(), user.GetDisplayName())
+		updatedAt := user.GetUpdatedAt()
+		for _, tag := range tags {
+			texts = append(texts, tag.GetTag())
+			if tag.UpdatedAt.After(updatedAt) {
+				updatedAt = tag.UpdatedAt
+			}
+		}
+
+		docs = append(docs, &model.SearchEntityIndex{
+			Type:      EntityTypeUser,
+			EntityID:  user.GetID(),
+			Tokens:    makeEntityTokens(texts...),
+			IsPublic:  true,
+			CreatedAt: user.GetCreatedAt(),
+			UpdatedAt: updatedAt,
+		})
+	}
+	return e.db.Clauses(clause.OnConflict{UpdateAll: true}).CreateInBatches(&docs, syncFileBulk).Error
+}
+
+// syncChannels DM以外の全チャンネルをインデックスに反映します
+func (e *entityDBEngine) syncChannels() error {
+	var ids []uuid.UUID
+	if err := e.db.
+		Model(&model.Channel{}).
+		Where("parent_id <> ?", model.DirectMessageChannelRootID).
+		Pluck("id", &ids).
+		Error; err != nil {
+		return err
+	}
+	if err := e.indexChannels(ids); err != nil {
+		return err
+	}
+	e.l.Info(fmt.Sprintf("indexed %v channel(s)", len(ids)))
+	return nil
+}
+
+func (e *entityDBEngine) indexChannels(ids []uuid.UUID) error {
+	docs := make([]*model.SearchEntityIndex, 0, len(ids))
+	for _, id := range ids {
+		ch, err := e.cm.GetChannel(id)
+		if err != nil {
+			if err == channel.ErrChannelNotFound {
+				continue
+			}
+			return err
+		}
+		if ch.IsDMChannel() {
+			continue
+		}
+
+		path := ch.Name
+		if ch.IsPublic {
+			path = e.cm.PublicChannelTree().GetChannelPath(id)
+		}
+		docs = append(docs, &model.SearchEntityIndex{
+			Type:      EntityTypeChannel,
+			EntityID:  ch.ID,
+			Tokens:    makeEntityTokens(path, ch.Topic),
+			CreatorID: ch.CreatorID,
+			IsPublic:  ch.IsPublic,
+			CreatedAt: ch.CreatedAt,
+			UpdatedAt: ch.UpdatedAt,
+		})
+	}
+	if len(docs) == 0 {
+		return nil
+	}
+	return e.db.Clauses(clause.OnConflict{UpdateAll: true}).CreateInBatches(&docs, syncFileBulk).Error
+}
+
+// syncFiles 新しくアップロードされたファイルをインデックスに反映し、削除されたファイルをインデックスから削除します
+func (e *entityDBEngine) syncFiles() error {
+	e.l.Debug("syncing files with search index")
+
+	lastInsert, err := e.lastInsertedFile()
+	if err != nil {
+		return err
+	}
+
+	for {
+		var files []*model.FileMeta
+		if err := e.db.
+			Where("type = ? AND created_at > ?", model.FileTypeUserFile, lastInsert).
+			Order("created_at").
+			Limit(syncFileBulk).
+			Find(&files).
+			Error; err != nil {
+			return err
+		}
+		if len(files) == 0 {
+			break
+		}
+		lastInsert = files[len(files)-1].CreatedAt
+
+		docs := make([]*model.SearchEntityIndex, len(files))
+		for i, f := range files {
+			docs[i] = &model.SearchEntityIndex{
+				Type:      EntityTypeFile,
+				EntityID:  f.ID,
+				Tokens:    makeEntityTokens(f.Name),
+				Mime:      f.Mime,
+				CreatorID: f.CreatorID.ValueOrZero(),
+				ChannelID: f.ChannelID.ValueOrZero(),
+				CreatedAt: f.CreatedAt,
+				UpdatedAt: f.CreatedAt,
+			}
+		}
+		if err := e.db.Clauses(clause.OnConflict{UpdateAll: true}).Create(&docs).Error; err != nil {
+			return err
+		}
+
+		e.l.Info(fmt.Sprintf("indexed %v file(s), last insert %v", len(docs), lastInsert))
+
+		if len(files) < syncFileBulk {
+			break
+		}
+	}
+
+	res := e.db.
+		Where("type = ? AND entity_id IN (SELECT id FROM files WHERE deleted_at IS NOT NULL)", EntityTypeFile).
+		Delete(&model.SearchEntityIndex{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected > 0 {
+		e.l.Info(fmt.Sprintf("deleted %v file(s) from index", res.RowsAffected))
+	}
+
+	return nil
+}
+
+// lastInsertedFile インデックスに存在している、createdAtが一番新しいファイルの値を取得します
+func (e *entityDBEngine) lastInsertedFile() (time.Time, error) {
+	var t sql.NullTime
+	if err := e.db.
+		Model(&model.SearchEntityIndex{}).
+		Where("type = ?", EntityTypeFile).
+		Select("MAX(created_at)").
+		Row().
+		Scan(&t); err != nil {
+		return time.Time{}, err
+	}
+	return t.Time, nil
+}
+
+// lastUpdatedEntity インデックスに存在している、指定した種類のupdatedAtが一番新しい値を取得します
+func (e *entityDBEngine) lastUpdatedEntity(entityType string) (time.Time, error) {
+	var t sql.NullTime
+	if err := e.db.
+		Model(&model.SearchEntityIndex{}).
+		Where("type = ?", entityType).
+		Select("MAX(updated_at)").
+		Row().
+		Scan(&t); err != nil {
+		return time.Time{}, err
+	}
+	return t.Time, nil
+}
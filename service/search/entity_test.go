@@ -0,0 +1,53 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+func TestEntityQuery_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		q       EntityQuery
+		wantErr bool
+	}{
+		{name: "empty", q: EntityQuery{}, wantErr: true},
+		{name: "word only", q: EntityQuery{Word: optional.From("traQ")}},
+		{name: "valid type", q: EntityQuery{Word: optional.From("traQ"), Type: optional.From(EntityTypeChannel)}},
+		{name: "invalid type", q: EntityQuery{Word: optional.From("traQ"), Type: optional.From("message")}, wantErr: true},
+		{name: "too large limit", q: EntityQuery{Word: optional.From("traQ"), Limit: optional.From(101)}, wantErr: true},
+		{name: "negative offset", q: EntityQuery{Word: optional.From("traQ"), Offset: optional.From(-1)}, wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if tt.wantErr {
+				assert.Error(t, tt.q.Validate())
+			} else {
+				assert.NoError(t, tt.q.Validate())
+			}
+		})
+	}
+}
+
+func TestEntityQuery_isFileOnly(t *testing.T) {
+	t.Parallel()
+
+	assert.False(t, EntityQuery{Word: optional.From("a")}.isFileOnly())
+	assert.False(t, EntityQuery{Word: optional.From("a"), Type: optional.From(EntityTypeFile)}.isFileOnly())
+	assert.True(t, EntityQuery{Word: optional.From("a"), Mime: optional.From("image/")}.isFileOnly())
+	assert.True(t, EntityQuery{Word: optional.From("a"), From: optional.From(uuid.Must(uuid.NewV4()))}.isFileOnly())
+}
+
+func TestEscapeLike(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, `image/\%\_\\`, escapeLike(`image/%_\`))
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/gofrs/uuid"
+	"github.com/leandro-lugaresi/hub"
 	"github.com/olivere/elastic/v7"
 	"go.uber.org/zap"
 
@@ -38,12 +39,13 @@ type esEngine struct {
 	cm     channel.Manager
 	repo   repository.Repository
 	l      *zap.Logger
+	saved  *savedSearchEvaluator
 	done   chan<- struct{}
 }
 
 // esMessageDoc Elasticsearchに入るメッセージの情報
 type esMessageDoc struct {
-	ID             uuid.UUID   `json:"-"`
+	ID             uuid.UUID   `json:"messageId"`
 	UserID         uuid.UUID   `json:"userId"`
 	ChannelID      uuid.UUID   `json:"channelId"`
 	IsPublic       bool        `json:"isPublic"`
@@ -78,6 +80,9 @@ type m map[string]interface{}
 // esMessageDoc と同じにする
 var esMapping = m{
 	"properties": m{
+		"messageId": m{
+			"type": "keyword",
+		},
 		"userId": m{
 			"type": "keyword",
 		},
@@ -158,8 +163,27 @@ var esSetting = m{
 	},
 }
 
+// migrateMessageIDField messageIdフィールドが無い既存のインデックスにフィールドを追加し、既存ドキュメントに値を埋めます
+func migrateMessageIDField(client *elastic.Client) error {
+	_, err := client.PutMapping().
+		Index(getIndexName(esMessageIndex)).
+		BodyJson(m{"properties": m{"messageId": esMapping["properties"].(m)["messageId"]}}).
+		Do(context.Background())
+	if err != nil {
+		return err
+	}
+
+	// 件数が多い場合があるため、Elasticsearch側のタスクとして非同期に実行する
+	_, err = client.UpdateByQuery(getIndexName(esMessageIndex)).
+		Query(elastic.NewBoolQuery().MustNot(elastic.NewExistsQuery("messageId"))).
+		Script(elastic.NewScript("ctx._source.messageId = ctx._id")).
+		ProceedOnVersionConflict().
+		DoAsync(context.Background())
+	return err
+}
+
 // NewESEngine Elasticsearch検索エンジンを生成します
-func NewESEngine(mm message.Manager, cm channel.Manager, repo repository.Repository, logger *zap.Logger, config ESEngineConfig) (Engine, error) {
+func NewESEngine(mm message.Manager, cm channel.Manager, repo repository.Repository, hub *hub.Hub, logger *zap.Logger, config ESEngineConfig) (Engine, error) {
 	// es接続
 	client, err := elastic.NewClient(elastic.SetURL(config.URL), elastic.SetSniff(false))
 	if err != nil {
@@ -190,6 +214,8 @@ func NewESEngine(mm message.Manager, cm channel.Manager, repo repository.Reposit
 		if !r1.Acknowledged {
 			return nil, fmt.Errorf("failed to init search engine: index not acknowledged")
 		}
+	} else if err := migrateMessageIDField(client); err != nil {
+		return nil, fmt.Errorf("failed to init search engine: %w", err)
 	}
 
 	done := make(chan struct{})
@@ -201,6 +227,13 @@ func NewESEngine(mm message.Manager, cm channel.Manager, repo repository.Reposit
 		l:      logger.Named("search"),
 		done:   done,
 	}
+	engine.saved = &savedSearchEvaluator{
+		engine: engine,
+		cm:     cm,
+		repo:   repo,
+		hub:    hub,
+		l:      engine.l,
+	}
 
 	go engine.syncLoop(done)
 
@@ -273,6 +306,14 @@ func (e *esEngine) Do(q *Query) (Result, error) {
 		musts = append(musts, elastic.NewTermQuery("hasAudio", q.HasAudio))
 	}
 
+	if len(q.messageIDs) > 0 {
+		ids := make([]string, len(q.messageIDs))
+		for i, id := range q.messageIDs {
+			ids[i] = id.String()
+		}
+		musts = append(musts, elastic.NewIdsQuery().Ids(ids...))
+	}
+
 	limit, offset := 20, 0
 	if q.Limit.Valid {
 		limit = q.Limit.V
@@ -284,19 +325,28 @@ func (e *esEngine) Do(q *Query) (Result, error) {
 	// NOTE: 現状`sort.Key`はそのままesのソートキーとして使える前提
 	sort := q.GetSortKey()
 
-	sr, err := e.client.Search().
+	// NOTE: 同じ日時のメッセージの順番を確定させるため、messageIdでも並べる
+	// _idでのソートはfielddataを必要とするため、keywordのmessageIdを用いる
+	search := e.client.Search().
 		Index(getIndexName(esMessageIndex)).
 		Query(elastic.NewBoolQuery().Must(musts...)).
+		Highlight(newESHighlight()).
 		Sort(sort.Key, !sort.Desc).
-		Size(limit).
-		From(offset).
-		Do(context.Background())
+		Sort("messageId", !sort.Desc).
+		Size(limit)
+	if c, ok := q.GetCursor(); ok {
+		// Elasticsearchはミリ秒精度で日時を保持している
+		search = search.SearchAfter(c.Value.UnixMilli(), c.ID.String())
+	} else {
+		search = search.From(offset)
+	}
+	sr, err := search.Do(context.Background())
 	if err != nil {
 		return nil, err
 	}
 
 	e.l.Debug("search result", zap.Reflect("hits", sr.Hits))
-	return e.bindESResult(sr)
+	return e.bindESResult(sr, q, limit)
 }
 
 func (e *esEngine) Available() bool {
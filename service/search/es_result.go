@@ -1,22 +1,47 @@
 package search
 
 import (
+	"strings"
+
 	"github.com/gofrs/uuid"
 	"github.com/olivere/elastic/v7"
 
 	"github.com/traPtitech/traQ/service/message"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+const (
+	// esHighlightPreTag ハイライト箇所の開始を表すタグ (私用領域の文字)
+	esHighlightPreTag = "\uE000"
+	// esHighlightPostTag ハイライト箇所の終了を表すタグ (私用領域の文字)
+	esHighlightPostTag = "\uE001"
 )
 
 // esResult search.Result 実装
 type esResult struct {
-	totalHits int64
-	messages  []message.Message
+	totalHits  int64
+	messages   []message.Message
+	highlights []Highlight
+	nextCursor optional.Of[string]
+}
+
+// newESHighlight 検索ワードにマッチした語句を取得するためのハイライト設定
+//
+// Elasticsearchの抜粋はスポイラーを考慮しないため、マッチした語句のみを取得し、
+// 抜粋はスポイラーを塗りつぶした本文からmakeHighlightで生成します。
+func newESHighlight() *elastic.Highlight {
+	return elastic.NewHighlight().
+		Field("text").
+		NumOfFragments(0).
+		PreTags(esHighlightPreTag).
+		PostTags(esHighlightPostTag)
 }
 
-func (e *esEngine) bindESResult(sr *elastic.SearchResult) (Result, error) {
+func (e *esEngine) bindESResult(sr *elastic.SearchResult, q *Query, limit int) (Result, error) {
 	r := &esResult{
-		totalHits: sr.TotalHits(),
-		messages:  make([]message.Message, 0, len(sr.Hits.Hits)),
+		totalHits:  sr.TotalHits(),
+		messages:   make([]message.Message, 0, len(sr.Hits.Hits)),
+		highlights: make([]Highlight, 0, len(sr.Hits.Hits)),
 	}
 
 	for _, hit := range sr.Hits.Hits {
@@ -26,11 +51,46 @@ func (e *esEngine) bindESResult(sr *elastic.SearchResult) (Result, error) {
 			return nil, err
 		}
 		r.messages = append(r.messages, m)
+
+		terms := extractESHighlightedTerms(hit.Highlight["text"])
+		if len(terms) == 0 {
+			terms = highlightTerms(q.Word.ValueOrZero())
+		}
+		r.highlights = append(r.highlights, makeHighlight(m, terms))
 	}
+	r.nextCursor = makeNextCursor(q, r.messages, limit)
 
 	return r, nil
 }
 
+// extractESHighlightedTerms Elasticsearchのハイライト結果からマッチした語句を重複なく取り出します
+func extractESHighlightedTerms(fragments []string) []string {
+	var (
+		terms []string
+		seen  = map[string]bool{}
+	)
+	for _, f := range fragments {
+		for {
+			start := strings.Index(f, esHighlightPreTag)
+			if start < 0 {
+				break
+			}
+			f = f[start+len(esHighlightPreTag):]
+			end := strings.Index(f, esHighlightPostTag)
+			if end < 0 {
+				break
+			}
+			term := strings.ToLower(f[:end])
+			f = f[end+len(esHighlightPostTag):]
+			if term != "" && !seen[term] {
+				seen[term] = true
+				terms = append(terms, term)
+			}
+		}
+	}
+	return terms
+}
+
 func (e *esResult) TotalHits() int64 {
 	return e.totalHits
 }
@@ -38,3 +98,11 @@ func (e *esResult) TotalHits() int64 {
 func (e *esResult) Hits() []message.Message {
 	return e.messages
 }
+
+func (e *esResult) Highlights() []Highlight {
+	return e.highlights
+}
+
+func (e *esResult) NextCursor() optional.Of[string] {
+	return e.nextCursor
+}
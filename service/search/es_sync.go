@@ -47,9 +47,10 @@ func (e *esEngine) convertMessageCreated(m *model.Message, parseResult *message.
 		isBot = user.IsBot()
 	}
 
-	attr := e.getAttributes(m, parseResult)
+	attr := getAttributes(e.repo, e.l, m, parseResult)
 
 	return &esMessageDoc{
+		ID:             m.ID,
 		UserID:         m.UserID,
 		ChannelID:      m.ChannelID,
 		IsPublic:       e.cm.IsPublicChannel(m.ChannelID),
@@ -69,7 +70,7 @@ func (e *esEngine) convertMessageCreated(m *model.Message, parseResult *message.
 
 // convertMessageUpdated 既存メッセージの更新情報をesへ入れる型に変換する
 func (e *esEngine) convertMessageUpdated(m *model.Message, parseResult *message.ParseResult) *esMessageDocUpdate {
-	attr := e.getAttributes(m, parseResult)
+	attr := getAttributes(e.repo, e.l, m, parseResult)
 	// Updateする項目のみ
 	return &esMessageDocUpdate{
 		Text:           m.Text,
@@ -83,7 +84,8 @@ func (e *esEngine) convertMessageUpdated(m *model.Message, parseResult *message.
 	}
 }
 
-func (e *esEngine) getAttributes(m *model.Message, parseResult *message.ParseResult) *attributes {
+// getAttributes メッセージの検索用の属性を抽出します
+func getAttributes(repo repository.Repository, l *zap.Logger, m *model.Message, parseResult *message.ParseResult) *attributes {
 	attr := &attributes{}
 
 	attr.To = append(parseResult.Mentions, parseResult.GroupMentions...)
@@ -92,9 +94,9 @@ func (e *esEngine) getAttributes(m *model.Message, parseResult *message.ParseRes
 	attr.HasAttachments = len(parseResult.Attachments) != 0
 
 	for _, attachmentID := range parseResult.Attachments {
-		meta, err := e.repo.GetFileMeta(attachmentID)
+		meta, err := repo.GetFileMeta(attachmentID)
 		if err != nil {
-			e.l.Warn(err.Error(), zap.Error(err))
+			l.Warn(err.Error(), zap.Error(err))
 			continue
 		}
 		if strings.HasPrefix(meta.Mime, "image/") {
@@ -127,12 +129,13 @@ loop:
 	}
 }
 
-func (e *esEngine) newUserCache() (userCache, error) {
-	users, err := e.repo.GetUsers(repository.UsersQuery{})
+// newUserCache ユーザーがBotかどうかのキャッシュを作成します
+func newUserCache(repo repository.Repository, l *zap.Logger) (userCache, error) {
+	users, err := repo.GetUsers(repository.UsersQuery{})
 	if err != nil {
 		return nil, err
 	}
-	e.l.Debug("making user cache of size", zap.Int("size", len(users)))
+	l.Debug("making user cache of size", zap.Int("size", len(users)))
 
 	cache := make(map[uuid.UUID]bool, len(users))
 	for _, u := range users {
@@ -166,16 +169,18 @@ func (e *esEngine) sync() error {
 		// ユーザーキャッシュサービスができたら書き換えても良い
 		if userCache == nil && more {
 			// 新規メッセージが2ページ以上の時のみデータが入ったキャッシュを作成
-			userCache, err = e.newUserCache()
+			userCache, err = newUserCache(e.repo, e.l)
 			if err != nil {
 				return err
 			}
 		}
 
 		bulk := e.client.Bulk().Index(getIndexName(esMessageIndex))
+		created := make([]*model.Message, 0, len(messages))
 		for _, v := range messages {
 			var bulkReq elastic.BulkableRequest
 			if v.CreatedAt.After(lastSynced) {
+				created = append(created, v)
 				doc, err := e.convertMessageCreated(v, message.Parse(v.Text), userCache)
 				if err != nil {
 					return err
@@ -197,6 +202,17 @@ func (e *esEngine) sync() error {
 
 		e.l.Info(fmt.Sprintf("indexed %v message(s) to index, updated %v message(s) on index, last insert %v", len(res.Indexed()), len(res.Updated()), lastInsert))
 
+		// 初回のインデックス作成時は保存された検索を実行しない
+		if !lastSynced.IsZero() && len(created) > 0 {
+			// 検索できるようにインデックスを更新してから実行する
+			if _, err := e.client.Refresh(getIndexName(esMessageIndex)).Do(context.Background()); err != nil {
+				return err
+			}
+			if err := e.saved.evaluate(created); err != nil {
+				e.l.Error("failed to evaluate saved searches", zap.Error(err))
+			}
+		}
+
 		if !more {
 			break
 		}
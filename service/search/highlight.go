@@ -0,0 +1,180 @@
+package search
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/service/message"
+	mutil "github.com/traPtitech/traQ/utils/message"
+)
+
+const (
+	// highlightFragmentSize 1つの断片の最大文字数
+	highlightFragmentSize = 100
+	// highlightMaxFragments 1つのメッセージあたりの最大断片数
+	highlightMaxFragments = 3
+)
+
+// Highlight ヒットしたメッセージのハイライト情報
+type Highlight struct {
+	MessageID uuid.UUID
+	// Fragments メッセージ本文の抜粋
+	Fragments []Fragment
+}
+
+// Fragment メッセージ本文の抜粋
+type Fragment struct {
+	// Text 抜粋した本文 スポイラーは塗りつぶされています
+	Text string
+	// Matches Text中の検索ワードにマッチした箇所
+	Matches []MatchRange
+}
+
+// MatchRange マッチした箇所 [Start, End) 文字単位
+type MatchRange struct {
+	Start int
+	End   int
+}
+
+// highlightTerms 検索ワードからハイライトする語句を抽出します
+func highlightTerms(word string) []string {
+	var terms []string
+	for _, w := range splitWords(word) {
+		switch {
+		case w == "|":
+			continue
+		case strings.HasPrefix(w, "-"):
+			continue
+		case strings.HasPrefix(w, "+"):
+			w = w[1:]
+		}
+		if w = strings.TrimSpace(w); w != "" {
+			terms = append(terms, w)
+		}
+	}
+	return terms
+}
+
+// snippetText ハイライトに用いる本文を返します
+//
+// 埋め込みを展開し、スポイラーを塗りつぶすため、隠された文字列が抜粋に含まれることはありません。
+func snippetText(text string) []rune {
+	runes := []rune(mutil.FillSpoiler(mutil.Parse(text).PlainText))
+	for i, r := range runes {
+		if r == '\n' || r == '\r' || r == '\t' {
+			runes[i] = ' '
+		}
+	}
+	return runes
+}
+
+// makeHighlight メッセージ本文中のtermsにマッチした箇所からハイライト情報を生成します
+//
+// マッチした箇所が無い場合は本文の先頭を抜粋します。
+func makeHighlight(m message.Message, terms []string) Highlight {
+	text := snippetText(m.GetText())
+	h := Highlight{MessageID: m.GetID()}
+	if len(text) == 0 {
+		return h
+	}
+
+	matches := findMatches(text, terms)
+	if len(matches) == 0 {
+		end := len(text)
+		if end > highlightFragmentSize {
+			end = highlightFragmentSize
+		}
+		h.Fragments = []Fragment{{Text: string(text[:end]), Matches: []MatchRange{}}}
+		return h
+	}
+
+	for i := 0; i < len(matches) && len(h.Fragments) < highlightMaxFragments; {
+		first := matches[i]
+		start := first.Start - (highlightFragmentSize-(first.End-first.Start))/2
+		if start < 0 {
+			start = 0
+		}
+		end := start + highlightFragmentSize
+		if end > len(text) {
+			end = len(text)
+			start = end - highlightFragmentSize
+			if start < 0 {
+				start = 0
+			}
+		}
+		if end < first.End {
+			// 語句自体が断片より長い
+			end = first.End
+		}
+
+		f := Fragment{Text: string(text[start:end])}
+		for ; i < len(matches) && matches[i].End <= end; i++ {
+			f.Matches = append(f.Matches, MatchRange{Start: matches[i].Start - start, End: matches[i].End - start})
+		}
+		h.Fragments = append(h.Fragments, f)
+	}
+	return h
+}
+
+// findMatches textからtermsにマッチした箇所を重ならないように昇順で返します
+//
+// 大文字小文字は区別しません。
+func findMatches(text []rune, terms []string) []MatchRange {
+	lower := foldRunes(text)
+
+	var matches []MatchRange
+	for _, term := range terms {
+		t := foldRunes([]rune(term))
+		if len(t) == 0 {
+			continue
+		}
+		for i := 0; i+len(t) <= len(lower); i++ {
+			if equalRunes(lower[i:i+len(t)], t) {
+				matches = append(matches, MatchRange{Start: i, End: i + len(t)})
+				i += len(t) - 1
+			}
+		}
+	}
+	if len(matches) == 0 {
+		return nil
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Start == matches[j].Start {
+			return matches[i].End > matches[j].End
+		}
+		return matches[i].Start < matches[j].Start
+	})
+	merged := matches[:1]
+	for _, m := range matches[1:] {
+		last := &merged[len(merged)-1]
+		if m.Start < last.End {
+			if m.End > last.End {
+				last.End = m.End
+			}
+			continue
+		}
+		merged = append(merged, m)
+	}
+	return merged
+}
+
+func foldRunes(rs []rune) []rune {
+	res := make([]rune, len(rs))
+	for i, r := range rs {
+		res[i] = unicode.ToLower(r)
+	}
+	return res
+}
+
+func equalRunes(a, b []rune) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
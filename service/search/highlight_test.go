@@ -0,0 +1,96 @@
+package search
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/traPtitech/traQ/service/message"
+)
+
+type testMessage struct {
+	message.Message
+	id   uuid.UUID
+	text string
+}
+
+func (m *testMessage) GetID() uuid.UUID {
+	return m.id
+}
+
+func (m *testMessage) GetText() string {
+	return m.text
+}
+
+func TestHighlightTerms(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []string{"foo", "bar baz", "qux"}, highlightTerms(`foo "bar baz" | +qux -quux`))
+	assert.Nil(t, highlightTerms(""))
+}
+
+func TestMakeHighlight(t *testing.T) {
+	t.Parallel()
+
+	id := uuid.NewV3(uuid.Nil, "m")
+
+	t.Run("match", func(t *testing.T) {
+		t.Parallel()
+		h := makeHighlight(&testMessage{id: id, text: "Hello traQ\nhello world"}, []string{"hello"})
+		assert.Equal(t, Highlight{
+			MessageID: id,
+			Fragments: []Fragment{{
+				Text:    "Hello traQ hello world",
+				Matches: []MatchRange{{Start: 0, End: 5}, {Start: 11, End: 16}},
+			}},
+		}, h)
+	})
+
+	t.Run("spoiler is masked", func(t *testing.T) {
+		t.Parallel()
+		h := makeHighlight(&testMessage{id: id, text: "secret !!secret!!"}, []string{"secret"})
+		assert.Equal(t, []Fragment{{
+			Text:    "secret ██████",
+			Matches: []MatchRange{{Start: 0, End: 6}},
+		}}, h.Fragments)
+	})
+
+	t.Run("only in spoiler", func(t *testing.T) {
+		t.Parallel()
+		h := makeHighlight(&testMessage{id: id, text: "foo !!secret!!"}, []string{"secret"})
+		assert.Equal(t, []Fragment{{Text: "foo ██████", Matches: []MatchRange{}}}, h.Fragments)
+	})
+
+	t.Run("multiple fragments", func(t *testing.T) {
+		t.Parallel()
+		pad := strings.Repeat("あ", highlightFragmentSize)
+		h := makeHighlight(&testMessage{id: id, text: "x" + pad + "x" + pad + "x" + pad + "x"}, []string{"x"})
+		if assert.Len(t, h.Fragments, highlightMaxFragments) {
+			for _, f := range h.Fragments {
+				assert.LessOrEqual(t, len([]rune(f.Text)), highlightFragmentSize)
+				if assert.Len(t, f.Matches, 1) {
+					m := f.Matches[0]
+					assert.Equal(t, "x", string([]rune(f.Text)[m.Start:m.End]))
+				}
+			}
+		}
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		t.Parallel()
+		h := makeHighlight(&testMessage{id: id, text: ""}, []string{"x"})
+		assert.Empty(t, h.Fragments)
+	})
+}
+
+func TestExtractESHighlightedTerms(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []string{"traq", "hello"}, extractESHighlightedTerms([]string{
+		esHighlightPreTag + "traQ" + esHighlightPostTag + " says " + esHighlightPreTag + "hello" + esHighlightPostTag,
+		esHighlightPreTag + "TRAQ" + esHighlightPostTag,
+	}))
+	assert.Nil(t, extractESHighlightedTerms(nil))
+}
@@ -1,5 +1,7 @@
 package search
 
+import "github.com/gofrs/uuid"
+
 var nullE = &nullEngine{}
 
 type nullEngine struct{}
@@ -20,3 +22,24 @@ func (n *nullEngine) Available() bool {
 func (n *nullEngine) Close() error {
 	return nil
 }
+
+var nullEntityE = &nullEntityEngine{}
+
+type nullEntityEngine struct{}
+
+// NewNullEntityEngine 常に利用不可なファイル・チャンネル・ユーザー検索エンジンを返します
+func NewNullEntityEngine() EntityEngine {
+	return nullEntityE
+}
+
+func (n *nullEntityEngine) Do(uuid.UUID, *EntityQuery) (EntityResult, error) {
+	return nil, ErrServiceUnavailable
+}
+
+func (n *nullEntityEngine) Available() bool {
+	return false
+}
+
+func (n *nullEntityEngine) Close() error {
+	return nil
+}
@@ -0,0 +1,242 @@
+package search
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/service/channel"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+// QueryParseError 検索クエリ言語の構文エラー
+type QueryParseError struct {
+	Offset  int    `json:"offset"`  // エラー箇所の先頭位置(文字単位)
+	Token   string `json:"token"`   // エラー箇所のトークン
+	Message string `json:"message"` // エラー内容
+}
+
+func (e *QueryParseError) Error() string {
+	return fmt.Sprintf("%s at %d: %s", e.Message, e.Offset, e.Token)
+}
+
+// QueryParseErrors 検索クエリ言語の構文エラーの集合
+type QueryParseErrors []*QueryParseError
+
+func (e QueryParseErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// queryToken 検索クエリ言語のトークン
+type queryToken struct {
+	offset int
+	text   string
+}
+
+// ParseQuery 検索クエリ言語で記述されたtextを解釈し、qに反映します
+//
+// 使用できる演算子は次の通りです。これら以外は検索ワードとして扱われます。
+//
+//	from:@name      投稿者
+//	to:@name        メンション先
+//	in:#path        投稿チャンネル(公開チャンネルのパス)
+//	citation:id     引用しているメッセージ
+//	has:image       添付ファイル(画像) video, audio, url, attachment も可
+//	is:bot          投稿者がBot
+//	before:date     投稿日時が指定日時より前 2006-01-02 またはRFC3339形式
+//	after:date      投稿日時が指定日時より後
+//	sort:key        並び順
+//
+// has:, is: は先頭に`-`を付けると否定になります。
+// 構文エラーがあった場合は QueryParseErrors を返します。
+func ParseQuery(q *Query, text string, users repository.UserRepository, tree channel.Tree) error {
+	tokens, errs := tokenizeQuery(text)
+
+	var (
+		words []string
+		seen  = map[string]bool{}
+	)
+	for _, token := range tokens {
+		negate := strings.HasPrefix(token.text, "-")
+		key, value, ok := strings.Cut(strings.TrimPrefix(token.text, "-"), ":")
+		key = strings.ToLower(key)
+		if !ok || !isQueryOperator(key) {
+			words = append(words, token.text)
+			continue
+		}
+
+		fail := func(msg string) {
+			errs = append(errs, &QueryParseError{Offset: token.offset, Token: token.text, Message: msg})
+		}
+		if value == "" {
+			fail("empty value")
+			continue
+		}
+		if negate && key != "has" && key != "is" {
+			fail("operator cannot be negated")
+			continue
+		}
+		dupKey := key
+		if key == "has" || key == "is" {
+			dupKey = key + ":" + strings.ToLower(value)
+		}
+		if seen[dupKey] {
+			fail("duplicated operator")
+			continue
+		}
+		seen[dupKey] = true
+
+		switch key {
+		case "from", "to":
+			user, err := users.GetUserByName(strings.TrimPrefix(value, "@"), false)
+			if err != nil {
+				if err == repository.ErrNotFound {
+					fail("user not found")
+					continue
+				}
+				return err
+			}
+			if key == "from" {
+				q.From = optional.From(user.GetID())
+			} else {
+				q.To = optional.From(user.GetID())
+			}
+		case "in":
+			id := tree.GetChannelIDFromPath(strings.TrimPrefix(value, "#"))
+			if id == uuid.Nil {
+				fail("channel not found")
+				continue
+			}
+			q.In = optional.From(id)
+		case "citation":
+			id, err := uuid.FromString(value)
+			if err != nil {
+				fail("invalid message id")
+				continue
+			}
+			q.Citation = optional.From(id)
+		case "has":
+			v := optional.From(!negate)
+			switch strings.ToLower(value) {
+			case "image":
+				q.HasImage = v
+			case "video":
+				q.HasVideo = v
+			case "audio":
+				q.HasAudio = v
+			case "url", "link":
+				q.HasURL = v
+			case "attachment", "attachments", "file":
+				q.HasAttachments = v
+			default:
+				fail("unknown has: value")
+			}
+		case "is":
+			switch strings.ToLower(value) {
+			case "bot":
+				q.Bot = optional.From(!negate)
+			default:
+				fail("unknown is: value")
+			}
+		case "before", "after":
+			t, ok := parseQueryTime(value)
+			if !ok {
+				fail("invalid date")
+				continue
+			}
+			if key == "before" {
+				q.Before = optional.From(t)
+			} else {
+				q.After = optional.From(t)
+			}
+		case "sort":
+			if allowedSortKeysRegExp.FindString(value) != value {
+				fail("unknown sort key")
+				continue
+			}
+			q.Sort = optional.From(value)
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	if len(words) > 0 {
+		if q.Word.Valid && q.Word.V != "" {
+			words = append([]string{q.Word.V}, words...)
+		}
+		q.Word = optional.From(strings.Join(words, " "))
+	}
+	return nil
+}
+
+// tokenizeQuery textを空白で区切ってトークンに分割します
+//
+// ダブルクオートで囲まれた部分は空白を含めて1つのトークンになります。
+func tokenizeQuery(text string) ([]queryToken, QueryParseErrors) {
+	var (
+		tokens  []queryToken
+		current []rune
+		start   int
+		inQuote bool
+		quoteAt int
+	)
+	flush := func() {
+		if len(current) > 0 {
+			tokens = append(tokens, queryToken{offset: start, text: string(current)})
+			current = nil
+		}
+	}
+
+	for i, r := range []rune(text) {
+		switch {
+		case r == '"':
+			if !inQuote {
+				quoteAt = i
+			}
+			inQuote = !inQuote
+		case unicode.IsSpace(r) && !inQuote:
+			flush()
+			continue
+		}
+		if len(current) == 0 {
+			start = i
+		}
+		current = append(current, r)
+	}
+	flush()
+
+	if inQuote {
+		last := tokens[len(tokens)-1]
+		return tokens, QueryParseErrors{{Offset: quoteAt, Token: last.text, Message: "unterminated quoted phrase"}}
+	}
+	return tokens, nil
+}
+
+func isQueryOperator(key string) bool {
+	switch key {
+	case "from", "to", "in", "citation", "has", "is", "before", "after", "sort":
+		return true
+	default:
+		return false
+	}
+}
+
+// parseQueryTime RFC3339形式または日付のみ(サーバーのタイムゾーン)の文字列を解釈します
+func parseQueryTime(s string) (time.Time, bool) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, true
+	}
+	if t, err := time.ParseInLocation("2006-01-02", s, time.Local); err == nil {
+		return t, true
+	}
+	return time.Time{}, false
+}
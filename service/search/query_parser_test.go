@@ -0,0 +1,101 @@
+package search
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/repository/mock_repository"
+	"github.com/traPtitech/traQ/service/channel/mock_channel"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+func TestParseQuery(t *testing.T) {
+	t.Parallel()
+
+	alice := &model.User{ID: uuid.NewV3(uuid.Nil, "alice"), Name: "alice"}
+	bob := &model.User{ID: uuid.NewV3(uuid.Nil, "bob"), Name: "bob"}
+	dev := uuid.NewV3(uuid.Nil, "general/dev")
+	cited := uuid.NewV3(uuid.Nil, "message")
+
+	setup := func(t *testing.T) (*mock_repository.MockUserRepository, *mock_channel.MockTree) {
+		ctrl := gomock.NewController(t)
+		repo := mock_repository.NewMockUserRepository(ctrl)
+		repo.EXPECT().GetUserByName("alice", false).Return(alice, nil).AnyTimes()
+		repo.EXPECT().GetUserByName("bob", false).Return(bob, nil).AnyTimes()
+		repo.EXPECT().GetUserByName(gomock.Any(), false).Return(nil, repository.ErrNotFound).AnyTimes()
+		tree := mock_channel.NewMockTree(ctrl)
+		tree.EXPECT().GetChannelIDFromPath("general/dev").Return(dev).AnyTimes()
+		tree.EXPECT().GetChannelIDFromPath(gomock.Any()).Return(uuid.Nil).AnyTimes()
+		return repo, tree
+	}
+
+	t.Run("operators", func(t *testing.T) {
+		t.Parallel()
+		repo, tree := setup(t)
+
+		var q Query
+		err := ParseQuery(&q, `from:@alice to:bob in:#general/dev has:image -is:bot citation:`+cited.String()+` before:2024-01-01 after:2023-12-01T00:00:00Z sort:-createdAt "exact phrase" foo`, repo, tree)
+		require.NoError(t, err)
+
+		assert.Equal(t, optional.From(alice.ID), q.From)
+		assert.Equal(t, optional.From(bob.ID), q.To)
+		assert.Equal(t, optional.From(dev), q.In)
+		assert.Equal(t, optional.From(cited), q.Citation)
+		assert.Equal(t, optional.From(true), q.HasImage)
+		assert.Equal(t, optional.From(false), q.Bot)
+		assert.Equal(t, optional.From(time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local)), q.Before)
+		assert.Equal(t, optional.From(time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC)), q.After)
+		assert.Equal(t, optional.From("-createdAt"), q.Sort)
+		assert.Equal(t, optional.From(`"exact phrase" foo`), q.Word)
+	})
+
+	t.Run("keeps existing word and unknown keys", func(t *testing.T) {
+		t.Parallel()
+		repo, tree := setup(t)
+
+		q := Query{Word: optional.From("traQ")}
+		err := ParseQuery(&q, "https://example.com -bar", repo, tree)
+		require.NoError(t, err)
+
+		assert.Equal(t, optional.From("traQ https://example.com -bar"), q.Word)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		t.Parallel()
+		repo, tree := setup(t)
+
+		var q Query
+		err := ParseQuery(&q, "from:@nobody in:#unknown has:gif -from:alice before:yesterday is:bot is:bot sort:foo", repo, tree)
+		require.Error(t, err)
+
+		errs, ok := err.(QueryParseErrors)
+		require.True(t, ok)
+		assert.Equal(t, QueryParseErrors{
+			{Offset: 0, Token: "from:@nobody", Message: "user not found"},
+			{Offset: 13, Token: "in:#unknown", Message: "channel not found"},
+			{Offset: 25, Token: "has:gif", Message: "unknown has: value"},
+			{Offset: 33, Token: "-from:alice", Message: "operator cannot be negated"},
+			{Offset: 45, Token: "before:yesterday", Message: "invalid date"},
+			{Offset: 69, Token: "is:bot", Message: "duplicated operator"},
+			{Offset: 76, Token: "sort:foo", Message: "unknown sort key"},
+		}, errs)
+	})
+
+	t.Run("unterminated quote", func(t *testing.T) {
+		t.Parallel()
+		repo, tree := setup(t)
+
+		var q Query
+		err := ParseQuery(&q, `foo "bar baz`, repo, tree)
+		assert.Equal(t, QueryParseErrors{
+			{Offset: 4, Token: `"bar baz`, Message: "unterminated quoted phrase"},
+		}, err)
+	})
+}
@@ -0,0 +1,152 @@
+package search
+
+import (
+	"fmt"
+
+	"github.com/gofrs/uuid"
+	json "github.com/json-iterator/go"
+	"github.com/leandro-lugaresi/hub"
+	"go.uber.org/zap"
+
+	"github.com/traPtitech/traQ/event"
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/service/channel"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+// EncodeSavedQuery 検索クエリを保存用の文字列にエンコードします
+//
+// 取得件数・並び順などのページングに関する条件は保存されません。
+func EncodeSavedQuery(q Query) (string, error) {
+	return json.MarshalToString(q)
+}
+
+// DecodeSavedQuery EncodeSavedQuery でエンコードされた検索クエリをデコードします
+func DecodeSavedQuery(s string) (Query, error) {
+	var q Query
+	if err := json.UnmarshalFromString(s, &q); err != nil {
+		return Query{}, err
+	}
+	return q, nil
+}
+
+// HasNarrowingConditions 検索ワード・チャンネル・ユーザー・引用のいずれかで絞り込まれているかどうか
+//
+// 保存された検索が全てのメッセージにヒットしないように使います。
+func (q Query) HasNarrowingConditions() bool {
+	return q.Word.Valid || q.In.Valid || q.To.Valid || q.From.Valid || q.Citation.Valid
+}
+
+// maxPendingSavedSearchMessages 評価に失敗した場合に、次回の再評価のために保持するメッセージの最大数
+const maxPendingSavedSearchMessages = 1000
+
+// savedSearchEvaluator 保存された検索を新しくインデックスされたメッセージに対して実行し、
+// ヒットしたメッセージがあれば event.SavedSearchMatched を発行します
+//
+// 評価に失敗したメッセージは保持しておき、次回の評価時に再評価します。
+// 保持しているメッセージはメモリ上にのみ存在するため、再起動すると失われます。
+type savedSearchEvaluator struct {
+	engine Engine
+	cm     channel.Manager
+	repo   repository.Repository
+	hub    *hub.Hub
+	l      *zap.Logger
+
+	// unevaluated 保存された検索の取得に失敗したため、まだ評価していないメッセージ
+	unevaluated []*model.Message
+	// pending 保存された検索ごとの、評価に失敗したため再評価するメッセージ
+	pending map[uuid.UUID][]*model.Message
+}
+
+// evaluate 保存された全ての検索を、新しくインデックスされたメッセージmessagesと、前回評価に失敗したメッセージに対して実行します
+func (ev *savedSearchEvaluator) evaluate(messages []*model.Message) error {
+	messages = append(ev.unevaluated, messages...)
+	if len(messages) == 0 {
+		return nil
+	}
+
+	sss, err := ev.repo.GetAllSavedSearches()
+	if err != nil {
+		ev.unevaluated = ev.truncatePending(messages)
+		return err
+	}
+	ev.unevaluated = nil
+
+	pending := make(map[uuid.UUID][]*model.Message, len(ev.pending))
+	for _, ss := range sss {
+		targets := append(ev.pending[ss.ID], messages...)
+
+		// 自分の投稿と、検索を保存する前の投稿は対象外
+		ids := make([]uuid.UUID, 0, len(targets))
+		for _, m := range targets {
+			if m.UserID != ss.UserID && m.CreatedAt.After(ss.CreatedAt) {
+				ids = append(ids, m.ID)
+			}
+		}
+		if len(ids) == 0 {
+			continue
+		}
+
+		hits, err := ev.do(ss, ids)
+		if err != nil {
+			ev.l.Error("failed to evaluate saved search, will retry on next sync", zap.Error(err), zap.Stringer("savedSearchId", ss.ID))
+			pending[ss.ID] = ev.truncatePending(targets)
+			continue
+		}
+		if len(hits) == 0 {
+			continue
+		}
+		ev.hub.Publish(hub.Message{
+			Name: event.SavedSearchMatched,
+			Fields: hub.Fields{
+				"user_id":      ss.UserID,
+				"saved_search": ss,
+				"message_ids":  hits,
+			},
+		})
+	}
+	// 削除された保存された検索の分は破棄される
+	ev.pending = pending
+	return nil
+}
+
+// truncatePending 再評価のために保持するメッセージを最大数まで減らします
+func (ev *savedSearchEvaluator) truncatePending(messages []*model.Message) []*model.Message {
+	if len(messages) <= maxPendingSavedSearchMessages {
+		return messages
+	}
+	dropped := len(messages) - maxPendingSavedSearchMessages
+	ev.l.Warn(fmt.Sprintf("dropped %v message(s) pending saved search evaluation", dropped))
+	return messages[dropped:]
+}
+
+// do 保存された検索をidsのメッセージに限定して実行し、ヒットしたメッセージのIDを返します
+func (ev *savedSearchEvaluator) do(ss *model.SavedSearch, ids []uuid.UUID) ([]uuid.UUID, error) {
+	q, err := DecodeSavedQuery(ss.Query)
+	if err != nil {
+		return nil, err
+	}
+	if q.In.Valid {
+		// 保存後にチャンネルにアクセスできなくなっている可能性がある
+		ok, err := ev.cm.IsChannelAccessibleToUser(ss.UserID, q.In.V)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, nil
+		}
+	}
+	q.messageIDs = ids
+	q.Limit = optional.From(len(ids))
+
+	r, err := ev.engine.Do(&q)
+	if err != nil {
+		return nil, err
+	}
+	hits := make([]uuid.UUID, len(r.Hits()))
+	for i, m := range r.Hits() {
+		hits[i] = m.GetID()
+	}
+	return hits, nil
+}
@@ -0,0 +1,180 @@
+package search
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang/mock/gomock"
+	"github.com/leandro-lugaresi/hub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/traPtitech/traQ/event"
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository/mock_repository"
+	"github.com/traPtitech/traQ/service/channel/mock_channel"
+	"github.com/traPtitech/traQ/service/message"
+	"github.com/traPtitech/traQ/testUtils"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+type savedSearchTestRepo struct {
+	*mock_repository.MockSavedSearchRepository
+	testUtils.EmptyTestRepository
+}
+
+// savedSearchTestEngine 渡されたクエリのmessageIDsのうち、hitsに含まれるものを返す検索エンジン
+type savedSearchTestEngine struct {
+	Engine
+	hits    map[uuid.UUID]bool
+	queries []*Query
+	err     error
+}
+
+func (e *savedSearchTestEngine) Do(q *Query) (Result, error) {
+	e.queries = append(e.queries, q)
+	if e.err != nil {
+		return nil, e.err
+	}
+	var hits []message.Message
+	for _, id := range q.messageIDs {
+		if e.hits[id] {
+			hits = append(hits, &testMessage{id: id})
+		}
+	}
+	return &dbResult{messages: hits}, nil
+}
+
+func TestSavedQuery(t *testing.T) {
+	t.Parallel()
+
+	in := uuid.NewV3(uuid.Nil, "c")
+	s, err := EncodeSavedQuery(Query{
+		Word:   optional.From("incident"),
+		In:     optional.From(in),
+		Limit:  optional.From(10),
+		Sort:   optional.From("createdAt"),
+		Cursor: optional.From("cursor"),
+	})
+	require.NoError(t, err)
+
+	q, err := DecodeSavedQuery(s)
+	if assert.NoError(t, err) {
+		assert.Equal(t, optional.From("incident"), q.Word)
+		assert.Equal(t, optional.From(in), q.In)
+		assert.False(t, q.Limit.Valid)
+		assert.False(t, q.Sort.Valid)
+		assert.False(t, q.Cursor.Valid)
+	}
+
+	_, err = DecodeSavedQuery("invalid")
+	assert.Error(t, err)
+}
+
+func TestQuery_HasNarrowingConditions(t *testing.T) {
+	t.Parallel()
+
+	assert.False(t, Query{}.HasNarrowingConditions())
+	assert.False(t, Query{HasURL: optional.From(true), Bot: optional.From(false)}.HasNarrowingConditions())
+	assert.True(t, Query{Word: optional.From("a")}.HasNarrowingConditions())
+	assert.True(t, Query{From: optional.From(uuid.NewV3(uuid.Nil, "u"))}.HasNarrowingConditions())
+}
+
+func TestSavedSearchEvaluator_evaluate(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	repo := &savedSearchTestRepo{MockSavedSearchRepository: mock_repository.NewMockSavedSearchRepository(ctrl)}
+	cm := mock_channel.NewMockManager(ctrl)
+	h := hub.New()
+	sub := h.Subscribe(10, event.SavedSearchMatched)
+	defer h.Unsubscribe(sub)
+
+	now := time.Now()
+	owner := uuid.NewV3(uuid.Nil, "owner")
+	other := uuid.NewV3(uuid.Nil, "other")
+	private := uuid.NewV3(uuid.Nil, "private")
+
+	old := &model.Message{ID: uuid.NewV3(uuid.Nil, "old"), UserID: other, CreatedAt: now.Add(-time.Hour)}
+	own := &model.Message{ID: uuid.NewV3(uuid.Nil, "own"), UserID: owner, CreatedAt: now}
+	hit := &model.Message{ID: uuid.NewV3(uuid.Nil, "hit"), UserID: other, CreatedAt: now}
+	miss := &model.Message{ID: uuid.NewV3(uuid.Nil, "miss"), UserID: other, CreatedAt: now}
+
+	ss := &model.SavedSearch{ID: uuid.NewV3(uuid.Nil, "ss"), UserID: owner, Query: `{"word":"incident"}`, CreatedAt: now.Add(-time.Minute)}
+	inaccessible := &model.SavedSearch{ID: uuid.NewV3(uuid.Nil, "ss2"), UserID: owner, Query: `{"in":"` + private.String() + `"}`, CreatedAt: now.Add(-time.Minute)}
+	repo.MockSavedSearchRepository.EXPECT().GetAllSavedSearches().Return([]*model.SavedSearch{ss, inaccessible}, nil).Times(1)
+	cm.EXPECT().IsChannelAccessibleToUser(owner, private).Return(false, nil).Times(1)
+
+	engine := &savedSearchTestEngine{hits: map[uuid.UUID]bool{old.ID: true, own.ID: true, hit.ID: true}}
+	ev := &savedSearchEvaluator{engine: engine, cm: cm, repo: repo, hub: h, l: zap.NewNop()}
+	require.NoError(t, ev.evaluate([]*model.Message{old, own, hit, miss}))
+
+	// 自分の投稿と保存前の投稿は検索しない
+	if assert.Len(t, engine.queries, 1) {
+		assert.Equal(t, []uuid.UUID{hit.ID, miss.ID}, engine.queries[0].messageIDs)
+		assert.Equal(t, optional.From("incident"), engine.queries[0].Word)
+	}
+
+	select {
+	case msg := <-sub.Receiver:
+		assert.Equal(t, owner, msg.Fields["user_id"])
+		assert.Equal(t, ss, msg.Fields["saved_search"])
+		assert.Equal(t, []uuid.UUID{hit.ID}, msg.Fields["message_ids"])
+	case <-time.After(time.Second):
+		t.Fatal("event was not published")
+	}
+}
+
+func TestSavedSearchEvaluator_evaluate_Retry(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	repo := &savedSearchTestRepo{MockSavedSearchRepository: mock_repository.NewMockSavedSearchRepository(ctrl)}
+	h := hub.New()
+	sub := h.Subscribe(10, event.SavedSearchMatched)
+	defer h.Unsubscribe(sub)
+
+	now := time.Now()
+	owner := uuid.NewV3(uuid.Nil, "owner")
+	other := uuid.NewV3(uuid.Nil, "other")
+
+	m1 := &model.Message{ID: uuid.NewV3(uuid.Nil, "m1"), UserID: other, CreatedAt: now}
+	m2 := &model.Message{ID: uuid.NewV3(uuid.Nil, "m2"), UserID: other, CreatedAt: now}
+	ss := &model.SavedSearch{ID: uuid.NewV3(uuid.Nil, "ss"), UserID: owner, Query: `{"word":"incident"}`, CreatedAt: now.Add(-time.Minute)}
+	gomock.InOrder(
+		repo.MockSavedSearchRepository.EXPECT().GetAllSavedSearches().Return(nil, errors.New("db error")).Times(1),
+		repo.MockSavedSearchRepository.EXPECT().GetAllSavedSearches().Return([]*model.SavedSearch{ss}, nil).Times(2),
+	)
+
+	engine := &savedSearchTestEngine{hits: map[uuid.UUID]bool{m1.ID: true, m2.ID: true}, err: errors.New("search error")}
+	ev := &savedSearchEvaluator{engine: engine, repo: repo, hub: h, l: zap.NewNop()}
+
+	// 保存された検索の取得に失敗した場合は、次回評価する
+	assert.Error(t, ev.evaluate([]*model.Message{m1}))
+	assert.Empty(t, engine.queries)
+
+	// 検索に失敗した場合は、次回再評価する
+	require.NoError(t, ev.evaluate(nil))
+	if assert.Len(t, engine.queries, 1) {
+		assert.Equal(t, []uuid.UUID{m1.ID}, engine.queries[0].messageIDs)
+	}
+
+	engine.err = nil
+	require.NoError(t, ev.evaluate([]*model.Message{m2}))
+	if assert.Len(t, engine.queries, 2) {
+		assert.Equal(t, []uuid.UUID{m1.ID, m2.ID}, engine.queries[1].messageIDs)
+	}
+	select {
+	case msg := <-sub.Receiver:
+		assert.Equal(t, []uuid.UUID{m1.ID, m2.ID}, msg.Fields["message_ids"])
+	case <-time.After(time.Second):
+		t.Fatal("event was not published")
+	}
+
+	// 評価に成功したメッセージは再評価しない
+	require.NoError(t, ev.evaluate(nil))
+	assert.Len(t, engine.queries, 2)
+}
@@ -5,6 +5,7 @@ import (
 	botWS "github.com/traPtitech/traQ/service/bot/ws"
 	"github.com/traPtitech/traQ/service/channel"
 	"github.com/traPtitech/traQ/service/counter"
+	"github.com/traPtitech/traQ/service/email"
 	"github.com/traPtitech/traQ/service/exevent"
 	"github.com/traPtitech/traQ/service/fcm"
 	"github.com/traPtitech/traQ/service/file"
@@ -12,7 +13,9 @@ import (
 	"github.com/traPtitech/traQ/service/message"
 	"github.com/traPtitech/traQ/service/notification"
 	"github.com/traPtitech/traQ/service/ogp"
+	"github.com/traPtitech/traQ/service/outgoingwebhook"
 	"github.com/traPtitech/traQ/service/rbac"
+	"github.com/traPtitech/traQ/service/schedule"
 	"github.com/traPtitech/traQ/service/search"
 	"github.com/traPtitech/traQ/service/viewer"
 	"github.com/traPtitech/traQ/service/webrtcv3"
@@ -28,13 +31,17 @@ type Services struct {
 	ChannelCounter       counter.ChannelCounter
 	StampThrottler       *exevent.StampThrottler
 	FCM                  fcm.Client
+	Email                email.Client
 	FileManager          file.Manager
 	Imaging              imaging.Processor
 	MessageManager       message.Manager
 	Notification         *notification.Service
 	OGP                  ogp.Service
+	OutgoingWebhook      outgoingwebhook.Service
 	RBAC                 rbac.RBAC
+	Schedule             schedule.Service
 	Search               search.Engine
+	EntitySearch         search.EntityEngine
 	ViewerManager        *viewer.Manager
 	WebRTCv3             *webrtcv3.Manager
 	WS                   *ws.Streamer
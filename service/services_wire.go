@@ -16,13 +16,17 @@ var ProviderSet = wire.NewSet(wire.FieldsOf(new(*Services),
 	"ChannelCounter",
 	"StampThrottler",
 	"FCM",
+	"Email",
 	"FileManager",
 	"Imaging",
 	"MessageManager",
 	"Notification",
 	"OGP",
+	"OutgoingWebhook",
 	"RBAC",
+	"Schedule",
 	"Search",
+	"EntitySearch",
 	"ViewerManager",
 	"WebRTCv3",
 	"WS",
@@ -13,15 +13,22 @@ type EmptyTestRepository struct {
 	repository.ChannelRepository
 	repository.MessageRepository
 	repository.MessageReportRepository
+	repository.MessageComponentRepository
 	repository.StampRepository
 	repository.StampPaletteRepository
 	repository.StarRepository
+	repository.ThreadRepository
+	repository.ScheduledMessageRepository
+	repository.SavedSearchRepository
 	repository.PinRepository
 	repository.DeviceRepository
 	repository.FileRepository
 	repository.WebhookRepository
+	repository.OutgoingWebhookRepository
 	repository.OAuth2Repository
 	repository.BotRepository
+	repository.BotEventDeliveryRepository
+	repository.BotSlashCommandRepository
 	repository.ClipRepository
 	repository.OgpCacheRepository
 }
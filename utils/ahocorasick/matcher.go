@@ -0,0 +1,98 @@
+// Package ahocorasick Aho-Corasick法による複数パターンの文字列検索
+package ahocorasick
+
+type node struct {
+	next map[byte]int
+	fail int
+	// out このノードで一致するパターンのインデックス(failリンク先の一致を含む)
+	out []int
+}
+
+// Matcher 複数パターンの文字列検索器
+//
+// 構築後は読み取り専用のため、複数のgoroutineから同時に使用できます。
+type Matcher struct {
+	nodes []node
+}
+
+// New patternsを検索するMatcherを生成します
+func New(patterns []string) *Matcher {
+	m := &Matcher{nodes: []node{{next: map[byte]int{}}}}
+
+	// トライ木の構築
+	for i, p := range patterns {
+		if len(p) == 0 {
+			continue
+		}
+		cur := 0
+		for j := 0; j < len(p); j++ {
+			n, ok := m.nodes[cur].next[p[j]]
+			if !ok {
+				m.nodes = append(m.nodes, node{next: map[byte]int{}})
+				n = len(m.nodes) - 1
+				m.nodes[cur].next[p[j]] = n
+			}
+			cur = n
+		}
+		m.nodes[cur].out = append(m.nodes[cur].out, i)
+	}
+
+	// failリンクの構築 (幅優先)
+	queue := make([]int, 0, len(m.nodes))
+	for _, n := range m.nodes[0].next {
+		queue = append(queue, n)
+	}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		for c, n := range m.nodes[cur].next {
+			f := m.nodes[cur].fail
+			for {
+				if to, ok := m.nodes[f].next[c]; ok {
+					m.nodes[n].fail = to
+					break
+				}
+				if f == 0 {
+					m.nodes[n].fail = 0
+					break
+				}
+				f = m.nodes[f].fail
+			}
+			m.nodes[n].out = append(m.nodes[n].out, m.nodes[m.nodes[n].fail].out...)
+			queue = append(queue, n)
+		}
+	}
+	return m
+}
+
+// Match textに含まれるパターンのインデックスを重複なしで返します
+func (m *Matcher) Match(text string) []int {
+	var (
+		res  []int
+		seen map[int]bool
+		cur  = 0
+	)
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		for {
+			if n, ok := m.nodes[cur].next[c]; ok {
+				cur = n
+				break
+			}
+			if cur == 0 {
+				break
+			}
+			cur = m.nodes[cur].fail
+		}
+		for _, p := range m.nodes[cur].out {
+			if seen == nil {
+				seen = map[int]bool{}
+			}
+			if !seen[p] {
+				seen[p] = true
+				res = append(res, p)
+			}
+		}
+	}
+	return res
+}
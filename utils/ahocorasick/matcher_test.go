@@ -0,0 +1,42 @@
+package ahocorasick
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatcher_Match(t *testing.T) {
+	t.Parallel()
+
+	patterns := []string{"he", "she", "his", "hers", "", "トラQ", "q"}
+	m := New(patterns)
+
+	tests := []struct {
+		text string
+		want []int
+	}{
+		{"ushers", []int{0, 1, 3}},
+		{"this is his", []int{2}},
+		{"nothing", nil},
+		{"", nil},
+		{"新しいトラQです", []int{5}},
+		{"トラ", nil},
+		{"qq", []int{6}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.text, func(t *testing.T) {
+			t.Parallel()
+			got := m.Match(tt.text)
+			sort.Ints(got)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMatcher_Empty(t *testing.T) {
+	t.Parallel()
+	assert.Empty(t, New(nil).Match("abc"))
+}
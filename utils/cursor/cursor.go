@@ -0,0 +1,58 @@
+package cursor
+
+import (
+	"encoding/base64"
+	"errors"
+	"time"
+
+	"github.com/gofrs/uuid"
+	jsonIter "github.com/json-iterator/go"
+)
+
+// ErrInvalid 不正なカーソルです
+var ErrInvalid = errors.New("invalid cursor")
+
+// Cursor 並び順における位置を表すカーソル
+//
+// ソートキーの値が同じ場合はIDの順に並んでいることを前提とします。
+type Cursor struct {
+	// Key ソートキー
+	Key string
+	// Desc 降順かどうか
+	Desc bool
+	// Value 位置の要素のソートキーの値
+	Value time.Time
+	// ID 位置の要素のID
+	ID uuid.UUID
+}
+
+type encoded struct {
+	Key   string    `json:"k"`
+	Desc  bool      `json:"d"`
+	Value time.Time `json:"v"`
+	ID    uuid.UUID `json:"i"`
+}
+
+// Encode クライアントに渡す不透明な文字列に変換します
+func (c Cursor) Encode() string {
+	b, _ := jsonIter.ConfigFastest.Marshal(encoded{Key: c.Key, Desc: c.Desc, Value: c.Value, ID: c.ID})
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// Decode Encode で生成された文字列をカーソルに戻します
+//
+// 不正な文字列の場合は ErrInvalid を返します。
+func Decode(s string) (Cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return Cursor{}, ErrInvalid
+	}
+	var e encoded
+	if err := jsonIter.ConfigFastest.Unmarshal(b, &e); err != nil {
+		return Cursor{}, ErrInvalid
+	}
+	if e.Key == "" || e.Value.IsZero() || e.ID == uuid.Nil {
+		return Cursor{}, ErrInvalid
+	}
+	return Cursor{Key: e.Key, Desc: e.Desc, Value: e.Value, ID: e.ID}, nil
+}
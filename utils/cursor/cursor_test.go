@@ -0,0 +1,39 @@
+package cursor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCursor(t *testing.T) {
+	t.Parallel()
+
+	c := Cursor{
+		Key:   "createdAt",
+		Desc:  true,
+		Value: time.Date(2024, 1, 2, 3, 4, 5, 678901000, time.UTC),
+		ID:    uuid.NewV3(uuid.Nil, "m"),
+	}
+
+	t.Run("round trip", func(t *testing.T) {
+		t.Parallel()
+		got, err := Decode(c.Encode())
+		if assert.NoError(t, err) {
+			assert.Equal(t, c.Key, got.Key)
+			assert.Equal(t, c.Desc, got.Desc)
+			assert.True(t, c.Value.Equal(got.Value))
+			assert.Equal(t, c.ID, got.ID)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+		for _, s := range []string{"", "!!!", "e30", Cursor{Key: "createdAt", Value: c.Value}.Encode()} {
+			_, err := Decode(s)
+			assert.ErrorIs(t, err, ErrInvalid, s)
+		}
+	})
+}
@@ -16,6 +16,16 @@ var (
 	ErrInvalidImageSrc        = errors.New("invalid image src")
 )
 
+// Format imagemagickで出力する画像フォーマット
+type Format string
+
+const (
+	FormatPNG  Format = "png"
+	FormatGIF  Format = "gif"
+	FormatWebP Format = "webp"
+	FormatAVIF Format = "avif"
+)
+
 // ConvertToPNG srcをimagemagickでPNGに変換します。5秒以内に変換できなかった場合はエラーとなります
 func ConvertToPNG(ctx context.Context, execPath string, src io.Reader, maxWidth, maxHeight int) (*bytes.Reader, error) {
 	if len(execPath) == 0 {
@@ -43,9 +53,38 @@ func ConvertToPNG(ctx context.Context, execPath string, src io.Reader, maxWidth,
 	return bytes.NewReader(b), nil
 }
 
+// Convert srcの先頭フレームをimagemagickでformatに変換します
+//
+// Goで読み込めない画像(AVIFやアニメーションWebPなど)の読み込みや、Goで書き出せないフォーマットへの書き出しに使用します
+func Convert(ctx context.Context, execPath string, src io.Reader, format Format) (*bytes.Reader, error) {
+	if len(execPath) == 0 {
+		return nil, ErrImageMagickUnavailable
+	}
+
+	cmd := exec.CommandContext(ctx, execPath, "-[0]", "-auto-orient", "-strip", string(format)+":-")
+
+	b, err := cmdPipe(cmd, src)
+	if err != nil {
+		switch err.(type) {
+		case *exec.ExitError:
+			return nil, ErrInvalidImageSrc
+		default:
+			return nil, err
+		}
+	}
+
+	return bytes.NewReader(b), nil
+}
+
 // ResizeAnimationGIF Animation GIF画像をimagemagickでリサイズします
 // expandがfalseの場合、縮小は行いますが拡大は行いません
 func ResizeAnimationGIF(ctx context.Context, execPath string, src io.Reader, maxWidth, maxHeight int, expand bool) (*bytes.Reader, error) {
+	return ResizeAnimation(ctx, execPath, src, FormatGIF, maxWidth, maxHeight, expand)
+}
+
+// ResizeAnimation アニメーション画像をimagemagickでリサイズし、format(GIFまたはWebP)で出力します
+// expandがfalseの場合、縮小は行いますが拡大は行いません
+func ResizeAnimation(ctx context.Context, execPath string, src io.Reader, format Format, maxWidth, maxHeight int, expand bool) (*bytes.Reader, error) {
 	if len(execPath) == 0 {
 		return nil, ErrImageMagickUnavailable
 	}
@@ -58,7 +97,16 @@ func ResizeAnimationGIF(ctx context.Context, execPath string, src io.Reader, max
 	if !expand {
 		sizer += ">"
 	}
-	cmd := exec.CommandContext(ctx, execPath, "-", "-coalesce", "-repage", "0x0", "-resize", sizer, "-layers", "Optimize", "gif:-")
+	args := []string{"-", "-coalesce", "-repage", "0x0", "-resize", sizer}
+	switch format {
+	case FormatGIF:
+		args = append(args, "-layers", "Optimize")
+	case FormatWebP:
+		args = append(args, "-loop", "0")
+	default:
+		return nil, errors.New("unsupported animation format")
+	}
+	cmd := exec.CommandContext(ctx, execPath, append(args, string(format)+":-")...)
 
 	b, err := cmdPipe(cmd, src)
 	if err != nil {
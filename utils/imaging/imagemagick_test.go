@@ -161,3 +161,65 @@ func TestResizeAnimationGIF(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestConvert(t *testing.T) {
+	t.Parallel()
+
+	im := os.Getenv("TRAQ_IMAGEMAGICK")
+	if len(im) == 0 {
+		t.SkipNow()
+	}
+
+	gif, _ := base64.RawStdEncoding.DecodeString(base64gif)
+
+	t.Run("unavailable", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := Convert(context.TODO(), "", bytes.NewBufferString(""), FormatWebP)
+		assert.Error(t, err)
+	})
+
+	for _, format := range []Format{FormatPNG, FormatWebP, FormatAVIF} {
+		format := format
+		t.Run("valid gif to "+string(format), func(t *testing.T) {
+			t.Parallel()
+
+			b, err := Convert(context.TODO(), im, bytes.NewReader(gif), format)
+			if assert.NoError(t, err) {
+				assert.NotZero(t, b.Len())
+			}
+		})
+	}
+
+	t.Run("broken image", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := Convert(context.TODO(), im, io.LimitReader(bytes.NewReader(gif), 10), FormatWebP)
+		assert.Error(t, err)
+	})
+}
+
+func TestResizeAnimation(t *testing.T) {
+	t.Parallel()
+
+	im := os.Getenv("TRAQ_IMAGEMAGICK")
+	if len(im) == 0 {
+		t.SkipNow()
+	}
+
+	gif, _ := base64.RawStdEncoding.DecodeString(base64gif)
+
+	t.Run("valid gif to webp", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ResizeAnimation(context.TODO(), im, bytes.NewReader(gif), FormatWebP, 50, 50, false)
+		assert.NoError(t, err)
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ResizeAnimation(context.TODO(), im, bytes.NewReader(gif), FormatAVIF, 50, 50, false)
+		assert.Error(t, err)
+	})
+}
@@ -32,3 +32,14 @@ func ExtractEmbedding(m string) (res []*EmbeddedInfo, plain string) {
 	})
 	return res, strings.Replace(tmp, "\n", " ", -1)
 }
+
+// ReplaceEmbedding メッセージのjson型埋め込みをそれぞれfの返り値で置き換えたものを返します
+func ReplaceEmbedding(m string, f func(info *EmbeddedInfo) string) string {
+	return embJSONRegex.ReplaceAllStringFunc(m, func(s string) string {
+		info := &EmbeddedInfo{}
+		if err := jsonIter.ConfigFastest.Unmarshal([]byte(s[1:]), info); err != nil || len(info.Type) == 0 || len(info.ID) == 0 {
+			return s
+		}
+		return f(info)
+	})
+}
@@ -100,3 +100,16 @@ func TestExtractEmbedding(t *testing.T) {
 		})
 	}
 }
+
+func TestReplaceEmbedding(t *testing.T) {
+	t.Parallel()
+
+	m := "a !{\"raw\": \"@old\",\"type\":\"user\",\"id\":\"user_id\"} b !{\"raw\": \"#a/b\",\"type\":\"channel\",\"id\":\"channel_id\"} !{aaa"
+	res := ReplaceEmbedding(m, func(info *EmbeddedInfo) string {
+		if info.Type == "user" {
+			return "@new"
+		}
+		return info.Raw
+	})
+	assert.Equal(t, "a @new b #a/b !{aaa", res)
+}
@@ -183,6 +183,11 @@ func (o *Of[T]) Scan(src any) error {
 		if !ok {
 			return fmt.Errorf("unsupported type for Scan: %T", o.V)
 		}
+		if src == nil {
+			var t T
+			o.V, o.Valid = t, false
+			return nil
+		}
 		if err := s.Scan(src); err != nil {
 			return err
 		}
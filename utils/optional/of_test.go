@@ -255,6 +255,14 @@ func TestOf_Scan(t *testing.T) {
 			assert.EqualValues(t, "b3b6173c-6dd4-45a6-bcb8-9b74acb037be", o.V.String())
 		}
 	})
+	t.Run("uuid.UUID, nil", func(t *testing.T) {
+		o := From(uuid.Must(uuid.NewV4()))
+		err := o.Scan(nil)
+		if assert.NoError(t, err) {
+			assert.False(t, o.Valid)
+			assert.EqualValues(t, uuid.Nil, o.V)
+		}
+	})
 }
 
 func TestOf_MarshalText(t *testing.T) {